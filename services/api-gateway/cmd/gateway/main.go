@@ -14,6 +14,8 @@ import (
 	"go.uber.org/zap"
 
 	zaplogger "github.com/khoihuynh300/go-microservice/shared/pkg/logger"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/metrics"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/telemetry"
 )
 
 func main() {
@@ -28,6 +30,34 @@ func main() {
 	}
 	defer logger.Sync()
 
+	shutdownTracer, err := telemetry.InitTracer(context.Background(), telemetry.Config{
+		ServiceName:  config.GetServiceName(),
+		Env:          config.GetEnv(),
+		Exporter:     config.GetTraceExporter(),
+		OTLPEndpoint: config.GetOTLPEndpoint(),
+		OTLPInsecure: config.GetOTLPInsecure(),
+		SampleRatio:  config.GetTraceSampleRatio(),
+	})
+	if err != nil {
+		logger.Fatal("Failed to initialize tracer", zap.Error(err))
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracer(ctx); err != nil {
+			logger.Error("Failed to flush traces", zap.Error(err))
+		}
+	}()
+
+	shutdownMetrics := metrics.Serve(config.GetMetricsAddr(), logger)
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownMetrics(ctx); err != nil {
+			logger.Error("Failed to stop metrics server", zap.Error(err))
+		}
+	}()
+
 	logger.Info("Starting API Gateway", zap.String("service", config.GetServiceName()), zap.String("env", config.GetEnv()))
 
 	srv, err := server.New(logger)
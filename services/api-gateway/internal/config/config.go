@@ -1,6 +1,7 @@
 package config
 
 import (
+	"strings"
 	"time"
 
 	"github.com/go-playground/validator/v10"
@@ -18,12 +19,18 @@ type Config struct {
 	ReadTimeout  int    `mapstructure:"READ_TIMEOUT" validate:"gte=0"`
 	WriteTimeout int    `mapstructure:"WRITE_TIMEOUT" validate:"gte=0"`
 
-	// Jwt Secret Key
-	Secret string `mapstructure:"SECRET" validate:"required"`
+	// Jwt verification keys
+	JWKSURL                string        `mapstructure:"JWKS_URL" validate:"required,url"`
+	JWKSRefreshInterval    time.Duration `mapstructure:"JWKS_REFRESH_INTERVAL"`
+	JWKSMinRefreshInterval time.Duration `mapstructure:"JWKS_MIN_REFRESH_INTERVAL"`
+
+	// Token revocation
+	TokenRevocationCacheTTL time.Duration `mapstructure:"TOKEN_REVOCATION_CACHE_TTL"`
 
 	// Microservices URLs
 	UserServiceURL    string `mapstructure:"USER_SERVICE_URL" validate:"required"`
 	ProductServiceURL string `mapstructure:"PRODUCT_SERVICE_URL" validate:"required"`
+	OrderServiceURL   string `mapstructure:"ORDER_SERVICE_URL" validate:"required"`
 
 	// MinIO
 	MinIOEndpoint      string        `mapstructure:"MINIO_ENDPOINT" validate:"required"`
@@ -32,6 +39,26 @@ type Config struct {
 	MinIOBucketName    string        `mapstructure:"MINIO_BUCKET_NAME" validate:"required"`
 	MinIOUseSSL        bool          `mapstructure:"MINIO_USE_SSL"`
 	PresignedURLExpiry time.Duration `mapstructure:"PRESIGNED_URL_EXPIRY"`
+
+	// Redis
+	RedisHost     string `mapstructure:"REDIS_HOST" validate:"required"`
+	RedisPort     int    `mapstructure:"REDIS_PORT" validate:"required"`
+	RedisPassword string `mapstructure:"REDIS_PASSWORD"`
+	RedisDB       int    `mapstructure:"REDIS_DB"`
+
+	// Rate limiting
+	RateLimitEnabled  bool   `mapstructure:"RATE_LIMIT_ENABLED"`
+	RateLimitRules    string `mapstructure:"RATE_LIMIT_RULES"`
+	TrustProxyHeaders bool   `mapstructure:"TRUST_PROXY_HEADERS"`
+
+	// Metrics
+	MetricsAddr string `mapstructure:"METRICS_ADDR"`
+
+	// Tracing
+	TraceExporter    string  `mapstructure:"OTEL_TRACES_EXPORTER" validate:"oneof=none stdout otlp"`
+	OTLPEndpoint     string  `mapstructure:"OTEL_EXPORTER_OTLP_ENDPOINT"`
+	OTLPInsecure     bool    `mapstructure:"OTEL_EXPORTER_OTLP_INSECURE"`
+	TraceSampleRatio float64 `mapstructure:"OTEL_TRACES_SAMPLER_RATIO" validate:"gte=0,lte=1"`
 }
 
 func LoadConfig() error {
@@ -47,15 +74,53 @@ func LoadConfig() error {
 	// Server default values
 	viper.SetDefault("ENV", "PROD")
 	viper.SetDefault("SERVICE_NAME", "api-gateway")
+	viper.SetDefault("METRICS_ADDR", ":9100")
+	viper.SetDefault("OTEL_TRACES_EXPORTER", "none")
+	viper.SetDefault("OTEL_EXPORTER_OTLP_ENDPOINT", "localhost:4317")
+	viper.SetDefault("OTEL_EXPORTER_OTLP_INSECURE", true)
+	viper.SetDefault("OTEL_TRACES_SAMPLER_RATIO", 1.0)
 	viper.SetDefault("HOST", "0.0.0.0")
 	viper.SetDefault("PORT", "8080")
 	viper.SetDefault("READ_TIMEOUT", 30)
 	viper.SetDefault("WRITE_TIMEOUT", 15)
 
+	// Jwt verification keys default values
+	viper.SetDefault("JWKS_URL", "http://localhost:8081/.well-known/jwks.json")
+	viper.SetDefault("JWKS_REFRESH_INTERVAL", "10m")
+	viper.SetDefault("JWKS_MIN_REFRESH_INTERVAL", "30s")
+
+	// Token revocation default values
+	viper.SetDefault("TOKEN_REVOCATION_CACHE_TTL", "5s")
+
 	// MinIO default values
 	viper.SetDefault("MINIO_USE_SSL", true)
 	viper.SetDefault("PRESIGNED_URL_EXPIRY", "15m")
 
+	// Redis default values
+	viper.SetDefault("REDIS_DB", 0)
+
+	// Rate limiting default values
+	viper.SetDefault("RATE_LIMIT_ENABLED", true)
+	viper.SetDefault("RATE_LIMIT_RULES", strings.Join([]string{
+		"POST /v1/auth/login=10/1m",
+		"POST /v1/auth/mfa/verify=10/1m",
+		"GET /v1/auth/oauth/*=20/1m",
+		"POST /v1/auth/magic-link=5/15m",
+		"POST /v1/auth/magic-link/consume=10/15m",
+		"POST /v1/auth/forgot-password=5/15m",
+		"POST /v1/auth/reset-password=10/15m",
+		"POST /v1/auth/confirm-email-change=10/15m",
+		"POST /v1/auth/register*=10/1h",
+		"POST /v1/auth/*=30/1m",
+		"POST /v1/users/me/change-email=5/15m",
+		"POST /v1/users/me/phone/verification=5/15m",
+		"POST /v1/users/me/phone/verify=10/15m",
+		"POST /v1/users/me/delete=5/15m",
+		"POST /v1/users/me/export=3/1h",
+		"* /v1/*=300/1m",
+	}, ","))
+	viper.SetDefault("TRUST_PROXY_HEADERS", false)
+
 	if err := viper.Unmarshal(&cfg); err != nil {
 		return err
 	}
@@ -91,8 +156,16 @@ func GetWriteTimeout() int {
 	return cfg.WriteTimeout
 }
 
-func GetSecret() string {
-	return cfg.Secret
+func GetJWKSURL() string {
+	return cfg.JWKSURL
+}
+
+func GetJWKSRefreshInterval() time.Duration {
+	return cfg.JWKSRefreshInterval
+}
+
+func GetJWKSMinRefreshInterval() time.Duration {
+	return cfg.JWKSMinRefreshInterval
 }
 
 func GetUserServiceURL() string {
@@ -103,6 +176,10 @@ func GetProductServiceURL() string {
 	return cfg.ProductServiceURL
 }
 
+func GetOrderServiceURL() string {
+	return cfg.OrderServiceURL
+}
+
 func GetMinIOEndpoint() string {
 	return cfg.MinIOEndpoint
 }
@@ -126,3 +203,55 @@ func GetMinIOUseSSL() bool {
 func GetPresignedURLExpiry() time.Duration {
 	return cfg.PresignedURLExpiry
 }
+
+func GetRedisHost() string {
+	return cfg.RedisHost
+}
+
+func GetRedisPort() int {
+	return cfg.RedisPort
+}
+
+func GetRedisPassword() string {
+	return cfg.RedisPassword
+}
+
+func GetRedisDB() int {
+	return cfg.RedisDB
+}
+
+func GetTokenRevocationCacheTTL() time.Duration {
+	return cfg.TokenRevocationCacheTTL
+}
+
+func GetRateLimitEnabled() bool {
+	return cfg.RateLimitEnabled
+}
+
+func GetRateLimitRules() string {
+	return cfg.RateLimitRules
+}
+
+func GetTrustProxyHeaders() bool {
+	return cfg.TrustProxyHeaders
+}
+
+func GetMetricsAddr() string {
+	return cfg.MetricsAddr
+}
+
+func GetTraceExporter() string {
+	return cfg.TraceExporter
+}
+
+func GetOTLPEndpoint() string {
+	return cfg.OTLPEndpoint
+}
+
+func GetOTLPInsecure() bool {
+	return cfg.OTLPInsecure
+}
+
+func GetTraceSampleRatio() float64 {
+	return cfg.TraceSampleRatio
+}
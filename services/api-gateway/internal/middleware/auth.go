@@ -4,13 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"slices"
 	"strings"
 
-	"github.com/khoihuynh300/go-microservice/api-gateway/internal/config"
 	"github.com/khoihuynh300/go-microservice/api-gateway/internal/security/jwtvalidator"
+	"github.com/khoihuynh300/go-microservice/api-gateway/internal/security/revocation"
 	"github.com/khoihuynh300/go-microservice/shared/pkg/const/contextkeys"
 	mdkeys "github.com/khoihuynh300/go-microservice/shared/pkg/const/metadata"
 	apperr "github.com/khoihuynh300/go-microservice/shared/pkg/errors"
+	"go.uber.org/zap"
 )
 
 const (
@@ -22,46 +24,88 @@ var publicRoutes = []string{
 	"/v1/auth/*",
 }
 
-func AuthMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if isPublicRoute(r.URL.Path) {
-			next.ServeHTTP(w, r)
-			return
-		}
+func AuthMiddleware(keys jwtvalidator.KeySet, revocationChecker revocation.Checker, logger *zap.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// identity headers are only trusted when set by this middleware
+			r.Header.Del(mdkeys.UserIDHeader)
+			r.Header.Del(mdkeys.UserRoleHeader)
+			r.Header.Set(mdkeys.ClientIPHeader, ClientIP(r))
+			r.Header.Set(mdkeys.UserAgentHeader, r.UserAgent())
+			forwardOAuthStateBinding(r)
 
-		authHeader := r.Header.Get(AuthorizationHeader)
-		if authHeader == "" {
-			writeErrorResponse(w, apperr.ErrUnauthenticated)
-			return
-		}
+			if isPublicRoute(r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
 
-		if !strings.HasPrefix(authHeader, BearerPrefix) {
-			writeErrorResponse(w, apperr.ErrInvalidAuthHeader)
-			return
-		}
+			authHeader := r.Header.Get(AuthorizationHeader)
+			if authHeader == "" {
+				writeErrorResponse(w, apperr.ErrUnauthenticated)
+				return
+			}
 
-		tokenString := strings.TrimPrefix(authHeader, BearerPrefix)
-		claims, err := jwtvalidator.VerifyAccessToken(tokenString, config.GetSecret())
-		if err != nil {
-			switch err {
-			case jwtvalidator.ErrTokenExpired:
-				writeErrorResponse(w, apperr.ErrTokenExpired)
+			if !strings.HasPrefix(authHeader, BearerPrefix) {
+				writeErrorResponse(w, apperr.ErrInvalidAuthHeader)
 				return
-			case jwtvalidator.ErrTokenInvalid:
+			}
+
+			tokenString := strings.TrimPrefix(authHeader, BearerPrefix)
+			claims, err := jwtvalidator.VerifyAccessToken(r.Context(), tokenString, keys)
+			if err != nil {
+				switch err {
+				case jwtvalidator.ErrTokenExpired:
+					writeErrorResponse(w, apperr.ErrTokenExpired)
+					return
+				case jwtvalidator.ErrTokenInvalid:
+					writeErrorResponse(w, apperr.ErrTokenInvalid)
+					return
+				}
+
+				logger.Error("Failed to load token verification keys", zap.Error(err))
+				writeErrorResponse(w, apperr.ErrInternal)
+				return
+			}
+
+			// revocation is decided by issue time, so tokens without iat are refused
+			if claims.IssuedAt == nil {
 				writeErrorResponse(w, apperr.ErrTokenInvalid)
 				return
 			}
 
-			writeErrorResponse(w, apperr.ErrInternal)
-			return
-		}
+			revoked, err := revocationChecker.IsRevoked(r.Context(), claims.Subject, claims.ID, claims.IssuedAt.Time)
+			if err != nil {
+				// fail open like the rate limiter: the signature is still valid
+				logger.Warn("Token revocation check failed", zap.Error(err))
+			}
+			if revoked {
+				writeErrorResponse(w, apperr.ErrTokenRevoked)
+				return
+			}
 
-		r.Header.Set(mdkeys.UserIDHeader, claims.Subject)
-		ctx := context.WithValue(r.Context(), contextkeys.UserIDKey, claims.Subject)
-		r = r.WithContext(ctx)
+			r.Header.Set(mdkeys.UserIDHeader, claims.Subject)
+			r.Header.Set(mdkeys.UserRoleHeader, claims.Role)
+			ctx := context.WithValue(r.Context(), contextkeys.UserIDKey, claims.Subject)
+			ctx = context.WithValue(ctx, contextkeys.UserRoleKey, claims.Role)
+			r = r.WithContext(ctx)
 
-		next.ServeHTTP(w, r)
-	})
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func RequireRoles(allowedRoles ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			role, _ := r.Context().Value(contextkeys.UserRoleKey).(string)
+			if !slices.Contains(allowedRoles, role) {
+				writeErrorResponse(w, apperr.ErrUnauthorized)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
 }
 
 func writeErrorResponse(w http.ResponseWriter, err *apperr.AppError) {
@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/khoihuynh300/go-microservice/api-gateway/internal/config"
+)
+
+const (
+	ForwardedForHeader = "X-Forwarded-For"
+	RealIPHeader       = "X-Real-Ip"
+)
+
+// ClientIP returns the address of the caller. Proxy headers are only honoured
+// when the gateway is configured to run behind a trusted proxy, since clients
+// can set them freely otherwise.
+func ClientIP(r *http.Request) string {
+	if config.GetTrustProxyHeaders() {
+		if forwarded := r.Header.Get(ForwardedForHeader); forwarded != "" {
+			first, _, _ := strings.Cut(forwarded, ",")
+			return strings.TrimSpace(first)
+		}
+		if realIP := r.Header.Get(RealIPHeader); realIP != "" {
+			return realIP
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -9,7 +9,7 @@ import (
 
 func CustomHeaderMatcher(key string) (string, bool) {
 	switch strings.ToLower(key) {
-	case mdkeys.UserIDHeader, mdkeys.TraceIDHeader:
+	case mdkeys.UserIDHeader, mdkeys.UserRoleHeader, mdkeys.TraceIDHeader, mdkeys.ClientIPHeader, mdkeys.UserAgentHeader, mdkeys.OAuthStateBindingHeader:
 		return key, true
 	default:
 		return runtime.DefaultHeaderMatcher(key)
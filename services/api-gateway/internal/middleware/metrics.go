@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/metrics"
+)
+
+// RouteTemplate labels a request with the template of the mux route it matched.
+// Requests handed to the gRPC-Gateway are refined by GatewayRouteMiddleware.
+func RouteTemplate(r *http.Request) string {
+	if route := mux.CurrentRoute(r); route != nil {
+		if template, err := route.GetPathTemplate(); err == nil {
+			return template
+		}
+	}
+	return "unmatched"
+}
+
+func GatewayRouteMiddleware(next runtime.HandlerFunc) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		if pattern, ok := runtime.HTTPPattern(r.Context()); ok {
+			metrics.SetHTTPRoute(r.Context(), pattern.String())
+		}
+		next(w, r, pathParams)
+	}
+}
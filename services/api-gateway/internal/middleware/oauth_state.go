@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"time"
+
+	mdkeys "github.com/khoihuynh300/go-microservice/shared/pkg/const/metadata"
+)
+
+const (
+	OAuthStateCookie = "oauth_state"
+
+	oauthStateCookiePath = "/v1/auth/oauth/"
+	// matches how long user-service keeps the state
+	oauthStateCookieTTL = 10 * time.Minute
+)
+
+// setOAuthStateCookie binds the state of an authorization URL to the browser
+// being redirected. Only a hash is stored, the state itself stays in the URL.
+// SameSite=Lax still sends the cookie on the provider's top-level redirect
+// back to the callback.
+func setOAuthStateCookie(w http.ResponseWriter, redirectURL string) {
+	u, err := url.Parse(redirectURL)
+	if err != nil {
+		return
+	}
+	state := u.Query().Get("state")
+	if state == "" {
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     OAuthStateCookie,
+		Value:    hashOAuthState(state),
+		Path:     oauthStateCookiePath,
+		MaxAge:   int(oauthStateCookieTTL.Seconds()),
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// forwardOAuthStateBinding passes the state cookie on to user-service, which
+// refuses a callback whose state does not match it.
+func forwardOAuthStateBinding(r *http.Request) {
+	// the binding is only trusted when taken from the cookie here
+	r.Header.Del(mdkeys.OAuthStateBindingHeader)
+
+	if cookie, err := r.Cookie(OAuthStateCookie); err == nil && cookie.Value != "" {
+		r.Header.Set(mdkeys.OAuthStateBindingHeader, cookie.Value)
+	}
+}
+
+func hashOAuthState(state string) string {
+	sum := sha256.Sum256([]byte(state))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/khoihuynh300/go-microservice/api-gateway/internal/ratelimit"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/const/contextkeys"
+	apperr "github.com/khoihuynh300/go-microservice/shared/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	RateLimitLimitHeader     = "RateLimit-Limit"
+	RateLimitRemainingHeader = "RateLimit-Remaining"
+	RateLimitResetHeader     = "RateLimit-Reset"
+	RateLimitPolicyHeader    = "RateLimit-Policy"
+	RetryAfterHeader         = "Retry-After"
+)
+
+// RateLimitMiddleware applies the first rule matching the request. Callers are
+// keyed by user ID once authenticated and by client IP otherwise. If Redis is
+// unavailable the request is let through rather than failing the API.
+func RateLimitMiddleware(limiter ratelimit.Limiter, rules []ratelimit.Rule, logger *zap.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rule, ok := ratelimit.Match(rules, r)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			key := "ip:" + ClientIP(r)
+			if userID, ok := r.Context().Value(contextkeys.UserIDKey).(string); ok && userID != "" {
+				key = "user:" + userID
+			}
+
+			result, err := limiter.Allow(r.Context(), key, rule)
+			if err != nil {
+				logger.Error("Rate limiter unavailable", zap.String("rule", rule.Name()), zap.Error(err))
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set(RateLimitLimitHeader, strconv.Itoa(result.Limit))
+			w.Header().Set(RateLimitRemainingHeader, strconv.Itoa(result.Remaining))
+			w.Header().Set(RateLimitResetHeader, seconds(result.Reset))
+			w.Header().Set(RateLimitPolicyHeader, strconv.Itoa(rule.Limit)+";w="+seconds(rule.Window))
+
+			if !result.Allowed {
+				w.Header().Set(RetryAfterHeader, seconds(result.RetryAfter))
+				writeErrorResponse(w, apperr.ErrRateLimitExceeded)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	userpb "github.com/khoihuynh300/go-microservice/shared/proto/user"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 )
@@ -28,6 +29,12 @@ type Pagination struct {
 	HasPrev    bool `json:"has_prev"`
 }
 
+// redirectResponse is implemented by responses that send the browser on to
+// another site, such as the start of an OAuth login.
+type redirectResponse interface {
+	GetRedirectUrl() string
+}
+
 // custom marshaler to avoid default proto marshaler behavior
 type CustomMarshaler struct {
 	runtime.JSONPb
@@ -60,6 +67,13 @@ func SuccessResponseModifier(ctx context.Context, w http.ResponseWriter, resp pr
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	if start, ok := resp.(*userpb.StartOAuthLoginResponse); ok {
+		setOAuthStateCookie(w, start.GetRedirectUrl())
+	}
+	if r, ok := resp.(redirectResponse); ok && r.GetRedirectUrl() != "" {
+		w.Header().Set("Location", r.GetRedirectUrl())
+		w.WriteHeader(http.StatusFound)
+	}
 	json.NewEncoder(w).Encode(response)
 	return nil
 }
@@ -4,28 +4,68 @@ import (
 	"context"
 	"net/http"
 
-	"github.com/google/uuid"
 	"github.com/khoihuynh300/go-microservice/shared/pkg/const/contextkeys"
 	mdkeys "github.com/khoihuynh300/go-microservice/shared/pkg/const/metadata"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/metrics"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/telemetry"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
 	TraceIDHeader = "X-Trace-Id"
 )
 
+// TracingMiddleware starts the server span for each request, continuing the
+// caller's trace when a traceparent header is present. The span's trace ID is
+// used as the request trace ID so logs and traces can be joined. Spans are
+// named by route template like the HTTP metrics, and the raw path is kept in
+// the url.path attribute.
 func TracingMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		traceID := r.Header.Get(TraceIDHeader)
-		if traceID == "" {
-			traceID = uuid.New().String()
-		}
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := telemetry.Tracer().Start(ctx, r.Method+" "+spanRoute(ctx, r),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", r.Method),
+				attribute.String("url.path", r.URL.Path),
+				attribute.String("user_agent.original", r.UserAgent()),
+			),
+		)
+		defer span.End()
+
+		traceID := telemetry.TraceID(ctx)
 
 		w.Header().Set(TraceIDHeader, traceID)
 		r.Header.Set(mdkeys.TraceIDHeader, traceID)
 
-		ctx := context.WithValue(r.Context(), contextkeys.TraceIDKey, traceID)
+		ctx = context.WithValue(ctx, contextkeys.TraceIDKey, traceID)
 		r = r.WithContext(ctx)
 
-		next.ServeHTTP(w, r)
+		wrapped := &responseWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(wrapped, r)
+
+		// the gRPC-Gateway only resolves its route while serving the request
+		route := spanRoute(ctx, r)
+		span.SetName(r.Method + " " + route)
+		span.SetAttributes(
+			attribute.String("http.route", route),
+			attribute.Int("http.response.status_code", wrapped.status),
+		)
+		if wrapped.status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(wrapped.status))
+		}
 	})
 }
+
+// spanRoute prefers the route label of the metrics middleware, which handlers
+// further down may have refined, over the mux route template.
+func spanRoute(ctx context.Context, r *http.Request) string {
+	if route := metrics.HTTPRoute(ctx); route != "" {
+		return route
+	}
+	return RouteTemplate(r)
+}
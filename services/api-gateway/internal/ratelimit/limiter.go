@@ -0,0 +1,20 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is when the current window ends.
+	Reset time.Duration
+	// RetryAfter is how long a rejected client should wait.
+	RetryAfter time.Duration
+}
+
+type Limiter interface {
+	Allow(ctx context.Context, key string, rule Rule) (*Result, error)
+}
@@ -0,0 +1,86 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/khoihuynh300/go-microservice/shared/pkg/const/rediskeys"
+	"github.com/redis/go-redis/v9"
+)
+
+// slidingWindowScript counts a request in the current fixed window unless the
+// weighted sum of the previous and current windows has reached the limit.
+// It returns {allowed, current, previous}.
+var slidingWindowScript = redis.NewScript(`
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local elapsed = tonumber(ARGV[3])
+
+local current = tonumber(redis.call('GET', KEYS[1]) or '0')
+local previous = tonumber(redis.call('GET', KEYS[2]) or '0')
+
+if previous * (window - elapsed) / window + current >= limit then
+	return {0, current, previous}
+end
+
+current = redis.call('INCR', KEYS[1])
+redis.call('PEXPIRE', KEYS[1], window * 2)
+return {1, current, previous}
+`)
+
+type redisLimiter struct {
+	client *redis.Client
+}
+
+// NewRedisLimiter returns a sliding-window limiter whose counters live in
+// Redis, so the limits hold across gateway replicas.
+func NewRedisLimiter(client *redis.Client) Limiter {
+	return &redisLimiter{client: client}
+}
+
+func (l *redisLimiter) Allow(ctx context.Context, key string, rule Rule) (*Result, error) {
+	windowMs := rule.Window.Milliseconds()
+	nowMs := time.Now().UnixMilli()
+	windowStart := nowMs / windowMs
+	elapsed := nowMs % windowMs
+
+	prefix := rediskeys.RateLimitPrefix + rule.Name() + ":" + key + ":"
+	keys := []string{
+		prefix + strconv.FormatInt(windowStart, 10),
+		prefix + strconv.FormatInt(windowStart-1, 10),
+	}
+
+	res, err := slidingWindowScript.Run(ctx, l.client, keys, rule.Limit, windowMs, elapsed).Int64Slice()
+	if err != nil {
+		return nil, err
+	}
+	allowed, current, previous := res[0] == 1, float64(res[1]), float64(res[2])
+
+	weight := float64(windowMs-elapsed) / float64(windowMs)
+	used := int(math.Ceil(previous*weight + current))
+
+	result := &Result{
+		Allowed:   allowed,
+		Limit:     rule.Limit,
+		Remaining: max(rule.Limit-used, 0),
+		Reset:     time.Duration(windowMs-elapsed) * time.Millisecond,
+	}
+	if !allowed {
+		result.RetryAfter = retryAfter(rule.Limit, current, previous, windowMs, elapsed)
+	}
+	return result, nil
+}
+
+// retryAfter is how long until the previous window's share decays enough to
+// admit one more request, or until the next window when the current one is full.
+func retryAfter(limit int, current, previous float64, windowMs, elapsed int64) time.Duration {
+	remaining := windowMs - elapsed
+	if current >= float64(limit) || previous == 0 {
+		return time.Duration(remaining) * time.Millisecond
+	}
+
+	wait := float64(remaining) - (float64(limit)-current)*float64(windowMs)/previous
+	return time.Duration(math.Ceil(max(wait, 1))) * time.Millisecond
+}
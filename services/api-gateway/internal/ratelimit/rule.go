@@ -0,0 +1,87 @@
+package ratelimit
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Rule limits requests matching Method and Path to Limit per Window. Path is
+// either exact or ends with "*" to match a prefix; Method "*" matches any.
+type Rule struct {
+	Method string
+	Path   string
+	Limit  int
+	Window time.Duration
+}
+
+// Name identifies the rule in Redis keys, so each rule has its own counters.
+func (r Rule) Name() string {
+	return r.Method + " " + r.Path
+}
+
+func (r Rule) Matches(req *http.Request) bool {
+	if r.Method != "*" && !strings.EqualFold(r.Method, req.Method) {
+		return false
+	}
+	if prefix, ok := strings.CutSuffix(r.Path, "*"); ok {
+		return strings.HasPrefix(req.URL.Path, prefix)
+	}
+	return req.URL.Path == r.Path
+}
+
+// Match returns the first rule matching req.
+func Match(rules []Rule, req *http.Request) (Rule, bool) {
+	for _, rule := range rules {
+		if rule.Matches(req) {
+			return rule, true
+		}
+	}
+	return Rule{}, false
+}
+
+// ParseRules parses a comma-separated list of rules of the form
+// "METHOD /path=limit/window", e.g. "POST /v1/auth/login=10/1m,* /v1/*=300/1m".
+// Rules are matched in the order given.
+func ParseRules(spec string) ([]Rule, error) {
+	var rules []Rule
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		route, quota, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("rate limit rule %q: missing '='", entry)
+		}
+		method, path, ok := strings.Cut(strings.TrimSpace(route), " ")
+		if !ok {
+			return nil, fmt.Errorf("rate limit rule %q: route must be \"METHOD /path\"", entry)
+		}
+		limitStr, windowStr, ok := strings.Cut(strings.TrimSpace(quota), "/")
+		if !ok {
+			return nil, fmt.Errorf("rate limit rule %q: quota must be \"limit/window\"", entry)
+		}
+
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			return nil, fmt.Errorf("rate limit rule %q: invalid limit %q", entry, limitStr)
+		}
+		// The limiter buckets time in whole milliseconds.
+		window, err := time.ParseDuration(windowStr)
+		if err != nil || window < time.Millisecond {
+			return nil, fmt.Errorf("rate limit rule %q: invalid window %q", entry, windowStr)
+		}
+
+		rules = append(rules, Rule{
+			Method: strings.ToUpper(method),
+			Path:   strings.TrimSpace(path),
+			Limit:  limit,
+			Window: window,
+		})
+	}
+	return rules, nil
+}
@@ -1,12 +1,15 @@
 package jwtvalidator
 
 import (
+	"context"
 	"errors"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/jwks"
 )
 
 type AccessTokenClaims struct {
+	Role string `json:"role"`
 	jwt.RegisteredClaims
 }
 
@@ -15,13 +18,30 @@ var (
 	ErrTokenInvalid = errors.New("token invalid")
 )
 
-func VerifyAccessToken(tokenString, secret string) (*AccessTokenClaims, error) {
+func VerifyAccessToken(ctx context.Context, tokenString string, keys KeySet) (*AccessTokenClaims, error) {
+	var keyErr error
 	token, err := jwt.ParseWithClaims(tokenString, &AccessTokenClaims{}, func(token *jwt.Token) (any, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		kid, _ := token.Header["kid"].(string)
+		if kid == "" {
 			return nil, ErrTokenInvalid
 		}
-		return []byte(secret), nil
-	})
+		key, alg, err := keys.Key(ctx, kid)
+		if err != nil {
+			if !errors.Is(err, ErrKeyNotFound) {
+				keyErr = err
+			}
+			return nil, ErrTokenInvalid
+		}
+		if token.Method.Alg() != alg {
+			return nil, ErrTokenInvalid
+		}
+		return key, nil
+	}, jwt.WithValidMethods([]string{jwks.AlgRS256, jwks.AlgEdDSA}))
+
+	// no keys could be loaded, which is not the caller's fault
+	if keyErr != nil {
+		return nil, keyErr
+	}
 
 	if err != nil || !token.Valid {
 		if errors.Is(err, jwt.ErrTokenExpired) {
@@ -0,0 +1,160 @@
+package jwtvalidator
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/khoihuynh300/go-microservice/shared/pkg/jwks"
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+)
+
+var (
+	ErrKeyNotFound     = errors.New("signing key not found")
+	ErrKeysUnavailable = errors.New("signing keys unavailable")
+)
+
+type KeySet interface {
+	Key(ctx context.Context, kid string) (crypto.PublicKey, string, error)
+}
+
+type publicKey struct {
+	key crypto.PublicKey
+	alg string
+}
+
+// remoteKeySet caches the issuer's JWKS. It refetches when the cache is older
+// than refreshInterval, or when a token names an unknown kid, but never more
+// often than minRefreshInterval so forged kids cannot hammer the issuer.
+// Concurrent refetches are collapsed into one and no lock is held while it
+// runs, so lookups of known kids never wait on the issuer.
+type remoteKeySet struct {
+	url                string
+	client             *http.Client
+	refreshInterval    time.Duration
+	minRefreshInterval time.Duration
+	logger             *zap.Logger
+
+	group singleflight.Group
+
+	mu        sync.RWMutex
+	keys      map[string]publicKey
+	fetchedAt time.Time
+	triedAt   time.Time
+}
+
+func NewRemoteKeySet(url string, refreshInterval, minRefreshInterval time.Duration, logger *zap.Logger) KeySet {
+	return &remoteKeySet{
+		url:                url,
+		client:             &http.Client{Timeout: 5 * time.Second},
+		refreshInterval:    refreshInterval,
+		minRefreshInterval: minRefreshInterval,
+		logger:             logger,
+	}
+}
+
+func (s *remoteKeySet) Key(ctx context.Context, kid string) (crypto.PublicKey, string, error) {
+	key, ok, stale := s.lookup(kid)
+	if !ok || stale {
+		// the fetch is shared by every waiting request, so one caller going away must not abort it
+		s.group.Do("refresh", func() (any, error) {
+			s.refresh(context.WithoutCancel(ctx))
+			return nil, nil
+		})
+		key, ok, _ = s.lookup(kid)
+	}
+
+	if !ok {
+		s.mu.RLock()
+		fetched := s.keys != nil
+		s.mu.RUnlock()
+
+		if !fetched {
+			return nil, "", ErrKeysUnavailable
+		}
+		return nil, "", ErrKeyNotFound
+	}
+	return key.key, key.alg, nil
+}
+
+func (s *remoteKeySet) lookup(kid string) (key publicKey, ok bool, stale bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	key, ok = s.keys[kid]
+	return key, ok, time.Since(s.fetchedAt) >= s.refreshInterval
+}
+
+// refresh refetches the key set unless the last attempt was less than
+// minRefreshInterval ago. Calls are serialized by the singleflight group.
+func (s *remoteKeySet) refresh(ctx context.Context) {
+	now := time.Now()
+
+	s.mu.Lock()
+	if now.Sub(s.triedAt) < s.minRefreshInterval {
+		s.mu.Unlock()
+		return
+	}
+	s.triedAt = now
+	s.mu.Unlock()
+
+	keys, err := s.fetch(ctx)
+	if err != nil {
+		// keep serving the cached keys if the issuer is unreachable
+		s.logger.Warn("Failed to refresh JWKS", zap.String("url", s.url), zap.Error(err))
+		return
+	}
+
+	s.mu.Lock()
+	s.keys = keys
+	s.fetchedAt = now
+	s.mu.Unlock()
+}
+
+func (s *remoteKeySet) fetch(ctx context.Context) (map[string]publicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	var set jwks.Set
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&set); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]publicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		pub, err := k.PublicKey()
+		if err != nil {
+			s.logger.Warn("Skipping JWKS key", zap.String("kid", k.Kid), zap.Error(err))
+			continue
+		}
+		alg, err := jwks.AlgForKey(pub)
+		if err != nil || (k.Alg != "" && k.Alg != alg) {
+			s.logger.Warn("Skipping JWKS key", zap.String("kid", k.Kid), zap.String("alg", k.Alg))
+			continue
+		}
+		keys[k.Kid] = publicKey{key: pub, alg: alg}
+	}
+
+	return keys, nil
+}
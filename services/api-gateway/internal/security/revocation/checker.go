@@ -0,0 +1,13 @@
+package revocation
+
+import (
+	"context"
+	"time"
+)
+
+// Checker reports whether an access token has been revoked since it was
+// issued, e.g. by a password change or a logout from all devices, or on its
+// own by a logout or a session revocation.
+type Checker interface {
+	IsRevoked(ctx context.Context, userID, tokenID string, issuedAt time.Time) (bool, error)
+}
@@ -0,0 +1,119 @@
+package revocation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/khoihuynh300/go-microservice/shared/pkg/cache"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/const/rediskeys"
+	"github.com/redis/go-redis/v9"
+)
+
+const maxCachedKeys = 10000
+
+type cachedEntry struct {
+	// value is empty when the key does not exist.
+	value     string
+	expiresAt time.Time
+}
+
+// redisChecker reads the "tokens valid after" timestamps and the revoked
+// token IDs written by the user-service. Lookups are cached in process for
+// cacheTTL, which bounds how long a revoked token can still be used through
+// this gateway instance.
+type redisChecker struct {
+	cache    cache.Cache
+	cacheTTL time.Duration
+
+	mu      sync.Mutex
+	entries map[string]cachedEntry
+}
+
+func NewRedisChecker(cache cache.Cache, cacheTTL time.Duration) Checker {
+	return &redisChecker{
+		cache:    cache,
+		cacheTTL: cacheTTL,
+		entries:  make(map[string]cachedEntry),
+	}
+}
+
+func (c *redisChecker) IsRevoked(ctx context.Context, userID, tokenID string, issuedAt time.Time) (bool, error) {
+	validAfter, err := c.validAfter(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	if issuedAt.Before(validAfter) {
+		return true, nil
+	}
+
+	if tokenID == "" {
+		return false, nil
+	}
+	denied, err := c.get(ctx, rediskeys.RevokedAccessTokenPrefix+tokenID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get revoked access token: %w", err)
+	}
+	return denied != "", nil
+}
+
+func (c *redisChecker) validAfter(ctx context.Context, userID string) (time.Time, error) {
+	value, err := c.get(ctx, rediskeys.TokensValidAfterPrefix+userID)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get token revocation: %w", err)
+	}
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	unix, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid token revocation value %q: %w", value, err)
+	}
+	return time.Unix(unix, 0), nil
+}
+
+// get returns the value of key, or "" when it does not exist, going to
+// Redis at most once per cacheTTL.
+func (c *redisChecker) get(ctx context.Context, key string) (string, error) {
+	now := time.Now()
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && now.Before(entry.expiresAt) {
+		return entry.value, nil
+	}
+
+	value, err := c.cache.Get(ctx, key)
+	if errors.Is(err, redis.Nil) {
+		value, err = "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	c.store(key, cachedEntry{value: value, expiresAt: now.Add(c.cacheTTL)})
+	return value, nil
+}
+
+func (c *redisChecker) store(key string, entry cachedEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.entries) >= maxCachedKeys {
+		now := time.Now()
+		for k, e := range c.entries {
+			if !now.Before(e.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+		if len(c.entries) >= maxCachedKeys {
+			clear(c.entries)
+		}
+	}
+	c.entries[key] = entry
+}
@@ -11,7 +11,16 @@ import (
 	"github.com/khoihuynh300/go-microservice/api-gateway/internal/config"
 	"github.com/khoihuynh300/go-microservice/api-gateway/internal/handler"
 	"github.com/khoihuynh300/go-microservice/api-gateway/internal/middleware"
+	"github.com/khoihuynh300/go-microservice/api-gateway/internal/ratelimit"
+	"github.com/khoihuynh300/go-microservice/api-gateway/internal/security/jwtvalidator"
+	"github.com/khoihuynh300/go-microservice/api-gateway/internal/security/revocation"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/cache"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/const/roles"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/interceptor"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/metrics"
 	"github.com/khoihuynh300/go-microservice/shared/pkg/storage"
+	cartpb "github.com/khoihuynh300/go-microservice/shared/proto/cart"
+	orderpb "github.com/khoihuynh300/go-microservice/shared/proto/order"
 	productpb "github.com/khoihuynh300/go-microservice/shared/proto/product"
 	userpb "github.com/khoihuynh300/go-microservice/shared/proto/user"
 	"go.uber.org/zap"
@@ -20,8 +29,13 @@ import (
 )
 
 type Server struct {
-	httpServer *http.Server
-	logger     *zap.Logger
+	httpServer     *http.Server
+	logger         *zap.Logger
+	redis          *cache.Client
+	rateLimiter    ratelimit.Limiter
+	rateLimitRules []ratelimit.Rule
+	jwtKeys        jwtvalidator.KeySet
+	revocation     revocation.Checker
 }
 
 func New(logger *zap.Logger) (*Server, error) {
@@ -43,16 +57,49 @@ func New(logger *zap.Logger) (*Server, error) {
 		return nil, fmt.Errorf("failed to initialize storage: %w", err)
 	}
 
+	// Initialize redis
+	s.redis, err = cache.NewClient(&cache.Config{
+		Host:     config.GetRedisHost(),
+		Port:     config.GetRedisPort(),
+		Password: config.GetRedisPassword(),
+		DB:       config.GetRedisDB(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize redis: %w", err)
+	}
+
+	// Initialize jwt key set
+	s.jwtKeys = jwtvalidator.NewRemoteKeySet(
+		config.GetJWKSURL(),
+		config.GetJWKSRefreshInterval(),
+		config.GetJWKSMinRefreshInterval(),
+		logger,
+	)
+
+	// Initialize token revocation checker
+	s.revocation = revocation.NewRedisChecker(s.redis, config.GetTokenRevocationCacheTTL())
+
+	// Initialize rate limiter
+	if config.GetRateLimitEnabled() {
+		s.rateLimitRules, err = ratelimit.ParseRules(config.GetRateLimitRules())
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse rate limit rules: %w", err)
+		}
+		s.rateLimiter = ratelimit.NewRedisLimiter(s.redis.GetClient())
+	}
+
 	// Set up gRPC-Gateway
 	gwmux := runtime.NewServeMux(
 		runtime.WithIncomingHeaderMatcher(middleware.CustomHeaderMatcher),
 		runtime.WithErrorHandler(middleware.CustomErrorHandler),
 		runtime.WithForwardResponseOption(middleware.SuccessResponseModifier),
 		runtime.WithMarshalerOption("*", &middleware.CustomMarshaler{}),
+		runtime.WithMiddlewares(middleware.GatewayRouteMiddleware),
 	)
 
 	opts := []grpc.DialOption{
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(interceptor.TracingClientInterceptor()),
 	}
 
 	// Register user service handler
@@ -64,6 +111,14 @@ func New(logger *zap.Logger) (*Server, error) {
 		return nil, fmt.Errorf("failed to register product service handler: %w", err)
 	}
 
+	if err := orderpb.RegisterOrderServiceHandlerFromEndpoint(ctx, gwmux, config.GetOrderServiceURL(), opts); err != nil {
+		return nil, fmt.Errorf("failed to register order service handler: %w", err)
+	}
+
+	if err := cartpb.RegisterCartServiceHandlerFromEndpoint(ctx, gwmux, config.GetOrderServiceURL(), opts); err != nil {
+		return nil, fmt.Errorf("failed to register cart service handler: %w", err)
+	}
+
 	// Initialize upload handler
 	uploadHandler := handler.NewUploadHandler(storageClient)
 
@@ -88,18 +143,26 @@ func (s *Server) setupRoutes(router *mux.Router, gwmux *runtime.ServeMux, upload
 	// Path: /v1
 	api := router.PathPrefix("/v1").Subrouter()
 	api.Use(func(next http.Handler) http.Handler {
-		handler := middleware.AuthMiddleware(next)
+		handler := next
+		if s.rateLimiter != nil {
+			handler = middleware.RateLimitMiddleware(s.rateLimiter, s.rateLimitRules, s.logger)(handler)
+		}
+		handler = middleware.AuthMiddleware(s.jwtKeys, s.revocation, s.logger)(handler)
 		handler = middleware.LoggingMiddleware(handler, s.logger)
 		handler = middleware.TracingMiddleware(handler)
+		handler = metrics.HTTPMiddleware(middleware.RouteTemplate)(handler)
 		return handler
 	})
 
 	// upload routes
 	upload := api.PathPrefix("/upload").Subrouter()
 	upload.HandleFunc("/avatar/presigned-url", uploadHandler.GetAvatarPresignedURL).Methods("POST")
-	upload.HandleFunc("/products/{product_id}/thumbnail/presigned-url", uploadHandler.GetProductImagePresignedURL).Methods("POST")
-	upload.HandleFunc("/products/{product_id}/image/presigned-url", uploadHandler.GetProductImagePresignedURL).Methods("POST")
-	upload.HandleFunc("/categories/{category_id}/image/presigned-url", uploadHandler.GetCategoryImagePresignedURL).Methods("POST")
+
+	catalogUpload := upload.NewRoute().Subrouter()
+	catalogUpload.Use(middleware.RequireRoles(roles.Admin, roles.Staff))
+	catalogUpload.HandleFunc("/products/{product_id}/thumbnail/presigned-url", uploadHandler.GetProductImagePresignedURL).Methods("POST")
+	catalogUpload.HandleFunc("/products/{product_id}/image/presigned-url", uploadHandler.GetProductImagePresignedURL).Methods("POST")
+	catalogUpload.HandleFunc("/categories/{category_id}/image/presigned-url", uploadHandler.GetCategoryImagePresignedURL).Methods("POST")
 
 	// gRPC-Gateway routes
 	api.PathPrefix("").Handler(gwmux)
@@ -113,8 +176,14 @@ func (s *Server) Run() error {
 }
 
 func (s *Server) Shutdown(ctx context.Context) error {
+	var err error
 	if s.httpServer != nil {
-		return s.httpServer.Shutdown(ctx)
+		err = s.httpServer.Shutdown(ctx)
+	}
+	if s.redis != nil {
+		if closeErr := s.redis.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
 	}
-	return nil
+	return err
 }
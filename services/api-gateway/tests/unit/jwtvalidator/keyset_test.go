@@ -0,0 +1,218 @@
+package jwtvalidator_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/khoihuynh300/go-microservice/api-gateway/internal/security/jwtvalidator"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/jwks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// jwksServer serves whatever key set the test installs and counts fetches.
+type jwksServer struct {
+	*httptest.Server
+
+	fetches atomic.Int32
+	release chan struct{}
+
+	mu     sync.Mutex
+	set    jwks.Set
+	status int
+}
+
+func newJWKSServer(t *testing.T) *jwksServer {
+	s := &jwksServer{status: http.StatusOK}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.fetches.Add(1)
+		if s.release != nil {
+			<-s.release
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.status != http.StatusOK {
+			w.WriteHeader(s.status)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(s.set)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *jwksServer) serve(t *testing.T, keys map[string]crypto.PublicKey) {
+	t.Helper()
+
+	set := jwks.Set{Keys: []jwks.Key{}}
+	for kid, pub := range keys {
+		key, err := jwks.FromPublicKey(kid, pub)
+		require.NoError(t, err)
+		set.Keys = append(set.Keys, key)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.set = set
+	s.status = http.StatusOK
+}
+
+func (s *jwksServer) fail(status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status = status
+}
+
+func newSigningKey(t *testing.T) crypto.PublicKey {
+	t.Helper()
+
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return pub
+}
+
+func TestRemoteKeySet_Key(t *testing.T) {
+	ctx := context.Background()
+	server := newJWKSServer(t)
+	current := newSigningKey(t)
+	server.serve(t, map[string]crypto.PublicKey{"key-1": current})
+
+	keySet := jwtvalidator.NewRemoteKeySet(server.URL, time.Hour, time.Hour, zap.NewNop())
+
+	key, alg, err := keySet.Key(ctx, "key-1")
+	require.NoError(t, err)
+	assert.Equal(t, current, key)
+	assert.Equal(t, jwks.AlgEdDSA, alg)
+
+	_, _, err = keySet.Key(ctx, "key-1")
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), server.fetches.Load(), "known kid must be served from the cache")
+}
+
+func TestRemoteKeySet_UnknownKidIsRateLimited(t *testing.T) {
+	ctx := context.Background()
+	server := newJWKSServer(t)
+	server.serve(t, map[string]crypto.PublicKey{"key-1": newSigningKey(t)})
+
+	keySet := jwtvalidator.NewRemoteKeySet(server.URL, time.Hour, time.Hour, zap.NewNop())
+
+	_, _, err := keySet.Key(ctx, "key-1")
+	require.NoError(t, err)
+
+	for range 10 {
+		_, _, err := keySet.Key(ctx, "forged-kid")
+		assert.ErrorIs(t, err, jwtvalidator.ErrKeyNotFound)
+	}
+	assert.Equal(t, int32(1), server.fetches.Load())
+}
+
+func TestRemoteKeySet_Rotation(t *testing.T) {
+	ctx := context.Background()
+	server := newJWKSServer(t)
+	oldKey, newKey := newSigningKey(t), newSigningKey(t)
+	server.serve(t, map[string]crypto.PublicKey{"key-1": oldKey})
+
+	keySet := jwtvalidator.NewRemoteKeySet(server.URL, time.Hour, 0, zap.NewNop())
+
+	key, _, err := keySet.Key(ctx, "key-1")
+	require.NoError(t, err)
+	assert.Equal(t, oldKey, key)
+
+	server.serve(t, map[string]crypto.PublicKey{"key-2": newKey})
+
+	key, _, err = keySet.Key(ctx, "key-2")
+	require.NoError(t, err)
+	assert.Equal(t, newKey, key)
+	assert.Equal(t, int32(2), server.fetches.Load())
+
+	_, _, err = keySet.Key(ctx, "key-1")
+	assert.ErrorIs(t, err, jwtvalidator.ErrKeyNotFound)
+}
+
+func TestRemoteKeySet_StaleKeysRefreshed(t *testing.T) {
+	ctx := context.Background()
+	server := newJWKSServer(t)
+	oldKey, newKey := newSigningKey(t), newSigningKey(t)
+	server.serve(t, map[string]crypto.PublicKey{"key-1": oldKey})
+
+	keySet := jwtvalidator.NewRemoteKeySet(server.URL, 0, 0, zap.NewNop())
+
+	_, _, err := keySet.Key(ctx, "key-1")
+	require.NoError(t, err)
+
+	// the issuer replaced the material behind the same kid
+	server.serve(t, map[string]crypto.PublicKey{"key-1": newKey})
+
+	key, _, err := keySet.Key(ctx, "key-1")
+	require.NoError(t, err)
+	assert.Equal(t, newKey, key)
+}
+
+func TestRemoteKeySet_IssuerUnavailable(t *testing.T) {
+	ctx := context.Background()
+	server := newJWKSServer(t)
+	current := newSigningKey(t)
+	server.fail(http.StatusServiceUnavailable)
+
+	keySet := jwtvalidator.NewRemoteKeySet(server.URL, 0, 0, zap.NewNop())
+
+	_, _, err := keySet.Key(ctx, "key-1")
+	assert.ErrorIs(t, err, jwtvalidator.ErrKeysUnavailable)
+
+	server.serve(t, map[string]crypto.PublicKey{"key-1": current})
+	_, _, err = keySet.Key(ctx, "key-1")
+	require.NoError(t, err)
+
+	// cached keys keep working while the issuer is down
+	server.fail(http.StatusServiceUnavailable)
+	key, _, err := keySet.Key(ctx, "key-1")
+	require.NoError(t, err)
+	assert.Equal(t, current, key)
+}
+
+func TestRemoteKeySet_ConcurrentMissesShareOneFetch(t *testing.T) {
+	ctx := context.Background()
+	server := newJWKSServer(t)
+	current := newSigningKey(t)
+	server.serve(t, map[string]crypto.PublicKey{"key-1": current})
+	server.release = make(chan struct{})
+
+	// with a long minimum interval a caller that did not wait for the shared
+	// fetch would find no keys and fail
+	keySet := jwtvalidator.NewRemoteKeySet(server.URL, time.Hour, time.Hour, zap.NewNop())
+
+	const callers = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, callers)
+	for range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, err := keySet.Key(ctx, "key-1")
+			errs <- err
+		}()
+	}
+
+	require.Eventually(t, func() bool { return server.fetches.Load() == 1 }, time.Second, time.Millisecond)
+	// give the remaining callers time to join the in-flight fetch
+	time.Sleep(50 * time.Millisecond)
+	close(server.release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, int32(1), server.fetches.Load())
+}
@@ -0,0 +1,49 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/khoihuynh300/go-microservice/api-gateway/internal/middleware"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/const/contextkeys"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/const/roles"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequireRoles(t *testing.T) {
+	tests := []struct {
+		name           string
+		allowedRoles   []string
+		role           string
+		expectedStatus int
+	}{
+		{name: "Admin Allowed", allowedRoles: []string{roles.Admin, roles.Staff}, role: roles.Admin, expectedStatus: http.StatusOK},
+		{name: "Staff Allowed", allowedRoles: []string{roles.Admin, roles.Staff}, role: roles.Staff, expectedStatus: http.StatusOK},
+		{name: "Customer Forbidden", allowedRoles: []string{roles.Admin, roles.Staff}, role: roles.Customer, expectedStatus: http.StatusForbidden},
+		{name: "Missing Role Forbidden", allowedRoles: []string{roles.Admin, roles.Staff}, role: "", expectedStatus: http.StatusForbidden},
+		{name: "Staff Forbidden On Admin Route", allowedRoles: []string{roles.Admin}, role: roles.Staff, expectedStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/v1/upload/products/product-1/thumbnail/presigned-url", nil)
+			if tt.role != "" {
+				req = req.WithContext(context.WithValue(req.Context(), contextkeys.UserRoleKey, tt.role))
+			}
+			rec := httptest.NewRecorder()
+
+			middleware.RequireRoles(tt.allowedRoles...)(next).ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedStatus == http.StatusOK, called)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package middleware_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/khoihuynh300/go-microservice/api-gateway/internal/middleware"
+	mdkeys "github.com/khoihuynh300/go-microservice/shared/pkg/const/metadata"
+	userpb "github.com/khoihuynh300/go-microservice/shared/proto/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func stateHash(state string) string {
+	sum := sha256.Sum256([]byte(state))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestSuccessResponseModifier_SetsOAuthStateCookie(t *testing.T) {
+	rec := httptest.NewRecorder()
+	resp := &userpb.StartOAuthLoginResponse{
+		RedirectUrl: "https://accounts.example.com/authorize?client_id=gateway&state=state-1",
+	}
+
+	require.NoError(t, middleware.SuccessResponseModifier(context.Background(), rec, resp))
+
+	assert.Equal(t, http.StatusFound, rec.Code)
+	assert.Equal(t, resp.RedirectUrl, rec.Header().Get("Location"))
+
+	cookies := rec.Result().Cookies()
+	require.Len(t, cookies, 1)
+	cookie := cookies[0]
+	assert.Equal(t, middleware.OAuthStateCookie, cookie.Name)
+	assert.Equal(t, stateHash("state-1"), cookie.Value)
+	assert.True(t, cookie.HttpOnly)
+	assert.True(t, cookie.Secure)
+	assert.Equal(t, http.SameSiteLaxMode, cookie.SameSite)
+	assert.Positive(t, cookie.MaxAge)
+}
+
+func TestSuccessResponseModifier_OtherResponsesSetNoCookie(t *testing.T) {
+	rec := httptest.NewRecorder()
+
+	require.NoError(t, middleware.SuccessResponseModifier(context.Background(), rec, &userpb.TokenResponse{AccessToken: "access-token"}))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Result().Cookies())
+}
+
+func TestAuthMiddleware_ForwardsOAuthStateBinding(t *testing.T) {
+	tests := []struct {
+		name            string
+		cookie          *http.Cookie
+		spoofedBinding  string
+		expectedBinding string
+	}{
+		{
+			name:            "Cookie Forwarded",
+			cookie:          &http.Cookie{Name: middleware.OAuthStateCookie, Value: stateHash("state-1")},
+			expectedBinding: stateHash("state-1"),
+		},
+		{
+			name:            "Header From Client Ignored",
+			spoofedBinding:  stateHash("state-1"),
+			expectedBinding: "",
+		},
+		{
+			name:            "Cookie Wins Over Header",
+			cookie:          &http.Cookie{Name: middleware.OAuthStateCookie, Value: stateHash("state-1")},
+			spoofedBinding:  stateHash("state-2"),
+			expectedBinding: stateHash("state-1"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var forwarded string
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				forwarded = r.Header.Get(mdkeys.OAuthStateBindingHeader)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/auth/oauth/google/callback?code=code&state=state-1", nil)
+			if tt.cookie != nil {
+				req.AddCookie(tt.cookie)
+			}
+			if tt.spoofedBinding != "" {
+				req.Header.Set(mdkeys.OAuthStateBindingHeader, tt.spoofedBinding)
+			}
+
+			middleware.AuthMiddleware(nil, nil, zap.NewNop())(next).ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.Equal(t, tt.expectedBinding, forwarded)
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package middleware_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/khoihuynh300/go-microservice/api-gateway/internal/middleware"
+	"github.com/khoihuynh300/go-microservice/api-gateway/internal/ratelimit"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/const/contextkeys"
+	apperr "github.com/khoihuynh300/go-microservice/shared/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeLimiter answers every call with the same result and records what it
+// was asked.
+type fakeLimiter struct {
+	result *ratelimit.Result
+	err    error
+
+	keys  []string
+	rules []ratelimit.Rule
+}
+
+func (l *fakeLimiter) Allow(ctx context.Context, key string, rule ratelimit.Rule) (*ratelimit.Result, error) {
+	l.keys = append(l.keys, key)
+	l.rules = append(l.rules, rule)
+	return l.result, l.err
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	rules, err := ratelimit.ParseRules("POST /v1/auth/login=10/1m,* /v1/*=300/1m")
+	require.NoError(t, err)
+
+	allowed := &ratelimit.Result{Allowed: true, Limit: 10, Remaining: 7, Reset: 1500 * time.Millisecond}
+	rejected := &ratelimit.Result{Allowed: false, Limit: 10, Remaining: 0, Reset: 40 * time.Second, RetryAfter: 2100 * time.Millisecond}
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		userID         string
+		limiter        *fakeLimiter
+		expectedStatus int
+		expectedNext   bool
+		checkFunc      func(t *testing.T, rec *httptest.ResponseRecorder, limiter *fakeLimiter)
+	}{
+		{
+			name:           "Anonymous Keyed By IP",
+			method:         http.MethodPost,
+			path:           "/v1/auth/login",
+			limiter:        &fakeLimiter{result: allowed},
+			expectedStatus: http.StatusOK,
+			expectedNext:   true,
+			checkFunc: func(t *testing.T, rec *httptest.ResponseRecorder, limiter *fakeLimiter) {
+				assert.Equal(t, []string{"ip:203.0.113.7"}, limiter.keys)
+				assert.Equal(t, "POST /v1/auth/login", limiter.rules[0].Name())
+			},
+		},
+		{
+			name:           "Authenticated Keyed By User",
+			method:         http.MethodGet,
+			path:           "/v1/orders",
+			userID:         "user-1",
+			limiter:        &fakeLimiter{result: allowed},
+			expectedStatus: http.StatusOK,
+			expectedNext:   true,
+			checkFunc: func(t *testing.T, rec *httptest.ResponseRecorder, limiter *fakeLimiter) {
+				assert.Equal(t, []string{"user:user-1"}, limiter.keys)
+				assert.Equal(t, "* /v1/*", limiter.rules[0].Name())
+			},
+		},
+		{
+			name:           "Allowed Sets Headers",
+			method:         http.MethodPost,
+			path:           "/v1/auth/login",
+			limiter:        &fakeLimiter{result: allowed},
+			expectedStatus: http.StatusOK,
+			expectedNext:   true,
+			checkFunc: func(t *testing.T, rec *httptest.ResponseRecorder, limiter *fakeLimiter) {
+				assert.Equal(t, "10", rec.Header().Get(middleware.RateLimitLimitHeader))
+				assert.Equal(t, "7", rec.Header().Get(middleware.RateLimitRemainingHeader))
+				assert.Equal(t, "2", rec.Header().Get(middleware.RateLimitResetHeader))
+				assert.Equal(t, "10;w=60", rec.Header().Get(middleware.RateLimitPolicyHeader))
+				assert.Empty(t, rec.Header().Get(middleware.RetryAfterHeader))
+			},
+		},
+		{
+			name:           "Rejected",
+			method:         http.MethodPost,
+			path:           "/v1/auth/login",
+			limiter:        &fakeLimiter{result: rejected},
+			expectedStatus: http.StatusTooManyRequests,
+			expectedNext:   false,
+			checkFunc: func(t *testing.T, rec *httptest.ResponseRecorder, limiter *fakeLimiter) {
+				assert.Equal(t, "0", rec.Header().Get(middleware.RateLimitRemainingHeader))
+				assert.Equal(t, "40", rec.Header().Get(middleware.RateLimitResetHeader))
+				assert.Equal(t, "3", rec.Header().Get(middleware.RetryAfterHeader))
+
+				var body apperr.AppError
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+				assert.Equal(t, apperr.CodeRateLimitExceeded, body.Code)
+			},
+		},
+		{
+			name:           "Limiter Unavailable Fails Open",
+			method:         http.MethodPost,
+			path:           "/v1/auth/login",
+			limiter:        &fakeLimiter{err: errors.New("connection refused")},
+			expectedStatus: http.StatusOK,
+			expectedNext:   true,
+			checkFunc: func(t *testing.T, rec *httptest.ResponseRecorder, limiter *fakeLimiter) {
+				assert.Len(t, limiter.keys, 1)
+				assert.Empty(t, rec.Header().Get(middleware.RateLimitLimitHeader))
+			},
+		},
+		{
+			name:           "No Matching Rule",
+			method:         http.MethodGet,
+			path:           "/health",
+			limiter:        &fakeLimiter{result: rejected},
+			expectedStatus: http.StatusOK,
+			expectedNext:   true,
+			checkFunc: func(t *testing.T, rec *httptest.ResponseRecorder, limiter *fakeLimiter) {
+				assert.Empty(t, limiter.keys)
+				assert.Empty(t, rec.Header().Get(middleware.RateLimitLimitHeader))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var called bool
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+			})
+
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.RemoteAddr = "203.0.113.7:52100"
+			if tt.userID != "" {
+				req = req.WithContext(context.WithValue(req.Context(), contextkeys.UserIDKey, tt.userID))
+			}
+			rec := httptest.NewRecorder()
+
+			middleware.RateLimitMiddleware(tt.limiter, rules, zap.NewNop())(next).ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedNext, called)
+
+			if tt.checkFunc != nil {
+				tt.checkFunc(t, rec, tt.limiter)
+			}
+		})
+	}
+}
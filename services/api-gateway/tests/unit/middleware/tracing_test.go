@@ -0,0 +1,98 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/khoihuynh300/go-microservice/api-gateway/internal/middleware"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func newSpanRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+func spanAttributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestTracingMiddleware_SpanName(t *testing.T) {
+	tests := []struct {
+		name          string
+		path          string
+		expectedName  string
+		expectedRoute string
+	}{
+		{
+			name:          "Mux Route Template",
+			path:          "/v1/upload/products/4c5e0f1a/thumbnail/presigned-url",
+			expectedName:  "POST /v1/upload/products/{product_id}/thumbnail/presigned-url",
+			expectedRoute: "/v1/upload/products/{product_id}/thumbnail/presigned-url",
+		},
+		{
+			name:          "Route Refined By Gateway",
+			path:          "/v1/orders/4c5e0f1a",
+			expectedName:  "POST /v1/orders/{order_id}",
+			expectedRoute: "/v1/orders/{order_id}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := newSpanRecorder(t)
+
+			router := mux.NewRouter()
+			api := router.PathPrefix("/v1").Subrouter()
+			api.Use(func(next http.Handler) http.Handler {
+				return metrics.HTTPMiddleware(middleware.RouteTemplate)(middleware.TracingMiddleware(next))
+			})
+			api.HandleFunc("/upload/products/{product_id}/thumbnail/presigned-url", func(w http.ResponseWriter, r *http.Request) {}).Methods("POST")
+			api.PathPrefix("").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				// stands in for GatewayRouteMiddleware, which knows the pattern only once routed
+				metrics.SetHTTPRoute(r.Context(), "/v1/orders/{order_id}")
+			})
+
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, tt.path, nil))
+
+			spans := recorder.Ended()
+			require.Len(t, spans, 1)
+			assert.Equal(t, tt.expectedName, spans[0].Name())
+
+			attrs := spanAttributes(spans[0])
+			assert.Equal(t, tt.path, attrs["url.path"].AsString())
+			assert.Equal(t, tt.expectedRoute, attrs["http.route"].AsString())
+		})
+	}
+}
+
+func TestTracingMiddleware_WithoutMetricsMiddleware(t *testing.T) {
+	recorder := newSpanRecorder(t)
+
+	router := mux.NewRouter()
+	router.Handle("/v1/products/{product_id}", middleware.TracingMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})))
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/products/4c5e0f1a", nil))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "GET /v1/products/{product_id}", spans[0].Name())
+	assert.Equal(t, "/v1/products/4c5e0f1a", spanAttributes(spans[0])["url.path"].AsString())
+}
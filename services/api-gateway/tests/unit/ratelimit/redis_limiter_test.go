@@ -0,0 +1,102 @@
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/khoihuynh300/go-microservice/api-gateway/internal/ratelimit"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLimiter(t *testing.T) (ratelimit.Limiter, *miniredis.Miniredis) {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	return ratelimit.NewRedisLimiter(client), server
+}
+
+func TestRedisLimiter_Allow(t *testing.T) {
+	ctx := context.Background()
+	limiter, _ := newTestLimiter(t)
+	// a long window keeps the test inside one window
+	rule := ratelimit.Rule{Method: "POST", Path: "/v1/auth/login", Limit: 3, Window: time.Hour}
+
+	for i := range rule.Limit {
+		result, err := limiter.Allow(ctx, "ip:203.0.113.7", rule)
+		require.NoError(t, err)
+
+		assert.True(t, result.Allowed)
+		assert.Equal(t, rule.Limit, result.Limit)
+		assert.Equal(t, rule.Limit-i-1, result.Remaining)
+		assert.Positive(t, result.Reset)
+		assert.LessOrEqual(t, result.Reset, rule.Window)
+		assert.Zero(t, result.RetryAfter)
+	}
+
+	result, err := limiter.Allow(ctx, "ip:203.0.113.7", rule)
+	require.NoError(t, err)
+
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+	assert.Positive(t, result.RetryAfter)
+	assert.LessOrEqual(t, result.RetryAfter, result.Reset)
+}
+
+func TestRedisLimiter_RejectedRequestsAreNotCounted(t *testing.T) {
+	ctx := context.Background()
+	limiter, server := newTestLimiter(t)
+	rule := ratelimit.Rule{Method: "POST", Path: "/v1/auth/login", Limit: 1, Window: time.Hour}
+
+	for range 5 {
+		_, err := limiter.Allow(ctx, "ip:203.0.113.7", rule)
+		require.NoError(t, err)
+	}
+
+	keys := server.Keys()
+	require.Len(t, keys, 1)
+	count, err := server.Get(keys[0])
+	require.NoError(t, err)
+	assert.Equal(t, "1", count)
+	// the counter outlives its window so the next one can weigh it in
+	assert.Equal(t, 2*rule.Window, server.TTL(keys[0]))
+}
+
+func TestRedisLimiter_CountersAreIndependent(t *testing.T) {
+	ctx := context.Background()
+	limiter, _ := newTestLimiter(t)
+	login := ratelimit.Rule{Method: "POST", Path: "/v1/auth/login", Limit: 1, Window: time.Hour}
+	register := ratelimit.Rule{Method: "POST", Path: "/v1/auth/register", Limit: 1, Window: time.Hour}
+
+	result, err := limiter.Allow(ctx, "ip:203.0.113.7", login)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+
+	result, err = limiter.Allow(ctx, "ip:203.0.113.7", login)
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+
+	result, err = limiter.Allow(ctx, "ip:203.0.113.8", login)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed, "other clients have their own counter")
+
+	result, err = limiter.Allow(ctx, "ip:203.0.113.7", register)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed, "other rules have their own counter")
+}
+
+func TestRedisLimiter_RedisUnavailable(t *testing.T) {
+	limiter, server := newTestLimiter(t)
+	server.Close()
+
+	rule := ratelimit.Rule{Method: "POST", Path: "/v1/auth/login", Limit: 1, Window: time.Hour}
+	_, err := limiter.Allow(context.Background(), "ip:203.0.113.7", rule)
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,106 @@
+package ratelimit_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/khoihuynh300/go-microservice/api-gateway/internal/ratelimit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRules(t *testing.T) {
+	tests := []struct {
+		name          string
+		spec          string
+		expected      []ratelimit.Rule
+		expectedError bool
+	}{
+		{
+			name: "Valid Rules",
+			spec: "POST /v1/auth/login=10/1m, get /v1/products/*=100/30s,* /v1/*=300/1m",
+			expected: []ratelimit.Rule{
+				{Method: "POST", Path: "/v1/auth/login", Limit: 10, Window: time.Minute},
+				{Method: "GET", Path: "/v1/products/*", Limit: 100, Window: 30 * time.Second},
+				{Method: "*", Path: "/v1/*", Limit: 300, Window: time.Minute},
+			},
+		},
+		{
+			name:     "Empty Entries Skipped",
+			spec:     " ,POST /v1/auth/login=10/1m,,",
+			expected: []ratelimit.Rule{{Method: "POST", Path: "/v1/auth/login", Limit: 10, Window: time.Minute}},
+		},
+		{
+			name:     "Empty Spec",
+			spec:     "",
+			expected: nil,
+		},
+		{name: "Missing Quota", spec: "POST /v1/auth/login", expectedError: true},
+		{name: "Missing Method", spec: "/v1/auth/login=10/1m", expectedError: true},
+		{name: "Missing Window", spec: "POST /v1/auth/login=10", expectedError: true},
+		{name: "Invalid Limit", spec: "POST /v1/auth/login=ten/1m", expectedError: true},
+		{name: "Zero Limit", spec: "POST /v1/auth/login=0/1m", expectedError: true},
+		{name: "Invalid Window", spec: "POST /v1/auth/login=10/minute", expectedError: true},
+		{name: "Negative Window", spec: "POST /v1/auth/login=10/-1m", expectedError: true},
+		{name: "Sub-Millisecond Window", spec: "POST /v1/auth/login=10/500us", expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := ratelimit.ParseRules(tt.spec)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, rules)
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	rules, err := ratelimit.ParseRules("POST /v1/auth/login=10/1m,GET /v1/products/*=100/1m,* /v1/*=300/1m")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		expected string
+		matched  bool
+	}{
+		{name: "Exact Path", method: http.MethodPost, path: "/v1/auth/login", expected: "POST /v1/auth/login", matched: true},
+		{name: "Method Case Insensitive", method: "post", path: "/v1/auth/login", expected: "POST /v1/auth/login", matched: true},
+		{name: "Exact Path Needs Same Method", method: http.MethodGet, path: "/v1/auth/login", expected: "* /v1/*", matched: true},
+		{name: "Exact Path Is Not A Prefix", method: http.MethodPost, path: "/v1/auth/login/extra", expected: "* /v1/*", matched: true},
+		{name: "Prefix Path", method: http.MethodGet, path: "/v1/products/123", expected: "GET /v1/products/*", matched: true},
+		{name: "Prefix Keeps Trailing Slash", method: http.MethodGet, path: "/v1/products", expected: "* /v1/*", matched: true},
+		{name: "Any Method", method: http.MethodDelete, path: "/v1/orders/1", expected: "* /v1/*", matched: true},
+		{name: "No Match", method: http.MethodGet, path: "/health", matched: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, ok := ratelimit.Match(rules, httptest.NewRequest(tt.method, tt.path, nil))
+
+			assert.Equal(t, tt.matched, ok)
+			if tt.matched {
+				assert.Equal(t, tt.expected, rule.Name())
+			}
+		})
+	}
+}
+
+func TestMatch_FirstMatchWins(t *testing.T) {
+	rules, err := ratelimit.ParseRules("* /v1/*=300/1m,POST /v1/auth/login=10/1m")
+	require.NoError(t, err)
+
+	rule, ok := ratelimit.Match(rules, httptest.NewRequest(http.MethodPost, "/v1/auth/login", nil))
+
+	require.True(t, ok)
+	assert.Equal(t, "* /v1/*", rule.Name())
+	assert.Equal(t, 300, rule.Limit)
+}
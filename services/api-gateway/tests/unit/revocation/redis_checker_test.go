@@ -0,0 +1,196 @@
+package revocation_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/khoihuynh300/go-microservice/api-gateway/internal/security/revocation"
+	mock_cache "github.com/khoihuynh300/go-microservice/shared/mocks/cache"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/const/rediskeys"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testUserID  = "user-1"
+	testTokenID = "jti-1"
+)
+
+var (
+	validAfterKey = rediskeys.TokensValidAfterPrefix + testUserID
+	deniedKey     = rediskeys.RevokedAccessTokenPrefix + testTokenID
+)
+
+func TestRedisChecker_IsRevoked(t *testing.T) {
+	revokedAt := time.Now().Truncate(time.Second)
+
+	tests := []struct {
+		name          string
+		tokenID       string
+		issuedAt      time.Time
+		setupMocks    func(c *mock_cache.MockCache)
+		expected      bool
+		expectedError bool
+	}{
+		{
+			name:     "Not Revoked",
+			tokenID:  testTokenID,
+			issuedAt: revokedAt,
+			setupMocks: func(c *mock_cache.MockCache) {
+				c.EXPECT().Get(gomock.Any(), validAfterKey).Return("", redis.Nil)
+				c.EXPECT().Get(gomock.Any(), deniedKey).Return("", redis.Nil)
+			},
+			expected: false,
+		},
+		{
+			name:     "Issued Before Valid After",
+			tokenID:  testTokenID,
+			issuedAt: revokedAt.Add(-time.Second),
+			setupMocks: func(c *mock_cache.MockCache) {
+				c.EXPECT().Get(gomock.Any(), validAfterKey).Return(strconv.FormatInt(revokedAt.Unix(), 10), nil)
+			},
+			expected: true,
+		},
+		{
+			name:     "Issued At Valid After",
+			tokenID:  testTokenID,
+			issuedAt: revokedAt,
+			setupMocks: func(c *mock_cache.MockCache) {
+				c.EXPECT().Get(gomock.Any(), validAfterKey).Return(strconv.FormatInt(revokedAt.Unix(), 10), nil)
+				c.EXPECT().Get(gomock.Any(), deniedKey).Return("", redis.Nil)
+			},
+			expected: false,
+		},
+		{
+			name:     "Token ID Denied",
+			tokenID:  testTokenID,
+			issuedAt: revokedAt,
+			setupMocks: func(c *mock_cache.MockCache) {
+				c.EXPECT().Get(gomock.Any(), validAfterKey).Return("", redis.Nil)
+				c.EXPECT().Get(gomock.Any(), deniedKey).Return("1", nil)
+			},
+			expected: true,
+		},
+		{
+			name:     "No Token ID",
+			tokenID:  "",
+			issuedAt: revokedAt,
+			setupMocks: func(c *mock_cache.MockCache) {
+				c.EXPECT().Get(gomock.Any(), validAfterKey).Return("", redis.Nil)
+			},
+			expected: false,
+		},
+		{
+			name:     "Invalid Valid After Value",
+			tokenID:  testTokenID,
+			issuedAt: revokedAt,
+			setupMocks: func(c *mock_cache.MockCache) {
+				c.EXPECT().Get(gomock.Any(), validAfterKey).Return("not-a-number", nil)
+			},
+			expectedError: true,
+		},
+		{
+			name:     "Valid After Lookup Fails",
+			tokenID:  testTokenID,
+			issuedAt: revokedAt,
+			setupMocks: func(c *mock_cache.MockCache) {
+				c.EXPECT().Get(gomock.Any(), validAfterKey).Return("", errors.New("connection refused"))
+			},
+			expectedError: true,
+		},
+		{
+			name:     "Denylist Lookup Fails",
+			tokenID:  testTokenID,
+			issuedAt: revokedAt,
+			setupMocks: func(c *mock_cache.MockCache) {
+				c.EXPECT().Get(gomock.Any(), validAfterKey).Return("", redis.Nil)
+				c.EXPECT().Get(gomock.Any(), deniedKey).Return("", errors.New("connection refused"))
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			cache := mock_cache.NewMockCache(ctrl)
+			tt.setupMocks(cache)
+			checker := revocation.NewRedisChecker(cache, time.Minute)
+
+			revoked, err := checker.IsRevoked(context.Background(), testUserID, tt.tokenID, tt.issuedAt)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, revoked)
+		})
+	}
+}
+
+func TestRedisChecker_CachesLookups(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cache := mock_cache.NewMockCache(ctrl)
+	cache.EXPECT().Get(gomock.Any(), validAfterKey).Return("", redis.Nil).Times(1)
+	cache.EXPECT().Get(gomock.Any(), deniedKey).Return("1", nil).Times(1)
+	checker := revocation.NewRedisChecker(cache, time.Minute)
+
+	for range 3 {
+		revoked, err := checker.IsRevoked(context.Background(), testUserID, testTokenID, time.Now())
+		require.NoError(t, err)
+		assert.True(t, revoked)
+	}
+}
+
+func TestRedisChecker_RefetchesAfterCacheTTL(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cache := mock_cache.NewMockCache(ctrl)
+	gomock.InOrder(
+		cache.EXPECT().Get(gomock.Any(), validAfterKey).Return("", redis.Nil),
+		cache.EXPECT().Get(gomock.Any(), validAfterKey).Return(strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10), nil),
+	)
+	cache.EXPECT().Get(gomock.Any(), deniedKey).Return("", redis.Nil)
+	checker := revocation.NewRedisChecker(cache, 10*time.Millisecond)
+
+	revoked, err := checker.IsRevoked(context.Background(), testUserID, testTokenID, time.Now())
+	require.NoError(t, err)
+	assert.False(t, revoked)
+
+	time.Sleep(20 * time.Millisecond)
+
+	revoked, err = checker.IsRevoked(context.Background(), testUserID, testTokenID, time.Now())
+	require.NoError(t, err)
+	assert.True(t, revoked)
+}
+
+func TestRedisChecker_DoesNotCacheErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cache := mock_cache.NewMockCache(ctrl)
+	gomock.InOrder(
+		cache.EXPECT().Get(gomock.Any(), validAfterKey).Return("", errors.New("connection refused")),
+		cache.EXPECT().Get(gomock.Any(), validAfterKey).Return("", redis.Nil),
+	)
+	cache.EXPECT().Get(gomock.Any(), deniedKey).Return("", redis.Nil)
+	checker := revocation.NewRedisChecker(cache, time.Minute)
+
+	_, err := checker.IsRevoked(context.Background(), testUserID, testTokenID, time.Now())
+	require.Error(t, err)
+
+	revoked, err := checker.IsRevoked(context.Background(), testUserID, testTokenID, time.Now())
+	require.NoError(t, err)
+	assert.False(t, revoked)
+}
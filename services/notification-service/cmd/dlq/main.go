@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/khoihuynh300/go-microservice/shared/pkg/messaging/events"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/messaging/kafka"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/messaging/topics"
+)
+
+const usage = `Usage:
+  dlq inspect [-brokers host:port,...] [-topic name] [-limit n]
+  dlq replay  [-brokers host:port,...] [-topic name] [-limit n] (-event-id id | -all)
+`
+
+// deadLetterQueue is the part of kafka.DLQ the commands use.
+type deadLetterQueue interface {
+	Inspect(ctx context.Context, limit int) ([]*kafka.DeadLetter, error)
+	Replay(ctx context.Context, limit int, match func(*kafka.DeadLetter) bool) (int, error)
+}
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(args []string) error {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, usage)
+		return errors.New("missing command")
+	}
+
+	fs := flag.NewFlagSet(args[0], flag.ExitOnError)
+	brokers := fs.String("brokers", os.Getenv("KAFKA_BROKERS"), "comma-separated Kafka brokers")
+	topic := fs.String("topic", topics.UserEventsTopic, "source topic whose dead-letter topic is read")
+	limit := fs.Int("limit", 0, "maximum number of messages, 0 for no limit")
+	eventID := fs.String("event-id", "", "replay only the message with this event ID")
+	all := fs.Bool("all", false, "replay every dead-lettered message")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	if *brokers == "" {
+		return errors.New("no brokers: set -brokers or KAFKA_BROKERS")
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	dlq := kafka.NewDLQ(strings.Split(*brokers, ","), *topic)
+	defer dlq.Close()
+
+	switch args[0] {
+	case "inspect":
+		return inspect(ctx, os.Stdout, dlq, *limit)
+	case "replay":
+		if *eventID == "" && !*all {
+			return errors.New("replay needs -event-id or -all")
+		}
+		return replay(ctx, dlq, *limit, *eventID)
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+}
+
+func inspect(ctx context.Context, w io.Writer, dlq deadLetterQueue, limit int) error {
+	deadLetters, err := dlq.Inspect(ctx, limit)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	for _, dl := range deadLetters {
+		out := map[string]any{
+			"partition":      dl.Partition,
+			"offset":         dl.Offset,
+			"key":            dl.Key,
+			"original_topic": dl.OriginalTopic,
+			"error":          dl.Error,
+			"attempts":       dl.Attempts,
+			"failed_at":      dl.FailedAt,
+		}
+		if dl.Event != nil {
+			if payload, err := events.DefaultRegistry.Decode(dl.Event); err == nil {
+				if data, err := events.MarshalData(dl.Event.EventType, payload); err == nil {
+					dl.Event.Data = data
+				}
+			}
+			out["event"] = dl.Event
+		} else {
+			out["raw"] = string(dl.Value)
+		}
+		if err := enc.Encode(out); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "%d dead-lettered message(s)\n", len(deadLetters))
+	return nil
+}
+
+func replay(ctx context.Context, dlq deadLetterQueue, limit int, eventID string) error {
+	replayed, err := dlq.Replay(ctx, limit, func(dl *kafka.DeadLetter) bool {
+		if eventID == "" {
+			return true
+		}
+		return dl.Event != nil && dl.Event.EventID == eventID
+	})
+	fmt.Fprintf(os.Stderr, "%d message(s) replayed\n", replayed)
+	return err
+}
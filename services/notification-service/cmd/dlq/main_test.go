@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/khoihuynh300/go-microservice/shared/pkg/messaging/events"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/messaging/kafka"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeDLQ struct {
+	deadLetters []*kafka.DeadLetter
+	limit       int
+	replayed    int
+	err         error
+}
+
+func (f *fakeDLQ) Inspect(ctx context.Context, limit int) ([]*kafka.DeadLetter, error) {
+	f.limit = limit
+	return f.deadLetters, f.err
+}
+
+func (f *fakeDLQ) Replay(ctx context.Context, limit int, match func(*kafka.DeadLetter) bool) (int, error) {
+	f.limit = limit
+	for _, dl := range f.deadLetters {
+		if match(dl) {
+			f.replayed++
+		}
+	}
+	return f.replayed, f.err
+}
+
+func newDeadLetter(eventID string) *kafka.DeadLetter {
+	return &kafka.DeadLetter{
+		Partition:     1,
+		Offset:        42,
+		Key:           "user-1",
+		OriginalTopic: "user-events",
+		Error:         "handler failed",
+		Attempts:      5,
+		FailedAt:      "2026-01-02T03:04:05Z",
+		Event: &events.Event{
+			EventID:   eventID,
+			EventType: "test.unregistered",
+			Data:      json.RawMessage(`{"id":"` + eventID + `"}`),
+		},
+	}
+}
+
+func TestRun_InvalidArguments(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		env  string
+	}{
+		{name: "Missing Command", args: nil, env: "localhost:9092"},
+		{name: "No Brokers", args: []string{"inspect"}, env: ""},
+		{name: "Replay Without Selector", args: []string{"replay"}, env: "localhost:9092"},
+		{name: "Unknown Command", args: []string{"purge"}, env: "localhost:9092"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("KAFKA_BROKERS", tt.env)
+			assert.Error(t, run(tt.args))
+		})
+	}
+}
+
+func TestReplay(t *testing.T) {
+	unparsable := &kafka.DeadLetter{OriginalTopic: "user-events", Value: []byte("not json")}
+
+	tests := []struct {
+		name             string
+		eventID          string
+		limit            int
+		err              error
+		expectedReplayed int
+	}{
+		{name: "All", eventID: "", expectedReplayed: 3},
+		{name: "By Event ID", eventID: "event-2", expectedReplayed: 1},
+		{name: "Unknown Event ID", eventID: "event-9", expectedReplayed: 0},
+		{name: "Passes Limit", eventID: "", limit: 2, expectedReplayed: 3},
+		{name: "Error", eventID: "", err: errors.New("broker down"), expectedReplayed: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dlq := &fakeDLQ{
+				deadLetters: []*kafka.DeadLetter{newDeadLetter("event-1"), newDeadLetter("event-2"), unparsable},
+				err:         tt.err,
+			}
+
+			err := replay(context.Background(), dlq, tt.limit, tt.eventID)
+
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.limit, dlq.limit)
+			assert.Equal(t, tt.expectedReplayed, dlq.replayed)
+		})
+	}
+}
+
+func TestInspect(t *testing.T) {
+	dlq := &fakeDLQ{deadLetters: []*kafka.DeadLetter{
+		newDeadLetter("event-1"),
+		{Partition: 0, Offset: 7, OriginalTopic: "user-events", Value: []byte("not json")},
+	}}
+
+	var out bytes.Buffer
+	require.NoError(t, inspect(context.Background(), &out, dlq, 10))
+	assert.Equal(t, 10, dlq.limit)
+
+	dec := json.NewDecoder(&out)
+	var first, second map[string]any
+	require.NoError(t, dec.Decode(&first))
+	require.NoError(t, dec.Decode(&second))
+	assert.ErrorIs(t, dec.Decode(&map[string]any{}), io.EOF)
+
+	assert.Equal(t, float64(1), first["partition"])
+	assert.Equal(t, float64(42), first["offset"])
+	assert.Equal(t, "user-events", first["original_topic"])
+	assert.Equal(t, "handler failed", first["error"])
+	assert.Equal(t, float64(5), first["attempts"])
+	event, ok := first["event"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, "event-1", event["event_id"])
+	assert.NotContains(t, first, "raw")
+
+	assert.Equal(t, "not json", second["raw"])
+	assert.NotContains(t, second, "event")
+}
+
+func TestInspect_Error(t *testing.T) {
+	dlq := &fakeDLQ{err: errors.New("broker down")}
+
+	var out bytes.Buffer
+	assert.Error(t, inspect(context.Background(), &out, dlq, 0))
+	assert.Empty(t, out.String())
+}
@@ -2,18 +2,24 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/khoihuynh300/go-microservice/notification-service/internal/config"
 	"github.com/khoihuynh300/go-microservice/notification-service/internal/events/handlers"
 	"github.com/khoihuynh300/go-microservice/notification-service/internal/service"
 	"github.com/khoihuynh300/go-microservice/notification-service/internal/template"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/cache"
 	zaplogger "github.com/khoihuynh300/go-microservice/shared/pkg/logger"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/messaging/idempotency"
 	"github.com/khoihuynh300/go-microservice/shared/pkg/messaging/kafka"
 	"github.com/khoihuynh300/go-microservice/shared/pkg/messaging/topics"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/metrics"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/telemetry"
 	"go.uber.org/zap"
 )
 
@@ -34,6 +40,34 @@ func run() error {
 	}
 	defer logger.Sync()
 
+	shutdownTracer, err := telemetry.InitTracer(context.Background(), telemetry.Config{
+		ServiceName:  config.GetServiceName(),
+		Env:          config.GetEnv(),
+		Exporter:     config.GetTraceExporter(),
+		OTLPEndpoint: config.GetOTLPEndpoint(),
+		OTLPInsecure: config.GetOTLPInsecure(),
+		SampleRatio:  config.GetTraceSampleRatio(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to initialize tracer: %w", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracer(ctx); err != nil {
+			logger.Error("Failed to flush traces", zap.Error(err))
+		}
+	}()
+
+	shutdownMetrics := metrics.Serve(config.GetMetricsAddr(), logger)
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownMetrics(ctx); err != nil {
+			logger.Error("Failed to stop metrics server", zap.Error(err))
+		}
+	}()
+
 	templateParser, err := template.NewParser()
 	if err != nil {
 		return err
@@ -48,10 +82,26 @@ func run() error {
 		config.GetUseTLS(),
 	)
 
+	smsService, err := newSMSService()
+	if err != nil {
+		return err
+	}
+
+	redis, err := cache.NewClient(&cache.Config{
+		Host:     config.GetRedisHost(),
+		Port:     config.GetRedisPort(),
+		Password: config.GetRedisPassword(),
+		DB:       config.GetRedisDB(),
+	})
+	if err != nil {
+		return err
+	}
+	defer redis.Close()
+
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	kafkaConsumer, err := startKafkaConsumer(ctx, emailService, logger)
+	kafkaConsumer, err := startKafkaConsumer(ctx, emailService, smsService, idempotency.NewRedisStore(redis), logger)
 	if err != nil {
 		return err
 	}
@@ -72,12 +122,39 @@ func run() error {
 	return nil
 }
 
-func startKafkaConsumer(ctx context.Context, emailService service.EmailService, logger *zap.Logger) (kafka.Consumer, error) {
+func newSMSService() (service.SMSService, error) {
+	switch config.GetSMSProvider() {
+	case "log":
+		return service.NewLogSMSService(config.GetSMSLogFile()), nil
+	default:
+		return nil, fmt.Errorf("unknown sms provider %q", config.GetSMSProvider())
+	}
+}
+
+func startKafkaConsumer(
+	ctx context.Context,
+	emailService service.EmailService,
+	smsService service.SMSService,
+	processedEvents idempotency.Store,
+	logger *zap.Logger,
+) (kafka.Consumer, error) {
 	topicConsume := []string{topics.UserEventsTopic}
 	kafkaConsumer := kafka.NewConsumer(config.GetKafkaBrokers(), topicConsume, config.GetKafkaConsumerGroup())
-
-	userEventHandler := handlers.NewUserEventHandler(emailService, config.GetBaseURL())
-	kafkaConsumer.RegisterHandler(topics.UserEventsTopic, userEventHandler.HandleEvent)
+	kafkaConsumer.SetWorkers(config.GetKafkaConsumerWorkers())
+
+	dedup := idempotency.Middleware(processedEvents, idempotency.Config{
+		Consumer: config.GetKafkaConsumerGroup(),
+		Lease:    config.GetEventDedupLease(),
+		TTL:      config.GetEventDedupTTL(),
+	})
+
+	userEventHandler := handlers.NewUserEventHandler(emailService, smsService, config.GetBaseURL())
+	kafkaConsumer.RegisterHandlerWithRetry(topics.UserEventsTopic, dedup(userEventHandler.HandleEvent), kafka.RetryPolicy{
+		MaxAttempts:    config.GetRetryMaxAttempts(),
+		InitialBackoff: config.GetRetryInitialBackoff(),
+		MaxBackoff:     config.GetRetryMaxBackoff(),
+		Multiplier:     2,
+	})
 
 	go func() {
 		logger.Info("Starting Kafka consumer...")
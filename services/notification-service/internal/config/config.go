@@ -1,6 +1,8 @@
 package config
 
 import (
+	"time"
+
 	"github.com/go-playground/validator/v10"
 	"github.com/spf13/viper"
 )
@@ -10,14 +12,39 @@ type Config struct {
 	ServiceName string `mapstructure:"SERVICE_NAME"`
 	BaseURL     string `mapstructure:"BASE_URL" validate:"required,url"`
 
-	KafkaBrokers  []string `mapstructure:"KAFKA_BROKERS" validate:"required,dive,required"`
-	ConsumerGroup string   `mapstructure:"KAFKA_CONSUMER_GROUP"`
+	KafkaBrokers    []string `mapstructure:"KAFKA_BROKERS" validate:"required,dive,required"`
+	ConsumerGroup   string   `mapstructure:"KAFKA_CONSUMER_GROUP"`
+	ConsumerWorkers int      `mapstructure:"KAFKA_CONSUMER_WORKERS" validate:"gte=1"`
+
+	RetryMaxAttempts    int           `mapstructure:"KAFKA_RETRY_MAX_ATTEMPTS" validate:"gte=1"`
+	RetryInitialBackoff time.Duration `mapstructure:"KAFKA_RETRY_INITIAL_BACKOFF"`
+	RetryMaxBackoff     time.Duration `mapstructure:"KAFKA_RETRY_MAX_BACKOFF"`
+
+	EventDedupLease time.Duration `mapstructure:"EVENT_DEDUP_LEASE"`
+	EventDedupTTL   time.Duration `mapstructure:"EVENT_DEDUP_TTL"`
+
+	RedisHost     string `mapstructure:"REDIS_HOST" validate:"required"`
+	RedisPort     int    `mapstructure:"REDIS_PORT" validate:"required"`
+	RedisPassword string `mapstructure:"REDIS_PASSWORD"`
+	RedisDB       int    `mapstructure:"REDIS_DB"`
 
 	SMTPHost     string `mapstructure:"SMTP_HOST" validate:"required"`
 	SMTPPort     int    `mapstructure:"SMTP_PORT" validate:"required"`
 	SMTPUsername string `mapstructure:"SMTP_USERNAME" validate:"required"`
 	SMTPPassword string `mapstructure:"SMTP_PASSWORD" validate:"required"`
 	UseTLS       bool   `mapstructure:"USE_TLS"`
+
+	SMSProvider string `mapstructure:"SMS_PROVIDER" validate:"oneof=log"`
+	SMSLogFile  string `mapstructure:"SMS_LOG_FILE"`
+
+	// Metrics
+	MetricsAddr string `mapstructure:"METRICS_ADDR"`
+
+	// Tracing
+	TraceExporter    string  `mapstructure:"OTEL_TRACES_EXPORTER" validate:"oneof=none stdout otlp"`
+	OTLPEndpoint     string  `mapstructure:"OTEL_EXPORTER_OTLP_ENDPOINT"`
+	OTLPInsecure     bool    `mapstructure:"OTEL_EXPORTER_OTLP_INSECURE"`
+	TraceSampleRatio float64 `mapstructure:"OTEL_TRACES_SAMPLER_RATIO" validate:"gte=0,lte=1"`
 }
 
 var config Config
@@ -31,9 +58,22 @@ func LoadConfig() error {
 	viper.AutomaticEnv()
 
 	viper.SetDefault("SERVICE_NAME", "notification-service")
+	viper.SetDefault("METRICS_ADDR", ":9104")
+	viper.SetDefault("OTEL_TRACES_EXPORTER", "none")
+	viper.SetDefault("OTEL_EXPORTER_OTLP_ENDPOINT", "localhost:4317")
+	viper.SetDefault("OTEL_EXPORTER_OTLP_INSECURE", true)
+	viper.SetDefault("OTEL_TRACES_SAMPLER_RATIO", 1.0)
 	viper.SetDefault("ENV", "PROD")
 	viper.SetDefault("KAFKA_CONSUMER_GROUP", "notification-service-group")
 	viper.SetDefault("USE_TLS", true)
+	viper.SetDefault("SMS_PROVIDER", "log")
+	viper.SetDefault("KAFKA_CONSUMER_WORKERS", 4)
+	viper.SetDefault("KAFKA_RETRY_MAX_ATTEMPTS", 5)
+	viper.SetDefault("KAFKA_RETRY_INITIAL_BACKOFF", "1s")
+	viper.SetDefault("KAFKA_RETRY_MAX_BACKOFF", "5m")
+	viper.SetDefault("EVENT_DEDUP_LEASE", "5m")
+	viper.SetDefault("EVENT_DEDUP_TTL", "168h")
+	viper.SetDefault("REDIS_DB", 0)
 
 	if err := viper.Unmarshal(&config); err != nil {
 		return err
@@ -66,6 +106,46 @@ func GetKafkaConsumerGroup() string {
 	return config.ConsumerGroup
 }
 
+func GetKafkaConsumerWorkers() int {
+	return config.ConsumerWorkers
+}
+
+func GetRetryMaxAttempts() int {
+	return config.RetryMaxAttempts
+}
+
+func GetRetryInitialBackoff() time.Duration {
+	return config.RetryInitialBackoff
+}
+
+func GetRetryMaxBackoff() time.Duration {
+	return config.RetryMaxBackoff
+}
+
+func GetEventDedupLease() time.Duration {
+	return config.EventDedupLease
+}
+
+func GetEventDedupTTL() time.Duration {
+	return config.EventDedupTTL
+}
+
+func GetRedisHost() string {
+	return config.RedisHost
+}
+
+func GetRedisPort() int {
+	return config.RedisPort
+}
+
+func GetRedisPassword() string {
+	return config.RedisPassword
+}
+
+func GetRedisDB() int {
+	return config.RedisDB
+}
+
 func GetSMTPHost() string {
 	return config.SMTPHost
 }
@@ -85,3 +165,31 @@ func GetSMTPPassword() string {
 func GetUseTLS() bool {
 	return config.UseTLS
 }
+
+func GetSMSProvider() string {
+	return config.SMSProvider
+}
+
+func GetSMSLogFile() string {
+	return config.SMSLogFile
+}
+
+func GetMetricsAddr() string {
+	return config.MetricsAddr
+}
+
+func GetTraceExporter() string {
+	return config.TraceExporter
+}
+
+func GetOTLPEndpoint() string {
+	return config.OTLPEndpoint
+}
+
+func GetOTLPInsecure() bool {
+	return config.OTLPInsecure
+}
+
+func GetTraceSampleRatio() float64 {
+	return config.TraceSampleRatio
+}
@@ -2,7 +2,6 @@ package handlers
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/khoihuynh300/go-microservice/notification-service/internal/service"
@@ -16,16 +15,31 @@ const (
 	EmailVerifySuccessSubject   = "Email Verify Successfully"
 	ResetPasswordSubject        = "Reset Your Password"
 	ResetPasswordSuccessSubject = "Password Reset Successfully"
+	AccountLockedSubject        = "Your Account Has Been Locked"
+	RefreshTokenReusedSubject   = "Suspicious Sign-in Activity"
+	EmailChangeConfirmSubject   = "Confirm Your New Email"
+	EmailChangeRequestedSubject = "Email Change Requested"
+	EmailChangedSubject         = "Your Email Has Been Changed"
+	MagicLinkSubject            = "Your Sign-in Link"
+	DataExportReadySubject      = "Your Data Export Is Ready"
+	AccountSuspendedSubject     = "Your Account Has Been Suspended"
+	AccountReactivatedSubject   = "Your Account Has Been Reactivated"
+	PasswordResetForcedSubject  = "Please Reset Your Password"
+	SessionsRevokedSubject      = "You Have Been Signed Out"
 )
 
+const PhoneVerificationSMS = "Your verification code is %s. It expires at %s. Never share this code with anyone."
+
 type UserEventHandler struct {
 	emailService service.EmailService
+	smsService   service.SMSService
 	baseURL      string
 }
 
-func NewUserEventHandler(emailService service.EmailService, baseURL string) EventHandler {
+func NewUserEventHandler(emailService service.EmailService, smsService service.SMSService, baseURL string) EventHandler {
 	return &UserEventHandler{
 		emailService: emailService,
+		smsService:   smsService,
 		baseURL:      baseURL,
 	}
 }
@@ -42,6 +56,28 @@ func (h *UserEventHandler) HandleEvent(ctx context.Context, event *events.Event)
 		return h.handleUserForgotPassword(ctx, event)
 	case events.TypePasswordResetSuccessEvent:
 		return h.handlePasswordResetSuccess(ctx, event)
+	case events.TypeAccountLockedEvent:
+		return h.handleAccountLocked(ctx, event)
+	case events.TypeRefreshTokenReusedEvent:
+		return h.handleRefreshTokenReused(ctx, event)
+	case events.TypeEmailChangeRequestedEvent:
+		return h.handleEmailChangeRequested(ctx, event)
+	case events.TypeEmailChangedEvent:
+		return h.handleEmailChanged(ctx, event)
+	case events.TypeMagicLinkRequestedEvent:
+		return h.handleMagicLinkRequested(ctx, event)
+	case events.TypePhoneVerificationRequestedEvent:
+		return h.handlePhoneVerificationRequested(ctx, event)
+	case events.TypeDataExportReadyEvent:
+		return h.handleDataExportReady(ctx, event)
+	case events.TypeUserSuspendedEvent:
+		return h.handleUserSuspended(ctx, event)
+	case events.TypeUserReactivatedEvent:
+		return h.handleUserReactivated(ctx, event)
+	case events.TypePasswordResetForcedEvent:
+		return h.handlePasswordResetForced(ctx, event)
+	case events.TypeSessionsRevokedEvent:
+		return h.handleSessionsRevoked(ctx, event)
 	default:
 		logger.Warn("Unhandled event type", zap.String("event_type", event.EventType))
 		return nil
@@ -51,13 +87,8 @@ func (h *UserEventHandler) HandleEvent(ctx context.Context, event *events.Event)
 func (h *UserEventHandler) handleUserRegistered(ctx context.Context, event *events.Event) error {
 	logger := zaplogger.FromContext(ctx)
 
-	jsonData, err := json.Marshal(event.Data)
+	payload, err := events.Decode[*events.UserRegisteredEvent](event)
 	if err != nil {
-		return fmt.Errorf("failed to marshal event data: %w", err)
-	}
-
-	var payload events.UserRegisteredEvent
-	if err := json.Unmarshal(jsonData, &payload); err != nil {
 		return fmt.Errorf("invalid event data format: %w", err)
 	}
 
@@ -82,13 +113,8 @@ func (h *UserEventHandler) handleUserRegistered(ctx context.Context, event *even
 func (h *UserEventHandler) handleEmailVerifySuccess(ctx context.Context, event *events.Event) error {
 	logger := zaplogger.FromContext(ctx)
 
-	jsonData, err := json.Marshal(event.Data)
+	payload, err := events.Decode[*events.EmailVerifySuccessEvent](event)
 	if err != nil {
-		return fmt.Errorf("failed to marshal event data: %w", err)
-	}
-
-	var payload events.EmailVerifySuccessEvent
-	if err := json.Unmarshal(jsonData, &payload); err != nil {
 		return fmt.Errorf("invalid event data format: %w", err)
 	}
 
@@ -110,13 +136,8 @@ func (h *UserEventHandler) handleEmailVerifySuccess(ctx context.Context, event *
 func (h *UserEventHandler) handleUserForgotPassword(ctx context.Context, event *events.Event) error {
 	logger := zaplogger.FromContext(ctx)
 
-	jsonData, err := json.Marshal(event.Data)
+	payload, err := events.Decode[*events.UserForgotPasswordEvent](event)
 	if err != nil {
-		return fmt.Errorf("failed to marshal event data: %w", err)
-	}
-
-	var payload events.UserForgotPasswordEvent
-	if err := json.Unmarshal(jsonData, &payload); err != nil {
 		return fmt.Errorf("invalid event data format: %w", err)
 	}
 
@@ -141,13 +162,8 @@ func (h *UserEventHandler) handleUserForgotPassword(ctx context.Context, event *
 func (h *UserEventHandler) handlePasswordResetSuccess(ctx context.Context, event *events.Event) error {
 	logger := zaplogger.FromContext(ctx)
 
-	jsonData, err := json.Marshal(event.Data)
+	payload, err := events.Decode[*events.UserPasswordResetSuccessEvent](event)
 	if err != nil {
-		return fmt.Errorf("failed to marshal event data: %w", err)
-	}
-
-	var payload events.UserPasswordResetSuccessEvent
-	if err := json.Unmarshal(jsonData, &payload); err != nil {
 		return fmt.Errorf("invalid event data format: %w", err)
 	}
 
@@ -165,3 +181,296 @@ func (h *UserEventHandler) handlePasswordResetSuccess(ctx context.Context, event
 	logger.Info("Password reset success event handled successfully", zap.String("email", payload.Email))
 	return nil
 }
+
+func (h *UserEventHandler) handleAccountLocked(ctx context.Context, event *events.Event) error {
+	logger := zaplogger.FromContext(ctx)
+
+	payload, err := events.Decode[*events.AccountLockedEvent](event)
+	if err != nil {
+		return fmt.Errorf("invalid event data format: %w", err)
+	}
+
+	unlockLink := fmt.Sprintf("%s/unlock-account?token=%s",
+		h.baseURL, payload.Token)
+	resetLink := fmt.Sprintf("%s/forgot-password", h.baseURL)
+
+	emailData := map[string]any{
+		"Subject":     AccountLockedSubject,
+		"FullName":    payload.FullName,
+		"IPAddress":   payload.IpAddress,
+		"LockedUntil": payload.LockedUntil.AsTime().Local().Format("15:04 02/01/2006"),
+		"UnlockLink":  unlockLink,
+		"ResetLink":   resetLink,
+	}
+
+	if err := h.emailService.SendTemplateEmail(ctx, "account_locked", []string{payload.Email}, emailData); err != nil {
+		logger.Error("Failed to send account locked email", zap.Error(err))
+		return fmt.Errorf("failed to send account locked email: %w", err)
+	}
+
+	logger.Info("Account locked event handled successfully", zap.String("email", payload.Email))
+	return nil
+}
+
+func (h *UserEventHandler) handleRefreshTokenReused(ctx context.Context, event *events.Event) error {
+	logger := zaplogger.FromContext(ctx)
+
+	payload, err := events.Decode[*events.RefreshTokenReusedEvent](event)
+	if err != nil {
+		return fmt.Errorf("invalid event data format: %w", err)
+	}
+
+	resetLink := fmt.Sprintf("%s/forgot-password", h.baseURL)
+
+	emailData := map[string]any{
+		"Subject":    RefreshTokenReusedSubject,
+		"FullName":   payload.FullName,
+		"IPAddress":  payload.IpAddress,
+		"UserAgent":  payload.UserAgent,
+		"DetectedAt": payload.DetectedAt.AsTime().Local().Format("15:04 02/01/2006"),
+		"ResetLink":  resetLink,
+	}
+
+	if err := h.emailService.SendTemplateEmail(ctx, "refresh_token_reused", []string{payload.Email}, emailData); err != nil {
+		logger.Error("Failed to send refresh token reused email", zap.Error(err))
+		return fmt.Errorf("failed to send refresh token reused email: %w", err)
+	}
+
+	logger.Info("Refresh token reused event handled successfully", zap.String("email", payload.Email))
+	return nil
+}
+
+func (h *UserEventHandler) handleEmailChangeRequested(ctx context.Context, event *events.Event) error {
+	logger := zaplogger.FromContext(ctx)
+
+	payload, err := events.Decode[*events.EmailChangeRequestedEvent](event)
+	if err != nil {
+		return fmt.Errorf("invalid event data format: %w", err)
+	}
+
+	confirmLink := fmt.Sprintf("%s/confirm-email-change?token=%s",
+		h.baseURL, payload.Token)
+
+	confirmData := map[string]any{
+		"Subject":     EmailChangeConfirmSubject,
+		"FullName":    payload.FullName,
+		"NewEmail":    payload.NewEmail,
+		"ConfirmLink": confirmLink,
+	}
+
+	if err := h.emailService.SendTemplateEmail(ctx, "email_change_confirm", []string{payload.NewEmail}, confirmData); err != nil {
+		logger.Error("Failed to send email change confirmation email", zap.Error(err))
+		return fmt.Errorf("failed to send email change confirmation email: %w", err)
+	}
+
+	noticeData := map[string]any{
+		"Subject":   EmailChangeRequestedSubject,
+		"FullName":  payload.FullName,
+		"NewEmail":  payload.NewEmail,
+		"ResetLink": fmt.Sprintf("%s/forgot-password", h.baseURL),
+	}
+
+	if err := h.emailService.SendTemplateEmail(ctx, "email_change_requested", []string{payload.OldEmail}, noticeData); err != nil {
+		logger.Error("Failed to send email change notice email", zap.Error(err))
+		return fmt.Errorf("failed to send email change notice email: %w", err)
+	}
+
+	logger.Info("Email change requested event handled successfully", zap.String("email", payload.OldEmail))
+	return nil
+}
+
+func (h *UserEventHandler) handleEmailChanged(ctx context.Context, event *events.Event) error {
+	logger := zaplogger.FromContext(ctx)
+
+	payload, err := events.Decode[*events.EmailChangedEvent](event)
+	if err != nil {
+		return fmt.Errorf("invalid event data format: %w", err)
+	}
+
+	emailData := map[string]any{
+		"Subject":   EmailChangedSubject,
+		"FullName":  payload.FullName,
+		"NewEmail":  payload.NewEmail,
+		"ChangedAt": payload.ChangedAt.AsTime().Local().Format("15:04 02/01/2006"),
+	}
+
+	if err := h.emailService.SendTemplateEmail(ctx, "email_changed", []string{payload.OldEmail}, emailData); err != nil {
+		logger.Error("Failed to send email changed email", zap.Error(err))
+		return fmt.Errorf("failed to send email changed email: %w", err)
+	}
+
+	logger.Info("Email changed event handled successfully", zap.String("email", payload.OldEmail))
+	return nil
+}
+
+func (h *UserEventHandler) handleMagicLinkRequested(ctx context.Context, event *events.Event) error {
+	logger := zaplogger.FromContext(ctx)
+
+	payload, err := events.Decode[*events.MagicLinkRequestedEvent](event)
+	if err != nil {
+		return fmt.Errorf("invalid event data format: %w", err)
+	}
+
+	loginLink := fmt.Sprintf("%s/magic-link?token=%s",
+		h.baseURL, payload.Token)
+
+	emailData := map[string]any{
+		"Subject":   MagicLinkSubject,
+		"FullName":  payload.FullName,
+		"LoginLink": loginLink,
+	}
+
+	if err := h.emailService.SendTemplateEmail(ctx, "magic_link", []string{payload.Email}, emailData); err != nil {
+		logger.Error("Failed to send magic link email", zap.Error(err))
+		return fmt.Errorf("failed to send magic link email: %w", err)
+	}
+
+	logger.Info("Magic link requested event handled successfully", zap.String("email", payload.Email))
+	return nil
+}
+
+func (h *UserEventHandler) handlePhoneVerificationRequested(ctx context.Context, event *events.Event) error {
+	logger := zaplogger.FromContext(ctx)
+
+	payload, err := events.Decode[*events.PhoneVerificationRequestedEvent](event)
+	if err != nil {
+		return fmt.Errorf("invalid event data format: %w", err)
+	}
+
+	sms := &service.SMS{
+		To:   payload.Phone,
+		Body: fmt.Sprintf(PhoneVerificationSMS, payload.Code, payload.ExpiresAt.AsTime().Local().Format("15:04 02/01/2006")),
+	}
+
+	if err := h.smsService.SendSMS(ctx, sms); err != nil {
+		logger.Error("Failed to send phone verification sms", zap.Error(err))
+		return fmt.Errorf("failed to send phone verification sms: %w", err)
+	}
+
+	logger.Info("Phone verification requested event handled successfully", zap.String("user_id", payload.UserId))
+	return nil
+}
+
+func (h *UserEventHandler) handleDataExportReady(ctx context.Context, event *events.Event) error {
+	logger := zaplogger.FromContext(ctx)
+
+	payload, err := events.Decode[*events.DataExportReadyEvent](event)
+	if err != nil {
+		return fmt.Errorf("invalid event data format: %w", err)
+	}
+
+	emailData := map[string]any{
+		"Subject":      DataExportReadySubject,
+		"FullName":     payload.FullName,
+		"DownloadLink": payload.DownloadUrl,
+		"ExpiresAt":    payload.ExpiresAt.AsTime().Local().Format("15:04 02/01/2006"),
+	}
+
+	if err := h.emailService.SendTemplateEmail(ctx, "data_export_ready", []string{payload.Email}, emailData); err != nil {
+		logger.Error("Failed to send data export ready email", zap.Error(err))
+		return fmt.Errorf("failed to send data export ready email: %w", err)
+	}
+
+	logger.Info("Data export ready event handled successfully", zap.String("email", payload.Email))
+	return nil
+}
+
+func (h *UserEventHandler) handleUserSuspended(ctx context.Context, event *events.Event) error {
+	logger := zaplogger.FromContext(ctx)
+
+	payload, err := events.Decode[*events.UserSuspendedEvent](event)
+	if err != nil {
+		return fmt.Errorf("invalid event data format: %w", err)
+	}
+
+	emailData := map[string]any{
+		"Subject":     AccountSuspendedSubject,
+		"FullName":    payload.FullName,
+		"Reason":      payload.Reason,
+		"SuspendedAt": payload.SuspendedAt.AsTime().Local().Format("15:04 02/01/2006"),
+	}
+
+	if err := h.emailService.SendTemplateEmail(ctx, "account_suspended", []string{payload.Email}, emailData); err != nil {
+		logger.Error("Failed to send account suspended email", zap.Error(err))
+		return fmt.Errorf("failed to send account suspended email: %w", err)
+	}
+
+	logger.Info("User suspended event handled successfully", zap.String("email", payload.Email))
+	return nil
+}
+
+func (h *UserEventHandler) handleUserReactivated(ctx context.Context, event *events.Event) error {
+	logger := zaplogger.FromContext(ctx)
+
+	payload, err := events.Decode[*events.UserReactivatedEvent](event)
+	if err != nil {
+		return fmt.Errorf("invalid event data format: %w", err)
+	}
+
+	emailData := map[string]any{
+		"Subject":       AccountReactivatedSubject,
+		"FullName":      payload.FullName,
+		"ReactivatedAt": payload.ReactivatedAt.AsTime().Local().Format("15:04 02/01/2006"),
+		"HomePageLink":  h.baseURL,
+	}
+
+	if err := h.emailService.SendTemplateEmail(ctx, "account_reactivated", []string{payload.Email}, emailData); err != nil {
+		logger.Error("Failed to send account reactivated email", zap.Error(err))
+		return fmt.Errorf("failed to send account reactivated email: %w", err)
+	}
+
+	logger.Info("User reactivated event handled successfully", zap.String("email", payload.Email))
+	return nil
+}
+
+func (h *UserEventHandler) handlePasswordResetForced(ctx context.Context, event *events.Event) error {
+	logger := zaplogger.FromContext(ctx)
+
+	payload, err := events.Decode[*events.PasswordResetForcedEvent](event)
+	if err != nil {
+		return fmt.Errorf("invalid event data format: %w", err)
+	}
+
+	resetLink := fmt.Sprintf("%s/reset-password?token=%s",
+		h.baseURL, payload.Token)
+
+	emailData := map[string]any{
+		"Subject":   PasswordResetForcedSubject,
+		"FullName":  payload.FullName,
+		"ResetLink": resetLink,
+	}
+
+	if err := h.emailService.SendTemplateEmail(ctx, "password_reset_forced", []string{payload.Email}, emailData); err != nil {
+		logger.Error("Failed to send password reset forced email", zap.Error(err))
+		return fmt.Errorf("failed to send password reset forced email: %w", err)
+	}
+
+	logger.Info("Password reset forced event handled successfully", zap.String("email", payload.Email))
+	return nil
+}
+
+func (h *UserEventHandler) handleSessionsRevoked(ctx context.Context, event *events.Event) error {
+	logger := zaplogger.FromContext(ctx)
+
+	payload, err := events.Decode[*events.SessionsRevokedEvent](event)
+	if err != nil {
+		return fmt.Errorf("invalid event data format: %w", err)
+	}
+
+	resetLink := fmt.Sprintf("%s/forgot-password", h.baseURL)
+
+	emailData := map[string]any{
+		"Subject":   SessionsRevokedSubject,
+		"FullName":  payload.FullName,
+		"RevokedAt": payload.RevokedAt.AsTime().Local().Format("15:04 02/01/2006"),
+		"ResetLink": resetLink,
+	}
+
+	if err := h.emailService.SendTemplateEmail(ctx, "sessions_revoked", []string{payload.Email}, emailData); err != nil {
+		logger.Error("Failed to send sessions revoked email", zap.Error(err))
+		return fmt.Errorf("failed to send sessions revoked email: %w", err)
+	}
+
+	logger.Info("Sessions revoked event handled successfully", zap.String("email", payload.Email))
+	return nil
+}
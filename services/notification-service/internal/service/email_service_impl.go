@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"net/smtp"
 	"strings"
+	"time"
 
 	"github.com/khoihuynh300/go-microservice/notification-service/internal/template"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/metrics"
 )
 
 type emailService struct {
@@ -40,7 +42,10 @@ func NewEmailService(
 func (s *emailService) SendEmail(ctx context.Context, email *Email) error {
 	message := s.buildMessage(email)
 
-	if err := s.sendSMTP(email.To, message); err != nil {
+	start := time.Now()
+	err := s.sendSMTP(email.To, message)
+	metrics.ObserveSMTPSend(err, time.Since(start))
+	if err != nil {
 		return fmt.Errorf("failed to send email: %w", err)
 	}
 
@@ -0,0 +1,14 @@
+package service
+
+import "context"
+
+type SMS struct {
+	To   string
+	Body string
+}
+
+// SMSService sends text messages through a provider. Only the log sink exists
+// so far; a real gateway plugs in behind the same interface.
+type SMSService interface {
+	SendSMS(ctx context.Context, sms *SMS) error
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	zaplogger "github.com/khoihuynh300/go-microservice/shared/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// logSMSService delivers nothing. It appends each message as a JSON line to
+// a file, or logs it when no file is set, for development and tests.
+type logSMSService struct {
+	path string
+	mu   sync.Mutex
+}
+
+func NewLogSMSService(path string) SMSService {
+	return &logSMSService{
+		path: path,
+	}
+}
+
+type smsRecord struct {
+	To     string    `json:"to"`
+	Body   string    `json:"body"`
+	SentAt time.Time `json:"sent_at"`
+}
+
+func (s *logSMSService) SendSMS(ctx context.Context, sms *SMS) error {
+	if s.path == "" {
+		zaplogger.FromContext(ctx).Info("SMS sent to log sink",
+			zap.String("to", sms.To),
+			zap.String("body", sms.Body),
+		)
+		return nil
+	}
+
+	line, err := json.Marshal(&smsRecord{To: sms.To, Body: sms.Body, SentAt: time.Now()})
+	if err != nil {
+		return fmt.Errorf("failed to marshal sms: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.OpenFile(s.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open sms log: %w", err)
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write sms log: %w", err)
+	}
+	return f.Close()
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/khoihuynh300/go-microservice/order-service/internal/config"
+	"github.com/khoihuynh300/go-microservice/order-service/internal/server"
+	zaplogger "github.com/khoihuynh300/go-microservice/shared/pkg/logger"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/metrics"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/telemetry"
+	"go.uber.org/zap"
+)
+
+func main() {
+	if err := run(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run() error {
+	err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	logger, err := zaplogger.New(config.GetServiceName(), config.GetEnv())
+	if err != nil {
+		return err
+	}
+	defer logger.Sync()
+
+	shutdownTracer, err := telemetry.InitTracer(context.Background(), telemetry.Config{
+		ServiceName:  config.GetServiceName(),
+		Env:          config.GetEnv(),
+		Exporter:     config.GetTraceExporter(),
+		OTLPEndpoint: config.GetOTLPEndpoint(),
+		OTLPInsecure: config.GetOTLPInsecure(),
+		SampleRatio:  config.GetTraceSampleRatio(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to initialize tracer: %w", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracer(ctx); err != nil {
+			logger.Error("failed to flush traces", zap.Error(err))
+		}
+	}()
+
+	shutdownMetrics := metrics.Serve(config.GetMetricsAddr(), logger)
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownMetrics(ctx); err != nil {
+			logger.Error("failed to stop metrics server", zap.Error(err))
+		}
+	}()
+
+	srv, err := server.New(logger)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		if err := srv.Run(); err != nil {
+			logger.Error("failed to serve grpc server", zap.Error(err))
+		}
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+
+	<-quit
+	logger.Info("shutdown signal received")
+
+	done := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		logger.Info("grpc server stopped gracefully")
+	case <-time.After(10 * time.Second):
+		logger.Warn("graceful shutdown timeout, force stop")
+		srv.Stop()
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package caching
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/khoihuynh300/go-microservice/order-service/internal/domain/models"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	CartPrefix = "order:cart"
+)
+
+const (
+	CartTTL = 30 * 24 * time.Hour
+
+	// maxUpdateAttempts bounds how often an update is retried when another
+	// request changed the same cart between the read and the write.
+	maxUpdateAttempts = 5
+)
+
+type CartCache interface {
+	Get(ctx context.Context, userID uuid.UUID) (*models.Cart, error)
+
+	// Update applies fn to the user's current cart and stores the result
+	// atomically. If the cart changes concurrently fn runs again on the fresh
+	// copy, so it must not have side effects. An error from fn aborts the
+	// update and is returned as is. A cart left without items is deleted.
+	Update(ctx context.Context, userID uuid.UUID, fn func(cart *models.Cart) error) (*models.Cart, error)
+
+	Delete(ctx context.Context, userID uuid.UUID) error
+}
+
+type cartCache struct {
+	client *redis.Client
+}
+
+func NewCartCache(client *redis.Client) CartCache {
+	return &cartCache{
+		client: client,
+	}
+}
+
+func (cc *cartCache) Get(ctx context.Context, userID uuid.UUID) (*models.Cart, error) {
+	return getCart(ctx, cc.client, userID)
+}
+
+func (cc *cartCache) Update(ctx context.Context, userID uuid.UUID, fn func(cart *models.Cart) error) (*models.Cart, error) {
+	key := cartKey(userID)
+
+	var cart *models.Cart
+	txf := func(tx *redis.Tx) error {
+		var err error
+		cart, err = getCart(ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+
+		if err := fn(cart); err != nil {
+			return err
+		}
+		cart.UpdatedAt = time.Now()
+
+		if len(cart.Items) == 0 {
+			_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+				return pipe.Del(ctx, key).Err()
+			})
+			return err
+		}
+
+		data, err := json.Marshal(cart)
+		if err != nil {
+			return fmt.Errorf("failed to marshal cart: %w", err)
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			return pipe.Set(ctx, key, data, CartTTL).Err()
+		})
+		return err
+	}
+
+	for range maxUpdateAttempts {
+		err := cc.client.Watch(ctx, txf, key)
+		if errors.Is(err, redis.TxFailedErr) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return cart, nil
+	}
+
+	return nil, fmt.Errorf("failed to save cart: %w", redis.TxFailedErr)
+}
+
+func (cc *cartCache) Delete(ctx context.Context, userID uuid.UUID) error {
+	if err := cc.client.Del(ctx, cartKey(userID)).Err(); err != nil {
+		return fmt.Errorf("failed to delete cart: %w", err)
+	}
+
+	return nil
+}
+
+func getCart(ctx context.Context, client redis.Cmdable, userID uuid.UUID) (*models.Cart, error) {
+	data, err := client.Get(ctx, cartKey(userID)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return &models.Cart{UserID: userID, Items: []*models.CartItem{}}, nil
+		}
+		return nil, fmt.Errorf("failed to get cart: %w", err)
+	}
+
+	var cart models.Cart
+	if err := json.Unmarshal(data, &cart); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal cart: %w", err)
+	}
+
+	return &cart, nil
+}
+
+func cartKey(userID uuid.UUID) string {
+	return fmt.Sprintf("%s:%s", CartPrefix, userID.String())
+}
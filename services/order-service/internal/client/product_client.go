@@ -0,0 +1,32 @@
+package client
+
+import (
+	"context"
+
+	apperr "github.com/khoihuynh300/go-microservice/shared/pkg/errors"
+	productpb "github.com/khoihuynh300/go-microservice/shared/proto/product"
+	"google.golang.org/grpc"
+)
+
+type ProductClient interface {
+	GetProductsByIDs(ctx context.Context, ids []string) ([]*productpb.ProductSummary, error)
+}
+
+type productClient struct {
+	client productpb.ProductServiceClient
+}
+
+func NewProductClient(conn *grpc.ClientConn) ProductClient {
+	return &productClient{
+		client: productpb.NewProductServiceClient(conn),
+	}
+}
+
+func (c *productClient) GetProductsByIDs(ctx context.Context, ids []string) ([]*productpb.ProductSummary, error) {
+	resp, err := c.client.GetProductsByIDs(ctx, &productpb.GetProductsByIDsRequest{Ids: ids})
+	if err != nil {
+		return nil, apperr.FromGRPCError(err)
+	}
+
+	return resp.Products, nil
+}
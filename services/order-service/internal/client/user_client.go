@@ -0,0 +1,32 @@
+package client
+
+import (
+	"context"
+
+	apperr "github.com/khoihuynh300/go-microservice/shared/pkg/errors"
+	userpb "github.com/khoihuynh300/go-microservice/shared/proto/user"
+	"google.golang.org/grpc"
+)
+
+type UserClient interface {
+	GetUserAddress(ctx context.Context, addressID string) (*userpb.Address, error)
+}
+
+type userClient struct {
+	client userpb.UserServiceClient
+}
+
+func NewUserClient(conn *grpc.ClientConn) UserClient {
+	return &userClient{
+		client: userpb.NewUserServiceClient(conn),
+	}
+}
+
+func (c *userClient) GetUserAddress(ctx context.Context, addressID string) (*userpb.Address, error) {
+	resp, err := c.client.GetUserAddress(ctx, &userpb.GetUserAddressRequest{AddressId: addressID})
+	if err != nil {
+		return nil, apperr.FromGRPCError(err)
+	}
+
+	return resp.Address, nil
+}
@@ -0,0 +1,128 @@
+package config
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/spf13/viper"
+)
+
+type Config struct {
+	// Service
+	ServiceName string `mapstructure:"SERVICE_NAME"`
+	GRPCAddr    string `mapstructure:"GRPC_ADDR"`
+	Env         string `mapstructure:"ENV"`
+
+	// Database
+	DBUrl string `mapstructure:"DATABASE_URL" validate:"required"`
+
+	// Redis
+	RedisHost     string `mapstructure:"REDIS_HOST" validate:"required"`
+	RedisPort     int    `mapstructure:"REDIS_PORT" validate:"required"`
+	RedisPassword string `mapstructure:"REDIS_PASSWORD"`
+	RedisDB       int    `mapstructure:"REDIS_DB"`
+
+	// Upstream services
+	UserServiceURL    string `mapstructure:"USER_SERVICE_URL" validate:"required"`
+	ProductServiceURL string `mapstructure:"PRODUCT_SERVICE_URL" validate:"required"`
+
+	// Metrics
+	MetricsAddr string `mapstructure:"METRICS_ADDR"`
+
+	// Tracing
+	TraceExporter    string  `mapstructure:"OTEL_TRACES_EXPORTER" validate:"oneof=none stdout otlp"`
+	OTLPEndpoint     string  `mapstructure:"OTEL_EXPORTER_OTLP_ENDPOINT"`
+	OTLPInsecure     bool    `mapstructure:"OTEL_EXPORTER_OTLP_INSECURE"`
+	TraceSampleRatio float64 `mapstructure:"OTEL_TRACES_SAMPLER_RATIO" validate:"gte=0,lte=1"`
+}
+
+var config Config
+
+func LoadConfig() error {
+	validate := validator.New()
+
+	viper.SetConfigFile(".env")
+	if err := viper.ReadInConfig(); err != nil {
+		return err
+	}
+
+	viper.AutomaticEnv()
+
+	viper.SetDefault("ENV", "DEV")
+	viper.SetDefault("SERVICE_NAME", "order-service")
+	viper.SetDefault("METRICS_ADDR", ":9103")
+	viper.SetDefault("OTEL_TRACES_EXPORTER", "none")
+	viper.SetDefault("OTEL_EXPORTER_OTLP_ENDPOINT", "localhost:4317")
+	viper.SetDefault("OTEL_EXPORTER_OTLP_INSECURE", true)
+	viper.SetDefault("OTEL_TRACES_SAMPLER_RATIO", 1.0)
+	viper.SetDefault("GRPC_ADDR", "localhost:5000")
+	viper.SetDefault("REDIS_DB", 0)
+
+	if err := viper.Unmarshal(&config); err != nil {
+		return err
+	}
+
+	if err := validate.Struct(config); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func GetServiceName() string {
+	return config.ServiceName
+}
+
+func GetGRPCAddr() string {
+	return config.GRPCAddr
+}
+
+func GetEnv() string {
+	return config.Env
+}
+
+func GetDBUrl() string {
+	return config.DBUrl
+}
+
+func GetRedisHost() string {
+	return config.RedisHost
+}
+
+func GetRedisPort() int {
+	return config.RedisPort
+}
+
+func GetRedisPassword() string {
+	return config.RedisPassword
+}
+
+func GetRedisDB() int {
+	return config.RedisDB
+}
+
+func GetUserServiceURL() string {
+	return config.UserServiceURL
+}
+
+func GetProductServiceURL() string {
+	return config.ProductServiceURL
+}
+
+func GetMetricsAddr() string {
+	return config.MetricsAddr
+}
+
+func GetTraceExporter() string {
+	return config.TraceExporter
+}
+
+func GetOTLPEndpoint() string {
+	return config.OTLPEndpoint
+}
+
+func GetOTLPInsecure() bool {
+	return config.OTLPInsecure
+}
+
+func GetTraceSampleRatio() float64 {
+	return config.TraceSampleRatio
+}
@@ -0,0 +1,90 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+
+package sqlc
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type OrderStatusEnum string
+
+const (
+	OrderStatusEnumPending   OrderStatusEnum = "pending"
+	OrderStatusEnumConfirmed OrderStatusEnum = "confirmed"
+	OrderStatusEnumShipping  OrderStatusEnum = "shipping"
+	OrderStatusEnumDelivered OrderStatusEnum = "delivered"
+	OrderStatusEnumCancelled OrderStatusEnum = "cancelled"
+)
+
+func (e *OrderStatusEnum) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = OrderStatusEnum(s)
+	case string:
+		*e = OrderStatusEnum(s)
+	default:
+		return fmt.Errorf("unsupported scan type for OrderStatusEnum: %T", src)
+	}
+	return nil
+}
+
+type NullOrderStatusEnum struct {
+	OrderStatusEnum OrderStatusEnum
+	Valid           bool // Valid is true if OrderStatusEnum is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullOrderStatusEnum) Scan(value interface{}) error {
+	if value == nil {
+		ns.OrderStatusEnum, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.OrderStatusEnum.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullOrderStatusEnum) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.OrderStatusEnum), nil
+}
+
+type Order struct {
+	ID                   uuid.UUID
+	UserID               uuid.UUID
+	Status               OrderStatusEnum
+	TotalAmount          pgtype.Numeric
+	Note                 pgtype.Text
+	ShippingFullName     string
+	ShippingPhone        string
+	ShippingAddressLine1 string
+	ShippingAddressLine2 pgtype.Text
+	ShippingWard         string
+	ShippingCity         string
+	ShippingCountry      string
+	CancelReason         pgtype.Text
+	CancelledAt          pgtype.Timestamptz
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+}
+
+type OrderItem struct {
+	ID          uuid.UUID
+	OrderID     uuid.UUID
+	ProductID   uuid.UUID
+	ProductName string
+	ProductSku  string
+	UnitPrice   pgtype.Numeric
+	Quantity    int32
+	Subtotal    pgtype.Numeric
+	CreatedAt   time.Time
+}
@@ -0,0 +1,141 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: order_items.sql
+
+package sqlc
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createOrderItem = `-- name: CreateOrderItem :one
+INSERT INTO order_items (
+    id,
+    order_id,
+    product_id,
+    product_name,
+    product_sku,
+    unit_price,
+    quantity,
+    subtotal,
+    created_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING id, order_id, product_id, product_name, product_sku, unit_price, quantity, subtotal, created_at
+`
+
+type CreateOrderItemParams struct {
+	ID          uuid.UUID
+	OrderID     uuid.UUID
+	ProductID   uuid.UUID
+	ProductName string
+	ProductSku  string
+	UnitPrice   pgtype.Numeric
+	Quantity    int32
+	Subtotal    pgtype.Numeric
+	CreatedAt   time.Time
+}
+
+func (q *Queries) CreateOrderItem(ctx context.Context, arg CreateOrderItemParams) (OrderItem, error) {
+	row := q.db.QueryRow(ctx, createOrderItem,
+		arg.ID,
+		arg.OrderID,
+		arg.ProductID,
+		arg.ProductName,
+		arg.ProductSku,
+		arg.UnitPrice,
+		arg.Quantity,
+		arg.Subtotal,
+		arg.CreatedAt,
+	)
+	var i OrderItem
+	err := row.Scan(
+		&i.ID,
+		&i.OrderID,
+		&i.ProductID,
+		&i.ProductName,
+		&i.ProductSku,
+		&i.UnitPrice,
+		&i.Quantity,
+		&i.Subtotal,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listOrderItemsByOrderID = `-- name: ListOrderItemsByOrderID :many
+SELECT id, order_id, product_id, product_name, product_sku, unit_price, quantity, subtotal, created_at FROM order_items
+WHERE order_id = $1
+ORDER BY created_at ASC, id ASC
+`
+
+func (q *Queries) ListOrderItemsByOrderID(ctx context.Context, orderID uuid.UUID) ([]OrderItem, error) {
+	rows, err := q.db.Query(ctx, listOrderItemsByOrderID, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []OrderItem
+	for rows.Next() {
+		var i OrderItem
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrderID,
+			&i.ProductID,
+			&i.ProductName,
+			&i.ProductSku,
+			&i.UnitPrice,
+			&i.Quantity,
+			&i.Subtotal,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOrderItemsByOrderIDs = `-- name: ListOrderItemsByOrderIDs :many
+SELECT id, order_id, product_id, product_name, product_sku, unit_price, quantity, subtotal, created_at FROM order_items
+WHERE order_id = ANY($1::uuid[])
+ORDER BY created_at ASC, id ASC
+`
+
+func (q *Queries) ListOrderItemsByOrderIDs(ctx context.Context, dollar_1 []uuid.UUID) ([]OrderItem, error) {
+	rows, err := q.db.Query(ctx, listOrderItemsByOrderIDs, dollar_1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []OrderItem
+	for rows.Next() {
+		var i OrderItem
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrderID,
+			&i.ProductID,
+			&i.ProductName,
+			&i.ProductSku,
+			&i.UnitPrice,
+			&i.Quantity,
+			&i.Subtotal,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
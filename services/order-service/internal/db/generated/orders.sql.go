@@ -0,0 +1,251 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: orders.sql
+
+package sqlc
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const cancelOrder = `-- name: CancelOrder :execrows
+UPDATE orders
+SET
+    status = 'cancelled',
+    cancel_reason = $2,
+    cancelled_at = $3,
+    updated_at = $3
+WHERE id = $1 AND status IN ('pending', 'confirmed')
+`
+
+type CancelOrderParams struct {
+	ID           uuid.UUID
+	CancelReason pgtype.Text
+	CancelledAt  pgtype.Timestamptz
+}
+
+func (q *Queries) CancelOrder(ctx context.Context, arg CancelOrderParams) (int64, error) {
+	result, err := q.db.Exec(ctx, cancelOrder, arg.ID, arg.CancelReason, arg.CancelledAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const countOrdersByUserID = `-- name: CountOrdersByUserID :one
+SELECT COUNT(*) FROM orders
+WHERE
+    user_id = $1
+    AND ($2::order_status_enum IS NULL OR status = $2)
+`
+
+type CountOrdersByUserIDParams struct {
+	UserID uuid.UUID
+	Status NullOrderStatusEnum
+}
+
+func (q *Queries) CountOrdersByUserID(ctx context.Context, arg CountOrdersByUserIDParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countOrdersByUserID, arg.UserID, arg.Status)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createOrder = `-- name: CreateOrder :one
+INSERT INTO orders (
+    id,
+    user_id,
+    status,
+    total_amount,
+    note,
+    shipping_full_name,
+    shipping_phone,
+    shipping_address_line1,
+    shipping_address_line2,
+    shipping_ward,
+    shipping_city,
+    shipping_country,
+    created_at,
+    updated_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
+) RETURNING id, user_id, status, total_amount, note, shipping_full_name, shipping_phone, shipping_address_line1, shipping_address_line2, shipping_ward, shipping_city, shipping_country, cancel_reason, cancelled_at, created_at, updated_at
+`
+
+type CreateOrderParams struct {
+	ID                   uuid.UUID
+	UserID               uuid.UUID
+	Status               OrderStatusEnum
+	TotalAmount          pgtype.Numeric
+	Note                 pgtype.Text
+	ShippingFullName     string
+	ShippingPhone        string
+	ShippingAddressLine1 string
+	ShippingAddressLine2 pgtype.Text
+	ShippingWard         string
+	ShippingCity         string
+	ShippingCountry      string
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+}
+
+func (q *Queries) CreateOrder(ctx context.Context, arg CreateOrderParams) (Order, error) {
+	row := q.db.QueryRow(ctx, createOrder,
+		arg.ID,
+		arg.UserID,
+		arg.Status,
+		arg.TotalAmount,
+		arg.Note,
+		arg.ShippingFullName,
+		arg.ShippingPhone,
+		arg.ShippingAddressLine1,
+		arg.ShippingAddressLine2,
+		arg.ShippingWard,
+		arg.ShippingCity,
+		arg.ShippingCountry,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	var i Order
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Status,
+		&i.TotalAmount,
+		&i.Note,
+		&i.ShippingFullName,
+		&i.ShippingPhone,
+		&i.ShippingAddressLine1,
+		&i.ShippingAddressLine2,
+		&i.ShippingWard,
+		&i.ShippingCity,
+		&i.ShippingCountry,
+		&i.CancelReason,
+		&i.CancelledAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getOrderByID = `-- name: GetOrderByID :one
+SELECT id, user_id, status, total_amount, note, shipping_full_name, shipping_phone, shipping_address_line1, shipping_address_line2, shipping_ward, shipping_city, shipping_country, cancel_reason, cancelled_at, created_at, updated_at FROM orders
+WHERE id = $1
+`
+
+func (q *Queries) GetOrderByID(ctx context.Context, id uuid.UUID) (Order, error) {
+	row := q.db.QueryRow(ctx, getOrderByID, id)
+	var i Order
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Status,
+		&i.TotalAmount,
+		&i.Note,
+		&i.ShippingFullName,
+		&i.ShippingPhone,
+		&i.ShippingAddressLine1,
+		&i.ShippingAddressLine2,
+		&i.ShippingWard,
+		&i.ShippingCity,
+		&i.ShippingCountry,
+		&i.CancelReason,
+		&i.CancelledAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getOrderByIDForUpdate = `-- name: GetOrderByIDForUpdate :one
+SELECT id, user_id, status, total_amount, note, shipping_full_name, shipping_phone, shipping_address_line1, shipping_address_line2, shipping_ward, shipping_city, shipping_country, cancel_reason, cancelled_at, created_at, updated_at FROM orders
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) GetOrderByIDForUpdate(ctx context.Context, id uuid.UUID) (Order, error) {
+	row := q.db.QueryRow(ctx, getOrderByIDForUpdate, id)
+	var i Order
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Status,
+		&i.TotalAmount,
+		&i.Note,
+		&i.ShippingFullName,
+		&i.ShippingPhone,
+		&i.ShippingAddressLine1,
+		&i.ShippingAddressLine2,
+		&i.ShippingWard,
+		&i.ShippingCity,
+		&i.ShippingCountry,
+		&i.CancelReason,
+		&i.CancelledAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listOrdersByUserID = `-- name: ListOrdersByUserID :many
+SELECT id, user_id, status, total_amount, note, shipping_full_name, shipping_phone, shipping_address_line1, shipping_address_line2, shipping_ward, shipping_city, shipping_country, cancel_reason, cancelled_at, created_at, updated_at FROM orders
+WHERE
+    user_id = $1
+    AND ($4::order_status_enum IS NULL OR status = $4)
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3
+`
+
+type ListOrdersByUserIDParams struct {
+	UserID uuid.UUID
+	Limit  int32
+	Offset int32
+	Status NullOrderStatusEnum
+}
+
+func (q *Queries) ListOrdersByUserID(ctx context.Context, arg ListOrdersByUserIDParams) ([]Order, error) {
+	rows, err := q.db.Query(ctx, listOrdersByUserID,
+		arg.UserID,
+		arg.Limit,
+		arg.Offset,
+		arg.Status,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Order
+	for rows.Next() {
+		var i Order
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Status,
+			&i.TotalAmount,
+			&i.Note,
+			&i.ShippingFullName,
+			&i.ShippingPhone,
+			&i.ShippingAddressLine1,
+			&i.ShippingAddressLine2,
+			&i.ShippingWard,
+			&i.ShippingCity,
+			&i.ShippingCountry,
+			&i.CancelReason,
+			&i.CancelledAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
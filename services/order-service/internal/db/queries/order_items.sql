@@ -0,0 +1,24 @@
+-- name: CreateOrderItem :one
+INSERT INTO order_items (
+    id,
+    order_id,
+    product_id,
+    product_name,
+    product_sku,
+    unit_price,
+    quantity,
+    subtotal,
+    created_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING *;
+
+-- name: ListOrderItemsByOrderID :many
+SELECT * FROM order_items
+WHERE order_id = $1
+ORDER BY created_at ASC, id ASC;
+
+-- name: ListOrderItemsByOrderIDs :many
+SELECT * FROM order_items
+WHERE order_id = ANY($1::uuid[])
+ORDER BY created_at ASC, id ASC;
@@ -0,0 +1,51 @@
+-- name: CreateOrder :one
+INSERT INTO orders (
+    id,
+    user_id,
+    status,
+    total_amount,
+    note,
+    shipping_full_name,
+    shipping_phone,
+    shipping_address_line1,
+    shipping_address_line2,
+    shipping_ward,
+    shipping_city,
+    shipping_country,
+    created_at,
+    updated_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
+) RETURNING *;
+
+-- name: GetOrderByID :one
+SELECT * FROM orders
+WHERE id = $1;
+
+-- name: GetOrderByIDForUpdate :one
+SELECT * FROM orders
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListOrdersByUserID :many
+SELECT * FROM orders
+WHERE
+    user_id = $1
+    AND (sqlc.narg('status')::order_status_enum IS NULL OR status = sqlc.narg('status'))
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: CountOrdersByUserID :one
+SELECT COUNT(*) FROM orders
+WHERE
+    user_id = $1
+    AND (sqlc.narg('status')::order_status_enum IS NULL OR status = sqlc.narg('status'));
+
+-- name: CancelOrder :execrows
+UPDATE orders
+SET
+    status = 'cancelled',
+    cancel_reason = $2,
+    cancelled_at = $3,
+    updated_at = $3
+WHERE id = $1 AND status IN ('pending', 'confirmed');
@@ -0,0 +1,6 @@
+package dto
+
+type CartItemDTO struct {
+	ProductID string
+	Quantity  int32
+}
@@ -0,0 +1,23 @@
+package dto
+
+type OrderItemInput struct {
+	ProductID string
+	Quantity  int32
+}
+
+type CreateOrderDTO struct {
+	AddressID string
+	Items     []OrderItemInput
+	Note      string
+}
+
+type ListOrdersDTO struct {
+	Status   *string
+	Page     int32
+	PageSize int32
+}
+
+type CancelOrderDTO struct {
+	OrderID string
+	Reason  string
+}
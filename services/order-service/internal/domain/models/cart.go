@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CartItem struct {
+	ProductID   uuid.UUID `json:"product_id"`
+	ProductName string    `json:"product_name"`
+	ProductSKU  string    `json:"product_sku"`
+	Thumbnail   *string   `json:"thumbnail,omitempty"`
+	Quantity    int32     `json:"quantity"`
+	UnitPrice   float64   `json:"unit_price"`
+	AddedAt     time.Time `json:"added_at"`
+
+	// populated when the cart is revalidated against the product catalog
+	Available     bool     `json:"-"`
+	PriceChanged  bool     `json:"-"`
+	PreviousPrice *float64 `json:"-"`
+}
+
+func (i *CartItem) Subtotal() float64 {
+	return i.UnitPrice * float64(i.Quantity)
+}
+
+type Cart struct {
+	UserID    uuid.UUID   `json:"user_id"`
+	Items     []*CartItem `json:"items"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+func (c *Cart) FindItem(productID uuid.UUID) (int, *CartItem) {
+	for i, item := range c.Items {
+		if item.ProductID == productID {
+			return i, item
+		}
+	}
+	return -1, nil
+}
+
+func (c *Cart) HasChanges() bool {
+	for _, item := range c.Items {
+		if !item.Available || item.PriceChanged {
+			return true
+		}
+	}
+	return false
+}
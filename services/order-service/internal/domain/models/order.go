@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OrderStatus string
+
+const (
+	OrderStatusPending   OrderStatus = "pending"
+	OrderStatusConfirmed OrderStatus = "confirmed"
+	OrderStatusShipping  OrderStatus = "shipping"
+	OrderStatusDelivered OrderStatus = "delivered"
+	OrderStatusCancelled OrderStatus = "cancelled"
+)
+
+func (s OrderStatus) IsCancellable() bool {
+	return s == OrderStatusPending || s == OrderStatusConfirmed
+}
+
+type ShippingAddress struct {
+	FullName     string
+	Phone        string
+	AddressLine1 string
+	AddressLine2 *string
+	Ward         string
+	City         string
+	Country      string
+}
+
+type Order struct {
+	ID              uuid.UUID
+	UserID          uuid.UUID
+	Status          OrderStatus
+	TotalAmount     float64
+	Note            *string
+	ShippingAddress ShippingAddress
+	Items           []*OrderItem
+	CancelReason    *string
+	CancelledAt     *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OrderItem struct {
+	ID          uuid.UUID
+	OrderID     uuid.UUID
+	ProductID   uuid.UUID
+	ProductName string
+	ProductSKU  string
+	UnitPrice   float64
+	Quantity    int32
+	Subtotal    float64
+	CreatedAt   time.Time
+}
@@ -0,0 +1,146 @@
+package grpchandler
+
+import (
+	"context"
+
+	"github.com/khoihuynh300/go-microservice/order-service/internal/domain/dto"
+	"github.com/khoihuynh300/go-microservice/order-service/internal/domain/models"
+	"github.com/khoihuynh300/go-microservice/order-service/internal/utils/convert"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/const/contextkeys"
+	apperr "github.com/khoihuynh300/go-microservice/shared/pkg/errors"
+	cartpb "github.com/khoihuynh300/go-microservice/shared/proto/cart"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func (h *CartHandler) AddItem(ctx context.Context, req *cartpb.AddItemRequest) (*cartpb.CartResponse, error) {
+	userID, ok := ctx.Value(contextkeys.UserIDKey).(string)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	cart, err := h.cartService.AddItem(ctx, userID, &dto.CartItemDTO{
+		ProductID: req.ProductId,
+		Quantity:  req.Quantity,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &cartpb.CartResponse{
+		Cart: toCartResponse(cart),
+	}, nil
+}
+
+func (h *CartHandler) UpdateQuantity(ctx context.Context, req *cartpb.UpdateQuantityRequest) (*cartpb.CartResponse, error) {
+	userID, ok := ctx.Value(contextkeys.UserIDKey).(string)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	cart, err := h.cartService.UpdateQuantity(ctx, userID, &dto.CartItemDTO{
+		ProductID: req.ProductId,
+		Quantity:  req.Quantity,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &cartpb.CartResponse{
+		Cart: toCartResponse(cart),
+	}, nil
+}
+
+func (h *CartHandler) RemoveItem(ctx context.Context, req *cartpb.RemoveItemRequest) (*cartpb.CartResponse, error) {
+	userID, ok := ctx.Value(contextkeys.UserIDKey).(string)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	cart, err := h.cartService.RemoveItem(ctx, userID, req.ProductId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cartpb.CartResponse{
+		Cart: toCartResponse(cart),
+	}, nil
+}
+
+func (h *CartHandler) GetCart(ctx context.Context, req *emptypb.Empty) (*cartpb.CartResponse, error) {
+	userID, ok := ctx.Value(contextkeys.UserIDKey).(string)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	cart, err := h.cartService.GetCart(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cartpb.CartResponse{
+		Cart: toCartResponse(cart),
+	}, nil
+}
+
+func (h *CartHandler) ClearCart(ctx context.Context, req *emptypb.Empty) (*emptypb.Empty, error) {
+	userID, ok := ctx.Value(contextkeys.UserIDKey).(string)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	if err := h.cartService.ClearCart(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func toCartResponse(cart *models.Cart) *cartpb.Cart {
+	items := make([]*cartpb.CartItem, len(cart.Items))
+	var totalQuantity int32
+	var totalAmount float64
+
+	for i, item := range cart.Items {
+		items[i] = toCartItemResponse(item)
+		if item.Available {
+			totalQuantity += item.Quantity
+			totalAmount += item.Subtotal()
+		}
+	}
+
+	var updatedAt *timestamppb.Timestamp
+	if !cart.UpdatedAt.IsZero() {
+		updatedAt = timestamppb.New(cart.UpdatedAt)
+	}
+
+	return &cartpb.Cart{
+		Items:         items,
+		TotalQuantity: totalQuantity,
+		TotalAmount:   convert.RoundPrice(totalAmount),
+		HasChanges:    cart.HasChanges(),
+		UpdatedAt:     updatedAt,
+	}
+}
+
+func toCartItemResponse(item *models.CartItem) *cartpb.CartItem {
+	var previousPrice *wrapperspb.DoubleValue
+	if item.PreviousPrice != nil {
+		previousPrice = wrapperspb.Double(*item.PreviousPrice)
+	}
+
+	return &cartpb.CartItem{
+		ProductId:     item.ProductID.String(),
+		ProductName:   item.ProductName,
+		ProductSku:    item.ProductSKU,
+		Thumbnail:     convert.PtrToStringWrapper(item.Thumbnail),
+		Quantity:      item.Quantity,
+		UnitPrice:     item.UnitPrice,
+		Subtotal:      convert.RoundPrice(item.Subtotal()),
+		Available:     item.Available,
+		PriceChanged:  item.PriceChanged,
+		PreviousPrice: previousPrice,
+		AddedAt:       timestamppb.New(item.AddedAt),
+	}
+}
@@ -0,0 +1,29 @@
+package grpchandler
+
+import (
+	"github.com/khoihuynh300/go-microservice/order-service/internal/service"
+	cartpb "github.com/khoihuynh300/go-microservice/shared/proto/cart"
+	orderpb "github.com/khoihuynh300/go-microservice/shared/proto/order"
+)
+
+type OrderHandler struct {
+	orderpb.UnimplementedOrderServiceServer
+	orderService service.OrderService
+}
+
+func NewOrderHandler(orderService service.OrderService) *OrderHandler {
+	return &OrderHandler{
+		orderService: orderService,
+	}
+}
+
+type CartHandler struct {
+	cartpb.UnimplementedCartServiceServer
+	cartService service.CartService
+}
+
+func NewCartHandler(cartService service.CartService) *CartHandler {
+	return &CartHandler{
+		cartService: cartService,
+	}
+}
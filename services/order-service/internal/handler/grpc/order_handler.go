@@ -0,0 +1,165 @@
+package grpchandler
+
+import (
+	"context"
+
+	"github.com/khoihuynh300/go-microservice/order-service/internal/domain/dto"
+	"github.com/khoihuynh300/go-microservice/order-service/internal/domain/models"
+	"github.com/khoihuynh300/go-microservice/order-service/internal/utils/convert"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/const/contextkeys"
+	apperr "github.com/khoihuynh300/go-microservice/shared/pkg/errors"
+	orderpb "github.com/khoihuynh300/go-microservice/shared/proto/order"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (h *OrderHandler) CreateOrder(ctx context.Context, req *orderpb.CreateOrderRequest) (*orderpb.OrderResponse, error) {
+	userID, ok := ctx.Value(contextkeys.UserIDKey).(string)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	items := make([]dto.OrderItemInput, len(req.Items))
+	for i, item := range req.Items {
+		items[i] = dto.OrderItemInput{
+			ProductID: item.ProductId,
+			Quantity:  item.Quantity,
+		}
+	}
+
+	input := &dto.CreateOrderDTO{
+		AddressID: req.AddressId,
+		Items:     items,
+		Note:      req.Note,
+	}
+
+	order, err := h.orderService.CreateOrder(ctx, userID, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return &orderpb.OrderResponse{
+		Order: toOrderResponse(order),
+	}, nil
+}
+
+func (h *OrderHandler) GetOrder(ctx context.Context, req *orderpb.GetOrderRequest) (*orderpb.OrderResponse, error) {
+	userID, ok := ctx.Value(contextkeys.UserIDKey).(string)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	order, err := h.orderService.GetOrder(ctx, userID, req.OrderId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &orderpb.OrderResponse{
+		Order: toOrderResponse(order),
+	}, nil
+}
+
+func (h *OrderHandler) ListMyOrders(ctx context.Context, req *orderpb.ListMyOrdersRequest) (*orderpb.ListOrdersResponse, error) {
+	userID, ok := ctx.Value(contextkeys.UserIDKey).(string)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	input := &dto.ListOrdersDTO{
+		Status:   convert.StringWrapperToPtr(req.Status),
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}
+
+	orders, total, err := h.orderService.ListMyOrders(ctx, userID, input)
+	if err != nil {
+		return nil, err
+	}
+
+	pbOrders := make([]*orderpb.Order, len(orders))
+	for i, o := range orders {
+		pbOrders[i] = toOrderResponse(o)
+	}
+
+	totalPages := int32(total) / req.PageSize
+	if int32(total)%req.PageSize > 0 {
+		totalPages++
+	}
+
+	return &orderpb.ListOrdersResponse{
+		Orders:     pbOrders,
+		Total:      total,
+		Page:       req.Page,
+		PageSize:   req.PageSize,
+		TotalPages: totalPages,
+	}, nil
+}
+
+func (h *OrderHandler) CancelOrder(ctx context.Context, req *orderpb.CancelOrderRequest) (*orderpb.OrderResponse, error) {
+	userID, ok := ctx.Value(contextkeys.UserIDKey).(string)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	input := &dto.CancelOrderDTO{
+		OrderID: req.OrderId,
+		Reason:  req.Reason,
+	}
+
+	order, err := h.orderService.CancelOrder(ctx, userID, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return &orderpb.OrderResponse{
+		Order: toOrderResponse(order),
+	}, nil
+}
+
+func toOrderResponse(order *models.Order) *orderpb.Order {
+	items := make([]*orderpb.OrderItem, len(order.Items))
+	for i, item := range order.Items {
+		items[i] = toOrderItemResponse(item)
+	}
+
+	var note, addressLine2 string
+	if order.Note != nil {
+		note = *order.Note
+	}
+	if order.ShippingAddress.AddressLine2 != nil {
+		addressLine2 = *order.ShippingAddress.AddressLine2
+	}
+
+	return &orderpb.Order{
+		Id:     order.ID.String(),
+		UserId: order.UserID.String(),
+		Status: string(order.Status),
+		Items:  items,
+		ShippingAddress: &orderpb.ShippingAddress{
+			FullName:     order.ShippingAddress.FullName,
+			Phone:        order.ShippingAddress.Phone,
+			AddressLine1: order.ShippingAddress.AddressLine1,
+			AddressLine2: addressLine2,
+			Ward:         order.ShippingAddress.Ward,
+			City:         order.ShippingAddress.City,
+			Country:      order.ShippingAddress.Country,
+		},
+		TotalAmount:  order.TotalAmount,
+		Note:         note,
+		CancelReason: convert.PtrToStringWrapper(order.CancelReason),
+		CancelledAt:  convert.TimePtrToTimestamp(order.CancelledAt),
+		CreatedAt:    timestamppb.New(order.CreatedAt),
+		UpdatedAt:    timestamppb.New(order.UpdatedAt),
+	}
+}
+
+func toOrderItemResponse(item *models.OrderItem) *orderpb.OrderItem {
+	return &orderpb.OrderItem{
+		Id:          item.ID.String(),
+		ProductId:   item.ProductID.String(),
+		ProductName: item.ProductName,
+		ProductSku:  item.ProductSKU,
+		UnitPrice:   item.UnitPrice,
+		Quantity:    item.Quantity,
+		Subtotal:    item.Subtotal,
+	}
+}
@@ -0,0 +1,93 @@
+package impl
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	sqlc "github.com/khoihuynh300/go-microservice/order-service/internal/db/generated"
+	"github.com/khoihuynh300/go-microservice/order-service/internal/domain/models"
+	"github.com/khoihuynh300/go-microservice/order-service/internal/repository"
+	"github.com/khoihuynh300/go-microservice/order-service/internal/utils/convert"
+)
+
+type orderItemRepository struct {
+	baseRepository
+}
+
+func NewOrderItemRepository(db *pgxpool.Pool) repository.OrderItemRepository {
+	return &orderItemRepository{
+		baseRepository: baseRepository{
+			db: db,
+			q:  sqlc.New(db),
+		},
+	}
+}
+
+func (r *orderItemRepository) Create(ctx context.Context, item *models.OrderItem) error {
+	unitPrice, err := convert.DoubleToNumeric(item.UnitPrice)
+	if err != nil {
+		return err
+	}
+
+	subtotal, err := convert.DoubleToNumeric(item.Subtotal)
+	if err != nil {
+		return err
+	}
+
+	dbItem, err := r.queries(ctx).CreateOrderItem(ctx, sqlc.CreateOrderItemParams{
+		ID:          uuid.New(),
+		OrderID:     item.OrderID,
+		ProductID:   item.ProductID,
+		ProductName: item.ProductName,
+		ProductSku:  item.ProductSKU,
+		UnitPrice:   unitPrice,
+		Quantity:    item.Quantity,
+		Subtotal:    subtotal,
+		CreatedAt:   time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	item.ID = dbItem.ID
+	item.CreatedAt = dbItem.CreatedAt
+	return nil
+}
+
+func (r *orderItemRepository) GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]*models.OrderItem, error) {
+	dbItems, err := r.queries(ctx).ListOrderItemsByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.toModels(dbItems), nil
+}
+
+func (r *orderItemRepository) GetByOrderIDs(ctx context.Context, orderIDs []uuid.UUID) ([]*models.OrderItem, error) {
+	dbItems, err := r.queries(ctx).ListOrderItemsByOrderIDs(ctx, orderIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.toModels(dbItems), nil
+}
+
+func (r *orderItemRepository) toModels(dbItems []sqlc.OrderItem) []*models.OrderItem {
+	items := make([]*models.OrderItem, len(dbItems))
+	for i, dbItem := range dbItems {
+		items[i] = &models.OrderItem{
+			ID:          dbItem.ID,
+			OrderID:     dbItem.OrderID,
+			ProductID:   dbItem.ProductID,
+			ProductName: dbItem.ProductName,
+			ProductSKU:  dbItem.ProductSku,
+			UnitPrice:   convert.NumericToDouble(dbItem.UnitPrice),
+			Quantity:    dbItem.Quantity,
+			Subtotal:    convert.NumericToDouble(dbItem.Subtotal),
+			CreatedAt:   dbItem.CreatedAt,
+		}
+	}
+	return items
+}
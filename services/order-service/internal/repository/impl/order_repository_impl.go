@@ -0,0 +1,158 @@
+package impl
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	sqlc "github.com/khoihuynh300/go-microservice/order-service/internal/db/generated"
+	"github.com/khoihuynh300/go-microservice/order-service/internal/domain/models"
+	"github.com/khoihuynh300/go-microservice/order-service/internal/repository"
+	"github.com/khoihuynh300/go-microservice/order-service/internal/utils/convert"
+)
+
+type orderRepository struct {
+	baseRepository
+}
+
+func NewOrderRepository(db *pgxpool.Pool) repository.OrderRepository {
+	return &orderRepository{
+		baseRepository: baseRepository{
+			db: db,
+			q:  sqlc.New(db),
+		},
+	}
+}
+
+func (r *orderRepository) Create(ctx context.Context, order *models.Order) error {
+	now := time.Now()
+
+	totalAmount, err := convert.DoubleToNumeric(order.TotalAmount)
+	if err != nil {
+		return err
+	}
+
+	dbOrder, err := r.queries(ctx).CreateOrder(ctx, sqlc.CreateOrderParams{
+		ID:                   uuid.New(),
+		UserID:               order.UserID,
+		Status:               sqlc.OrderStatusEnum(order.Status),
+		TotalAmount:          totalAmount,
+		Note:                 convert.PtrToText(order.Note),
+		ShippingFullName:     order.ShippingAddress.FullName,
+		ShippingPhone:        order.ShippingAddress.Phone,
+		ShippingAddressLine1: order.ShippingAddress.AddressLine1,
+		ShippingAddressLine2: convert.PtrToText(order.ShippingAddress.AddressLine2),
+		ShippingWard:         order.ShippingAddress.Ward,
+		ShippingCity:         order.ShippingAddress.City,
+		ShippingCountry:      order.ShippingAddress.Country,
+		CreatedAt:            now,
+		UpdatedAt:            now,
+	})
+	if err != nil {
+		return err
+	}
+
+	order.ID = dbOrder.ID
+	order.CreatedAt = dbOrder.CreatedAt
+	order.UpdatedAt = dbOrder.UpdatedAt
+	return nil
+}
+
+func (r *orderRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Order, error) {
+	dbOrder, err := r.queries(ctx).GetOrderByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r.toModel(&dbOrder), nil
+}
+
+func (r *orderRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*models.Order, error) {
+	dbOrder, err := r.queries(ctx).GetOrderByIDForUpdate(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r.toModel(&dbOrder), nil
+}
+
+func (r *orderRepository) ListByUserID(ctx context.Context, userID uuid.UUID, status *models.OrderStatus, page, pageSize int32) ([]*models.Order, int64, error) {
+	statusFilter := sqlc.NullOrderStatusEnum{}
+	if status != nil {
+		statusFilter = sqlc.NullOrderStatusEnum{OrderStatusEnum: sqlc.OrderStatusEnum(*status), Valid: true}
+	}
+
+	total, err := r.queries(ctx).CountOrdersByUserID(ctx, sqlc.CountOrdersByUserIDParams{
+		UserID: userID,
+		Status: statusFilter,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	dbOrders, err := r.queries(ctx).ListOrdersByUserID(ctx, sqlc.ListOrdersByUserIDParams{
+		UserID: userID,
+		Limit:  pageSize,
+		Offset: offset,
+		Status: statusFilter,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	orders := make([]*models.Order, len(dbOrders))
+	for i, dbOrder := range dbOrders {
+		orders[i] = r.toModel(&dbOrder)
+	}
+
+	return orders, total, nil
+}
+
+func (r *orderRepository) Cancel(ctx context.Context, id uuid.UUID, reason *string) (int64, error) {
+	now := time.Now()
+	return r.queries(ctx).CancelOrder(ctx, sqlc.CancelOrderParams{
+		ID:           id,
+		CancelReason: convert.PtrToText(reason),
+		CancelledAt:  pgtype.Timestamptz{Time: now, Valid: true},
+	})
+}
+
+func (r *orderRepository) toModel(dbOrder *sqlc.Order) *models.Order {
+	var cancelledAt *time.Time
+	if dbOrder.CancelledAt.Valid {
+		cancelledAt = &dbOrder.CancelledAt.Time
+	}
+
+	return &models.Order{
+		ID:          dbOrder.ID,
+		UserID:      dbOrder.UserID,
+		Status:      models.OrderStatus(dbOrder.Status),
+		TotalAmount: convert.NumericToDouble(dbOrder.TotalAmount),
+		Note:        convert.PgTextToPtr(dbOrder.Note),
+		ShippingAddress: models.ShippingAddress{
+			FullName:     dbOrder.ShippingFullName,
+			Phone:        dbOrder.ShippingPhone,
+			AddressLine1: dbOrder.ShippingAddressLine1,
+			AddressLine2: convert.PgTextToPtr(dbOrder.ShippingAddressLine2),
+			Ward:         dbOrder.ShippingWard,
+			City:         dbOrder.ShippingCity,
+			Country:      dbOrder.ShippingCountry,
+		},
+		CancelReason: convert.PgTextToPtr(dbOrder.CancelReason),
+		CancelledAt:  cancelledAt,
+		CreatedAt:    dbOrder.CreatedAt,
+		UpdatedAt:    dbOrder.UpdatedAt,
+	}
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/khoihuynh300/go-microservice/order-service/internal/domain/models"
+)
+
+type OrderItemRepository interface {
+	Repository
+
+	Create(ctx context.Context, item *models.OrderItem) error
+	GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]*models.OrderItem, error)
+	GetByOrderIDs(ctx context.Context, orderIDs []uuid.UUID) ([]*models.OrderItem, error)
+}
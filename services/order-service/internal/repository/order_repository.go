@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/khoihuynh300/go-microservice/order-service/internal/domain/models"
+)
+
+type OrderRepository interface {
+	Repository
+
+	Create(ctx context.Context, order *models.Order) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Order, error)
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*models.Order, error)
+	ListByUserID(ctx context.Context, userID uuid.UUID, status *models.OrderStatus, page, pageSize int32) ([]*models.Order, int64, error)
+	Cancel(ctx context.Context, id uuid.UUID, reason *string) (int64, error)
+}
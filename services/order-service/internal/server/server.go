@@ -0,0 +1,182 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/khoihuynh300/go-microservice/order-service/internal/caching"
+	"github.com/khoihuynh300/go-microservice/order-service/internal/client"
+	"github.com/khoihuynh300/go-microservice/order-service/internal/config"
+	grpchandler "github.com/khoihuynh300/go-microservice/order-service/internal/handler/grpc"
+	"github.com/khoihuynh300/go-microservice/order-service/internal/repository/impl"
+	"github.com/khoihuynh300/go-microservice/order-service/internal/service"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/cache"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/interceptor"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/metrics"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/telemetry"
+	cartpb "github.com/khoihuynh300/go-microservice/shared/proto/cart"
+	orderpb "github.com/khoihuynh300/go-microservice/shared/proto/order"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+type Server struct {
+	grpcServer    *grpc.Server
+	logger        *zap.Logger
+	dbPool        *pgxpool.Pool
+	redis         *cache.Client
+	userConn      *grpc.ClientConn
+	productConn   *grpc.ClientConn
+	healthHandler *health.Server
+}
+
+func New(logger *zap.Logger) (*Server, error) {
+	dbpool, err := initDB(config.GetDBUrl())
+	if err != nil {
+		return nil, fmt.Errorf("failed to init db: %w", err)
+	}
+
+	userConn, err := initClientConn(config.GetUserServiceURL())
+	if err != nil {
+		return nil, fmt.Errorf("failed to init user service client: %w", err)
+	}
+
+	productConn, err := initClientConn(config.GetProductServiceURL())
+	if err != nil {
+		return nil, fmt.Errorf("failed to init product service client: %w", err)
+	}
+
+	redis, err := cache.NewClient(&cache.Config{
+		Host:     config.GetRedisHost(),
+		Port:     config.GetRedisPort(),
+		Password: config.GetRedisPassword(),
+		DB:       config.GetRedisDB(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to init redis: %w", err)
+	}
+	cartCache := caching.NewCartCache(redis.GetClient())
+
+	orderRepository := impl.NewOrderRepository(dbpool)
+	orderItemRepository := impl.NewOrderItemRepository(dbpool)
+
+	userClient := client.NewUserClient(userConn)
+	productClient := client.NewProductClient(productConn)
+
+	orderService := service.NewOrderService(orderRepository, orderItemRepository, productClient, userClient)
+	cartService := service.NewCartService(cartCache, productClient)
+
+	healthHandler := health.NewServer()
+	orderHandler := grpchandler.NewOrderHandler(orderService)
+	cartHandler := grpchandler.NewCartHandler(cartService)
+
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			interceptor.TracingInterceptor(logger),
+			interceptor.MetricsInterceptor(),
+			interceptor.RecoveryUnaryInterceptor(),
+			interceptor.LoggingUnaryInterceptor(),
+			interceptor.AuthInterceptor(),
+			interceptor.AuthorizationInterceptor(),
+			interceptor.ValidationUnaryInterceptor(),
+			interceptor.ErrorHandlerInterceptor(),
+		),
+	)
+
+	healthpb.RegisterHealthServer(grpcServer, healthHandler)
+	orderpb.RegisterOrderServiceServer(grpcServer, orderHandler)
+	cartpb.RegisterCartServiceServer(grpcServer, cartHandler)
+
+	if config.GetEnv() == "DEV" {
+		reflection.Register(grpcServer)
+	}
+
+	return &Server{
+		grpcServer:    grpcServer,
+		logger:        logger,
+		dbPool:        dbpool,
+		redis:         redis,
+		userConn:      userConn,
+		productConn:   productConn,
+		healthHandler: healthHandler,
+	}, nil
+}
+
+func (s *Server) Run() error {
+	lis, err := net.Listen("tcp", config.GetGRPCAddr())
+	if err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+
+	s.logger.Info("order service listening on", zap.String("addr", config.GetGRPCAddr()))
+	s.healthHandler.SetServingStatus(config.GetServiceName(), healthpb.HealthCheckResponse_SERVING)
+
+	return s.grpcServer.Serve(lis)
+}
+
+func (s *Server) GracefulStop() {
+	s.healthHandler.SetServingStatus(config.GetServiceName(), healthpb.HealthCheckResponse_NOT_SERVING)
+	s.grpcServer.GracefulStop()
+	s.close()
+}
+
+func (s *Server) Stop() {
+	s.grpcServer.Stop()
+	s.close()
+}
+
+func (s *Server) close() {
+	if s.userConn != nil {
+		s.userConn.Close()
+	}
+	if s.productConn != nil {
+		s.productConn.Close()
+	}
+	if s.redis != nil {
+		s.redis.Close()
+	}
+	if s.dbPool != nil {
+		s.dbPool.Close()
+	}
+}
+
+func initDB(dbURL string) (*pgxpool.Pool, error) {
+	dbCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	poolConfig, err := pgxpool.ParseConfig(dbURL)
+	if err != nil {
+		return nil, err
+	}
+	poolConfig.ConnConfig.Tracer = telemetry.NewPgxTracer()
+
+	pool, err := pgxpool.NewWithConfig(dbCtx, poolConfig)
+	if err != nil {
+		return nil, err
+	}
+	if err := pool.Ping(dbCtx); err != nil {
+		return nil, err
+	}
+	if err := metrics.RegisterPgxPool(pool); err != nil {
+		pool.Close()
+		return nil, err
+	}
+	return pool, nil
+}
+
+func initClientConn(target string) (*grpc.ClientConn, error) {
+	return grpc.NewClient(target,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(
+			interceptor.TracingClientInterceptor(),
+			interceptor.MetadataForwardingClientInterceptor(),
+		),
+	)
+}
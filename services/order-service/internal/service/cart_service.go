@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+
+	"github.com/khoihuynh300/go-microservice/order-service/internal/domain/dto"
+	"github.com/khoihuynh300/go-microservice/order-service/internal/domain/models"
+)
+
+type CartService interface {
+	AddItem(ctx context.Context, userID string, input *dto.CartItemDTO) (*models.Cart, error)
+	UpdateQuantity(ctx context.Context, userID string, input *dto.CartItemDTO) (*models.Cart, error)
+	RemoveItem(ctx context.Context, userID string, productID string) (*models.Cart, error)
+	GetCart(ctx context.Context, userID string) (*models.Cart, error)
+	ClearCart(ctx context.Context, userID string) error
+}
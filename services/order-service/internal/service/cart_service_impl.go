@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/khoihuynh300/go-microservice/order-service/internal/caching"
+	"github.com/khoihuynh300/go-microservice/order-service/internal/client"
+	"github.com/khoihuynh300/go-microservice/order-service/internal/domain/dto"
+	"github.com/khoihuynh300/go-microservice/order-service/internal/domain/models"
+	apperr "github.com/khoihuynh300/go-microservice/shared/pkg/errors"
+	zaplogger "github.com/khoihuynh300/go-microservice/shared/pkg/logger"
+	productpb "github.com/khoihuynh300/go-microservice/shared/proto/product"
+	"go.uber.org/zap"
+)
+
+const (
+	maxCartItems        = 50
+	maxCartItemQuantity = 100
+)
+
+type cartService struct {
+	cartCache     caching.CartCache
+	productClient client.ProductClient
+}
+
+func NewCartService(cartCache caching.CartCache, productClient client.ProductClient) CartService {
+	return &cartService{
+		cartCache:     cartCache,
+		productClient: productClient,
+	}
+}
+
+func (s *cartService) AddItem(ctx context.Context, userID string, input *dto.CartItemDTO) (*models.Cart, error) {
+	logger := zaplogger.FromContext(ctx)
+
+	userUUID, productUUID, err := parseCartIDs(userID, input.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	products, err := s.productClient.GetProductsByIDs(ctx, []string{productUUID.String()})
+	if err != nil {
+		return nil, err
+	}
+	if len(products) == 0 {
+		return nil, apperr.ErrProductNotFound
+	}
+	product := products[0]
+
+	var quantity int32
+	cart, err := s.cartCache.Update(ctx, userUUID, func(cart *models.Cart) error {
+		_, item := cart.FindItem(productUUID)
+		if item == nil {
+			if len(cart.Items) >= maxCartItems {
+				return apperr.ErrCartFull
+			}
+			item = &models.CartItem{
+				ProductID: productUUID,
+				AddedAt:   time.Now(),
+			}
+			cart.Items = append(cart.Items, item)
+		}
+
+		quantity = item.Quantity + input.Quantity
+		if quantity > maxCartItemQuantity {
+			return apperr.NewErrValidationFailedWithDetail("quantity", "max_quantity", "quantity per item must not exceed 100")
+		}
+		item.Quantity = quantity
+		applyProductSnapshot(item, product)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Item added to cart",
+		zap.String("user_id", userID),
+		zap.String("product_id", productUUID.String()),
+		zap.Int32("quantity", quantity),
+	)
+
+	return s.revalidate(ctx, cart)
+}
+
+func (s *cartService) UpdateQuantity(ctx context.Context, userID string, input *dto.CartItemDTO) (*models.Cart, error) {
+	userUUID, productUUID, err := parseCartIDs(userID, input.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	products, err := s.productClient.GetProductsByIDs(ctx, []string{productUUID.String()})
+	if err != nil {
+		return nil, err
+	}
+	if len(products) == 0 {
+		return nil, apperr.ErrProductNotFound
+	}
+
+	cart, err := s.cartCache.Update(ctx, userUUID, func(cart *models.Cart) error {
+		_, item := cart.FindItem(productUUID)
+		if item == nil {
+			return apperr.ErrCartItemNotFound
+		}
+
+		item.Quantity = input.Quantity
+		// updating an item acknowledges its current price
+		applyProductSnapshot(item, products[0])
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.revalidate(ctx, cart)
+}
+
+func (s *cartService) RemoveItem(ctx context.Context, userID string, productID string) (*models.Cart, error) {
+	userUUID, productUUID, err := parseCartIDs(userID, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	cart, err := s.cartCache.Update(ctx, userUUID, func(cart *models.Cart) error {
+		idx, item := cart.FindItem(productUUID)
+		if item == nil {
+			return apperr.ErrCartItemNotFound
+		}
+		cart.Items = append(cart.Items[:idx], cart.Items[idx+1:]...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.revalidate(ctx, cart)
+}
+
+func (s *cartService) GetCart(ctx context.Context, userID string) (*models.Cart, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	cart, err := s.cartCache.Get(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.revalidate(ctx, cart)
+}
+
+func (s *cartService) ClearCart(ctx context.Context, userID string) error {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return err
+	}
+
+	return s.cartCache.Delete(ctx, userUUID)
+}
+
+// revalidate refreshes product details and prices from the catalog. Items whose
+// product is gone or whose price moved stay in the cart and are flagged, so the
+// user can decide what to do with them.
+func (s *cartService) revalidate(ctx context.Context, cart *models.Cart) (*models.Cart, error) {
+	if len(cart.Items) == 0 {
+		return cart, nil
+	}
+
+	productIDs := make([]string, len(cart.Items))
+	for i, item := range cart.Items {
+		productIDs[i] = item.ProductID.String()
+	}
+
+	products, err := s.productClient.GetProductsByIDs(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	productsByID := make(map[string]*productpb.ProductSummary, len(products))
+	for _, p := range products {
+		productsByID[p.Id] = p
+	}
+
+	for _, item := range cart.Items {
+		product, ok := productsByID[item.ProductID.String()]
+		if !ok {
+			item.Available = false
+			continue
+		}
+
+		item.Available = true
+		item.ProductName = product.Name
+		item.ProductSKU = product.Sku
+		if product.Thumbnail != nil {
+			item.Thumbnail = &product.Thumbnail.Value
+		}
+
+		if product.Price != item.UnitPrice {
+			previousPrice := item.UnitPrice
+			item.PriceChanged = true
+			item.PreviousPrice = &previousPrice
+			item.UnitPrice = product.Price
+		}
+	}
+
+	return cart, nil
+}
+
+func applyProductSnapshot(item *models.CartItem, product *productpb.ProductSummary) {
+	item.ProductName = product.Name
+	item.ProductSKU = product.Sku
+	item.UnitPrice = product.Price
+	item.Thumbnail = nil
+	if product.Thumbnail != nil {
+		item.Thumbnail = &product.Thumbnail.Value
+	}
+}
+
+func parseCartIDs(userID, productID string) (uuid.UUID, uuid.UUID, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+
+	productUUID, err := uuid.Parse(productID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+
+	return userUUID, productUUID, nil
+}
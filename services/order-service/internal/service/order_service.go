@@ -0,0 +1,15 @@
+package service
+
+import (
+	"context"
+
+	"github.com/khoihuynh300/go-microservice/order-service/internal/domain/dto"
+	"github.com/khoihuynh300/go-microservice/order-service/internal/domain/models"
+)
+
+type OrderService interface {
+	CreateOrder(ctx context.Context, userID string, input *dto.CreateOrderDTO) (*models.Order, error)
+	GetOrder(ctx context.Context, userID string, orderID string) (*models.Order, error)
+	ListMyOrders(ctx context.Context, userID string, input *dto.ListOrdersDTO) ([]*models.Order, int64, error)
+	CancelOrder(ctx context.Context, userID string, input *dto.CancelOrderDTO) (*models.Order, error)
+}
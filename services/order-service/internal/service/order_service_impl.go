@@ -0,0 +1,319 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/khoihuynh300/go-microservice/order-service/internal/client"
+	"github.com/khoihuynh300/go-microservice/order-service/internal/domain/dto"
+	"github.com/khoihuynh300/go-microservice/order-service/internal/domain/models"
+	"github.com/khoihuynh300/go-microservice/order-service/internal/repository"
+	"github.com/khoihuynh300/go-microservice/order-service/internal/utils/convert"
+	apperr "github.com/khoihuynh300/go-microservice/shared/pkg/errors"
+	zaplogger "github.com/khoihuynh300/go-microservice/shared/pkg/logger"
+	productpb "github.com/khoihuynh300/go-microservice/shared/proto/product"
+	"go.uber.org/zap"
+)
+
+// maxOrderItemQuantity mirrors the per-item limit of OrderItemInput.quantity,
+// applied again after lines for the same product are merged.
+const maxOrderItemQuantity = 100
+
+type orderService struct {
+	orderRepo     repository.OrderRepository
+	orderItemRepo repository.OrderItemRepository
+	productClient client.ProductClient
+	userClient    client.UserClient
+}
+
+func NewOrderService(
+	orderRepo repository.OrderRepository,
+	orderItemRepo repository.OrderItemRepository,
+	productClient client.ProductClient,
+	userClient client.UserClient,
+) OrderService {
+	return &orderService{
+		orderRepo:     orderRepo,
+		orderItemRepo: orderItemRepo,
+		productClient: productClient,
+		userClient:    userClient,
+	}
+}
+
+func (s *orderService) CreateOrder(ctx context.Context, userID string, input *dto.CreateOrderDTO) (*models.Order, error) {
+	logger := zaplogger.FromContext(ctx)
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	quantities, productIDs, err := mergeOrderItems(input.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	address, err := s.userClient.GetUserAddress(ctx, input.AddressID)
+	if err != nil {
+		return nil, err
+	}
+
+	products, err := s.productClient.GetProductsByIDs(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	productsByID := make(map[string]*productpb.ProductSummary, len(products))
+	for _, p := range products {
+		productsByID[p.Id] = p
+	}
+
+	var unavailable []apperr.ErrorDetail
+	items := make([]*models.OrderItem, 0, len(productIDs))
+	var totalAmount float64
+	for _, productID := range productIDs {
+		product, ok := productsByID[productID]
+		if !ok {
+			unavailable = append(unavailable, apperr.ErrorDetail{
+				Field:   productID,
+				Code:    apperr.CodeProductNotFound,
+				Message: "product does not exist or has been removed",
+			})
+			continue
+		}
+
+		productUUID, err := uuid.Parse(product.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		quantity := quantities[productID]
+		subtotal := convert.RoundPrice(product.Price * float64(quantity))
+		totalAmount += subtotal
+
+		items = append(items, &models.OrderItem{
+			ProductID:   productUUID,
+			ProductName: product.Name,
+			ProductSKU:  product.Sku,
+			UnitPrice:   product.Price,
+			Quantity:    quantity,
+			Subtotal:    subtotal,
+		})
+	}
+
+	if len(unavailable) > 0 {
+		return nil, apperr.NewErrProductUnavailable(unavailable)
+	}
+
+	order := &models.Order{
+		UserID:      userUUID,
+		Status:      models.OrderStatusPending,
+		TotalAmount: convert.RoundPrice(totalAmount),
+		ShippingAddress: models.ShippingAddress{
+			FullName:     address.FullName,
+			Phone:        address.Phone,
+			AddressLine1: address.AddressLine1,
+			Ward:         address.Ward,
+			City:         address.City,
+			Country:      address.Country,
+		},
+		Items: items,
+	}
+	if address.AddressLine2 != "" {
+		order.ShippingAddress.AddressLine2 = &address.AddressLine2
+	}
+	if input.Note != "" {
+		order.Note = &input.Note
+	}
+
+	err = s.orderRepo.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.orderRepo.Create(ctx, order); err != nil {
+			return err
+		}
+
+		for _, item := range order.Items {
+			item.OrderID = order.ID
+			if err := s.orderItemRepo.Create(ctx, item); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Order created",
+		zap.String("order_id", order.ID.String()),
+		zap.String("user_id", userID),
+		zap.Int("item_count", len(order.Items)),
+		zap.Float64("total_amount", order.TotalAmount),
+	)
+
+	return order, nil
+}
+
+func (s *orderService) GetOrder(ctx context.Context, userID string, orderID string) (*models.Order, error) {
+	order, err := s.getUserOrder(ctx, userID, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.orderItemRepo.GetByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+	order.Items = items
+
+	return order, nil
+}
+
+func (s *orderService) ListMyOrders(ctx context.Context, userID string, input *dto.ListOrdersDTO) ([]*models.Order, int64, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var status *models.OrderStatus
+	if input.Status != nil {
+		st := models.OrderStatus(*input.Status)
+		status = &st
+	}
+
+	orders, total, err := s.orderRepo.ListByUserID(ctx, userUUID, status, input.Page, input.PageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(orders) == 0 {
+		return orders, total, nil
+	}
+
+	orderIDs := make([]uuid.UUID, len(orders))
+	ordersByID := make(map[uuid.UUID]*models.Order, len(orders))
+	for i, order := range orders {
+		orderIDs[i] = order.ID
+		ordersByID[order.ID] = order
+	}
+
+	items, err := s.orderItemRepo.GetByOrderIDs(ctx, orderIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for _, item := range items {
+		if order, ok := ordersByID[item.OrderID]; ok {
+			order.Items = append(order.Items, item)
+		}
+	}
+
+	return orders, total, nil
+}
+
+func (s *orderService) CancelOrder(ctx context.Context, userID string, input *dto.CancelOrderDTO) (*models.Order, error) {
+	logger := zaplogger.FromContext(ctx)
+
+	var reason *string
+	if input.Reason != "" {
+		reason = &input.Reason
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	orderUUID, err := uuid.Parse(input.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	var order *models.Order
+	err = s.orderRepo.WithinTransaction(ctx, func(ctx context.Context) error {
+		existing, err := s.orderRepo.GetByIDForUpdate(ctx, orderUUID)
+		if err != nil {
+			return err
+		}
+		if existing == nil || existing.UserID != userUUID {
+			return apperr.ErrOrderNotFound
+		}
+		if !existing.Status.IsCancellable() {
+			return apperr.ErrOrderCannotBeCancelled
+		}
+
+		rows, err := s.orderRepo.Cancel(ctx, existing.ID, reason)
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			return apperr.ErrOrderCannotBeCancelled
+		}
+
+		order, err = s.orderRepo.GetByID(ctx, existing.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.orderItemRepo.GetByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+	order.Items = items
+
+	logger.Info("Order cancelled",
+		zap.String("order_id", order.ID.String()),
+		zap.String("user_id", userID),
+	)
+
+	return order, nil
+}
+
+func (s *orderService) getUserOrder(ctx context.Context, userID string, orderID string) (*models.Order, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	orderUUID, err := uuid.Parse(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := s.orderRepo.GetByID(ctx, orderUUID)
+	if err != nil {
+		return nil, err
+	}
+	// orders owned by other users are reported as missing to avoid leaking their existence
+	if order == nil || order.UserID != userUUID {
+		return nil, apperr.ErrOrderNotFound
+	}
+
+	return order, nil
+}
+
+func mergeOrderItems(inputs []dto.OrderItemInput) (map[string]int32, []string, error) {
+	quantities := make(map[string]int32, len(inputs))
+	productIDs := make([]string, 0, len(inputs))
+
+	for _, input := range inputs {
+		productUUID, err := uuid.Parse(input.ProductID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid product id %q: %w", input.ProductID, err)
+		}
+
+		productID := productUUID.String()
+		if _, ok := quantities[productID]; !ok {
+			productIDs = append(productIDs, productID)
+		}
+		quantities[productID] += input.Quantity
+		if quantities[productID] > maxOrderItemQuantity {
+			return nil, nil, apperr.NewErrValidationFailedWithDetail("items", "max_quantity",
+				fmt.Sprintf("total quantity of product %s must not exceed %d", productID, maxOrderItemQuantity))
+		}
+	}
+
+	return quantities, productIDs, nil
+}
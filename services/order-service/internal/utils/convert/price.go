@@ -0,0 +1,7 @@
+package convert
+
+import "math"
+
+func RoundPrice(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
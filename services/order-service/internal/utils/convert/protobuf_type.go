@@ -0,0 +1,31 @@
+package convert
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TimePtrToTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func StringWrapperToPtr(s *wrapperspb.StringValue) *string {
+	if s == nil {
+		return nil
+	}
+
+	v := s.Value
+	return &v
+}
+
+func PtrToStringWrapper(s *string) *wrapperspb.StringValue {
+	if s == nil {
+		return nil
+	}
+	return wrapperspb.String(*s)
+}
@@ -0,0 +1,24 @@
+CREATE TYPE order_status_enum AS ENUM ('pending', 'confirmed', 'shipping', 'delivered', 'cancelled');
+
+CREATE TABLE IF NOT EXISTS orders (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    status order_status_enum NOT NULL DEFAULT 'pending',
+    total_amount DECIMAL(12, 2) NOT NULL CHECK (total_amount >= 0),
+    note TEXT,
+    shipping_full_name VARCHAR(255) NOT NULL,
+    shipping_phone VARCHAR(20) NOT NULL,
+    shipping_address_line1 VARCHAR(255) NOT NULL,
+    shipping_address_line2 VARCHAR(255),
+    shipping_ward VARCHAR(100) NOT NULL,
+    shipping_city VARCHAR(100) NOT NULL,
+    shipping_country VARCHAR(100) NOT NULL,
+    cancel_reason VARCHAR(255),
+    cancelled_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_orders_user_id ON orders(user_id);
+CREATE INDEX idx_orders_status ON orders(status);
+CREATE INDEX idx_orders_created_at ON orders(created_at);
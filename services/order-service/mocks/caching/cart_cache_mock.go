@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/khoihuynh300/go-microservice/order-service/internal/caching (interfaces: CartCache)
+
+// Package mock_caching is a generated GoMock package.
+package mock_caching
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	models "github.com/khoihuynh300/go-microservice/order-service/internal/domain/models"
+)
+
+// MockCartCache is a mock of CartCache interface.
+type MockCartCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockCartCacheMockRecorder
+}
+
+// MockCartCacheMockRecorder is the mock recorder for MockCartCache.
+type MockCartCacheMockRecorder struct {
+	mock *MockCartCache
+}
+
+// NewMockCartCache creates a new mock instance.
+func NewMockCartCache(ctrl *gomock.Controller) *MockCartCache {
+	mock := &MockCartCache{ctrl: ctrl}
+	mock.recorder = &MockCartCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCartCache) EXPECT() *MockCartCacheMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockCartCache) Delete(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCartCacheMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCartCache)(nil).Delete), arg0, arg1)
+}
+
+// Get mocks base method.
+func (m *MockCartCache) Get(arg0 context.Context, arg1 uuid.UUID) (*models.Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(*models.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCartCacheMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCartCache)(nil).Get), arg0, arg1)
+}
+
+// Update mocks base method.
+func (m *MockCartCache) Update(arg0 context.Context, arg1 uuid.UUID, arg2 func(*models.Cart) error) (*models.Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCartCacheMockRecorder) Update(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCartCache)(nil).Update), arg0, arg1, arg2)
+}
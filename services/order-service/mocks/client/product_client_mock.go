@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/khoihuynh300/go-microservice/order-service/internal/client (interfaces: ProductClient)
+
+// Package mock_client is a generated GoMock package.
+package mock_client
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	productpb "github.com/khoihuynh300/go-microservice/shared/proto/product"
+)
+
+// MockProductClient is a mock of ProductClient interface.
+type MockProductClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockProductClientMockRecorder
+}
+
+// MockProductClientMockRecorder is the mock recorder for MockProductClient.
+type MockProductClientMockRecorder struct {
+	mock *MockProductClient
+}
+
+// NewMockProductClient creates a new mock instance.
+func NewMockProductClient(ctrl *gomock.Controller) *MockProductClient {
+	mock := &MockProductClient{ctrl: ctrl}
+	mock.recorder = &MockProductClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProductClient) EXPECT() *MockProductClientMockRecorder {
+	return m.recorder
+}
+
+// GetProductsByIDs mocks base method.
+func (m *MockProductClient) GetProductsByIDs(arg0 context.Context, arg1 []string) ([]*productpb.ProductSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductsByIDs", arg0, arg1)
+	ret0, _ := ret[0].([]*productpb.ProductSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductsByIDs indicates an expected call of GetProductsByIDs.
+func (mr *MockProductClientMockRecorder) GetProductsByIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductsByIDs", reflect.TypeOf((*MockProductClient)(nil).GetProductsByIDs), arg0, arg1)
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/khoihuynh300/go-microservice/order-service/internal/client (interfaces: UserClient)
+
+// Package mock_client is a generated GoMock package.
+package mock_client
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	userpb "github.com/khoihuynh300/go-microservice/shared/proto/user"
+)
+
+// MockUserClient is a mock of UserClient interface.
+type MockUserClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserClientMockRecorder
+}
+
+// MockUserClientMockRecorder is the mock recorder for MockUserClient.
+type MockUserClientMockRecorder struct {
+	mock *MockUserClient
+}
+
+// NewMockUserClient creates a new mock instance.
+func NewMockUserClient(ctrl *gomock.Controller) *MockUserClient {
+	mock := &MockUserClient{ctrl: ctrl}
+	mock.recorder = &MockUserClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserClient) EXPECT() *MockUserClientMockRecorder {
+	return m.recorder
+}
+
+// GetUserAddress mocks base method.
+func (m *MockUserClient) GetUserAddress(arg0 context.Context, arg1 string) (*userpb.Address, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserAddress", arg0, arg1)
+	ret0, _ := ret[0].(*userpb.Address)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserAddress indicates an expected call of GetUserAddress.
+func (mr *MockUserClientMockRecorder) GetUserAddress(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAddress", reflect.TypeOf((*MockUserClient)(nil).GetUserAddress), arg0, arg1)
+}
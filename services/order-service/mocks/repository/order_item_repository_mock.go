@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/khoihuynh300/go-microservice/order-service/internal/repository (interfaces: OrderItemRepository)
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	models "github.com/khoihuynh300/go-microservice/order-service/internal/domain/models"
+)
+
+// MockOrderItemRepository is a mock of OrderItemRepository interface.
+type MockOrderItemRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderItemRepositoryMockRecorder
+}
+
+// MockOrderItemRepositoryMockRecorder is the mock recorder for MockOrderItemRepository.
+type MockOrderItemRepositoryMockRecorder struct {
+	mock *MockOrderItemRepository
+}
+
+// NewMockOrderItemRepository creates a new mock instance.
+func NewMockOrderItemRepository(ctrl *gomock.Controller) *MockOrderItemRepository {
+	mock := &MockOrderItemRepository{ctrl: ctrl}
+	mock.recorder = &MockOrderItemRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderItemRepository) EXPECT() *MockOrderItemRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockOrderItemRepository) Create(arg0 context.Context, arg1 *models.OrderItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOrderItemRepositoryMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrderItemRepository)(nil).Create), arg0, arg1)
+}
+
+// GetByOrderID mocks base method.
+func (m *MockOrderItemRepository) GetByOrderID(arg0 context.Context, arg1 uuid.UUID) ([]*models.OrderItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByOrderID", arg0, arg1)
+	ret0, _ := ret[0].([]*models.OrderItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByOrderID indicates an expected call of GetByOrderID.
+func (mr *MockOrderItemRepositoryMockRecorder) GetByOrderID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOrderID", reflect.TypeOf((*MockOrderItemRepository)(nil).GetByOrderID), arg0, arg1)
+}
+
+// GetByOrderIDs mocks base method.
+func (m *MockOrderItemRepository) GetByOrderIDs(arg0 context.Context, arg1 []uuid.UUID) ([]*models.OrderItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByOrderIDs", arg0, arg1)
+	ret0, _ := ret[0].([]*models.OrderItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByOrderIDs indicates an expected call of GetByOrderIDs.
+func (mr *MockOrderItemRepositoryMockRecorder) GetByOrderIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOrderIDs", reflect.TypeOf((*MockOrderItemRepository)(nil).GetByOrderIDs), arg0, arg1)
+}
+
+// WithinTransaction mocks base method.
+func (m *MockOrderItemRepository) WithinTransaction(arg0 context.Context, arg1 func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithinTransaction", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithinTransaction indicates an expected call of WithinTransaction.
+func (mr *MockOrderItemRepositoryMockRecorder) WithinTransaction(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithinTransaction", reflect.TypeOf((*MockOrderItemRepository)(nil).WithinTransaction), arg0, arg1)
+}
@@ -0,0 +1,125 @@
+package caching_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/khoihuynh300/go-microservice/order-service/internal/caching"
+	"github.com/khoihuynh300/go-microservice/order-service/internal/domain/models"
+	apperr "github.com/khoihuynh300/go-microservice/shared/pkg/errors"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCartCache(t *testing.T) (caching.CartCache, *miniredis.Miniredis) {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	return caching.NewCartCache(client), server
+}
+
+func addOne(productID uuid.UUID) func(cart *models.Cart) error {
+	return func(cart *models.Cart) error {
+		_, item := cart.FindItem(productID)
+		if item == nil {
+			item = &models.CartItem{ProductID: productID}
+			cart.Items = append(cart.Items, item)
+		}
+		item.Quantity++
+		return nil
+	}
+}
+
+func TestCartCache_Update(t *testing.T) {
+	ctx := context.Background()
+	cartCache, server := newTestCartCache(t)
+	userID := uuid.New()
+	productID := uuid.New()
+
+	cart, err := cartCache.Get(ctx, userID)
+	require.NoError(t, err)
+	assert.Empty(t, cart.Items)
+
+	_, err = cartCache.Update(ctx, userID, addOne(productID))
+	require.NoError(t, err)
+
+	cart, err = cartCache.Get(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, cart.Items, 1)
+	assert.Equal(t, int32(1), cart.Items[0].Quantity)
+	assert.Equal(t, caching.CartTTL, server.TTL(caching.CartPrefix+":"+userID.String()))
+}
+
+func TestCartCache_UpdateError(t *testing.T) {
+	ctx := context.Background()
+	cartCache, _ := newTestCartCache(t)
+	userID := uuid.New()
+	productID := uuid.New()
+
+	_, err := cartCache.Update(ctx, userID, addOne(productID))
+	require.NoError(t, err)
+
+	_, err = cartCache.Update(ctx, userID, func(cart *models.Cart) error {
+		cart.Items[0].Quantity = 99
+		return apperr.ErrCartFull
+	})
+	assert.True(t, errors.Is(err, apperr.ErrCartFull))
+
+	cart, err := cartCache.Get(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), cart.Items[0].Quantity, "a failed update must not be stored")
+}
+
+func TestCartCache_UpdateToEmptyDeletesCart(t *testing.T) {
+	ctx := context.Background()
+	cartCache, server := newTestCartCache(t)
+	userID := uuid.New()
+
+	_, err := cartCache.Update(ctx, userID, addOne(uuid.New()))
+	require.NoError(t, err)
+
+	_, err = cartCache.Update(ctx, userID, func(cart *models.Cart) error {
+		cart.Items = nil
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.False(t, server.Exists(caching.CartPrefix+":"+userID.String()))
+}
+
+func TestCartCache_ConcurrentUpdatesAreNotLost(t *testing.T) {
+	ctx := context.Background()
+	cartCache, _ := newTestCartCache(t)
+	userID := uuid.New()
+	productID := uuid.New()
+
+	const writers = 10
+	var succeeded atomic.Int32
+	var wg sync.WaitGroup
+	for range writers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// an update may give up after repeated conflicts, but must never overwrite another
+			if _, err := cartCache.Update(ctx, userID, addOne(productID)); err == nil {
+				succeeded.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	cart, err := cartCache.Get(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, cart.Items, 1)
+	assert.Positive(t, succeeded.Load())
+	assert.Equal(t, succeeded.Load(), cart.Items[0].Quantity)
+}
@@ -0,0 +1,410 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/khoihuynh300/go-microservice/order-service/internal/domain/dto"
+	"github.com/khoihuynh300/go-microservice/order-service/internal/domain/models"
+	"github.com/khoihuynh300/go-microservice/order-service/internal/service"
+	mock_caching "github.com/khoihuynh300/go-microservice/order-service/mocks/caching"
+	mock_client "github.com/khoihuynh300/go-microservice/order-service/mocks/client"
+	apperr "github.com/khoihuynh300/go-microservice/shared/pkg/errors"
+	productpb "github.com/khoihuynh300/go-microservice/shared/proto/product"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type CartServiceTestSuite struct {
+	ctrl          *gomock.Controller
+	cartCache     *mock_caching.MockCartCache
+	productClient *mock_client.MockProductClient
+	cartService   service.CartService
+}
+
+func NewCartServiceTestSuite(t *testing.T) *CartServiceTestSuite {
+	ctrl := gomock.NewController(t)
+	cartCache := mock_caching.NewMockCartCache(ctrl)
+	productClient := mock_client.NewMockProductClient(ctrl)
+	cartService := service.NewCartService(cartCache, productClient)
+	return &CartServiceTestSuite{
+		ctrl:          ctrl,
+		cartCache:     cartCache,
+		productClient: productClient,
+		cartService:   cartService,
+	}
+}
+
+// expectUpdate runs the update function against cart, the way the cache does
+// for the copy it reads from Redis.
+func (s *CartServiceTestSuite) expectUpdate(userID uuid.UUID, cart *models.Cart) {
+	s.cartCache.EXPECT().
+		Update(gomock.Any(), userID, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, userID uuid.UUID, fn func(cart *models.Cart) error) (*models.Cart, error) {
+			if err := fn(cart); err != nil {
+				return nil, err
+			}
+			return cart, nil
+		})
+}
+
+func fullCart(userID uuid.UUID, size int) *models.Cart {
+	cart := &models.Cart{UserID: userID}
+	for range size {
+		cart.Items = append(cart.Items, &models.CartItem{ProductID: uuid.New(), Quantity: 1, UnitPrice: 1})
+	}
+	return cart
+}
+
+func TestCartService_AddItem(t *testing.T) {
+	testUserID := uuid.New()
+	testProductID := uuid.New()
+
+	product := &productpb.ProductSummary{
+		Id:        testProductID.String(),
+		Sku:       "KB-001",
+		Name:      "Mechanical Keyboard",
+		Price:     49.99,
+		Thumbnail: wrapperspb.String("https://cdn.example.com/kb-001.png"),
+	}
+
+	tests := []struct {
+		name          string
+		input         *dto.CartItemDTO
+		setupMock     func(s *CartServiceTestSuite)
+		expectedError error
+		checkFunc     func(t *testing.T, cart *models.Cart, err error)
+	}{
+		{
+			name:  "Add New Item",
+			input: &dto.CartItemDTO{ProductID: testProductID.String(), Quantity: 2},
+			setupMock: func(s *CartServiceTestSuite) {
+				s.productClient.EXPECT().GetProductsByIDs(gomock.Any(), []string{testProductID.String()}).
+					Return([]*productpb.ProductSummary{product}, nil).Times(2)
+				s.expectUpdate(testUserID, &models.Cart{UserID: testUserID})
+			},
+			expectedError: nil,
+			checkFunc: func(t *testing.T, cart *models.Cart, err error) {
+				require.NotNil(t, cart)
+				require.Len(t, cart.Items, 1)
+				item := cart.Items[0]
+				assert.Equal(t, int32(2), item.Quantity)
+				assert.Equal(t, "Mechanical Keyboard", item.ProductName)
+				assert.Equal(t, "KB-001", item.ProductSKU)
+				assert.Equal(t, 49.99, item.UnitPrice)
+				require.NotNil(t, item.Thumbnail)
+				assert.Equal(t, "https://cdn.example.com/kb-001.png", *item.Thumbnail)
+				assert.True(t, item.Available)
+				assert.False(t, cart.HasChanges())
+			},
+		},
+		{
+			name:  "Add To Existing Item",
+			input: &dto.CartItemDTO{ProductID: testProductID.String(), Quantity: 3},
+			setupMock: func(s *CartServiceTestSuite) {
+				s.productClient.EXPECT().GetProductsByIDs(gomock.Any(), []string{testProductID.String()}).
+					Return([]*productpb.ProductSummary{product}, nil).Times(2)
+				s.expectUpdate(testUserID, &models.Cart{
+					UserID: testUserID,
+					Items:  []*models.CartItem{{ProductID: testProductID, Quantity: 97, UnitPrice: 49.99}},
+				})
+			},
+			expectedError: nil,
+			checkFunc: func(t *testing.T, cart *models.Cart, err error) {
+				require.NotNil(t, cart)
+				require.Len(t, cart.Items, 1)
+				assert.Equal(t, int32(100), cart.Items[0].Quantity)
+			},
+		},
+		{
+			name:  "Quantity Limit Exceeded",
+			input: &dto.CartItemDTO{ProductID: testProductID.String(), Quantity: 4},
+			setupMock: func(s *CartServiceTestSuite) {
+				s.productClient.EXPECT().GetProductsByIDs(gomock.Any(), []string{testProductID.String()}).
+					Return([]*productpb.ProductSummary{product}, nil)
+				s.expectUpdate(testUserID, &models.Cart{
+					UserID: testUserID,
+					Items:  []*models.CartItem{{ProductID: testProductID, Quantity: 97, UnitPrice: 49.99}},
+				})
+			},
+			checkFunc: func(t *testing.T, cart *models.Cart, err error) {
+				assert.Nil(t, cart)
+
+				var appErr *apperr.AppError
+				require.True(t, errors.As(err, &appErr))
+				assert.Equal(t, apperr.CodeValidationFailed, appErr.Code)
+				require.Len(t, appErr.Details, 1)
+				assert.Equal(t, "quantity", appErr.Details[0].Field)
+			},
+		},
+		{
+			name:  "Cart Full",
+			input: &dto.CartItemDTO{ProductID: testProductID.String(), Quantity: 1},
+			setupMock: func(s *CartServiceTestSuite) {
+				s.productClient.EXPECT().GetProductsByIDs(gomock.Any(), []string{testProductID.String()}).
+					Return([]*productpb.ProductSummary{product}, nil)
+				s.expectUpdate(testUserID, fullCart(testUserID, 50))
+			},
+			expectedError: apperr.ErrCartFull,
+			checkFunc: func(t *testing.T, cart *models.Cart, err error) {
+				assert.Nil(t, cart)
+			},
+		},
+		{
+			name:  "Existing Item In Full Cart",
+			input: &dto.CartItemDTO{ProductID: testProductID.String(), Quantity: 1},
+			setupMock: func(s *CartServiceTestSuite) {
+				cart := fullCart(testUserID, 49)
+				cart.Items = append(cart.Items, &models.CartItem{ProductID: testProductID, Quantity: 1, UnitPrice: 49.99})
+
+				s.productClient.EXPECT().GetProductsByIDs(gomock.Any(), []string{testProductID.String()}).
+					Return([]*productpb.ProductSummary{product}, nil)
+				s.expectUpdate(testUserID, cart)
+				s.productClient.EXPECT().GetProductsByIDs(gomock.Any(), gomock.Len(50)).
+					Return([]*productpb.ProductSummary{product}, nil)
+			},
+			expectedError: nil,
+			checkFunc: func(t *testing.T, cart *models.Cart, err error) {
+				require.NotNil(t, cart)
+				assert.Len(t, cart.Items, 50)
+				_, item := cart.FindItem(testProductID)
+				require.NotNil(t, item)
+				assert.Equal(t, int32(2), item.Quantity)
+			},
+		},
+		{
+			name:  "Product Not Found",
+			input: &dto.CartItemDTO{ProductID: testProductID.String(), Quantity: 1},
+			setupMock: func(s *CartServiceTestSuite) {
+				s.productClient.EXPECT().GetProductsByIDs(gomock.Any(), []string{testProductID.String()}).Return(nil, nil)
+			},
+			expectedError: apperr.ErrProductNotFound,
+			checkFunc:     nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suite := NewCartServiceTestSuite(t)
+			defer suite.ctrl.Finish()
+
+			tt.setupMock(suite)
+
+			cart, err := suite.cartService.AddItem(newTestContext(), testUserID.String(), tt.input)
+
+			if tt.expectedError != nil {
+				assert.True(t, errors.Is(err, tt.expectedError))
+			}
+
+			if tt.checkFunc != nil {
+				tt.checkFunc(t, cart, err)
+			}
+		})
+	}
+}
+
+func TestCartService_UpdateQuantity(t *testing.T) {
+	testUserID := uuid.New()
+	testProductID := uuid.New()
+	product := &productpb.ProductSummary{Id: testProductID.String(), Sku: "KB-001", Name: "Mechanical Keyboard", Price: 59.99}
+
+	tests := []struct {
+		name          string
+		setupMock     func(s *CartServiceTestSuite)
+		expectedError error
+		checkFunc     func(t *testing.T, cart *models.Cart, err error)
+	}{
+		{
+			name: "Update Acknowledges Current Price",
+			setupMock: func(s *CartServiceTestSuite) {
+				s.productClient.EXPECT().GetProductsByIDs(gomock.Any(), []string{testProductID.String()}).
+					Return([]*productpb.ProductSummary{product}, nil).Times(2)
+				s.expectUpdate(testUserID, &models.Cart{
+					UserID: testUserID,
+					Items:  []*models.CartItem{{ProductID: testProductID, Quantity: 1, UnitPrice: 49.99}},
+				})
+			},
+			expectedError: nil,
+			checkFunc: func(t *testing.T, cart *models.Cart, err error) {
+				require.NotNil(t, cart)
+				require.Len(t, cart.Items, 1)
+				assert.Equal(t, int32(5), cart.Items[0].Quantity)
+				assert.Equal(t, 59.99, cart.Items[0].UnitPrice)
+				assert.False(t, cart.Items[0].PriceChanged)
+			},
+		},
+		{
+			name: "Item Not In Cart",
+			setupMock: func(s *CartServiceTestSuite) {
+				s.productClient.EXPECT().GetProductsByIDs(gomock.Any(), []string{testProductID.String()}).
+					Return([]*productpb.ProductSummary{product}, nil)
+				s.expectUpdate(testUserID, &models.Cart{UserID: testUserID})
+			},
+			expectedError: apperr.ErrCartItemNotFound,
+			checkFunc:     nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suite := NewCartServiceTestSuite(t)
+			defer suite.ctrl.Finish()
+
+			tt.setupMock(suite)
+
+			input := &dto.CartItemDTO{ProductID: testProductID.String(), Quantity: 5}
+			cart, err := suite.cartService.UpdateQuantity(newTestContext(), testUserID.String(), input)
+
+			assert.True(t, errors.Is(err, tt.expectedError))
+
+			if tt.checkFunc != nil {
+				tt.checkFunc(t, cart, err)
+			}
+		})
+	}
+}
+
+func TestCartService_RemoveItem(t *testing.T) {
+	testUserID := uuid.New()
+	testProductID := uuid.New()
+
+	tests := []struct {
+		name          string
+		setupMock     func(s *CartServiceTestSuite)
+		expectedError error
+		checkFunc     func(t *testing.T, cart *models.Cart, err error)
+	}{
+		{
+			name: "Remove Last Item",
+			setupMock: func(s *CartServiceTestSuite) {
+				s.expectUpdate(testUserID, &models.Cart{
+					UserID: testUserID,
+					Items:  []*models.CartItem{{ProductID: testProductID, Quantity: 1}},
+				})
+			},
+			expectedError: nil,
+			checkFunc: func(t *testing.T, cart *models.Cart, err error) {
+				require.NotNil(t, cart)
+				assert.Empty(t, cart.Items)
+			},
+		},
+		{
+			name: "Item Not In Cart",
+			setupMock: func(s *CartServiceTestSuite) {
+				s.expectUpdate(testUserID, &models.Cart{UserID: testUserID})
+			},
+			expectedError: apperr.ErrCartItemNotFound,
+			checkFunc:     nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suite := NewCartServiceTestSuite(t)
+			defer suite.ctrl.Finish()
+
+			tt.setupMock(suite)
+
+			cart, err := suite.cartService.RemoveItem(newTestContext(), testUserID.String(), testProductID.String())
+
+			assert.True(t, errors.Is(err, tt.expectedError))
+
+			if tt.checkFunc != nil {
+				tt.checkFunc(t, cart, err)
+			}
+		})
+	}
+}
+
+func TestCartService_GetCart(t *testing.T) {
+	testUserID := uuid.New()
+	keyboardID := uuid.New()
+	mouseID := uuid.New()
+	removedID := uuid.New()
+	addedAt := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name          string
+		setupMock     func(s *CartServiceTestSuite)
+		expectedError error
+		checkFunc     func(t *testing.T, cart *models.Cart, err error)
+	}{
+		{
+			name: "Revalidate Flags Unavailable Items And Price Changes",
+			setupMock: func(s *CartServiceTestSuite) {
+				s.cartCache.EXPECT().Get(gomock.Any(), testUserID).Return(&models.Cart{
+					UserID: testUserID,
+					Items: []*models.CartItem{
+						{ProductID: keyboardID, ProductName: "Keyboard", Quantity: 1, UnitPrice: 49.99, AddedAt: addedAt},
+						{ProductID: mouseID, ProductName: "Wireless Mouse", Quantity: 2, UnitPrice: 19.95, AddedAt: addedAt},
+						{ProductID: removedID, ProductName: "Discontinued Headset", Quantity: 1, UnitPrice: 89, AddedAt: addedAt},
+					},
+				}, nil)
+				s.productClient.EXPECT().
+					GetProductsByIDs(gomock.Any(), []string{keyboardID.String(), mouseID.String(), removedID.String()}).
+					Return([]*productpb.ProductSummary{
+						{Id: keyboardID.String(), Sku: "KB-001", Name: "Mechanical Keyboard", Price: 44.99},
+						{Id: mouseID.String(), Sku: "MS-001", Name: "Wireless Mouse", Price: 19.95},
+					}, nil)
+			},
+			expectedError: nil,
+			checkFunc: func(t *testing.T, cart *models.Cart, err error) {
+				require.NotNil(t, cart)
+				require.Len(t, cart.Items, 3)
+				assert.True(t, cart.HasChanges())
+
+				keyboard := cart.Items[0]
+				assert.True(t, keyboard.Available)
+				assert.True(t, keyboard.PriceChanged)
+				require.NotNil(t, keyboard.PreviousPrice)
+				assert.Equal(t, 49.99, *keyboard.PreviousPrice)
+				assert.Equal(t, 44.99, keyboard.UnitPrice)
+				assert.Equal(t, "Mechanical Keyboard", keyboard.ProductName)
+
+				mouse := cart.Items[1]
+				assert.True(t, mouse.Available)
+				assert.False(t, mouse.PriceChanged)
+				assert.Nil(t, mouse.PreviousPrice)
+
+				removed := cart.Items[2]
+				assert.False(t, removed.Available)
+				assert.Equal(t, "Discontinued Headset", removed.ProductName)
+				assert.Equal(t, float64(89), removed.UnitPrice)
+			},
+		},
+		{
+			name: "Empty Cart Skips Catalog Lookup",
+			setupMock: func(s *CartServiceTestSuite) {
+				s.cartCache.EXPECT().Get(gomock.Any(), testUserID).Return(&models.Cart{UserID: testUserID, Items: []*models.CartItem{}}, nil)
+			},
+			expectedError: nil,
+			checkFunc: func(t *testing.T, cart *models.Cart, err error) {
+				require.NotNil(t, cart)
+				assert.Empty(t, cart.Items)
+				assert.False(t, cart.HasChanges())
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suite := NewCartServiceTestSuite(t)
+			defer suite.ctrl.Finish()
+
+			tt.setupMock(suite)
+
+			cart, err := suite.cartService.GetCart(newTestContext(), testUserID.String())
+
+			assert.True(t, errors.Is(err, tt.expectedError))
+
+			if tt.checkFunc != nil {
+				tt.checkFunc(t, cart, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,547 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/khoihuynh300/go-microservice/order-service/internal/domain/dto"
+	"github.com/khoihuynh300/go-microservice/order-service/internal/domain/models"
+	"github.com/khoihuynh300/go-microservice/order-service/internal/service"
+	mock_client "github.com/khoihuynh300/go-microservice/order-service/mocks/client"
+	mock_repository "github.com/khoihuynh300/go-microservice/order-service/mocks/repository"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/const/contextkeys"
+	apperr "github.com/khoihuynh300/go-microservice/shared/pkg/errors"
+	productpb "github.com/khoihuynh300/go-microservice/shared/proto/product"
+	userpb "github.com/khoihuynh300/go-microservice/shared/proto/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type OrderServiceTestSuite struct {
+	ctrl          *gomock.Controller
+	orderRepo     *mock_repository.MockOrderRepository
+	orderItemRepo *mock_repository.MockOrderItemRepository
+	productClient *mock_client.MockProductClient
+	userClient    *mock_client.MockUserClient
+	orderService  service.OrderService
+}
+
+func NewOrderServiceTestSuite(t *testing.T) *OrderServiceTestSuite {
+	ctrl := gomock.NewController(t)
+	orderRepo := mock_repository.NewMockOrderRepository(ctrl)
+	orderItemRepo := mock_repository.NewMockOrderItemRepository(ctrl)
+	productClient := mock_client.NewMockProductClient(ctrl)
+	userClient := mock_client.NewMockUserClient(ctrl)
+	orderService := service.NewOrderService(orderRepo, orderItemRepo, productClient, userClient)
+	return &OrderServiceTestSuite{
+		ctrl:          ctrl,
+		orderRepo:     orderRepo,
+		orderItemRepo: orderItemRepo,
+		productClient: productClient,
+		userClient:    userClient,
+		orderService:  orderService,
+	}
+}
+
+func (s *OrderServiceTestSuite) expectTransaction() {
+	s.orderRepo.EXPECT().
+		WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+}
+
+func newTestContext() context.Context {
+	return context.WithValue(context.Background(), contextkeys.LoggerKey, zap.NewNop())
+}
+
+func TestOrderService_CreateOrder(t *testing.T) {
+	testUserID := uuid.New()
+	testOrderID := uuid.New()
+	testAddressID := uuid.New().String()
+	keyboardID := uuid.New()
+	mouseID := uuid.New()
+
+	address := &userpb.Address{
+		Id:           testAddressID,
+		UserId:       testUserID.String(),
+		FullName:     "John Doe",
+		Phone:        "0123456789",
+		AddressLine1: "123 Main St",
+		AddressLine2: "Apartment 4B",
+		Ward:         "Ben Nghe",
+		City:         "Ho Chi Minh",
+		Country:      "Vietnam",
+	}
+	keyboard := &productpb.ProductSummary{Id: keyboardID.String(), Sku: "KB-001", Name: "Mechanical Keyboard", Price: 49.99}
+	mouse := &productpb.ProductSummary{Id: mouseID.String(), Sku: "MS-001", Name: "Wireless Mouse", Price: 19.95}
+
+	tests := []struct {
+		name          string
+		input         *dto.CreateOrderDTO
+		setupMock     func(s *OrderServiceTestSuite)
+		expectedError error
+		checkFunc     func(t *testing.T, order *models.Order, err error)
+	}{
+		{
+			name: "Create Order Snapshots Products And Address",
+			input: &dto.CreateOrderDTO{
+				AddressID: testAddressID,
+				Items: []dto.OrderItemInput{
+					{ProductID: keyboardID.String(), Quantity: 1},
+					{ProductID: mouseID.String(), Quantity: 2},
+					{ProductID: keyboardID.String(), Quantity: 2},
+				},
+				Note: "Leave at the door",
+			},
+			setupMock: func(s *OrderServiceTestSuite) {
+				s.userClient.EXPECT().GetUserAddress(gomock.Any(), testAddressID).Return(address, nil)
+				s.productClient.EXPECT().
+					GetProductsByIDs(gomock.Any(), []string{keyboardID.String(), mouseID.String()}).
+					Return([]*productpb.ProductSummary{mouse, keyboard}, nil)
+				s.expectTransaction()
+				s.orderRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, order *models.Order) error {
+					order.ID = testOrderID
+					return nil
+				})
+				s.orderItemRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(2).DoAndReturn(func(ctx context.Context, item *models.OrderItem) error {
+					if item.OrderID != testOrderID {
+						return errors.New("order item is not linked to the created order")
+					}
+					return nil
+				})
+			},
+			expectedError: nil,
+			checkFunc: func(t *testing.T, order *models.Order, err error) {
+				require.NotNil(t, order)
+				assert.Equal(t, testOrderID, order.ID)
+				assert.Equal(t, testUserID, order.UserID)
+				assert.Equal(t, models.OrderStatusPending, order.Status)
+				assert.Equal(t, 189.87, order.TotalAmount)
+				require.NotNil(t, order.Note)
+				assert.Equal(t, "Leave at the door", *order.Note)
+
+				assert.Equal(t, "John Doe", order.ShippingAddress.FullName)
+				assert.Equal(t, "0123456789", order.ShippingAddress.Phone)
+				assert.Equal(t, "123 Main St", order.ShippingAddress.AddressLine1)
+				require.NotNil(t, order.ShippingAddress.AddressLine2)
+				assert.Equal(t, "Apartment 4B", *order.ShippingAddress.AddressLine2)
+				assert.Equal(t, "Ben Nghe", order.ShippingAddress.Ward)
+				assert.Equal(t, "Ho Chi Minh", order.ShippingAddress.City)
+				assert.Equal(t, "Vietnam", order.ShippingAddress.Country)
+
+				require.Len(t, order.Items, 2)
+				assert.Equal(t, keyboardID, order.Items[0].ProductID)
+				assert.Equal(t, "Mechanical Keyboard", order.Items[0].ProductName)
+				assert.Equal(t, "KB-001", order.Items[0].ProductSKU)
+				assert.Equal(t, 49.99, order.Items[0].UnitPrice)
+				assert.Equal(t, int32(3), order.Items[0].Quantity)
+				assert.Equal(t, 149.97, order.Items[0].Subtotal)
+
+				assert.Equal(t, mouseID, order.Items[1].ProductID)
+				assert.Equal(t, "Wireless Mouse", order.Items[1].ProductName)
+				assert.Equal(t, "MS-001", order.Items[1].ProductSKU)
+				assert.Equal(t, 19.95, order.Items[1].UnitPrice)
+				assert.Equal(t, int32(2), order.Items[1].Quantity)
+				assert.Equal(t, 39.9, order.Items[1].Subtotal)
+
+				for _, item := range order.Items {
+					assert.Equal(t, testOrderID, item.OrderID)
+				}
+			},
+		},
+		{
+			name: "Create Order Without Optional Fields",
+			input: &dto.CreateOrderDTO{
+				AddressID: testAddressID,
+				Items:     []dto.OrderItemInput{{ProductID: mouseID.String(), Quantity: 1}},
+			},
+			setupMock: func(s *OrderServiceTestSuite) {
+				s.userClient.EXPECT().GetUserAddress(gomock.Any(), testAddressID).Return(&userpb.Address{
+					Id:           testAddressID,
+					UserId:       testUserID.String(),
+					FullName:     "John Doe",
+					AddressLine1: "123 Main St",
+					City:         "Ho Chi Minh",
+					Country:      "Vietnam",
+				}, nil)
+				s.productClient.EXPECT().GetProductsByIDs(gomock.Any(), []string{mouseID.String()}).Return([]*productpb.ProductSummary{mouse}, nil)
+				s.expectTransaction()
+				s.orderRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+				s.orderItemRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedError: nil,
+			checkFunc: func(t *testing.T, order *models.Order, err error) {
+				require.NotNil(t, order)
+				assert.Nil(t, order.Note)
+				assert.Nil(t, order.ShippingAddress.AddressLine2)
+				assert.Equal(t, 19.95, order.TotalAmount)
+			},
+		},
+		{
+			name: "Unknown Product",
+			input: &dto.CreateOrderDTO{
+				AddressID: testAddressID,
+				Items: []dto.OrderItemInput{
+					{ProductID: keyboardID.String(), Quantity: 1},
+					{ProductID: mouseID.String(), Quantity: 1},
+				},
+			},
+			setupMock: func(s *OrderServiceTestSuite) {
+				s.userClient.EXPECT().GetUserAddress(gomock.Any(), testAddressID).Return(address, nil)
+				s.productClient.EXPECT().
+					GetProductsByIDs(gomock.Any(), []string{keyboardID.String(), mouseID.String()}).
+					Return([]*productpb.ProductSummary{keyboard}, nil)
+			},
+			checkFunc: func(t *testing.T, order *models.Order, err error) {
+				assert.Nil(t, order)
+
+				var appErr *apperr.AppError
+				require.True(t, errors.As(err, &appErr))
+				assert.Equal(t, apperr.CodeProductUnavailable, appErr.Code)
+				require.Len(t, appErr.Details, 1)
+				assert.Equal(t, mouseID.String(), appErr.Details[0].Field)
+				assert.Equal(t, apperr.CodeProductNotFound, appErr.Details[0].Code)
+			},
+		},
+		{
+			name: "Address Belongs To Another User",
+			input: &dto.CreateOrderDTO{
+				AddressID: testAddressID,
+				Items:     []dto.OrderItemInput{{ProductID: keyboardID.String(), Quantity: 1}},
+			},
+			setupMock: func(s *OrderServiceTestSuite) {
+				// user-service scopes address lookups to the caller, so a foreign address is reported as missing
+				s.userClient.EXPECT().GetUserAddress(gomock.Any(), testAddressID).Return(nil, apperr.ErrAddressNotFound)
+			},
+			expectedError: apperr.ErrAddressNotFound,
+			checkFunc: func(t *testing.T, order *models.Order, err error) {
+				assert.Nil(t, order)
+			},
+		},
+		{
+			name: "Invalid Product ID",
+			input: &dto.CreateOrderDTO{
+				AddressID: testAddressID,
+				Items:     []dto.OrderItemInput{{ProductID: "not-a-uuid", Quantity: 1}},
+			},
+			setupMock: func(s *OrderServiceTestSuite) {},
+			checkFunc: func(t *testing.T, order *models.Order, err error) {
+				assert.Error(t, err)
+				assert.Nil(t, order)
+			},
+		},
+		{
+			name: "Merged Quantity Exceeds Limit",
+			input: &dto.CreateOrderDTO{
+				AddressID: testAddressID,
+				Items: []dto.OrderItemInput{
+					{ProductID: keyboardID.String(), Quantity: 60},
+					{ProductID: mouseID.String(), Quantity: 1},
+					{ProductID: keyboardID.String(), Quantity: 60},
+				},
+			},
+			setupMock: func(s *OrderServiceTestSuite) {},
+			checkFunc: func(t *testing.T, order *models.Order, err error) {
+				assert.Nil(t, order)
+
+				var appErr *apperr.AppError
+				require.True(t, errors.As(err, &appErr))
+				assert.Equal(t, apperr.CodeValidationFailed, appErr.Code)
+				require.Len(t, appErr.Details, 1)
+				assert.Equal(t, "items", appErr.Details[0].Field)
+				assert.Equal(t, "max_quantity", appErr.Details[0].Code)
+				assert.Contains(t, appErr.Details[0].Message, keyboardID.String())
+			},
+		},
+		{
+			name: "Create Order Item Fails",
+			input: &dto.CreateOrderDTO{
+				AddressID: testAddressID,
+				Items:     []dto.OrderItemInput{{ProductID: keyboardID.String(), Quantity: 1}},
+			},
+			setupMock: func(s *OrderServiceTestSuite) {
+				s.userClient.EXPECT().GetUserAddress(gomock.Any(), testAddressID).Return(address, nil)
+				s.productClient.EXPECT().GetProductsByIDs(gomock.Any(), []string{keyboardID.String()}).Return([]*productpb.ProductSummary{keyboard}, nil)
+				s.expectTransaction()
+				s.orderRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+				s.orderItemRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(apperr.ErrInternal)
+			},
+			expectedError: apperr.ErrInternal,
+			checkFunc: func(t *testing.T, order *models.Order, err error) {
+				assert.Nil(t, order)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suite := NewOrderServiceTestSuite(t)
+			defer suite.ctrl.Finish()
+
+			tt.setupMock(suite)
+
+			order, err := suite.orderService.CreateOrder(newTestContext(), testUserID.String(), tt.input)
+
+			if tt.expectedError != nil {
+				assert.True(t, errors.Is(err, tt.expectedError))
+			}
+
+			if tt.checkFunc != nil {
+				tt.checkFunc(t, order, err)
+			}
+		})
+	}
+}
+
+func TestOrderService_GetOrder(t *testing.T) {
+	testUserID := uuid.New()
+	testOrderID := uuid.New()
+
+	tests := []struct {
+		name          string
+		setupMock     func(s *OrderServiceTestSuite)
+		expectedError error
+		checkFunc     func(t *testing.T, order *models.Order, err error)
+	}{
+		{
+			name: "Get Order Success",
+			setupMock: func(s *OrderServiceTestSuite) {
+				s.orderRepo.EXPECT().GetByID(gomock.Any(), testOrderID).Return(&models.Order{ID: testOrderID, UserID: testUserID}, nil)
+				s.orderItemRepo.EXPECT().GetByOrderID(gomock.Any(), testOrderID).Return([]*models.OrderItem{{OrderID: testOrderID}}, nil)
+			},
+			expectedError: nil,
+			checkFunc: func(t *testing.T, order *models.Order, err error) {
+				require.NotNil(t, order)
+				assert.Len(t, order.Items, 1)
+			},
+		},
+		{
+			name: "Order Of Another User",
+			setupMock: func(s *OrderServiceTestSuite) {
+				s.orderRepo.EXPECT().GetByID(gomock.Any(), testOrderID).Return(&models.Order{ID: testOrderID, UserID: uuid.New()}, nil)
+			},
+			expectedError: apperr.ErrOrderNotFound,
+			checkFunc:     nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suite := NewOrderServiceTestSuite(t)
+			defer suite.ctrl.Finish()
+
+			tt.setupMock(suite)
+
+			order, err := suite.orderService.GetOrder(newTestContext(), testUserID.String(), testOrderID.String())
+
+			assert.True(t, errors.Is(err, tt.expectedError))
+
+			if tt.checkFunc != nil {
+				tt.checkFunc(t, order, err)
+			}
+		})
+	}
+}
+
+func TestOrderService_ListMyOrders(t *testing.T) {
+	testUserID := uuid.New()
+	firstOrderID := uuid.New()
+	secondOrderID := uuid.New()
+	shipping := string(models.OrderStatusShipping)
+
+	tests := []struct {
+		name          string
+		input         *dto.ListOrdersDTO
+		setupMock     func(s *OrderServiceTestSuite)
+		expectedError error
+		checkFunc     func(t *testing.T, orders []*models.Order, total int64, err error)
+	}{
+		{
+			name:  "List Page With Items",
+			input: &dto.ListOrdersDTO{Page: 2, PageSize: 2},
+			setupMock: func(s *OrderServiceTestSuite) {
+				orders := []*models.Order{
+					{ID: firstOrderID, UserID: testUserID},
+					{ID: secondOrderID, UserID: testUserID},
+				}
+				s.orderRepo.EXPECT().ListByUserID(gomock.Any(), testUserID, nil, int32(2), int32(2)).Return(orders, int64(5), nil)
+				s.orderItemRepo.EXPECT().
+					GetByOrderIDs(gomock.Any(), []uuid.UUID{firstOrderID, secondOrderID}).
+					Return([]*models.OrderItem{
+						{OrderID: secondOrderID, ProductName: "Wireless Mouse"},
+						{OrderID: firstOrderID, ProductName: "Mechanical Keyboard"},
+						{OrderID: secondOrderID, ProductName: "Mouse Pad"},
+					}, nil)
+			},
+			expectedError: nil,
+			checkFunc: func(t *testing.T, orders []*models.Order, total int64, err error) {
+				assert.Equal(t, int64(5), total)
+				require.Len(t, orders, 2)
+				require.Len(t, orders[0].Items, 1)
+				assert.Equal(t, "Mechanical Keyboard", orders[0].Items[0].ProductName)
+				require.Len(t, orders[1].Items, 2)
+				assert.Equal(t, "Wireless Mouse", orders[1].Items[0].ProductName)
+				assert.Equal(t, "Mouse Pad", orders[1].Items[1].ProductName)
+			},
+		},
+		{
+			name:  "List Filtered By Status",
+			input: &dto.ListOrdersDTO{Status: &shipping, Page: 1, PageSize: 10},
+			setupMock: func(s *OrderServiceTestSuite) {
+				status := models.OrderStatusShipping
+				orders := []*models.Order{{ID: firstOrderID, UserID: testUserID, Status: status}}
+				s.orderRepo.EXPECT().ListByUserID(gomock.Any(), testUserID, &status, int32(1), int32(10)).Return(orders, int64(1), nil)
+				s.orderItemRepo.EXPECT().GetByOrderIDs(gomock.Any(), []uuid.UUID{firstOrderID}).Return(nil, nil)
+			},
+			expectedError: nil,
+			checkFunc: func(t *testing.T, orders []*models.Order, total int64, err error) {
+				assert.Equal(t, int64(1), total)
+				assert.Len(t, orders, 1)
+			},
+		},
+		{
+			name:  "Page Past The End",
+			input: &dto.ListOrdersDTO{Page: 4, PageSize: 2},
+			setupMock: func(s *OrderServiceTestSuite) {
+				s.orderRepo.EXPECT().ListByUserID(gomock.Any(), testUserID, nil, int32(4), int32(2)).Return([]*models.Order{}, int64(5), nil)
+			},
+			expectedError: nil,
+			checkFunc: func(t *testing.T, orders []*models.Order, total int64, err error) {
+				assert.Equal(t, int64(5), total)
+				assert.Empty(t, orders)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suite := NewOrderServiceTestSuite(t)
+			defer suite.ctrl.Finish()
+
+			tt.setupMock(suite)
+
+			orders, total, err := suite.orderService.ListMyOrders(newTestContext(), testUserID.String(), tt.input)
+
+			assert.True(t, errors.Is(err, tt.expectedError))
+
+			if tt.checkFunc != nil {
+				tt.checkFunc(t, orders, total, err)
+			}
+		})
+	}
+}
+
+func TestOrderService_CancelOrder(t *testing.T) {
+	testUserID := uuid.New()
+	testOrderID := uuid.New()
+
+	tests := []struct {
+		name          string
+		input         *dto.CancelOrderDTO
+		setupMock     func(s *OrderServiceTestSuite)
+		expectedError error
+		checkFunc     func(t *testing.T, order *models.Order, err error)
+	}{
+		{
+			name:  "Cancel Order Success",
+			input: &dto.CancelOrderDTO{OrderID: testOrderID.String(), Reason: "Changed my mind"},
+			setupMock: func(s *OrderServiceTestSuite) {
+				s.expectTransaction()
+				s.orderRepo.EXPECT().GetByIDForUpdate(gomock.Any(), testOrderID).
+					Return(&models.Order{ID: testOrderID, UserID: testUserID, Status: models.OrderStatusConfirmed}, nil)
+				s.orderRepo.EXPECT().Cancel(gomock.Any(), testOrderID, gomock.Any()).DoAndReturn(func(ctx context.Context, id uuid.UUID, reason *string) (int64, error) {
+					if reason == nil || *reason != "Changed my mind" {
+						return 0, errors.New("unexpected cancel reason")
+					}
+					return 1, nil
+				})
+				s.orderRepo.EXPECT().GetByID(gomock.Any(), testOrderID).
+					Return(&models.Order{ID: testOrderID, UserID: testUserID, Status: models.OrderStatusCancelled}, nil)
+				s.orderItemRepo.EXPECT().GetByOrderID(gomock.Any(), testOrderID).Return([]*models.OrderItem{{OrderID: testOrderID}}, nil)
+			},
+			expectedError: nil,
+			checkFunc: func(t *testing.T, order *models.Order, err error) {
+				require.NotNil(t, order)
+				assert.Equal(t, models.OrderStatusCancelled, order.Status)
+				assert.Len(t, order.Items, 1)
+			},
+		},
+		{
+			name:  "Order Of Another User",
+			input: &dto.CancelOrderDTO{OrderID: testOrderID.String()},
+			setupMock: func(s *OrderServiceTestSuite) {
+				s.expectTransaction()
+				s.orderRepo.EXPECT().GetByIDForUpdate(gomock.Any(), testOrderID).
+					Return(&models.Order{ID: testOrderID, UserID: uuid.New(), Status: models.OrderStatusPending}, nil)
+			},
+			expectedError: apperr.ErrOrderNotFound,
+			checkFunc:     nil,
+		},
+		{
+			name:  "Order Not Found",
+			input: &dto.CancelOrderDTO{OrderID: testOrderID.String()},
+			setupMock: func(s *OrderServiceTestSuite) {
+				s.expectTransaction()
+				s.orderRepo.EXPECT().GetByIDForUpdate(gomock.Any(), testOrderID).Return(nil, nil)
+			},
+			expectedError: apperr.ErrOrderNotFound,
+			checkFunc:     nil,
+		},
+		{
+			name:  "Order Already Shipping",
+			input: &dto.CancelOrderDTO{OrderID: testOrderID.String()},
+			setupMock: func(s *OrderServiceTestSuite) {
+				s.expectTransaction()
+				s.orderRepo.EXPECT().GetByIDForUpdate(gomock.Any(), testOrderID).
+					Return(&models.Order{ID: testOrderID, UserID: testUserID, Status: models.OrderStatusShipping}, nil)
+			},
+			expectedError: apperr.ErrOrderCannotBeCancelled,
+			checkFunc:     nil,
+		},
+		{
+			name:  "Order Already Cancelled",
+			input: &dto.CancelOrderDTO{OrderID: testOrderID.String()},
+			setupMock: func(s *OrderServiceTestSuite) {
+				s.expectTransaction()
+				s.orderRepo.EXPECT().GetByIDForUpdate(gomock.Any(), testOrderID).
+					Return(&models.Order{ID: testOrderID, UserID: testUserID, Status: models.OrderStatusCancelled}, nil)
+			},
+			expectedError: apperr.ErrOrderCannotBeCancelled,
+			checkFunc:     nil,
+		},
+		{
+			name:  "Status Changed Concurrently",
+			input: &dto.CancelOrderDTO{OrderID: testOrderID.String()},
+			setupMock: func(s *OrderServiceTestSuite) {
+				s.expectTransaction()
+				s.orderRepo.EXPECT().GetByIDForUpdate(gomock.Any(), testOrderID).
+					Return(&models.Order{ID: testOrderID, UserID: testUserID, Status: models.OrderStatusPending}, nil)
+				s.orderRepo.EXPECT().Cancel(gomock.Any(), testOrderID, nil).Return(int64(0), nil)
+			},
+			expectedError: apperr.ErrOrderCannotBeCancelled,
+			checkFunc:     nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suite := NewOrderServiceTestSuite(t)
+			defer suite.ctrl.Finish()
+
+			tt.setupMock(suite)
+
+			order, err := suite.orderService.CancelOrder(newTestContext(), testUserID.String(), tt.input)
+
+			assert.True(t, errors.Is(err, tt.expectedError))
+
+			if tt.checkFunc != nil {
+				tt.checkFunc(t, order, err)
+			}
+		})
+	}
+}
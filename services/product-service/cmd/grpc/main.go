@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -11,6 +12,8 @@ import (
 	"github.com/khoihuynh300/go-microservice/product-service/internal/config"
 	"github.com/khoihuynh300/go-microservice/product-service/internal/server"
 	zaplogger "github.com/khoihuynh300/go-microservice/shared/pkg/logger"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/metrics"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/telemetry"
 	"go.uber.org/zap"
 )
 
@@ -32,6 +35,34 @@ func run() error {
 	}
 	defer logger.Sync()
 
+	shutdownTracer, err := telemetry.InitTracer(context.Background(), telemetry.Config{
+		ServiceName:  config.GetServiceName(),
+		Env:          config.GetEnv(),
+		Exporter:     config.GetTraceExporter(),
+		OTLPEndpoint: config.GetOTLPEndpoint(),
+		OTLPInsecure: config.GetOTLPInsecure(),
+		SampleRatio:  config.GetTraceSampleRatio(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to initialize tracer: %w", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracer(ctx); err != nil {
+			logger.Error("failed to flush traces", zap.Error(err))
+		}
+	}()
+
+	shutdownMetrics := metrics.Serve(config.GetMetricsAddr(), logger)
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownMetrics(ctx); err != nil {
+			logger.Error("failed to stop metrics server", zap.Error(err))
+		}
+	}()
+
 	srv, err := server.New(logger)
 	if err != nil {
 		return err
@@ -20,6 +20,15 @@ type Config struct {
 	MinIOSecretKey  string `mapstructure:"MINIO_SECRET_KEY" validate:"required"`
 	MinIOBucketName string `mapstructure:"MINIO_BUCKET_NAME" validate:"required"`
 	MinIOUseSSL     bool   `mapstructure:"MINIO_USE_SSL"`
+
+	// Metrics
+	MetricsAddr string `mapstructure:"METRICS_ADDR"`
+
+	// Tracing
+	TraceExporter    string  `mapstructure:"OTEL_TRACES_EXPORTER" validate:"oneof=none stdout otlp"`
+	OTLPEndpoint     string  `mapstructure:"OTEL_EXPORTER_OTLP_ENDPOINT"`
+	OTLPInsecure     bool    `mapstructure:"OTEL_EXPORTER_OTLP_INSECURE"`
+	TraceSampleRatio float64 `mapstructure:"OTEL_TRACES_SAMPLER_RATIO" validate:"gte=0,lte=1"`
 }
 
 var config Config
@@ -36,6 +45,11 @@ func LoadConfig() error {
 
 	viper.SetDefault("ENV", "DEV")
 	viper.SetDefault("SERVICE_NAME", "product-service")
+	viper.SetDefault("METRICS_ADDR", ":9102")
+	viper.SetDefault("OTEL_TRACES_EXPORTER", "none")
+	viper.SetDefault("OTEL_EXPORTER_OTLP_ENDPOINT", "localhost:4317")
+	viper.SetDefault("OTEL_EXPORTER_OTLP_INSECURE", true)
+	viper.SetDefault("OTEL_TRACES_SAMPLER_RATIO", 1.0)
 	viper.SetDefault("GRPC_ADDR", "localhost:5000")
 
 	if err := viper.Unmarshal(&config); err != nil {
@@ -84,3 +98,23 @@ func GetMinIOBucketName() string {
 func GetMinIOUseSSL() bool {
 	return config.MinIOUseSSL
 }
+
+func GetMetricsAddr() string {
+	return config.MetricsAddr
+}
+
+func GetTraceExporter() string {
+	return config.TraceExporter
+}
+
+func GetOTLPEndpoint() string {
+	return config.OTLPEndpoint
+}
+
+func GetOTLPInsecure() bool {
+	return config.OTLPInsecure
+}
+
+func GetTraceSampleRatio() float64 {
+	return config.TraceSampleRatio
+}
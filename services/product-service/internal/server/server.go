@@ -12,7 +12,9 @@ import (
 	"github.com/khoihuynh300/go-microservice/product-service/internal/repository/impl"
 	"github.com/khoihuynh300/go-microservice/product-service/internal/service"
 	"github.com/khoihuynh300/go-microservice/shared/pkg/interceptor"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/metrics"
 	"github.com/khoihuynh300/go-microservice/shared/pkg/storage"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/telemetry"
 	productpb "github.com/khoihuynh300/go-microservice/shared/proto/product"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
@@ -58,9 +60,11 @@ func New(logger *zap.Logger) (*Server, error) {
 	grpcServer := grpc.NewServer(
 		grpc.ChainUnaryInterceptor(
 			interceptor.TracingInterceptor(logger),
+			interceptor.MetricsInterceptor(),
 			interceptor.RecoveryUnaryInterceptor(),
 			interceptor.LoggingUnaryInterceptor(),
 			interceptor.AuthInterceptor(),
+			interceptor.AuthorizationInterceptor(),
 			interceptor.ValidationUnaryInterceptor(),
 			interceptor.ErrorHandlerInterceptor(),
 		),
@@ -112,12 +116,22 @@ func initDB(dbURL string) (*pgxpool.Pool, error) {
 	dbCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	pool, err := pgxpool.New(dbCtx, dbURL)
+	poolConfig, err := pgxpool.ParseConfig(dbURL)
+	if err != nil {
+		return nil, err
+	}
+	poolConfig.ConnConfig.Tracer = telemetry.NewPgxTracer()
+
+	pool, err := pgxpool.NewWithConfig(dbCtx, poolConfig)
 	if err != nil {
 		return nil, err
 	}
 	if err := pool.Ping(dbCtx); err != nil {
 		return nil, err
 	}
+	if err := metrics.RegisterPgxPool(pool); err != nil {
+		pool.Close()
+		return nil, err
+	}
 	return pool, nil
 }
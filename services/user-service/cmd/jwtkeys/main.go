@@ -0,0 +1,109 @@
+// Command jwtkeys manages the access-token signing keys in JWT_KEYS_DIR.
+//
+//	jwtkeys generate -dir ./keys -alg EdDSA   writes <kid>.pem and prints the kid
+//	jwtkeys list -dir ./keys                  prints the kid and algorithm of every key
+//
+// See docs/jwt-key-rotation.md for the rotation procedure.
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/khoihuynh300/go-microservice/shared/pkg/jwks"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/security/jwtprovider"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "generate":
+		err = generate(os.Args[2:])
+	case "list":
+		err = list(os.Args[2:])
+	default:
+		usage()
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: jwtkeys generate|list -dir <keys dir> [-alg RS256|EdDSA] [-kid <kid>]")
+	os.Exit(2)
+}
+
+func generate(args []string) error {
+	fs := flag.NewFlagSet("generate", flag.ExitOnError)
+	dir := fs.String("dir", "./keys", "keys directory")
+	alg := fs.String("alg", jwks.AlgEdDSA, "signing algorithm (RS256 or EdDSA)")
+	kid := fs.String("kid", "", "key id (default: date plus random suffix)")
+	fs.Parse(args)
+
+	if *kid == "" {
+		suffix := make([]byte, 4)
+		if _, err := rand.Read(suffix); err != nil {
+			return err
+		}
+		*kid = time.Now().UTC().Format("20060102") + "-" + hex.EncodeToString(suffix)
+	}
+	if strings.ContainsAny(*kid, `/\`) {
+		return fmt.Errorf("invalid kid %q", *kid)
+	}
+
+	pemData, err := jwtprovider.GenerateKey(*alg)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(*dir, 0o700); err != nil {
+		return err
+	}
+	path := filepath.Join(*dir, *kid+jwtprovider.KeyFileExt)
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(pemData); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	fmt.Println(*kid)
+	return nil
+}
+
+func list(args []string) error {
+	fs := flag.NewFlagSet("list", flag.ExitOnError)
+	dir := fs.String("dir", "./keys", "keys directory")
+	fs.Parse(args)
+
+	ks, err := jwtprovider.LoadKeys(*dir)
+	if err != nil {
+		return err
+	}
+
+	set, err := ks.JWKS()
+	if err != nil {
+		return err
+	}
+	for _, key := range set.Keys {
+		fmt.Printf("%s\t%s\n", key.Kid, key.Alg)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+
+	"github.com/google/uuid"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/const/contextkeys"
+	apperr "github.com/khoihuynh300/go-microservice/shared/pkg/errors"
+	zaplogger "github.com/khoihuynh300/go-microservice/shared/pkg/logger"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/domain/models"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/repository"
+	"go.uber.org/zap"
+)
+
+// ReasonRefreshTokenReused is recorded when a rotated refresh token is
+// replayed. The caller only sees an invalid token.
+const ReasonRefreshTokenReused = "REFRESH_TOKEN_REUSED"
+
+// Recorder appends entries to the authentication audit log, filling in the
+// client details and trace ID forwarded with the request. Writes are best
+// effort: a failure is logged rather than returned, so an outage of the log
+// never blocks a sign-in.
+type Recorder struct {
+	repo repository.AuditLogRepository
+}
+
+func NewRecorder(repo repository.AuditLogRepository) *Recorder {
+	return &Recorder{
+		repo: repo,
+	}
+}
+
+// Record appends an entry for userID, which may be nil when the account is
+// unknown. A nil err marks the entry successful, otherwise the error's code
+// becomes the reason.
+func (r *Recorder) Record(ctx context.Context, eventType models.AuditEventType, userID *uuid.UUID, err error) {
+	entry := &models.AuditLogEntry{
+		UserID:    userID,
+		EventType: eventType,
+		Success:   err == nil,
+	}
+	if err != nil {
+		reason := reasonOf(err)
+		entry.Reason = &reason
+	}
+
+	r.append(ctx, entry)
+}
+
+// RecordFailure appends a failed entry whose reason differs from the error
+// returned to the caller.
+func (r *Recorder) RecordFailure(ctx context.Context, eventType models.AuditEventType, userID *uuid.UUID, reason string) {
+	r.append(ctx, &models.AuditLogEntry{
+		UserID:    userID,
+		EventType: eventType,
+		Success:   false,
+		Reason:    &reason,
+	})
+}
+
+// RecordAdminAction appends a successful action taken by adminID on userID.
+func (r *Recorder) RecordAdminAction(ctx context.Context, eventType models.AuditEventType, adminID string, userID uuid.UUID) {
+	entry := &models.AuditLogEntry{
+		UserID:    &userID,
+		EventType: eventType,
+		Success:   true,
+	}
+	if actorID, err := uuid.Parse(adminID); err == nil {
+		entry.ActorID = &actorID
+	}
+
+	r.append(ctx, entry)
+}
+
+func (r *Recorder) append(ctx context.Context, entry *models.AuditLogEntry) {
+	// the forwarded address is only kept if it parses, as it comes from a header
+	clientIP, _ := ctx.Value(contextkeys.ClientIPKey).(string)
+	if addr, err := netip.ParseAddr(clientIP); err == nil {
+		entry.IPAddress = addr.String()
+	}
+	entry.UserAgent, _ = ctx.Value(contextkeys.UserAgentKey).(string)
+	entry.TraceID, _ = ctx.Value(contextkeys.TraceIDKey).(string)
+
+	if err := r.repo.Create(ctx, entry); err != nil {
+		zaplogger.FromContext(ctx).Error("Failed to write audit log",
+			zap.String("event_type", string(entry.EventType)),
+			zap.Error(err),
+		)
+	}
+}
+
+func reasonOf(err error) string {
+	var appErr *apperr.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Code
+	}
+	return apperr.CodeInternal
+}
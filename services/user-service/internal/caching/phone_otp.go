@@ -0,0 +1,130 @@
+package caching
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/khoihuynh300/go-microservice/shared/pkg/cache"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/utils"
+)
+
+const (
+	PhoneOTPPrefix         = "user:phone_otp"
+	PhoneOTPAttemptsPrefix = "user:phone_otp_attempts"
+	PhoneOTPCooldownPrefix = "user:phone_otp_cooldown"
+)
+
+const (
+	PhoneOTPTTL      = 10 * time.Minute
+	PhoneOTPCooldown = 1 * time.Minute
+	// wrong codes allowed before the OTP is discarded
+	PhoneOTPMaxAttempts = 5
+)
+
+var (
+	ErrOTPInvalid          = errors.New("otp invalid or expired")
+	ErrOTPAttemptsExceeded = errors.New("otp attempts exceeded")
+	ErrOTPCooldown         = errors.New("otp requested too recently")
+)
+
+type phoneOTP struct {
+	Phone    string `json:"phone"`
+	CodeHash string `json:"code_hash"`
+}
+
+// PhoneOTPCache holds at most one pending phone verification per user. A new
+// request replaces the previous code and resets its attempt count.
+type PhoneOTPCache struct {
+	cache cache.Cache
+}
+
+func NewPhoneOTPCache(cache cache.Cache) *PhoneOTPCache {
+	return &PhoneOTPCache{
+		cache: cache,
+	}
+}
+
+// Issue stores a fresh code for phone and returns it. It fails with
+// ErrOTPCooldown if the user was sent a code less than PhoneOTPCooldown ago.
+func (pc *PhoneOTPCache) Issue(ctx context.Context, userID, phone string) (string, error) {
+	ok, err := pc.cache.SetNX(ctx, fmt.Sprintf("%s:%s", PhoneOTPCooldownPrefix, userID), 1, PhoneOTPCooldown)
+	if err != nil {
+		return "", fmt.Errorf("failed to check otp cooldown: %w", err)
+	}
+	if !ok {
+		return "", ErrOTPCooldown
+	}
+
+	code, err := generateOTP()
+	if err != nil {
+		return "", err
+	}
+
+	data, err := json.Marshal(&phoneOTP{Phone: phone, CodeHash: utils.HashToken(code)})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal otp: %w", err)
+	}
+
+	if err := pc.cache.Set(ctx, fmt.Sprintf("%s:%s", PhoneOTPPrefix, userID), string(data), PhoneOTPTTL); err != nil {
+		return "", fmt.Errorf("failed to set otp: %w", err)
+	}
+	if err := pc.cache.Delete(ctx, fmt.Sprintf("%s:%s", PhoneOTPAttemptsPrefix, userID)); err != nil {
+		return "", fmt.Errorf("failed to reset otp attempts: %w", err)
+	}
+
+	return code, nil
+}
+
+// Verify consumes the user's pending code and returns the phone it was sent
+// to. The last allowed wrong guess discards the code, so it cannot be brute
+// forced within its TTL.
+func (pc *PhoneOTPCache) Verify(ctx context.Context, userID, code string) (string, error) {
+	otpKey := fmt.Sprintf("%s:%s", PhoneOTPPrefix, userID)
+	attemptsKey := fmt.Sprintf("%s:%s", PhoneOTPAttemptsPrefix, userID)
+
+	data, err := pc.cache.Get(ctx, otpKey)
+	if err != nil {
+		return "", ErrOTPInvalid
+	}
+
+	var otp phoneOTP
+	if err := json.Unmarshal([]byte(data), &otp); err != nil {
+		return "", ErrOTPInvalid
+	}
+
+	attempts, err := pc.cache.Incr(ctx, attemptsKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to count otp attempt: %w", err)
+	}
+	if attempts == 1 {
+		if err := pc.cache.Expire(ctx, attemptsKey, PhoneOTPTTL); err != nil {
+			return "", fmt.Errorf("failed to count otp attempt: %w", err)
+		}
+	}
+
+	if attempts <= PhoneOTPMaxAttempts && subtle.ConstantTimeCompare([]byte(utils.HashToken(code)), []byte(otp.CodeHash)) == 1 {
+		_ = pc.cache.Delete(ctx, otpKey, attemptsKey)
+		return otp.Phone, nil
+	}
+
+	if attempts >= PhoneOTPMaxAttempts {
+		_ = pc.cache.Delete(ctx, otpKey, attemptsKey)
+		return "", ErrOTPAttemptsExceeded
+	}
+
+	return "", ErrOTPInvalid
+}
+
+func generateOTP() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1_000_000))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate otp: %w", err)
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
@@ -2,8 +2,10 @@ package caching
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,18 +17,49 @@ const (
 	EmailVerifyPrefix   = "user:verify_email"
 	PasswordResetPrefix = "user:reset_password"
 	EmailChangePrefix   = "user:change_email"
+	AccountUnlockPrefix = "user:unlock_account"
+	MFAChallengePrefix  = "user:mfa_challenge"
+	TOTPUsedCodePrefix  = "user:totp_used"
+	OAuthStatePrefix    = "user:oauth_state"
+	MagicLinkPrefix     = "user:magic_link"
+	MagicLinkRatePrefix = "user:magic_link_rate"
 )
 
 const (
 	EmailVerifyTTL   = 15 * time.Minute
 	PasswordResetTTL = 30 * time.Minute
 	EmailChangeTTL   = 15 * time.Minute
+	AccountUnlockTTL = 1 * time.Hour
+	MFAChallengeTTL  = 5 * time.Minute
+	// covers the current TOTP step and the skew window on either side
+	TOTPUsedCodeTTL = 90 * time.Second
+	OAuthStateTTL   = 10 * time.Minute
+	MagicLinkTTL    = 15 * time.Minute
+	MagicLinkWindow = 15 * time.Minute
 )
 
+// MagicLinkMaxRequests caps how many links one address can be sent per
+// MagicLinkWindow.
+const MagicLinkMaxRequests = 3
+
 var (
 	ErrTokenInvalidOrExpired = errors.New("token invalid or expired")
 )
 
+type EmailChange struct {
+	UserID   string `json:"user_id"`
+	OldEmail string `json:"old_email"`
+	NewEmail string `json:"new_email"`
+}
+
+// OAuthState is kept server-side for the duration of an authorization request
+// and looked up by the state parameter the provider echoes back.
+type OAuthState struct {
+	Provider     string `json:"provider"`
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"code_verifier"`
+}
+
 type TokenCache struct {
 	cache cache.Cache
 }
@@ -91,3 +124,195 @@ func (tc *TokenCache) VerifyPasswordResetToken(ctx context.Context, tokenStr str
 
 	return email, nil
 }
+
+func (tc *TokenCache) SetAccountUnlockToken(ctx context.Context, email string) (string, error) {
+	tokenStr := uuid.New().String()
+	tokenHash := utils.HashToken(tokenStr)
+	key := fmt.Sprintf("%s:%s", AccountUnlockPrefix, tokenHash)
+
+	err := tc.cache.Set(ctx, key, email, AccountUnlockTTL)
+	if err != nil {
+		return "", fmt.Errorf("failed to set account unlock token: %w", err)
+	}
+
+	return tokenStr, nil
+}
+
+func (tc *TokenCache) VerifyAccountUnlockToken(ctx context.Context, tokenStr string) (string, error) {
+	tokenHash := utils.HashToken(tokenStr)
+	key := fmt.Sprintf("%s:%s", AccountUnlockPrefix, tokenHash)
+
+	email, err := tc.cache.Get(ctx, key)
+	if err != nil {
+		return "", ErrTokenInvalidOrExpired
+	}
+
+	_ = tc.cache.Delete(ctx, key)
+
+	return email, nil
+}
+
+func (tc *TokenCache) SetEmailChangeToken(ctx context.Context, change *EmailChange) (string, error) {
+	tokenStr := uuid.New().String()
+	tokenHash := utils.HashToken(tokenStr)
+	key := fmt.Sprintf("%s:%s", EmailChangePrefix, tokenHash)
+
+	data, err := json.Marshal(change)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal email change: %w", err)
+	}
+
+	if err := tc.cache.Set(ctx, key, string(data), EmailChangeTTL); err != nil {
+		return "", fmt.Errorf("failed to set email change token: %w", err)
+	}
+
+	return tokenStr, nil
+}
+
+func (tc *TokenCache) VerifyEmailChangeToken(ctx context.Context, tokenStr string) (*EmailChange, error) {
+	tokenHash := utils.HashToken(tokenStr)
+	key := fmt.Sprintf("%s:%s", EmailChangePrefix, tokenHash)
+
+	data, err := tc.cache.Get(ctx, key)
+	if err != nil {
+		return nil, ErrTokenInvalidOrExpired
+	}
+
+	_ = tc.cache.Delete(ctx, key)
+
+	var change EmailChange
+	if err := json.Unmarshal([]byte(data), &change); err != nil {
+		return nil, ErrTokenInvalidOrExpired
+	}
+
+	return &change, nil
+}
+
+func (tc *TokenCache) SetOAuthState(ctx context.Context, state *OAuthState) (string, error) {
+	tokenStr := uuid.New().String()
+	tokenHash := utils.HashToken(tokenStr)
+	key := fmt.Sprintf("%s:%s", OAuthStatePrefix, tokenHash)
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal oauth state: %w", err)
+	}
+
+	if err := tc.cache.Set(ctx, key, string(data), OAuthStateTTL); err != nil {
+		return "", fmt.Errorf("failed to set oauth state: %w", err)
+	}
+
+	return tokenStr, nil
+}
+
+func (tc *TokenCache) VerifyOAuthState(ctx context.Context, tokenStr string) (*OAuthState, error) {
+	tokenHash := utils.HashToken(tokenStr)
+	key := fmt.Sprintf("%s:%s", OAuthStatePrefix, tokenHash)
+
+	data, err := tc.cache.Get(ctx, key)
+	if err != nil {
+		return nil, ErrTokenInvalidOrExpired
+	}
+
+	_ = tc.cache.Delete(ctx, key)
+
+	var state OAuthState
+	if err := json.Unmarshal([]byte(data), &state); err != nil {
+		return nil, ErrTokenInvalidOrExpired
+	}
+
+	return &state, nil
+}
+
+func (tc *TokenCache) SetMagicLinkToken(ctx context.Context, email string) (string, error) {
+	tokenStr := uuid.New().String()
+	tokenHash := utils.HashToken(tokenStr)
+	key := fmt.Sprintf("%s:%s", MagicLinkPrefix, tokenHash)
+
+	err := tc.cache.Set(ctx, key, email, MagicLinkTTL)
+	if err != nil {
+		return "", fmt.Errorf("failed to set magic link token: %w", err)
+	}
+
+	return tokenStr, nil
+}
+
+func (tc *TokenCache) VerifyMagicLinkToken(ctx context.Context, tokenStr string) (string, error) {
+	tokenHash := utils.HashToken(tokenStr)
+	key := fmt.Sprintf("%s:%s", MagicLinkPrefix, tokenHash)
+
+	email, err := tc.cache.Get(ctx, key)
+	if err != nil {
+		return "", ErrTokenInvalidOrExpired
+	}
+
+	_ = tc.cache.Delete(ctx, key)
+
+	return email, nil
+}
+
+// AllowMagicLinkRequest counts a request for email and reports whether it is
+// still within MagicLinkMaxRequests. Unknown addresses are counted too, so the
+// limit itself does not reveal which emails have accounts.
+func (tc *TokenCache) AllowMagicLinkRequest(ctx context.Context, email string) (bool, error) {
+	key := fmt.Sprintf("%s:%s", MagicLinkRatePrefix, strings.ToLower(email))
+
+	count, err := tc.cache.Incr(ctx, key)
+	if err != nil {
+		return false, fmt.Errorf("failed to count magic link request: %w", err)
+	}
+	if count == 1 {
+		if err := tc.cache.Expire(ctx, key, MagicLinkWindow); err != nil {
+			return false, fmt.Errorf("failed to count magic link request: %w", err)
+		}
+	}
+
+	return count <= MagicLinkMaxRequests, nil
+}
+
+func (tc *TokenCache) SetMFAChallengeToken(ctx context.Context, userID string) (string, error) {
+	tokenStr := uuid.New().String()
+	tokenHash := utils.HashToken(tokenStr)
+	key := fmt.Sprintf("%s:%s", MFAChallengePrefix, tokenHash)
+
+	err := tc.cache.Set(ctx, key, userID, MFAChallengeTTL)
+	if err != nil {
+		return "", fmt.Errorf("failed to set mfa challenge token: %w", err)
+	}
+
+	return tokenStr, nil
+}
+
+// GetMFAChallengeToken returns the user of a challenge without consuming it,
+// so a mistyped code can be retried.
+func (tc *TokenCache) GetMFAChallengeToken(ctx context.Context, tokenStr string) (string, error) {
+	tokenHash := utils.HashToken(tokenStr)
+	key := fmt.Sprintf("%s:%s", MFAChallengePrefix, tokenHash)
+
+	userID, err := tc.cache.Get(ctx, key)
+	if err != nil {
+		return "", ErrTokenInvalidOrExpired
+	}
+
+	return userID, nil
+}
+
+func (tc *TokenCache) DeleteMFAChallengeToken(ctx context.Context, tokenStr string) error {
+	tokenHash := utils.HashToken(tokenStr)
+	key := fmt.Sprintf("%s:%s", MFAChallengePrefix, tokenHash)
+
+	return tc.cache.Delete(ctx, key)
+}
+
+// MarkTOTPCodeUsed reports whether the code was unused, so an intercepted
+// code cannot be replayed within its validity window.
+func (tc *TokenCache) MarkTOTPCodeUsed(ctx context.Context, userID, code string) (bool, error) {
+	key := fmt.Sprintf("%s:%s:%s", TOTPUsedCodePrefix, userID, code)
+
+	ok, err := tc.cache.SetNX(ctx, key, 1, TOTPUsedCodeTTL)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark totp code used: %w", err)
+	}
+
+	return ok, nil
+}
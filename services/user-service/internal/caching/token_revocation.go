@@ -0,0 +1,59 @@
+package caching
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/khoihuynh300/go-microservice/shared/pkg/cache"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/const/rediskeys"
+)
+
+// TokenRevocationCache publishes the per-user "tokens valid after" timestamp
+// and the per-token denylist that the gateway checks on every authenticated
+// request. The keys only need to outlive the access tokens they revoke.
+type TokenRevocationCache struct {
+	cache     cache.Cache
+	accessTTL time.Duration
+}
+
+func NewTokenRevocationCache(cache cache.Cache, accessTTL time.Duration) *TokenRevocationCache {
+	return &TokenRevocationCache{
+		cache:     cache,
+		accessTTL: accessTTL,
+	}
+}
+
+// RevokeUserTokens invalidates every access token issued to the user before
+// now. Tokens carry whole-second iat values, so the cut-off is truncated to
+// the second to keep tokens issued right after the change valid.
+func (rc *TokenRevocationCache) RevokeUserTokens(ctx context.Context, userID string) error {
+	key := rediskeys.TokensValidAfterPrefix + userID
+	validAfter := strconv.FormatInt(time.Now().Unix(), 10)
+
+	if err := rc.cache.Set(ctx, key, validAfter, rc.accessTTL); err != nil {
+		return fmt.Errorf("failed to revoke access tokens: %w", err)
+	}
+	return nil
+}
+
+// RevokeAccessToken denies a single access token, issued at issuedAt, for
+// the rest of its lifetime. Tokens that already expired are skipped.
+func (rc *TokenRevocationCache) RevokeAccessToken(ctx context.Context, tokenID string, issuedAt time.Time) error {
+	remaining := time.Until(issuedAt.Add(rc.accessTTL))
+	if tokenID == "" || remaining <= 0 {
+		return nil
+	}
+
+	if err := rc.cache.Set(ctx, rediskeys.RevokedAccessTokenPrefix+tokenID, "1", remaining); err != nil {
+		return fmt.Errorf("failed to revoke access token: %w", err)
+	}
+	return nil
+}
+
+// LiveSince returns the issue time of the oldest access token that has not
+// expired yet.
+func (rc *TokenRevocationCache) LiveSince() time.Time {
+	return time.Now().Add(-rc.accessTTL)
+}
@@ -0,0 +1,33 @@
+package client
+
+import (
+	"context"
+
+	apperr "github.com/khoihuynh300/go-microservice/shared/pkg/errors"
+	orderpb "github.com/khoihuynh300/go-microservice/shared/proto/order"
+	"google.golang.org/grpc"
+)
+
+type OrderClient interface {
+	// ListMyOrders lists the orders of the user carried in ctx.
+	ListMyOrders(ctx context.Context, page, pageSize int32) (*orderpb.ListOrdersResponse, error)
+}
+
+type orderClient struct {
+	client orderpb.OrderServiceClient
+}
+
+func NewOrderClient(conn *grpc.ClientConn) OrderClient {
+	return &orderClient{
+		client: orderpb.NewOrderServiceClient(conn),
+	}
+}
+
+func (c *orderClient) ListMyOrders(ctx context.Context, page, pageSize int32) (*orderpb.ListOrdersResponse, error) {
+	resp, err := c.client.ListMyOrders(ctx, &orderpb.ListMyOrdersRequest{Page: page, PageSize: pageSize})
+	if err != nil {
+		return nil, apperr.FromGRPCError(err)
+	}
+
+	return resp, nil
+}
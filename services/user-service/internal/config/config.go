@@ -1,6 +1,8 @@
 package config
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/go-playground/validator/v10"
@@ -17,10 +19,17 @@ type Config struct {
 	DBUrl string `mapstructure:"DATABASE_URL" validate:"required"`
 
 	// Security
-	JwtAccessSecret  string        `mapstructure:"JWT_ACCESS_SECRET" validate:"required"`
+	JwtKeysDir       string        `mapstructure:"JWT_KEYS_DIR" validate:"required"`
+	JwtSigningKeyID  string        `mapstructure:"JWT_SIGNING_KEY_ID" validate:"required"`
 	JwtRefreshSecret string        `mapstructure:"JWT_REFRESH_SECRET" validate:"required"`
 	AccessTokenTTL   time.Duration `mapstructure:"ACCESS_TOKEN_TTL"`
 	RefreshTokenTTL  time.Duration `mapstructure:"REFRESH_TOKEN_TTL"`
+	JWKSAddr         string        `mapstructure:"JWKS_ADDR"`
+	TOTPIssuer       string        `mapstructure:"TOTP_ISSUER"`
+
+	// OAuth
+	OAuthProviders       []string `mapstructure:"OAUTH_PROVIDERS"`
+	OAuthRedirectBaseURL string   `mapstructure:"OAUTH_REDIRECT_BASE_URL" validate:"required_with=OAuthProviders"`
 
 	// Redis
 	RedisHost     string `mapstructure:"REDIS_HOST" validate:"required"`
@@ -29,17 +38,75 @@ type Config struct {
 	RedisDB       int    `mapstructure:"REDIS_DB"`
 
 	// Kafka
-	KafkaBrokers []string `mapstructure:"KAFKA_BROKERS" validate:"required"`
+	KafkaBrokers     []string `mapstructure:"KAFKA_BROKERS" validate:"required"`
+	KafkaContentType string   `mapstructure:"KAFKA_CONTENT_TYPE" validate:"oneof=application/json application/x-protobuf"`
+
+	// Outbox
+	OutboxPollInterval time.Duration `mapstructure:"OUTBOX_POLL_INTERVAL" validate:"gt=0"`
+	OutboxBatchSize    int32         `mapstructure:"OUTBOX_BATCH_SIZE" validate:"gte=1"`
+	OutboxRetention    time.Duration `mapstructure:"OUTBOX_RETENTION" validate:"gt=0"`
+	OutboxMaxAttempts  int32         `mapstructure:"OUTBOX_MAX_ATTEMPTS" validate:"gte=1"`
+
+	// Login protection
+	LoginMaxFailures     int           `mapstructure:"LOGIN_MAX_FAILURES" validate:"gte=1"`
+	LoginDelayAfter      int           `mapstructure:"LOGIN_DELAY_AFTER" validate:"gte=1"`
+	LoginBaseDelay       time.Duration `mapstructure:"LOGIN_BASE_DELAY"`
+	LoginMaxDelay        time.Duration `mapstructure:"LOGIN_MAX_DELAY"`
+	LoginLockoutDuration time.Duration `mapstructure:"LOGIN_LOCKOUT_DURATION"`
+	LoginFailureWindow   time.Duration `mapstructure:"LOGIN_FAILURE_WINDOW"`
+	LoginIPMaxFailures   int           `mapstructure:"LOGIN_IP_MAX_FAILURES" validate:"gte=1"`
+
+	// Account deletion
+	AccountDeletionGracePeriod time.Duration `mapstructure:"ACCOUNT_DELETION_GRACE_PERIOD"`
+	AccountAnonymizeInterval   time.Duration `mapstructure:"ACCOUNT_ANONYMIZE_INTERVAL"`
+	AccountAnonymizeBatchSize  int32         `mapstructure:"ACCOUNT_ANONYMIZE_BATCH_SIZE" validate:"gte=1"`
+
+	// Audit log
+	AuditLogRetention      time.Duration `mapstructure:"AUDIT_LOG_RETENTION"`
+	AuditLogPruneInterval  time.Duration `mapstructure:"AUDIT_LOG_PRUNE_INTERVAL"`
+	AuditLogPruneBatchSize int32         `mapstructure:"AUDIT_LOG_PRUNE_BATCH_SIZE" validate:"gte=1"`
+
+	// Password policy
+	PasswordMinLength     int    `mapstructure:"PASSWORD_MIN_LENGTH" validate:"gte=8,lte=64"`
+	PasswordRequireUpper  bool   `mapstructure:"PASSWORD_REQUIRE_UPPERCASE"`
+	PasswordRequireLower  bool   `mapstructure:"PASSWORD_REQUIRE_LOWERCASE"`
+	PasswordRequireDigit  bool   `mapstructure:"PASSWORD_REQUIRE_DIGIT"`
+	PasswordRequireSymbol bool   `mapstructure:"PASSWORD_REQUIRE_SYMBOL"`
+	BreachedPasswordsFile string `mapstructure:"BREACHED_PASSWORDS_FILE"`
+
+	// Upstream services
+	OrderServiceURL string `mapstructure:"ORDER_SERVICE_URL"`
 
 	// MinIO
-	MinIOEndpoint   string `mapstructure:"MINIO_ENDPOINT" validate:"required"`
-	MinIOAccessKey  string `mapstructure:"MINIO_ACCESS_KEY" validate:"required"`
-	MinIOSecretKey  string `mapstructure:"MINIO_SECRET_KEY" validate:"required"`
-	MinIOBucketName string `mapstructure:"MINIO_BUCKET_NAME" validate:"required"`
-	MinIOUseSSL     bool   `mapstructure:"MINIO_USE_SSL"`
+	MinIOEndpoint         string `mapstructure:"MINIO_ENDPOINT" validate:"required"`
+	MinIOAccessKey        string `mapstructure:"MINIO_ACCESS_KEY" validate:"required"`
+	MinIOSecretKey        string `mapstructure:"MINIO_SECRET_KEY" validate:"required"`
+	MinIOBucketName       string `mapstructure:"MINIO_BUCKET_NAME" validate:"required"`
+	MinIOUseSSL           bool   `mapstructure:"MINIO_USE_SSL"`
+	MinIOExportBucketName string `mapstructure:"MINIO_EXPORT_BUCKET_NAME" validate:"required"`
+
+	// Metrics
+	MetricsAddr string `mapstructure:"METRICS_ADDR"`
+
+	// Tracing
+	TraceExporter    string  `mapstructure:"OTEL_TRACES_EXPORTER" validate:"oneof=none stdout otlp"`
+	OTLPEndpoint     string  `mapstructure:"OTEL_EXPORTER_OTLP_ENDPOINT"`
+	OTLPInsecure     bool    `mapstructure:"OTEL_EXPORTER_OTLP_INSECURE"`
+	TraceSampleRatio float64 `mapstructure:"OTEL_TRACES_SAMPLER_RATIO" validate:"gte=0,lte=1"`
+}
+
+// OAuthProviderConfig is read from OAUTH_<NAME>_ISSUER_URL, _CLIENT_ID,
+// _CLIENT_SECRET and _SCOPES for every name listed in OAUTH_PROVIDERS.
+type OAuthProviderConfig struct {
+	Name         string
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
 }
 
 var config Config
+var oauthProviders []OAuthProviderConfig
 
 func LoadConfig() error {
 	validate := validator.New()
@@ -53,10 +120,36 @@ func LoadConfig() error {
 
 	viper.SetDefault("ENV", "DEV")
 	viper.SetDefault("SERVICE_NAME", "user-service")
+	viper.SetDefault("METRICS_ADDR", ":9101")
+	viper.SetDefault("OTEL_TRACES_EXPORTER", "none")
+	viper.SetDefault("OTEL_EXPORTER_OTLP_ENDPOINT", "localhost:4317")
+	viper.SetDefault("OTEL_EXPORTER_OTLP_INSECURE", true)
+	viper.SetDefault("OTEL_TRACES_SAMPLER_RATIO", 1.0)
 	viper.SetDefault("GRPC_ADDR", "localhost:5001")
 	viper.SetDefault("ACCESS_TOKEN_TTL", "15m")
 	viper.SetDefault("REFRESH_TOKEN_TTL", "168h")
+	viper.SetDefault("JWKS_ADDR", ":8081")
+	viper.SetDefault("TOTP_ISSUER", "go-microservice")
 	viper.SetDefault("REDIS_DB", 0)
+	viper.SetDefault("KAFKA_CONTENT_TYPE", "application/json")
+	viper.SetDefault("OUTBOX_POLL_INTERVAL", "1s")
+	viper.SetDefault("OUTBOX_BATCH_SIZE", 100)
+	viper.SetDefault("OUTBOX_RETENTION", "168h")
+	viper.SetDefault("OUTBOX_MAX_ATTEMPTS", 20)
+	viper.SetDefault("LOGIN_MAX_FAILURES", 10)
+	viper.SetDefault("LOGIN_DELAY_AFTER", 3)
+	viper.SetDefault("LOGIN_BASE_DELAY", "1s")
+	viper.SetDefault("LOGIN_MAX_DELAY", "30s")
+	viper.SetDefault("LOGIN_LOCKOUT_DURATION", "15m")
+	viper.SetDefault("LOGIN_FAILURE_WINDOW", "15m")
+	viper.SetDefault("LOGIN_IP_MAX_FAILURES", 50)
+	viper.SetDefault("ACCOUNT_DELETION_GRACE_PERIOD", "720h")
+	viper.SetDefault("ACCOUNT_ANONYMIZE_INTERVAL", "1h")
+	viper.SetDefault("ACCOUNT_ANONYMIZE_BATCH_SIZE", 100)
+	viper.SetDefault("AUDIT_LOG_RETENTION", "2160h")
+	viper.SetDefault("AUDIT_LOG_PRUNE_INTERVAL", "1h")
+	viper.SetDefault("AUDIT_LOG_PRUNE_BATCH_SIZE", 1000)
+	viper.SetDefault("PASSWORD_MIN_LENGTH", 8)
 
 	if err := viper.Unmarshal(&config); err != nil {
 		return err
@@ -66,9 +159,39 @@ func LoadConfig() error {
 		return err
 	}
 
+	providers, err := loadOAuthProviders(config.OAuthProviders)
+	if err != nil {
+		return err
+	}
+	oauthProviders = providers
+
 	return nil
 }
 
+func loadOAuthProviders(names []string) ([]OAuthProviderConfig, error) {
+	providers := make([]OAuthProviderConfig, 0, len(names))
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		prefix := "OAUTH_" + strings.ToUpper(name) + "_"
+		provider := OAuthProviderConfig{
+			Name:         name,
+			IssuerURL:    viper.GetString(prefix + "ISSUER_URL"),
+			ClientID:     viper.GetString(prefix + "CLIENT_ID"),
+			ClientSecret: viper.GetString(prefix + "CLIENT_SECRET"),
+			Scopes:       viper.GetStringSlice(prefix + "SCOPES"),
+		}
+		if provider.IssuerURL == "" || provider.ClientID == "" {
+			return nil, fmt.Errorf("oauth provider %s: %sISSUER_URL and %sCLIENT_ID are required", name, prefix, prefix)
+		}
+		providers = append(providers, provider)
+	}
+	return providers, nil
+}
+
 func GetServiceName() string {
 	return config.ServiceName
 }
@@ -85,8 +208,12 @@ func GetDBUrl() string {
 	return config.DBUrl
 }
 
-func GetJwtAccessSecret() string {
-	return config.JwtAccessSecret
+func GetJwtKeysDir() string {
+	return config.JwtKeysDir
+}
+
+func GetJwtSigningKeyID() string {
+	return config.JwtSigningKeyID
 }
 
 func GetJwtRefreshSecret() string {
@@ -101,6 +228,23 @@ func GetRefreshTokenTTL() time.Duration {
 	return config.RefreshTokenTTL
 }
 
+func GetJWKSAddr() string {
+	return config.JWKSAddr
+}
+
+func GetTOTPIssuer() string {
+	return config.TOTPIssuer
+}
+
+func GetOAuthProviders() []OAuthProviderConfig {
+	return oauthProviders
+}
+
+// GetOAuthRedirectURL is the gateway callback the provider sends the user back to.
+func GetOAuthRedirectURL(provider string) string {
+	return strings.TrimRight(config.OAuthRedirectBaseURL, "/") + "/v1/auth/oauth/" + provider + "/callback"
+}
+
 func GetRedisHost() string {
 	return config.RedisHost
 }
@@ -120,6 +264,106 @@ func GetKafkaBrokers() []string {
 	return config.KafkaBrokers
 }
 
+func GetKafkaContentType() string {
+	return config.KafkaContentType
+}
+
+func GetOutboxPollInterval() time.Duration {
+	return config.OutboxPollInterval
+}
+
+func GetOutboxBatchSize() int32 {
+	return config.OutboxBatchSize
+}
+
+func GetOutboxRetention() time.Duration {
+	return config.OutboxRetention
+}
+
+func GetOutboxMaxAttempts() int32 {
+	return config.OutboxMaxAttempts
+}
+
+func GetLoginMaxFailures() int {
+	return config.LoginMaxFailures
+}
+
+func GetLoginDelayAfter() int {
+	return config.LoginDelayAfter
+}
+
+func GetLoginBaseDelay() time.Duration {
+	return config.LoginBaseDelay
+}
+
+func GetLoginMaxDelay() time.Duration {
+	return config.LoginMaxDelay
+}
+
+func GetLoginLockoutDuration() time.Duration {
+	return config.LoginLockoutDuration
+}
+
+func GetLoginFailureWindow() time.Duration {
+	return config.LoginFailureWindow
+}
+
+func GetLoginIPMaxFailures() int {
+	return config.LoginIPMaxFailures
+}
+
+func GetAccountDeletionGracePeriod() time.Duration {
+	return config.AccountDeletionGracePeriod
+}
+
+func GetAccountAnonymizeInterval() time.Duration {
+	return config.AccountAnonymizeInterval
+}
+
+func GetAccountAnonymizeBatchSize() int32 {
+	return config.AccountAnonymizeBatchSize
+}
+
+func GetAuditLogRetention() time.Duration {
+	return config.AuditLogRetention
+}
+
+func GetAuditLogPruneInterval() time.Duration {
+	return config.AuditLogPruneInterval
+}
+
+func GetAuditLogPruneBatchSize() int32 {
+	return config.AuditLogPruneBatchSize
+}
+
+func GetPasswordMinLength() int {
+	return config.PasswordMinLength
+}
+
+func GetPasswordRequireUpper() bool {
+	return config.PasswordRequireUpper
+}
+
+func GetPasswordRequireLower() bool {
+	return config.PasswordRequireLower
+}
+
+func GetPasswordRequireDigit() bool {
+	return config.PasswordRequireDigit
+}
+
+func GetPasswordRequireSymbol() bool {
+	return config.PasswordRequireSymbol
+}
+
+func GetBreachedPasswordsFile() string {
+	return config.BreachedPasswordsFile
+}
+
+func GetOrderServiceURL() string {
+	return config.OrderServiceURL
+}
+
 func GetMinIOEndpoint() string {
 	return config.MinIOEndpoint
 }
@@ -139,3 +383,27 @@ func GetMinIOBucketName() string {
 func GetMinIOUseSSL() bool {
 	return config.MinIOUseSSL
 }
+
+func GetMinIOExportBucketName() string {
+	return config.MinIOExportBucketName
+}
+
+func GetMetricsAddr() string {
+	return config.MetricsAddr
+}
+
+func GetTraceExporter() string {
+	return config.TraceExporter
+}
+
+func GetOTLPEndpoint() string {
+	return config.OTLPEndpoint
+}
+
+func GetOTLPInsecure() bool {
+	return config.OTLPInsecure
+}
+
+func GetTraceSampleRatio() float64 {
+	return config.TraceSampleRatio
+}
@@ -87,6 +87,15 @@ func (q *Queries) DeleteAddress(ctx context.Context, id uuid.UUID) (int64, error
 	return result.RowsAffected(), nil
 }
 
+const deleteAddressesByUserID = `-- name: DeleteAddressesByUserID :exec
+DELETE FROM user_addresses WHERE user_id = $1
+`
+
+func (q *Queries) DeleteAddressesByUserID(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteAddressesByUserID, userID)
+	return err
+}
+
 const getAddressByIDAndUserID = `-- name: GetAddressByIDAndUserID :one
 SELECT id, user_id, address_type, full_name, phone, address_line1, address_line2, ward, city, country, is_default, created_at, updated_at FROM user_addresses
 WHERE id = $1 AND user_id = $2
@@ -0,0 +1,161 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: auth_audit_log.sql
+
+package sqlc
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const countAuditLogs = `-- name: CountAuditLogs :one
+SELECT COUNT(*) FROM auth_audit_log
+WHERE ($1::uuid IS NULL OR user_id = $1)
+  AND ($2::text IS NULL OR event_type = $2)
+  AND ($3::text IS NULL OR ip_address = $3)
+  AND ($4::timestamptz IS NULL OR created_at >= $4)
+  AND ($5::timestamptz IS NULL OR created_at < $5)
+`
+
+type CountAuditLogsParams struct {
+	UserID      pgtype.UUID
+	EventType   pgtype.Text
+	IpAddress   pgtype.Text
+	CreatedFrom pgtype.Timestamptz
+	CreatedTo   pgtype.Timestamptz
+}
+
+func (q *Queries) CountAuditLogs(ctx context.Context, arg CountAuditLogsParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countAuditLogs,
+		arg.UserID,
+		arg.EventType,
+		arg.IpAddress,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createAuditLog = `-- name: CreateAuditLog :exec
+INSERT INTO auth_audit_log (
+    user_id, actor_id, event_type, success, reason, ip_address, user_agent, trace_id, created_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+)
+`
+
+type CreateAuditLogParams struct {
+	UserID    pgtype.UUID
+	ActorID   pgtype.UUID
+	EventType string
+	Success   bool
+	Reason    pgtype.Text
+	IpAddress string
+	UserAgent string
+	TraceID   string
+	CreatedAt time.Time
+}
+
+func (q *Queries) CreateAuditLog(ctx context.Context, arg CreateAuditLogParams) error {
+	_, err := q.db.Exec(ctx, createAuditLog,
+		arg.UserID,
+		arg.ActorID,
+		arg.EventType,
+		arg.Success,
+		arg.Reason,
+		arg.IpAddress,
+		arg.UserAgent,
+		arg.TraceID,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const deleteAuditLogsBefore = `-- name: DeleteAuditLogsBefore :execrows
+DELETE FROM auth_audit_log
+WHERE id IN (
+    SELECT expired.id FROM auth_audit_log AS expired
+    WHERE expired.created_at < $1
+    ORDER BY expired.id
+    LIMIT $2
+)
+`
+
+type DeleteAuditLogsBeforeParams struct {
+	Before time.Time
+	Limit  int32
+}
+
+func (q *Queries) DeleteAuditLogsBefore(ctx context.Context, arg DeleteAuditLogsBeforeParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteAuditLogsBefore, arg.Before, arg.Limit)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const listAuditLogs = `-- name: ListAuditLogs :many
+SELECT id, user_id, actor_id, event_type, success, reason, ip_address, user_agent, trace_id, created_at FROM auth_audit_log
+WHERE ($1::uuid IS NULL OR user_id = $1)
+  AND ($2::text IS NULL OR event_type = $2)
+  AND ($3::text IS NULL OR ip_address = $3)
+  AND ($4::timestamptz IS NULL OR created_at >= $4)
+  AND ($5::timestamptz IS NULL OR created_at < $5)
+ORDER BY created_at DESC, id DESC
+LIMIT $7 OFFSET $6
+`
+
+type ListAuditLogsParams struct {
+	UserID      pgtype.UUID
+	EventType   pgtype.Text
+	IpAddress   pgtype.Text
+	CreatedFrom pgtype.Timestamptz
+	CreatedTo   pgtype.Timestamptz
+	Offset      int32
+	Limit       int32
+}
+
+func (q *Queries) ListAuditLogs(ctx context.Context, arg ListAuditLogsParams) ([]AuthAuditLog, error) {
+	rows, err := q.db.Query(ctx, listAuditLogs,
+		arg.UserID,
+		arg.EventType,
+		arg.IpAddress,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AuthAuditLog
+	for rows.Next() {
+		var i AuthAuditLog
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.ActorID,
+			&i.EventType,
+			&i.Success,
+			&i.Reason,
+			&i.IpAddress,
+			&i.UserAgent,
+			&i.TraceID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
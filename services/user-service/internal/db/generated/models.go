@@ -100,6 +100,49 @@ func (ns NullUserGenderEnum) Value() (driver.Value, error) {
 	return string(ns.UserGenderEnum), nil
 }
 
+type UserRoleEnum string
+
+const (
+	UserRoleEnumCustomer UserRoleEnum = "customer"
+	UserRoleEnumStaff    UserRoleEnum = "staff"
+	UserRoleEnumAdmin    UserRoleEnum = "admin"
+)
+
+func (e *UserRoleEnum) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = UserRoleEnum(s)
+	case string:
+		*e = UserRoleEnum(s)
+	default:
+		return fmt.Errorf("unsupported scan type for UserRoleEnum: %T", src)
+	}
+	return nil
+}
+
+type NullUserRoleEnum struct {
+	UserRoleEnum UserRoleEnum
+	Valid        bool // Valid is true if UserRoleEnum is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullUserRoleEnum) Scan(value interface{}) error {
+	if value == nil {
+		ns.UserRoleEnum, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.UserRoleEnum.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullUserRoleEnum) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.UserRoleEnum), nil
+}
+
 type UserStatusEnum string
 
 const (
@@ -144,16 +187,53 @@ func (ns NullUserStatusEnum) Value() (driver.Value, error) {
 	return string(ns.UserStatusEnum), nil
 }
 
+type AuthAuditLog struct {
+	ID        int64
+	UserID    pgtype.UUID
+	ActorID   pgtype.UUID
+	EventType string
+	Success   bool
+	Reason    pgtype.Text
+	IpAddress string
+	UserAgent string
+	TraceID   string
+	CreatedAt time.Time
+}
+
+type OutboxEvent struct {
+	ID            int64
+	EventID       uuid.UUID
+	Topic         string
+	PartitionKey  string
+	EventType     string
+	TraceID       string
+	Payload       []byte
+	OccurredAt    time.Time
+	Attempts      int32
+	LastError     pgtype.Text
+	NextAttemptAt time.Time
+	PublishedAt   pgtype.Timestamptz
+	CreatedAt     time.Time
+	EventVersion  int32
+	TraceContext  []byte
+	FailedAt      pgtype.Timestamptz
+}
+
 type RefreshToken struct {
-	ID         uuid.UUID
-	UserID     uuid.UUID
-	TokenHash  string
-	DeviceInfo pgtype.Text
-	IpAddress  *netip.Addr
-	UserAgent  pgtype.Text
-	ExpiresAt  time.Time
-	RevokedAt  pgtype.Timestamptz
-	CreatedAt  time.Time
+	ID            uuid.UUID
+	UserID        uuid.UUID
+	TokenHash     string
+	DeviceInfo    pgtype.Text
+	IpAddress     *netip.Addr
+	UserAgent     pgtype.Text
+	ExpiresAt     time.Time
+	RevokedAt     pgtype.Timestamptz
+	CreatedAt     time.Time
+	LastUsedAt    pgtype.Timestamptz
+	FamilyID      uuid.UUID
+	ParentID      pgtype.UUID
+	RotatedAt     pgtype.Timestamptz
+	AccessTokenID string
 }
 
 type User struct {
@@ -170,6 +250,11 @@ type User struct {
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
 	DeletedAt       pgtype.Timestamptz
+	Role            UserRoleEnum
+	TotpSecret      pgtype.Text
+	TotpEnabledAt   pgtype.Timestamptz
+	PhoneVerifiedAt pgtype.Timestamptz
+	AnonymizedAt    pgtype.Timestamptz
 }
 
 type UserAddress struct {
@@ -187,3 +272,21 @@ type UserAddress struct {
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
 }
+
+type UserIdentity struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	Provider    string
+	Subject     string
+	Email       pgtype.Text
+	CreatedAt   time.Time
+	LastLoginAt pgtype.Timestamptz
+}
+
+type UserRecoveryCode struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	CodeHash  string
+	UsedAt    pgtype.Timestamptz
+	CreatedAt time.Time
+}
@@ -0,0 +1,207 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: outbox_events.sql
+
+package sqlc
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createOutboxEvent = `-- name: CreateOutboxEvent :exec
+INSERT INTO outbox_events (
+    event_id, topic, partition_key, event_type, event_version, trace_id, trace_context, payload, occurred_at, created_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+)
+`
+
+type CreateOutboxEventParams struct {
+	EventID      uuid.UUID
+	Topic        string
+	PartitionKey string
+	EventType    string
+	EventVersion int32
+	TraceID      string
+	TraceContext []byte
+	Payload      []byte
+	OccurredAt   time.Time
+	CreatedAt    time.Time
+}
+
+func (q *Queries) CreateOutboxEvent(ctx context.Context, arg CreateOutboxEventParams) error {
+	_, err := q.db.Exec(ctx, createOutboxEvent,
+		arg.EventID,
+		arg.Topic,
+		arg.PartitionKey,
+		arg.EventType,
+		arg.EventVersion,
+		arg.TraceID,
+		arg.TraceContext,
+		arg.Payload,
+		arg.OccurredAt,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const deletePublishedOutboxEvents = `-- name: DeletePublishedOutboxEvents :execrows
+DELETE FROM outbox_events
+WHERE published_at IS NOT NULL AND published_at < $1
+`
+
+func (q *Queries) DeletePublishedOutboxEvents(ctx context.Context, publishedAt pgtype.Timestamptz) (int64, error) {
+	result, err := q.db.Exec(ctx, deletePublishedOutboxEvents, publishedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const listPendingOutboxEvents = `-- name: ListPendingOutboxEvents :many
+SELECT id, event_id, topic, partition_key, event_type, trace_id, payload, occurred_at, attempts, last_error, next_attempt_at, published_at, created_at, event_version, trace_context, failed_at FROM outbox_events o
+WHERE o.published_at IS NULL
+  AND o.failed_at IS NULL
+  AND o.next_attempt_at <= now()
+  AND o.id > $1
+  AND NOT EXISTS (
+      SELECT 1 FROM outbox_events earlier
+      WHERE earlier.partition_key = o.partition_key
+        AND earlier.id < o.id
+        AND earlier.published_at IS NULL
+        AND earlier.failed_at IS NULL
+        AND earlier.next_attempt_at > now()
+  )
+ORDER BY o.id ASC
+LIMIT $2
+`
+
+type ListPendingOutboxEventsParams struct {
+	AfterID int64
+	Limit   int32
+}
+
+// Due events after after_id. An event is left out while an earlier event with
+// the same partition key waits for a retry, so blocked keys do not fill the page.
+func (q *Queries) ListPendingOutboxEvents(ctx context.Context, arg ListPendingOutboxEventsParams) ([]OutboxEvent, error) {
+	rows, err := q.db.Query(ctx, listPendingOutboxEvents, arg.AfterID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []OutboxEvent
+	for rows.Next() {
+		var i OutboxEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.EventID,
+			&i.Topic,
+			&i.PartitionKey,
+			&i.EventType,
+			&i.TraceID,
+			&i.Payload,
+			&i.OccurredAt,
+			&i.Attempts,
+			&i.LastError,
+			&i.NextAttemptAt,
+			&i.PublishedAt,
+			&i.CreatedAt,
+			&i.EventVersion,
+			&i.TraceContext,
+			&i.FailedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markOutboxEventFailed = `-- name: MarkOutboxEventFailed :execrows
+UPDATE outbox_events
+SET attempts = attempts + 1, last_error = $2, next_attempt_at = $3
+WHERE id = $1
+`
+
+type MarkOutboxEventFailedParams struct {
+	ID            int64
+	LastError     pgtype.Text
+	NextAttemptAt time.Time
+}
+
+func (q *Queries) MarkOutboxEventFailed(ctx context.Context, arg MarkOutboxEventFailedParams) (int64, error) {
+	result, err := q.db.Exec(ctx, markOutboxEventFailed, arg.ID, arg.LastError, arg.NextAttemptAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const markOutboxEventPublished = `-- name: MarkOutboxEventPublished :execrows
+UPDATE outbox_events
+SET published_at = $2, attempts = attempts + 1, last_error = NULL
+WHERE id = $1
+`
+
+type MarkOutboxEventPublishedParams struct {
+	ID          int64
+	PublishedAt pgtype.Timestamptz
+}
+
+func (q *Queries) MarkOutboxEventPublished(ctx context.Context, arg MarkOutboxEventPublishedParams) (int64, error) {
+	result, err := q.db.Exec(ctx, markOutboxEventPublished, arg.ID, arg.PublishedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const parkOutboxEvent = `-- name: ParkOutboxEvent :execrows
+UPDATE outbox_events
+SET attempts = attempts + 1, last_error = $2, failed_at = $3
+WHERE id = $1
+`
+
+type ParkOutboxEventParams struct {
+	ID        int64
+	LastError pgtype.Text
+	FailedAt  pgtype.Timestamptz
+}
+
+func (q *Queries) ParkOutboxEvent(ctx context.Context, arg ParkOutboxEventParams) (int64, error) {
+	result, err := q.db.Exec(ctx, parkOutboxEvent, arg.ID, arg.LastError, arg.FailedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const tryLockOutbox = `-- name: TryLockOutbox :one
+SELECT pg_try_advisory_lock($1::bigint) AS locked
+`
+
+func (q *Queries) TryLockOutbox(ctx context.Context, lockID int64) (bool, error) {
+	row := q.db.QueryRow(ctx, tryLockOutbox, lockID)
+	var locked bool
+	err := row.Scan(&locked)
+	return locked, err
+}
+
+const unlockOutbox = `-- name: UnlockOutbox :one
+SELECT pg_advisory_unlock($1::bigint) AS unlocked
+`
+
+func (q *Queries) UnlockOutbox(ctx context.Context, lockID int64) (bool, error) {
+	row := q.db.QueryRow(ctx, unlockOutbox, lockID)
+	var unlocked bool
+	err := row.Scan(&unlocked)
+	return unlocked, err
+}
@@ -0,0 +1,69 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: recovery_codes.sql
+
+package sqlc
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createRecoveryCode = `-- name: CreateRecoveryCode :exec
+INSERT INTO user_recovery_codes (
+    id, user_id, code_hash, created_at
+) VALUES (
+    $1, $2, $3, $4
+)
+`
+
+type CreateRecoveryCodeParams struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	CodeHash  string
+	CreatedAt time.Time
+}
+
+func (q *Queries) CreateRecoveryCode(ctx context.Context, arg CreateRecoveryCodeParams) error {
+	_, err := q.db.Exec(ctx, createRecoveryCode,
+		arg.ID,
+		arg.UserID,
+		arg.CodeHash,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const deleteRecoveryCodesByUserID = `-- name: DeleteRecoveryCodesByUserID :exec
+DELETE FROM user_recovery_codes
+WHERE user_id = $1
+`
+
+func (q *Queries) DeleteRecoveryCodesByUserID(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteRecoveryCodesByUserID, userID)
+	return err
+}
+
+const useRecoveryCode = `-- name: UseRecoveryCode :execrows
+UPDATE user_recovery_codes
+SET used_at = $3
+WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL
+`
+
+type UseRecoveryCodeParams struct {
+	UserID   uuid.UUID
+	CodeHash string
+	UsedAt   pgtype.Timestamptz
+}
+
+func (q *Queries) UseRecoveryCode(ctx context.Context, arg UseRecoveryCodeParams) (int64, error) {
+	result, err := q.db.Exec(ctx, useRecoveryCode, arg.UserID, arg.CodeHash, arg.UsedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
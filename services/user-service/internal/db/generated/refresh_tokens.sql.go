@@ -7,33 +7,51 @@ package sqlc
 
 import (
 	"context"
+	"net/netip"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const createRefreshToken = `-- name: CreateRefreshToken :exec
 INSERT INTO refresh_tokens (
-    id, user_id, token_hash, expires_at, created_at
+    id, user_id, family_id, parent_id, token_hash, device_info, ip_address, user_agent,
+    access_token_id, expires_at, last_used_at, created_at
 ) VALUES (
-    $1, $2, $3, $4, $5
+    $1, $2, $3, $4, $5, $6, $7, $8,
+    $9, $10, $11, $12
 )
 `
 
 type CreateRefreshTokenParams struct {
-	ID        uuid.UUID
-	UserID    uuid.UUID
-	TokenHash string
-	ExpiresAt time.Time
-	CreatedAt time.Time
+	ID            uuid.UUID
+	UserID        uuid.UUID
+	FamilyID      uuid.UUID
+	ParentID      pgtype.UUID
+	TokenHash     string
+	DeviceInfo    pgtype.Text
+	IpAddress     *netip.Addr
+	UserAgent     pgtype.Text
+	AccessTokenID string
+	ExpiresAt     time.Time
+	LastUsedAt    pgtype.Timestamptz
+	CreatedAt     time.Time
 }
 
 func (q *Queries) CreateRefreshToken(ctx context.Context, arg CreateRefreshTokenParams) error {
 	_, err := q.db.Exec(ctx, createRefreshToken,
 		arg.ID,
 		arg.UserID,
+		arg.FamilyID,
+		arg.ParentID,
 		arg.TokenHash,
+		arg.DeviceInfo,
+		arg.IpAddress,
+		arg.UserAgent,
+		arg.AccessTokenID,
 		arg.ExpiresAt,
+		arg.LastUsedAt,
 		arg.CreatedAt,
 	)
 	return err
@@ -52,29 +70,222 @@ func (q *Queries) DeleteRefreshTokenByID(ctx context.Context, id uuid.UUID) (int
 	return result.RowsAffected(), nil
 }
 
-const getRefreshTokenByTokenHash = `-- name: GetRefreshTokenByTokenHash :one
-SELECT id, user_id, token_hash, expires_at, created_at
-FROM refresh_tokens
-WHERE token_hash = $1
+const deleteRefreshTokensByUserID = `-- name: DeleteRefreshTokensByUserID :exec
+DELETE FROM refresh_tokens
+WHERE user_id = $1
 `
 
-type GetRefreshTokenByTokenHashRow struct {
-	ID        uuid.UUID
-	UserID    uuid.UUID
-	TokenHash string
-	ExpiresAt time.Time
-	CreatedAt time.Time
+func (q *Queries) DeleteRefreshTokensByUserID(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteRefreshTokensByUserID, userID)
+	return err
 }
 
-func (q *Queries) GetRefreshTokenByTokenHash(ctx context.Context, tokenHash string) (GetRefreshTokenByTokenHashRow, error) {
+const getRefreshTokenByTokenHash = `-- name: GetRefreshTokenByTokenHash :one
+SELECT id, user_id, token_hash, device_info, ip_address, user_agent, expires_at, revoked_at, created_at, last_used_at, family_id, parent_id, rotated_at, access_token_id FROM refresh_tokens
+WHERE token_hash = $1
+`
+
+func (q *Queries) GetRefreshTokenByTokenHash(ctx context.Context, tokenHash string) (RefreshToken, error) {
 	row := q.db.QueryRow(ctx, getRefreshTokenByTokenHash, tokenHash)
-	var i GetRefreshTokenByTokenHashRow
+	var i RefreshToken
 	err := row.Scan(
 		&i.ID,
 		&i.UserID,
 		&i.TokenHash,
+		&i.DeviceInfo,
+		&i.IpAddress,
+		&i.UserAgent,
 		&i.ExpiresAt,
+		&i.RevokedAt,
 		&i.CreatedAt,
+		&i.LastUsedAt,
+		&i.FamilyID,
+		&i.ParentID,
+		&i.RotatedAt,
+		&i.AccessTokenID,
 	)
 	return i, err
 }
+
+const listRefreshTokensByFamilyID = `-- name: ListRefreshTokensByFamilyID :many
+SELECT id, user_id, token_hash, device_info, ip_address, user_agent, expires_at, revoked_at, created_at, last_used_at, family_id, parent_id, rotated_at, access_token_id FROM refresh_tokens
+WHERE family_id = $1 AND created_at > $2
+`
+
+type ListRefreshTokensByFamilyIDParams struct {
+	FamilyID  uuid.UUID
+	CreatedAt time.Time
+}
+
+func (q *Queries) ListRefreshTokensByFamilyID(ctx context.Context, arg ListRefreshTokensByFamilyIDParams) ([]RefreshToken, error) {
+	rows, err := q.db.Query(ctx, listRefreshTokensByFamilyID, arg.FamilyID, arg.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RefreshToken
+	for rows.Next() {
+		var i RefreshToken
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.TokenHash,
+			&i.DeviceInfo,
+			&i.IpAddress,
+			&i.UserAgent,
+			&i.ExpiresAt,
+			&i.RevokedAt,
+			&i.CreatedAt,
+			&i.LastUsedAt,
+			&i.FamilyID,
+			&i.ParentID,
+			&i.RotatedAt,
+			&i.AccessTokenID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSessionsByUserID = `-- name: ListSessionsByUserID :many
+SELECT
+    current.family_id,
+    current.device_info,
+    current.ip_address,
+    current.user_agent,
+    current.expires_at,
+    current.last_used_at,
+    root.created_at AS started_at
+FROM refresh_tokens current
+JOIN refresh_tokens root ON root.id = current.family_id
+WHERE current.user_id = $1
+    AND current.rotated_at IS NULL
+    AND current.revoked_at IS NULL
+    AND current.expires_at > $2
+ORDER BY current.last_used_at DESC
+`
+
+type ListSessionsByUserIDParams struct {
+	UserID    uuid.UUID
+	ExpiresAt time.Time
+}
+
+type ListSessionsByUserIDRow struct {
+	FamilyID   uuid.UUID
+	DeviceInfo pgtype.Text
+	IpAddress  *netip.Addr
+	UserAgent  pgtype.Text
+	ExpiresAt  time.Time
+	LastUsedAt pgtype.Timestamptz
+	StartedAt  time.Time
+}
+
+func (q *Queries) ListSessionsByUserID(ctx context.Context, arg ListSessionsByUserIDParams) ([]ListSessionsByUserIDRow, error) {
+	rows, err := q.db.Query(ctx, listSessionsByUserID, arg.UserID, arg.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListSessionsByUserIDRow
+	for rows.Next() {
+		var i ListSessionsByUserIDRow
+		if err := rows.Scan(
+			&i.FamilyID,
+			&i.DeviceInfo,
+			&i.IpAddress,
+			&i.UserAgent,
+			&i.ExpiresAt,
+			&i.LastUsedAt,
+			&i.StartedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markRefreshTokenRotated = `-- name: MarkRefreshTokenRotated :execrows
+UPDATE refresh_tokens
+SET rotated_at = $2
+WHERE id = $1 AND rotated_at IS NULL AND revoked_at IS NULL
+`
+
+type MarkRefreshTokenRotatedParams struct {
+	ID        uuid.UUID
+	RotatedAt pgtype.Timestamptz
+}
+
+func (q *Queries) MarkRefreshTokenRotated(ctx context.Context, arg MarkRefreshTokenRotatedParams) (int64, error) {
+	result, err := q.db.Exec(ctx, markRefreshTokenRotated, arg.ID, arg.RotatedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const revokeRefreshTokenFamily = `-- name: RevokeRefreshTokenFamily :execrows
+UPDATE refresh_tokens
+SET revoked_at = $2
+WHERE family_id = $1 AND revoked_at IS NULL
+`
+
+type RevokeRefreshTokenFamilyParams struct {
+	FamilyID  uuid.UUID
+	RevokedAt pgtype.Timestamptz
+}
+
+func (q *Queries) RevokeRefreshTokenFamily(ctx context.Context, arg RevokeRefreshTokenFamilyParams) (int64, error) {
+	result, err := q.db.Exec(ctx, revokeRefreshTokenFamily, arg.FamilyID, arg.RevokedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const revokeRefreshTokenFamilyByUserID = `-- name: RevokeRefreshTokenFamilyByUserID :execrows
+UPDATE refresh_tokens
+SET revoked_at = $3
+WHERE family_id = $1 AND user_id = $2 AND revoked_at IS NULL
+`
+
+type RevokeRefreshTokenFamilyByUserIDParams struct {
+	FamilyID  uuid.UUID
+	UserID    uuid.UUID
+	RevokedAt pgtype.Timestamptz
+}
+
+func (q *Queries) RevokeRefreshTokenFamilyByUserID(ctx context.Context, arg RevokeRefreshTokenFamilyByUserIDParams) (int64, error) {
+	result, err := q.db.Exec(ctx, revokeRefreshTokenFamilyByUserID, arg.FamilyID, arg.UserID, arg.RevokedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const revokeRefreshTokensByUserID = `-- name: RevokeRefreshTokensByUserID :execrows
+UPDATE refresh_tokens
+SET revoked_at = $2
+WHERE user_id = $1 AND revoked_at IS NULL
+`
+
+type RevokeRefreshTokensByUserIDParams struct {
+	UserID    uuid.UUID
+	RevokedAt pgtype.Timestamptz
+}
+
+func (q *Queries) RevokeRefreshTokensByUserID(ctx context.Context, arg RevokeRefreshTokensByUserIDParams) (int64, error) {
+	result, err := q.db.Exec(ctx, revokeRefreshTokensByUserID, arg.UserID, arg.RevokedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,100 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: user_identities.sql
+
+package sqlc
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createUserIdentity = `-- name: CreateUserIdentity :exec
+INSERT INTO user_identities (
+    id, user_id, provider, subject, email, created_at, last_login_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+`
+
+type CreateUserIdentityParams struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	Provider    string
+	Subject     string
+	Email       pgtype.Text
+	CreatedAt   time.Time
+	LastLoginAt pgtype.Timestamptz
+}
+
+func (q *Queries) CreateUserIdentity(ctx context.Context, arg CreateUserIdentityParams) error {
+	_, err := q.db.Exec(ctx, createUserIdentity,
+		arg.ID,
+		arg.UserID,
+		arg.Provider,
+		arg.Subject,
+		arg.Email,
+		arg.CreatedAt,
+		arg.LastLoginAt,
+	)
+	return err
+}
+
+const deleteUserIdentitiesByUserID = `-- name: DeleteUserIdentitiesByUserID :exec
+DELETE FROM user_identities
+WHERE user_id = $1
+`
+
+func (q *Queries) DeleteUserIdentitiesByUserID(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteUserIdentitiesByUserID, userID)
+	return err
+}
+
+const getUserIdentity = `-- name: GetUserIdentity :one
+SELECT id, user_id, provider, subject, email, created_at, last_login_at FROM user_identities
+WHERE provider = $1 AND subject = $2
+`
+
+type GetUserIdentityParams struct {
+	Provider string
+	Subject  string
+}
+
+func (q *Queries) GetUserIdentity(ctx context.Context, arg GetUserIdentityParams) (UserIdentity, error) {
+	row := q.db.QueryRow(ctx, getUserIdentity, arg.Provider, arg.Subject)
+	var i UserIdentity
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Provider,
+		&i.Subject,
+		&i.Email,
+		&i.CreatedAt,
+		&i.LastLoginAt,
+	)
+	return i, err
+}
+
+const recordUserIdentityLogin = `-- name: RecordUserIdentityLogin :execrows
+UPDATE user_identities
+SET email = $2, last_login_at = $3
+WHERE id = $1
+`
+
+type RecordUserIdentityLoginParams struct {
+	ID          uuid.UUID
+	Email       pgtype.Text
+	LastLoginAt pgtype.Timestamptz
+}
+
+func (q *Queries) RecordUserIdentityLogin(ctx context.Context, arg RecordUserIdentityLoginParams) (int64, error) {
+	result, err := q.db.Exec(ctx, recordUserIdentityLogin, arg.ID, arg.Email, arg.LastLoginAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
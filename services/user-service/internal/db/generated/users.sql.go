@@ -13,6 +13,67 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const anonymizeUser = `-- name: AnonymizeUser :execrows
+UPDATE users
+SET
+    email = 'deleted-' || id::text || '@deleted.invalid',
+    hashed_password = '',
+    full_name = '',
+    phone = NULL,
+    avatar_url = NULL,
+    date_of_birth = NULL,
+    gender = NULL,
+    email_verified_at = NULL,
+    phone_verified_at = NULL,
+    totp_secret = NULL,
+    totp_enabled_at = NULL,
+    anonymized_at = $2,
+    updated_at = $2
+WHERE id = $1 AND deleted_at IS NOT NULL AND anonymized_at IS NULL
+`
+
+type AnonymizeUserParams struct {
+	ID           uuid.UUID
+	AnonymizedAt pgtype.Timestamptz
+}
+
+func (q *Queries) AnonymizeUser(ctx context.Context, arg AnonymizeUserParams) (int64, error) {
+	result, err := q.db.Exec(ctx, anonymizeUser, arg.ID, arg.AnonymizedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const countUsers = `-- name: CountUsers :one
+SELECT COUNT(*) FROM users
+WHERE
+    deleted_at IS NULL
+    AND ($1::user_status_enum IS NULL OR status = $1)
+    AND ($2::text IS NULL OR email ILIKE '%' || $2 || '%')
+    AND ($3::timestamptz IS NULL OR created_at >= $3)
+    AND ($4::timestamptz IS NULL OR created_at < $4)
+`
+
+type CountUsersParams struct {
+	Status      NullUserStatusEnum
+	Email       pgtype.Text
+	CreatedFrom pgtype.Timestamptz
+	CreatedTo   pgtype.Timestamptz
+}
+
+func (q *Queries) CountUsers(ctx context.Context, arg CountUsersParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countUsers,
+		arg.Status,
+		arg.Email,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createUser = `-- name: CreateUser :one
 INSERT INTO users (
     id, email, hashed_password, full_name, status, created_at, updated_at
@@ -24,6 +85,7 @@ RETURNING
     email,
     full_name,
     status,
+    role,
     created_at,
     updated_at
 `
@@ -43,6 +105,7 @@ type CreateUserRow struct {
 	Email     string
 	FullName  string
 	Status    UserStatusEnum
+	Role      UserRoleEnum
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
@@ -63,14 +126,54 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (CreateU
 		&i.Email,
 		&i.FullName,
 		&i.Status,
+		&i.Role,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
 	return i, err
 }
 
+const disableUserTOTP = `-- name: DisableUserTOTP :execrows
+UPDATE users
+SET totp_secret = NULL, totp_enabled_at = NULL, updated_at = $2
+WHERE id = $1 AND deleted_at IS NULL
+`
+
+type DisableUserTOTPParams struct {
+	ID        uuid.UUID
+	UpdatedAt time.Time
+}
+
+func (q *Queries) DisableUserTOTP(ctx context.Context, arg DisableUserTOTPParams) (int64, error) {
+	result, err := q.db.Exec(ctx, disableUserTOTP, arg.ID, arg.UpdatedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const enableUserTOTP = `-- name: EnableUserTOTP :execrows
+UPDATE users
+SET totp_enabled_at = $2, updated_at = $3
+WHERE id = $1 AND totp_secret IS NOT NULL AND totp_enabled_at IS NULL AND deleted_at IS NULL
+`
+
+type EnableUserTOTPParams struct {
+	ID            uuid.UUID
+	TotpEnabledAt pgtype.Timestamptz
+	UpdatedAt     time.Time
+}
+
+func (q *Queries) EnableUserTOTP(ctx context.Context, arg EnableUserTOTPParams) (int64, error) {
+	result, err := q.db.Exec(ctx, enableUserTOTP, arg.ID, arg.TotpEnabledAt, arg.UpdatedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, email, hashed_password, full_name, phone, avatar_url, date_of_birth, gender, status, email_verified_at, created_at, updated_at, deleted_at FROM users
+SELECT id, email, hashed_password, full_name, phone, avatar_url, date_of_birth, gender, status, email_verified_at, created_at, updated_at, deleted_at, role, totp_secret, totp_enabled_at, phone_verified_at, anonymized_at FROM users
 WHERE email = $1 AND deleted_at IS NULL LIMIT 1
 `
 
@@ -91,12 +194,17 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Role,
+		&i.TotpSecret,
+		&i.TotpEnabledAt,
+		&i.PhoneVerifiedAt,
+		&i.AnonymizedAt,
 	)
 	return i, err
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, email, hashed_password, full_name, phone, avatar_url, date_of_birth, gender, status, email_verified_at, created_at, updated_at, deleted_at FROM users
+SELECT id, email, hashed_password, full_name, phone, avatar_url, date_of_birth, gender, status, email_verified_at, created_at, updated_at, deleted_at, role, totp_secret, totp_enabled_at, phone_verified_at, anonymized_at FROM users
 WHERE id = $1 AND deleted_at IS NULL
 `
 
@@ -117,10 +225,165 @@ func (q *Queries) GetUserByID(ctx context.Context, id uuid.UUID) (User, error) {
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Role,
+		&i.TotpSecret,
+		&i.TotpEnabledAt,
+		&i.PhoneVerifiedAt,
+		&i.AnonymizedAt,
+	)
+	return i, err
+}
+
+const getUserByPhone = `-- name: GetUserByPhone :one
+SELECT id, email, hashed_password, full_name, phone, avatar_url, date_of_birth, gender, status, email_verified_at, created_at, updated_at, deleted_at, role, totp_secret, totp_enabled_at, phone_verified_at, anonymized_at FROM users
+WHERE phone = $1 AND deleted_at IS NULL LIMIT 1
+`
+
+func (q *Queries) GetUserByPhone(ctx context.Context, phone pgtype.Text) (User, error) {
+	row := q.db.QueryRow(ctx, getUserByPhone, phone)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Phone,
+		&i.AvatarUrl,
+		&i.DateOfBirth,
+		&i.Gender,
+		&i.Status,
+		&i.EmailVerifiedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Role,
+		&i.TotpSecret,
+		&i.TotpEnabledAt,
+		&i.PhoneVerifiedAt,
+		&i.AnonymizedAt,
 	)
 	return i, err
 }
 
+const listUsers = `-- name: ListUsers :many
+SELECT id, email, hashed_password, full_name, phone, avatar_url, date_of_birth, gender, status, email_verified_at, created_at, updated_at, deleted_at, role, totp_secret, totp_enabled_at, phone_verified_at, anonymized_at FROM users
+WHERE
+    deleted_at IS NULL
+    AND ($1::user_status_enum IS NULL OR status = $1)
+    AND ($2::text IS NULL OR email ILIKE '%' || $2 || '%')
+    AND ($3::timestamptz IS NULL OR created_at >= $3)
+    AND ($4::timestamptz IS NULL OR created_at < $4)
+ORDER BY created_at DESC
+LIMIT $6 OFFSET $5
+`
+
+type ListUsersParams struct {
+	Status      NullUserStatusEnum
+	Email       pgtype.Text
+	CreatedFrom pgtype.Timestamptz
+	CreatedTo   pgtype.Timestamptz
+	Offset      int32
+	Limit       int32
+}
+
+func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error) {
+	rows, err := q.db.Query(ctx, listUsers,
+		arg.Status,
+		arg.Email,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Email,
+			&i.HashedPassword,
+			&i.FullName,
+			&i.Phone,
+			&i.AvatarUrl,
+			&i.DateOfBirth,
+			&i.Gender,
+			&i.Status,
+			&i.EmailVerifiedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.Role,
+			&i.TotpSecret,
+			&i.TotpEnabledAt,
+			&i.PhoneVerifiedAt,
+			&i.AnonymizedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUsersPendingAnonymization = `-- name: ListUsersPendingAnonymization :many
+SELECT id FROM users
+WHERE deleted_at IS NOT NULL AND deleted_at < $1 AND anonymized_at IS NULL
+ORDER BY deleted_at
+LIMIT $2
+`
+
+type ListUsersPendingAnonymizationParams struct {
+	DeletedAt pgtype.Timestamptz
+	Limit     int32
+}
+
+func (q *Queries) ListUsersPendingAnonymization(ctx context.Context, arg ListUsersPendingAnonymizationParams) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, listUsersPendingAnonymization, arg.DeletedAt, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setUserTOTPSecret = `-- name: SetUserTOTPSecret :execrows
+UPDATE users
+SET totp_secret = $2, updated_at = $3
+WHERE id = $1 AND totp_enabled_at IS NULL AND deleted_at IS NULL
+`
+
+type SetUserTOTPSecretParams struct {
+	ID         uuid.UUID
+	TotpSecret pgtype.Text
+	UpdatedAt  time.Time
+}
+
+func (q *Queries) SetUserTOTPSecret(ctx context.Context, arg SetUserTOTPSecretParams) (int64, error) {
+	result, err := q.db.Exec(ctx, setUserTOTPSecret, arg.ID, arg.TotpSecret, arg.UpdatedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const softDeleteUser = `-- name: SoftDeleteUser :execrows
 UPDATE users
 SET deleted_at = $2, updated_at = $3
@@ -196,6 +459,34 @@ func (q *Queries) UpdateUserAvatar(ctx context.Context, arg UpdateUserAvatarPara
 	return result.RowsAffected(), nil
 }
 
+const updateUserEmail = `-- name: UpdateUserEmail :execrows
+UPDATE users
+SET email = $1, email_verified_at = $2, updated_at = $3
+WHERE id = $4 AND email = $5 AND deleted_at IS NULL
+`
+
+type UpdateUserEmailParams struct {
+	NewEmail        string
+	EmailVerifiedAt pgtype.Timestamptz
+	UpdatedAt       time.Time
+	ID              uuid.UUID
+	OldEmail        string
+}
+
+func (q *Queries) UpdateUserEmail(ctx context.Context, arg UpdateUserEmailParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateUserEmail,
+		arg.NewEmail,
+		arg.EmailVerifiedAt,
+		arg.UpdatedAt,
+		arg.ID,
+		arg.OldEmail,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const updateUserPassword = `-- name: UpdateUserPassword :execrows
 UPDATE users
 SET hashed_password = $2, updated_at = $3
@@ -255,3 +546,29 @@ func (q *Queries) VerifyUserEmail(ctx context.Context, arg VerifyUserEmailParams
 	}
 	return result.RowsAffected(), nil
 }
+
+const verifyUserPhone = `-- name: VerifyUserPhone :execrows
+UPDATE users
+SET phone = $1, phone_verified_at = $2, updated_at = $3
+WHERE id = $4 AND deleted_at IS NULL
+`
+
+type VerifyUserPhoneParams struct {
+	Phone           pgtype.Text
+	PhoneVerifiedAt pgtype.Timestamptz
+	UpdatedAt       time.Time
+	ID              uuid.UUID
+}
+
+func (q *Queries) VerifyUserPhone(ctx context.Context, arg VerifyUserPhoneParams) (int64, error) {
+	result, err := q.db.Exec(ctx, verifyUserPhone,
+		arg.Phone,
+		arg.PhoneVerifiedAt,
+		arg.UpdatedAt,
+		arg.ID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
@@ -40,3 +40,6 @@ WHERE user_id = $1;
 
 -- name: DeleteAddress :execrows
 DELETE FROM user_addresses WHERE id = $1;
+
+-- name: DeleteAddressesByUserID :exec
+DELETE FROM user_addresses WHERE user_id = $1;
@@ -0,0 +1,33 @@
+-- name: CreateAuditLog :exec
+INSERT INTO auth_audit_log (
+    user_id, actor_id, event_type, success, reason, ip_address, user_agent, trace_id, created_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+);
+
+-- name: ListAuditLogs :many
+SELECT * FROM auth_audit_log
+WHERE (sqlc.narg('user_id')::uuid IS NULL OR user_id = sqlc.narg('user_id'))
+  AND (sqlc.narg('event_type')::text IS NULL OR event_type = sqlc.narg('event_type'))
+  AND (sqlc.narg('ip_address')::text IS NULL OR ip_address = sqlc.narg('ip_address'))
+  AND (sqlc.narg('created_from')::timestamptz IS NULL OR created_at >= sqlc.narg('created_from'))
+  AND (sqlc.narg('created_to')::timestamptz IS NULL OR created_at < sqlc.narg('created_to'))
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: CountAuditLogs :one
+SELECT COUNT(*) FROM auth_audit_log
+WHERE (sqlc.narg('user_id')::uuid IS NULL OR user_id = sqlc.narg('user_id'))
+  AND (sqlc.narg('event_type')::text IS NULL OR event_type = sqlc.narg('event_type'))
+  AND (sqlc.narg('ip_address')::text IS NULL OR ip_address = sqlc.narg('ip_address'))
+  AND (sqlc.narg('created_from')::timestamptz IS NULL OR created_at >= sqlc.narg('created_from'))
+  AND (sqlc.narg('created_to')::timestamptz IS NULL OR created_at < sqlc.narg('created_to'));
+
+-- name: DeleteAuditLogsBefore :execrows
+DELETE FROM auth_audit_log
+WHERE id IN (
+    SELECT expired.id FROM auth_audit_log AS expired
+    WHERE expired.created_at < sqlc.arg('before')
+    ORDER BY expired.id
+    LIMIT sqlc.arg('limit')
+);
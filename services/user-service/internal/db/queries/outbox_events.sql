@@ -0,0 +1,50 @@
+-- name: CreateOutboxEvent :exec
+INSERT INTO outbox_events (
+    event_id, topic, partition_key, event_type, event_version, trace_id, trace_context, payload, occurred_at, created_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+);
+
+-- name: TryLockOutbox :one
+SELECT pg_try_advisory_lock(sqlc.arg('lock_id')::bigint) AS locked;
+
+-- name: UnlockOutbox :one
+SELECT pg_advisory_unlock(sqlc.arg('lock_id')::bigint) AS unlocked;
+
+-- name: ListPendingOutboxEvents :many
+-- Due events after after_id. An event is left out while an earlier event with
+-- the same partition key waits for a retry, so blocked keys do not fill the page.
+SELECT * FROM outbox_events o
+WHERE o.published_at IS NULL
+  AND o.failed_at IS NULL
+  AND o.next_attempt_at <= now()
+  AND o.id > sqlc.arg('after_id')
+  AND NOT EXISTS (
+      SELECT 1 FROM outbox_events earlier
+      WHERE earlier.partition_key = o.partition_key
+        AND earlier.id < o.id
+        AND earlier.published_at IS NULL
+        AND earlier.failed_at IS NULL
+        AND earlier.next_attempt_at > now()
+  )
+ORDER BY o.id ASC
+LIMIT sqlc.arg('limit');
+
+-- name: MarkOutboxEventPublished :execrows
+UPDATE outbox_events
+SET published_at = $2, attempts = attempts + 1, last_error = NULL
+WHERE id = $1;
+
+-- name: MarkOutboxEventFailed :execrows
+UPDATE outbox_events
+SET attempts = attempts + 1, last_error = $2, next_attempt_at = $3
+WHERE id = $1;
+
+-- name: ParkOutboxEvent :execrows
+UPDATE outbox_events
+SET attempts = attempts + 1, last_error = $2, failed_at = $3
+WHERE id = $1;
+
+-- name: DeletePublishedOutboxEvents :execrows
+DELETE FROM outbox_events
+WHERE published_at IS NOT NULL AND published_at < $1;
@@ -0,0 +1,15 @@
+-- name: CreateRecoveryCode :exec
+INSERT INTO user_recovery_codes (
+    id, user_id, code_hash, created_at
+) VALUES (
+    $1, $2, $3, $4
+);
+
+-- name: UseRecoveryCode :execrows
+UPDATE user_recovery_codes
+SET used_at = $3
+WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL;
+
+-- name: DeleteRecoveryCodesByUserID :exec
+DELETE FROM user_recovery_codes
+WHERE user_id = $1;
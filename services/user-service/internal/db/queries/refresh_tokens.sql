@@ -1,15 +1,61 @@
 -- name: GetRefreshTokenByTokenHash :one
-SELECT id, user_id, token_hash, expires_at, created_at
-FROM refresh_tokens
+SELECT * FROM refresh_tokens
 WHERE token_hash = $1;
 
 -- name: CreateRefreshToken :exec
 INSERT INTO refresh_tokens (
-    id, user_id, token_hash, expires_at, created_at
+    id, user_id, family_id, parent_id, token_hash, device_info, ip_address, user_agent,
+    access_token_id, expires_at, last_used_at, created_at
 ) VALUES (
-    $1, $2, $3, $4, $5
+    $1, $2, $3, $4, $5, $6, $7, $8,
+    $9, $10, $11, $12
 );
 
 -- name: DeleteRefreshTokenByID :execrows
 DELETE FROM refresh_tokens
 WHERE id = $1;
+
+-- name: MarkRefreshTokenRotated :execrows
+UPDATE refresh_tokens
+SET rotated_at = $2
+WHERE id = $1 AND rotated_at IS NULL AND revoked_at IS NULL;
+
+-- name: ListSessionsByUserID :many
+SELECT
+    current.family_id,
+    current.device_info,
+    current.ip_address,
+    current.user_agent,
+    current.expires_at,
+    current.last_used_at,
+    root.created_at AS started_at
+FROM refresh_tokens current
+JOIN refresh_tokens root ON root.id = current.family_id
+WHERE current.user_id = $1
+    AND current.rotated_at IS NULL
+    AND current.revoked_at IS NULL
+    AND current.expires_at > $2
+ORDER BY current.last_used_at DESC;
+
+-- name: ListRefreshTokensByFamilyID :many
+SELECT * FROM refresh_tokens
+WHERE family_id = $1 AND created_at > $2;
+
+-- name: RevokeRefreshTokenFamily :execrows
+UPDATE refresh_tokens
+SET revoked_at = $2
+WHERE family_id = $1 AND revoked_at IS NULL;
+
+-- name: RevokeRefreshTokenFamilyByUserID :execrows
+UPDATE refresh_tokens
+SET revoked_at = $3
+WHERE family_id = $1 AND user_id = $2 AND revoked_at IS NULL;
+
+-- name: RevokeRefreshTokensByUserID :execrows
+UPDATE refresh_tokens
+SET revoked_at = $2
+WHERE user_id = $1 AND revoked_at IS NULL;
+
+-- name: DeleteRefreshTokensByUserID :exec
+DELETE FROM refresh_tokens
+WHERE user_id = $1;
@@ -0,0 +1,19 @@
+-- name: GetUserIdentity :one
+SELECT * FROM user_identities
+WHERE provider = $1 AND subject = $2;
+
+-- name: CreateUserIdentity :exec
+INSERT INTO user_identities (
+    id, user_id, provider, subject, email, created_at, last_login_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+);
+
+-- name: RecordUserIdentityLogin :execrows
+UPDATE user_identities
+SET email = $2, last_login_at = $3
+WHERE id = $1;
+
+-- name: DeleteUserIdentitiesByUserID :exec
+DELETE FROM user_identities
+WHERE user_id = $1;
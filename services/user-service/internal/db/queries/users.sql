@@ -6,6 +6,10 @@ WHERE id = $1 AND deleted_at IS NULL;
 SELECT * FROM users
 WHERE email = $1 AND deleted_at IS NULL LIMIT 1;
 
+-- name: GetUserByPhone :one
+SELECT * FROM users
+WHERE phone = $1 AND deleted_at IS NULL LIMIT 1;
+
 -- name: CreateUser :one
 INSERT INTO users (
     id, email, hashed_password, full_name, status, created_at, updated_at
@@ -17,6 +21,7 @@ RETURNING
     email,
     full_name,
     status,
+    role,
     created_at,
     updated_at;
 
@@ -54,3 +59,72 @@ WHERE id = $1 AND deleted_at IS NULL;
 UPDATE users
 SET deleted_at = $2, updated_at = $3
 WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: SetUserTOTPSecret :execrows
+UPDATE users
+SET totp_secret = $2, updated_at = $3
+WHERE id = $1 AND totp_enabled_at IS NULL AND deleted_at IS NULL;
+
+-- name: EnableUserTOTP :execrows
+UPDATE users
+SET totp_enabled_at = $2, updated_at = $3
+WHERE id = $1 AND totp_secret IS NOT NULL AND totp_enabled_at IS NULL AND deleted_at IS NULL;
+
+-- name: DisableUserTOTP :execrows
+UPDATE users
+SET totp_secret = NULL, totp_enabled_at = NULL, updated_at = $2
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: UpdateUserEmail :execrows
+UPDATE users
+SET email = sqlc.arg(new_email), email_verified_at = sqlc.arg(email_verified_at), updated_at = sqlc.arg(updated_at)
+WHERE id = sqlc.arg(id) AND email = sqlc.arg(old_email) AND deleted_at IS NULL;
+
+-- name: VerifyUserPhone :execrows
+UPDATE users
+SET phone = sqlc.arg(phone), phone_verified_at = sqlc.arg(phone_verified_at), updated_at = sqlc.arg(updated_at)
+WHERE id = sqlc.arg(id) AND deleted_at IS NULL;
+
+-- name: ListUsersPendingAnonymization :many
+SELECT id FROM users
+WHERE deleted_at IS NOT NULL AND deleted_at < $1 AND anonymized_at IS NULL
+ORDER BY deleted_at
+LIMIT $2;
+
+-- name: AnonymizeUser :execrows
+UPDATE users
+SET
+    email = 'deleted-' || id::text || '@deleted.invalid',
+    hashed_password = '',
+    full_name = '',
+    phone = NULL,
+    avatar_url = NULL,
+    date_of_birth = NULL,
+    gender = NULL,
+    email_verified_at = NULL,
+    phone_verified_at = NULL,
+    totp_secret = NULL,
+    totp_enabled_at = NULL,
+    anonymized_at = $2,
+    updated_at = $2
+WHERE id = $1 AND deleted_at IS NOT NULL AND anonymized_at IS NULL;
+
+-- name: ListUsers :many
+SELECT * FROM users
+WHERE
+    deleted_at IS NULL
+    AND (sqlc.narg('status')::user_status_enum IS NULL OR status = sqlc.narg('status'))
+    AND (sqlc.narg('email')::text IS NULL OR email ILIKE '%' || sqlc.narg('email') || '%')
+    AND (sqlc.narg('created_from')::timestamptz IS NULL OR created_at >= sqlc.narg('created_from'))
+    AND (sqlc.narg('created_to')::timestamptz IS NULL OR created_at < sqlc.narg('created_to'))
+ORDER BY created_at DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: CountUsers :one
+SELECT COUNT(*) FROM users
+WHERE
+    deleted_at IS NULL
+    AND (sqlc.narg('status')::user_status_enum IS NULL OR status = sqlc.narg('status'))
+    AND (sqlc.narg('email')::text IS NULL OR email ILIKE '%' || sqlc.narg('email') || '%')
+    AND (sqlc.narg('created_from')::timestamptz IS NULL OR created_at >= sqlc.narg('created_from'))
+    AND (sqlc.narg('created_to')::timestamptz IS NULL OR created_at < sqlc.narg('created_to'));
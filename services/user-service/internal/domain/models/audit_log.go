@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditEventType string
+
+const (
+	AuditEventLogin               AuditEventType = "login"
+	AuditEventTokenRefresh        AuditEventType = "token_refresh"
+	AuditEventLogout              AuditEventType = "logout"
+	AuditEventSessionRevoked      AuditEventType = "session_revoked"
+	AuditEventAllSessionsRevoked  AuditEventType = "all_sessions_revoked"
+	AuditEventPasswordChange      AuditEventType = "password_change"
+	AuditEventPasswordReset       AuditEventType = "password_reset"
+	AuditEventEmailVerification   AuditEventType = "email_verification"
+	AuditEventEmailChange         AuditEventType = "email_change"
+	AuditEventTOTPEnabled         AuditEventType = "totp_enabled"
+	AuditEventTOTPDisabled        AuditEventType = "totp_disabled"
+	AuditEventAccountDeleted      AuditEventType = "account_deleted"
+	AuditEventUserSuspended       AuditEventType = "user_suspended"
+	AuditEventUserReactivated     AuditEventType = "user_reactivated"
+	AuditEventPasswordResetForced AuditEventType = "password_reset_forced"
+)
+
+// AuditLogEntry is one row of the append-only authentication audit log.
+// UserID is nil for failed logins against an unknown email, and ActorID is
+// set only when an admin acted on the user's account.
+type AuditLogEntry struct {
+	ID        int64
+	UserID    *uuid.UUID
+	ActorID   *uuid.UUID
+	EventType AuditEventType
+	Success   bool
+	Reason    *string
+	IPAddress string
+	UserAgent string
+	TraceID   string
+	CreatedAt time.Time
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OutboxEvent struct {
+	ID            int64
+	EventID       uuid.UUID
+	Topic         string
+	PartitionKey  string
+	EventType     string
+	EventVersion  int32
+	TraceID       string
+	TraceContext  map[string]string
+	Payload       []byte
+	OccurredAt    time.Time
+	Attempts      int32
+	LastError     *string
+	NextAttemptAt time.Time
+	PublishedAt   *time.Time
+	// FailedAt is set once the event is parked after too many attempts.
+	FailedAt  *time.Time
+	CreatedAt time.Time
+}
@@ -6,14 +6,35 @@ import (
 	"github.com/google/uuid"
 )
 
+// RefreshToken is one link in a token family. Every rotation issues a child
+// token in the same family and marks the parent as rotated, so presenting a
+// rotated token again means it was replayed.
 type RefreshToken struct {
-	ID        uuid.UUID
-	UserID    uuid.UUID
-	TokenHash string
-	ExpiresAt time.Time
-	CreatedAt time.Time
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	FamilyID   uuid.UUID
+	ParentID   *uuid.UUID
+	TokenHash  string
+	DeviceInfo string
+	IPAddress  string
+	UserAgent  string
+	// AccessTokenID is the jti of the access token issued with this token.
+	AccessTokenID string
+	ExpiresAt     time.Time
+	LastUsedAt    *time.Time
+	RotatedAt     *time.Time
+	RevokedAt     *time.Time
+	CreatedAt     time.Time
 }
 
 func (rt *RefreshToken) IsExpired() bool {
 	return time.Now().After(rt.ExpiresAt)
 }
+
+func (rt *RefreshToken) IsRevoked() bool {
+	return rt.RevokedAt != nil
+}
+
+func (rt *RefreshToken) IsRotated() bool {
+	return rt.RotatedAt != nil
+}
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Session is a refresh token family seen from the user's side: the current
+// token's client details plus when the family was started.
+type Session struct {
+	ID         uuid.UUID
+	DeviceInfo string
+	IPAddress  string
+	UserAgent  string
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+	ExpiresAt  time.Time
+}
@@ -15,6 +15,14 @@ const (
 	UserStatusSuspended UserStatus = "suspended"
 )
 
+type UserRole string
+
+const (
+	UserRoleCustomer UserRole = "customer"
+	UserRoleStaff    UserRole = "staff"
+	UserRoleAdmin    UserRole = "admin"
+)
+
 type Gender string
 
 const (
@@ -33,7 +41,11 @@ type User struct {
 	DateOfBirth     *time.Time
 	Gender          *Gender
 	Status          UserStatus
+	Role            UserRole
 	EmailVerifiedAt *time.Time
+	PhoneVerifiedAt *time.Time
+	TOTPSecret      *string
+	TOTPEnabledAt   *time.Time
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
 }
@@ -45,3 +57,11 @@ func (u *User) IsActive() bool {
 func (u *User) IsEmailVerified() bool {
 	return u.EmailVerifiedAt != nil
 }
+
+func (u *User) IsPhoneVerified() bool {
+	return u.Phone != nil && u.PhoneVerifiedAt != nil
+}
+
+func (u *User) IsTOTPEnabled() bool {
+	return u.TOTPEnabledAt != nil
+}
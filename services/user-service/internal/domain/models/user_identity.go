@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserIdentity links an account at an external OAuth/OIDC provider, named by
+// the provider's subject, to a local user.
+type UserIdentity struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	Provider    string
+	Subject     string
+	Email       string
+	CreatedAt   time.Time
+	LastLoginAt *time.Time
+}
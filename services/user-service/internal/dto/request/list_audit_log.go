@@ -0,0 +1,13 @@
+package request
+
+import "time"
+
+type ListAuditLogRequest struct {
+	UserID      *string
+	EventType   *string
+	IPAddress   *string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	Page        int32
+	PageSize    int32
+}
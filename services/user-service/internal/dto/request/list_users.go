@@ -0,0 +1,12 @@
+package request
+
+import "time"
+
+type ListUsersRequest struct {
+	Status      *string
+	Email       *string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	Page        int32
+	PageSize    int32
+}
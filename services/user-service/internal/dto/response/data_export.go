@@ -0,0 +1,122 @@
+package response
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/khoihuynh300/go-microservice/user-service/internal/domain/models"
+)
+
+// DataExport is the archive handed to a user who asks for a copy of their
+// personal data.
+type DataExport struct {
+	ExportedAt time.Time         `json:"exported_at"`
+	Profile    ProfileExport     `json:"profile"`
+	Addresses  []AddressExport   `json:"addresses"`
+	Sessions   []SessionExport   `json:"sessions"`
+	Orders     []json.RawMessage `json:"orders"`
+}
+
+type ProfileExport struct {
+	ID              string     `json:"id"`
+	Email           string     `json:"email"`
+	FullName        string     `json:"full_name"`
+	Phone           *string    `json:"phone"`
+	AvatarURL       *string    `json:"avatar_url"`
+	DateOfBirth     *time.Time `json:"date_of_birth"`
+	Gender          *string    `json:"gender"`
+	Status          string     `json:"status"`
+	Role            string     `json:"role"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+	PhoneVerifiedAt *time.Time `json:"phone_verified_at"`
+	TOTPEnabledAt   *time.Time `json:"totp_enabled_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+type AddressExport struct {
+	ID           string    `json:"id"`
+	AddressType  string    `json:"address_type"`
+	FullName     string    `json:"full_name"`
+	Phone        string    `json:"phone"`
+	AddressLine1 string    `json:"address_line1"`
+	AddressLine2 string    `json:"address_line2"`
+	Ward         string    `json:"ward"`
+	City         string    `json:"city"`
+	Country      string    `json:"country"`
+	IsDefault    bool      `json:"is_default"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type SessionExport struct {
+	ID         string     `json:"id"`
+	DeviceInfo string     `json:"device_info"`
+	IPAddress  string     `json:"ip_address"`
+	UserAgent  string     `json:"user_agent"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+}
+
+func NewDataExport(user *models.User, addresses []*models.Address, sessions []*models.Session, orders []json.RawMessage) *DataExport {
+	export := &DataExport{
+		ExportedAt: time.Now().UTC(),
+		Profile: ProfileExport{
+			ID:              user.ID.String(),
+			Email:           user.Email,
+			FullName:        user.FullName,
+			Phone:           user.Phone,
+			AvatarURL:       user.AvatarURL,
+			DateOfBirth:     user.DateOfBirth,
+			Status:          string(user.Status),
+			Role:            string(user.Role),
+			EmailVerifiedAt: user.EmailVerifiedAt,
+			PhoneVerifiedAt: user.PhoneVerifiedAt,
+			TOTPEnabledAt:   user.TOTPEnabledAt,
+			CreatedAt:       user.CreatedAt,
+			UpdatedAt:       user.UpdatedAt,
+		},
+		Addresses: make([]AddressExport, 0, len(addresses)),
+		Sessions:  make([]SessionExport, 0, len(sessions)),
+		Orders:    orders,
+	}
+	if user.Gender != nil {
+		gender := string(*user.Gender)
+		export.Profile.Gender = &gender
+	}
+	if export.Orders == nil {
+		export.Orders = []json.RawMessage{}
+	}
+
+	for _, address := range addresses {
+		export.Addresses = append(export.Addresses, AddressExport{
+			ID:           address.ID.String(),
+			AddressType:  string(address.AddressType),
+			FullName:     address.FullName,
+			Phone:        address.Phone,
+			AddressLine1: address.AddressLine1,
+			AddressLine2: address.AddressLine2,
+			Ward:         address.Ward,
+			City:         address.City,
+			Country:      address.Country,
+			IsDefault:    address.IsDefault,
+			CreatedAt:    address.CreatedAt,
+			UpdatedAt:    address.UpdatedAt,
+		})
+	}
+
+	for _, session := range sessions {
+		export.Sessions = append(export.Sessions, SessionExport{
+			ID:         session.ID.String(),
+			DeviceInfo: session.DeviceInfo,
+			IPAddress:  session.IPAddress,
+			UserAgent:  session.UserAgent,
+			CreatedAt:  session.CreatedAt,
+			LastUsedAt: session.LastUsedAt,
+			ExpiresAt:  session.ExpiresAt,
+		})
+	}
+
+	return export
+}
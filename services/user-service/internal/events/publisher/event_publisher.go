@@ -2,6 +2,7 @@ package publisher
 
 import (
 	"context"
+	"time"
 
 	"github.com/khoihuynh300/go-microservice/user-service/internal/domain/models"
 )
@@ -11,6 +12,17 @@ type EventPublisher interface {
 	PublishEmailVerifySuccess(ctx context.Context, email string) error
 	PublishForgotPassword(ctx context.Context, user *models.User, token string) error
 	PublishPasswordResetSuccess(ctx context.Context, email string) error
+	PublishAccountLocked(ctx context.Context, user *models.User, token string, ipAddress string, lockedUntil time.Time) error
+	PublishRefreshTokenReused(ctx context.Context, user *models.User, sessionID string, ipAddress string, userAgent string) error
+	PublishEmailChangeRequested(ctx context.Context, user *models.User, newEmail string, token string) error
+	PublishEmailChanged(ctx context.Context, user *models.User, oldEmail string) error
+	PublishMagicLinkRequested(ctx context.Context, user *models.User, token string) error
+	PublishPhoneVerificationRequested(ctx context.Context, user *models.User, phone string, code string, expiresAt time.Time) error
+	PublishDataExportReady(ctx context.Context, user *models.User, downloadURL string, expiresAt time.Time) error
+	PublishUserSuspended(ctx context.Context, user *models.User, reason string) error
+	PublishUserReactivated(ctx context.Context, user *models.User) error
+	PublishPasswordResetForced(ctx context.Context, user *models.User, token string) error
+	PublishSessionsRevoked(ctx context.Context, user *models.User) error
 
 	Close() error
 }
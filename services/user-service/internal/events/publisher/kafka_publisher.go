@@ -3,41 +3,40 @@ package publisher
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/khoihuynh300/go-microservice/shared/pkg/const/contextkeys"
 	"github.com/khoihuynh300/go-microservice/shared/pkg/messaging/events"
-	"github.com/khoihuynh300/go-microservice/shared/pkg/messaging/kafka"
 	"github.com/khoihuynh300/go-microservice/shared/pkg/messaging/topics"
 	"github.com/khoihuynh300/go-microservice/user-service/internal/domain/models"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/repository"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// kafkaEventPublisher records events in the outbox table. When the context
+// carries a transaction the event is committed atomically with it, and the
+// outbox relay delivers it to Kafka afterwards.
 type kafkaEventPublisher struct {
-	producer kafka.Producer
+	outboxRepo repository.OutboxRepository
 }
 
-func NewKafkaEventPublisher(producer kafka.Producer) EventPublisher {
+func NewKafkaEventPublisher(outboxRepo repository.OutboxRepository) EventPublisher {
 	return &kafkaEventPublisher{
-		producer: producer,
+		outboxRepo: outboxRepo,
 	}
 }
 
 func (p *kafkaEventPublisher) PublishVerifyEmail(ctx context.Context, user *models.User, token string) error {
-	traceID := ctx.Value(contextkeys.TraceIDKey).(string)
-
-	payload := &events.Event{
-		EventID:    uuid.NewString(),
-		EventType:  events.TypeUserRegisteredEvent,
-		OccurredAt: time.Now().UTC(),
-		TraceID:    traceID,
-		Data: &events.UserRegisteredEvent{
-			Email:    user.Email,
-			FullName: user.FullName,
-			Token:    token,
-		},
-	}
-	if err := p.producer.Publish(ctx, topics.UserEventsTopic, payload); err != nil {
+	data := &events.UserRegisteredEvent{
+		Email:    user.Email,
+		FullName: user.FullName,
+		Token:    token,
+	}
+	if err := p.enqueue(ctx, events.TypeUserRegisteredEvent, user.Email, data); err != nil {
 		return fmt.Errorf("failed to publish user registered event: %w", err)
 	}
 
@@ -45,18 +44,10 @@ func (p *kafkaEventPublisher) PublishVerifyEmail(ctx context.Context, user *mode
 }
 
 func (p *kafkaEventPublisher) PublishEmailVerifySuccess(ctx context.Context, email string) error {
-	traceID := ctx.Value(contextkeys.TraceIDKey).(string)
-
-	payload := &events.Event{
-		EventID:    uuid.NewString(),
-		EventType:  events.TypeEmailVerifySuccessEvent,
-		OccurredAt: time.Now().UTC(),
-		TraceID:    traceID,
-		Data: &events.EmailVerifySuccessEvent{
-			Email: email,
-		},
-	}
-	if err := p.producer.Publish(ctx, topics.UserEventsTopic, payload); err != nil {
+	data := &events.EmailVerifySuccessEvent{
+		Email: email,
+	}
+	if err := p.enqueue(ctx, events.TypeEmailVerifySuccessEvent, email, data); err != nil {
 		return fmt.Errorf("failed to publish email verified event: %w", err)
 	}
 
@@ -64,44 +55,219 @@ func (p *kafkaEventPublisher) PublishEmailVerifySuccess(ctx context.Context, ema
 }
 
 func (p *kafkaEventPublisher) PublishForgotPassword(ctx context.Context, user *models.User, token string) error {
-	traceID := ctx.Value(contextkeys.TraceIDKey).(string)
-
-	payload := &events.Event{
-		EventID:    uuid.NewString(),
-		EventType:  events.TypeForgotPasswordEvent,
-		OccurredAt: time.Now().UTC(),
-		TraceID:    traceID,
-		Data: &events.UserForgotPasswordEvent{
-			Email:    user.Email,
-			FullName: user.FullName,
-			Token:    token,
-		},
-	}
-	if err := p.producer.Publish(ctx, topics.UserEventsTopic, payload); err != nil {
-		return fmt.Errorf("failed to publish user registered event: %w", err)
+	data := &events.UserForgotPasswordEvent{
+		Email:    user.Email,
+		FullName: user.FullName,
+		Token:    token,
+	}
+	if err := p.enqueue(ctx, events.TypeForgotPasswordEvent, user.Email, data); err != nil {
+		return fmt.Errorf("failed to publish forgot password event: %w", err)
 	}
 
 	return nil
 }
 
 func (p *kafkaEventPublisher) PublishPasswordResetSuccess(ctx context.Context, email string) error {
-	traceID := ctx.Value(contextkeys.TraceIDKey).(string)
-	payload := &events.Event{
-		EventID:    uuid.NewString(),
-		EventType:  events.TypePasswordResetSuccessEvent,
-		OccurredAt: time.Now().UTC(),
-		TraceID:    traceID,
-		Data: &events.UserPasswordResetSuccessEvent{
-			Email: email,
-		},
-	}
-	if err := p.producer.Publish(ctx, topics.UserEventsTopic, payload); err != nil {
+	data := &events.UserPasswordResetSuccessEvent{
+		Email: email,
+	}
+	if err := p.enqueue(ctx, events.TypePasswordResetSuccessEvent, email, data); err != nil {
 		return fmt.Errorf("failed to publish password reset success event: %w", err)
 	}
 
 	return nil
 }
 
+func (p *kafkaEventPublisher) PublishAccountLocked(ctx context.Context, user *models.User, token string, ipAddress string, lockedUntil time.Time) error {
+	data := &events.AccountLockedEvent{
+		Email:       user.Email,
+		FullName:    user.FullName,
+		Token:       token,
+		IpAddress:   ipAddress,
+		LockedUntil: timestamppb.New(lockedUntil),
+	}
+	if err := p.enqueue(ctx, events.TypeAccountLockedEvent, user.Email, data); err != nil {
+		return fmt.Errorf("failed to publish account locked event: %w", err)
+	}
+
+	return nil
+}
+
+func (p *kafkaEventPublisher) PublishRefreshTokenReused(ctx context.Context, user *models.User, sessionID string, ipAddress string, userAgent string) error {
+	data := &events.RefreshTokenReusedEvent{
+		UserId:     user.ID.String(),
+		Email:      user.Email,
+		FullName:   user.FullName,
+		SessionId:  sessionID,
+		IpAddress:  ipAddress,
+		UserAgent:  userAgent,
+		DetectedAt: timestamppb.Now(),
+	}
+	if err := p.enqueue(ctx, events.TypeRefreshTokenReusedEvent, user.Email, data); err != nil {
+		return fmt.Errorf("failed to publish refresh token reused event: %w", err)
+	}
+
+	return nil
+}
+
+func (p *kafkaEventPublisher) PublishEmailChangeRequested(ctx context.Context, user *models.User, newEmail string, token string) error {
+	data := &events.EmailChangeRequestedEvent{
+		UserId:   user.ID.String(),
+		FullName: user.FullName,
+		OldEmail: user.Email,
+		NewEmail: newEmail,
+		Token:    token,
+	}
+	if err := p.enqueue(ctx, events.TypeEmailChangeRequestedEvent, user.Email, data); err != nil {
+		return fmt.Errorf("failed to publish email change requested event: %w", err)
+	}
+
+	return nil
+}
+
+// PublishEmailChanged expects user to already carry the new address. The event
+// is keyed by the old one so it stays ordered after the account's earlier events.
+func (p *kafkaEventPublisher) PublishEmailChanged(ctx context.Context, user *models.User, oldEmail string) error {
+	data := &events.EmailChangedEvent{
+		UserId:    user.ID.String(),
+		FullName:  user.FullName,
+		OldEmail:  oldEmail,
+		NewEmail:  user.Email,
+		ChangedAt: timestamppb.Now(),
+	}
+	if err := p.enqueue(ctx, events.TypeEmailChangedEvent, oldEmail, data); err != nil {
+		return fmt.Errorf("failed to publish email changed event: %w", err)
+	}
+
+	return nil
+}
+
+func (p *kafkaEventPublisher) PublishMagicLinkRequested(ctx context.Context, user *models.User, token string) error {
+	data := &events.MagicLinkRequestedEvent{
+		UserId:   user.ID.String(),
+		Email:    user.Email,
+		FullName: user.FullName,
+		Token:    token,
+	}
+	if err := p.enqueue(ctx, events.TypeMagicLinkRequestedEvent, user.Email, data); err != nil {
+		return fmt.Errorf("failed to publish magic link requested event: %w", err)
+	}
+
+	return nil
+}
+
+func (p *kafkaEventPublisher) PublishPhoneVerificationRequested(ctx context.Context, user *models.User, phone string, code string, expiresAt time.Time) error {
+	data := &events.PhoneVerificationRequestedEvent{
+		UserId:    user.ID.String(),
+		Phone:     phone,
+		Code:      code,
+		ExpiresAt: timestamppb.New(expiresAt),
+	}
+	if err := p.enqueue(ctx, events.TypePhoneVerificationRequestedEvent, user.Email, data); err != nil {
+		return fmt.Errorf("failed to publish phone verification requested event: %w", err)
+	}
+
+	return nil
+}
+
+func (p *kafkaEventPublisher) PublishDataExportReady(ctx context.Context, user *models.User, downloadURL string, expiresAt time.Time) error {
+	data := &events.DataExportReadyEvent{
+		UserId:      user.ID.String(),
+		Email:       user.Email,
+		FullName:    user.FullName,
+		DownloadUrl: downloadURL,
+		ExpiresAt:   timestamppb.New(expiresAt),
+	}
+	if err := p.enqueue(ctx, events.TypeDataExportReadyEvent, user.Email, data); err != nil {
+		return fmt.Errorf("failed to publish data export ready event: %w", err)
+	}
+
+	return nil
+}
+
+func (p *kafkaEventPublisher) PublishUserSuspended(ctx context.Context, user *models.User, reason string) error {
+	data := &events.UserSuspendedEvent{
+		UserId:      user.ID.String(),
+		Email:       user.Email,
+		FullName:    user.FullName,
+		Reason:      reason,
+		SuspendedAt: timestamppb.Now(),
+	}
+	if err := p.enqueue(ctx, events.TypeUserSuspendedEvent, user.Email, data); err != nil {
+		return fmt.Errorf("failed to publish user suspended event: %w", err)
+	}
+
+	return nil
+}
+
+func (p *kafkaEventPublisher) PublishUserReactivated(ctx context.Context, user *models.User) error {
+	data := &events.UserReactivatedEvent{
+		UserId:        user.ID.String(),
+		Email:         user.Email,
+		FullName:      user.FullName,
+		ReactivatedAt: timestamppb.Now(),
+	}
+	if err := p.enqueue(ctx, events.TypeUserReactivatedEvent, user.Email, data); err != nil {
+		return fmt.Errorf("failed to publish user reactivated event: %w", err)
+	}
+
+	return nil
+}
+
+func (p *kafkaEventPublisher) PublishPasswordResetForced(ctx context.Context, user *models.User, token string) error {
+	data := &events.PasswordResetForcedEvent{
+		UserId:   user.ID.String(),
+		Email:    user.Email,
+		FullName: user.FullName,
+		Token:    token,
+	}
+	if err := p.enqueue(ctx, events.TypePasswordResetForcedEvent, user.Email, data); err != nil {
+		return fmt.Errorf("failed to publish password reset forced event: %w", err)
+	}
+
+	return nil
+}
+
+func (p *kafkaEventPublisher) PublishSessionsRevoked(ctx context.Context, user *models.User) error {
+	data := &events.SessionsRevokedEvent{
+		UserId:    user.ID.String(),
+		Email:     user.Email,
+		FullName:  user.FullName,
+		RevokedAt: timestamppb.Now(),
+	}
+	if err := p.enqueue(ctx, events.TypeSessionsRevokedEvent, user.Email, data); err != nil {
+		return fmt.Errorf("failed to publish sessions revoked event: %w", err)
+	}
+
+	return nil
+}
+
 func (p *kafkaEventPublisher) Close() error {
-	return p.producer.Close()
+	return nil
+}
+
+// enqueue keys events by email so everything about one account lands on the
+// same partition in order.
+func (p *kafkaEventPublisher) enqueue(ctx context.Context, eventType string, email string, data any) error {
+	payload, err := events.MarshalData(eventType, data)
+	if err != nil {
+		return err
+	}
+
+	traceID, _ := ctx.Value(contextkeys.TraceIDKey).(string)
+
+	traceContext := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, traceContext)
+
+	return p.outboxRepo.Create(ctx, &models.OutboxEvent{
+		EventID:      uuid.New(),
+		Topic:        topics.UserEventsTopic,
+		PartitionKey: strings.ToLower(email),
+		EventType:    eventType,
+		EventVersion: int32(events.DefaultRegistry.Latest(eventType)),
+		TraceID:      traceID,
+		TraceContext: traceContext,
+		Payload:      payload,
+		OccurredAt:   time.Now().UTC(),
+	})
 }
@@ -0,0 +1,208 @@
+package relay
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/khoihuynh300/go-microservice/shared/pkg/messaging/events"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/messaging/kafka"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/metrics"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/domain/models"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/repository"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.uber.org/zap"
+)
+
+const (
+	publishTimeout  = 10 * time.Second
+	baseBackoff     = time.Second
+	maxBackoff      = 5 * time.Minute
+	cleanupInterval = time.Hour
+)
+
+type Config struct {
+	PollInterval time.Duration
+	BatchSize    int32
+	Retention    time.Duration
+	// MaxAttempts is the number of failed publishes after which an event is parked.
+	MaxAttempts int32
+}
+
+// OutboxRelay drains the outbox table to Kafka. Events are published in insert
+// order; once an event for a partition key fails, later events with the same key
+// wait until it goes through or is parked, so per-key ordering survives retries.
+type OutboxRelay struct {
+	outboxRepo  repository.OutboxRepository
+	producer    kafka.Producer
+	logger      *zap.Logger
+	cfg         Config
+	lastCleanup time.Time
+}
+
+func NewOutboxRelay(outboxRepo repository.OutboxRepository, producer kafka.Producer, logger *zap.Logger, cfg Config) *OutboxRelay {
+	return &OutboxRelay{
+		outboxRepo: outboxRepo,
+		producer:   producer,
+		logger:     logger,
+		cfg:        cfg,
+	}
+}
+
+func (r *OutboxRelay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.tick(ctx)
+		}
+	}
+}
+
+func (r *OutboxRelay) tick(ctx context.Context) {
+	if _, err := r.Drain(ctx); err != nil && ctx.Err() == nil {
+		r.logger.Error("failed to drain outbox", zap.Error(err))
+	}
+
+	if time.Since(r.lastCleanup) >= cleanupInterval {
+		r.cleanup(ctx)
+	}
+}
+
+// Drain runs a single relay pass over every due event and returns the number of
+// events published. It holds the relay lock for the whole pass, but no
+// transaction is kept open while events are published.
+func (r *OutboxRelay) Drain(ctx context.Context) (int, error) {
+	unlock, locked, err := r.outboxRepo.TryLock(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if !locked {
+		return 0, nil
+	}
+	defer unlock()
+
+	published := 0
+	blockedKeys := make(map[string]bool)
+	var afterID int64
+
+	for {
+		pending, err := r.outboxRepo.ListPending(ctx, afterID, r.cfg.BatchSize)
+		if err != nil {
+			return published, err
+		}
+
+		for _, event := range pending {
+			afterID = event.ID
+			if blockedKeys[event.PartitionKey] {
+				continue
+			}
+
+			if err := r.publish(ctx, event); err != nil {
+				if ctx.Err() != nil {
+					return published, ctx.Err()
+				}
+
+				blocked, err := r.handleFailure(ctx, event, err)
+				if err != nil {
+					return published, err
+				}
+				if blocked {
+					blockedKeys[event.PartitionKey] = true
+				}
+				continue
+			}
+
+			if _, err := r.outboxRepo.MarkPublished(ctx, event.ID); err != nil {
+				return published, err
+			}
+			published++
+		}
+
+		if len(pending) < int(r.cfg.BatchSize) {
+			return published, nil
+		}
+	}
+}
+
+// handleFailure schedules a retry of event, or parks it once it has used up
+// its attempts. It reports whether later events with the same key must wait.
+func (r *OutboxRelay) handleFailure(ctx context.Context, event *models.OutboxEvent, publishErr error) (bool, error) {
+	attempts := event.Attempts + 1
+
+	if attempts >= r.cfg.MaxAttempts {
+		if _, err := r.outboxRepo.Park(ctx, event.ID, publishErr.Error()); err != nil {
+			return false, err
+		}
+		metrics.IncOutboxParked(event.Topic, event.EventType)
+
+		// a parked event no longer holds back the rest of its key
+		r.logger.Error("parked outbox event after too many attempts",
+			zap.String("event_id", event.EventID.String()),
+			zap.String("event_type", event.EventType),
+			zap.Int32("attempts", attempts),
+			zap.Error(publishErr),
+		)
+		return false, nil
+	}
+
+	nextAttemptAt := time.Now().Add(backoff(attempts))
+	if _, err := r.outboxRepo.MarkFailed(ctx, event.ID, publishErr.Error(), nextAttemptAt); err != nil {
+		return false, err
+	}
+
+	r.logger.Warn("failed to relay outbox event",
+		zap.String("event_id", event.EventID.String()),
+		zap.String("event_type", event.EventType),
+		zap.Int32("attempts", attempts),
+		zap.Time("next_attempt_at", nextAttemptAt),
+		zap.Error(publishErr),
+	)
+	return true, nil
+}
+
+func (r *OutboxRelay) publish(ctx context.Context, event *models.OutboxEvent) error {
+	ctx, cancel := context.WithTimeout(ctx, publishTimeout)
+	defer cancel()
+
+	// publish as part of the trace of the request that wrote the event
+	ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(event.TraceContext))
+
+	return r.producer.PublishWithKey(ctx, event.Topic, event.PartitionKey, &events.Event{
+		EventID:    event.EventID.String(),
+		EventType:  event.EventType,
+		Version:    int(event.EventVersion),
+		OccurredAt: event.OccurredAt,
+		TraceID:    event.TraceID,
+		Data:       json.RawMessage(event.Payload),
+	})
+}
+
+func (r *OutboxRelay) cleanup(ctx context.Context) {
+	deleted, err := r.outboxRepo.DeletePublishedBefore(ctx, time.Now().Add(-r.cfg.Retention))
+	if err != nil {
+		r.logger.Error("failed to clean up outbox", zap.Error(err))
+		return
+	}
+
+	r.lastCleanup = time.Now()
+	if deleted > 0 {
+		r.logger.Info("outbox cleaned up", zap.Int64("deleted", deleted))
+	}
+}
+
+func backoff(attempt int32) time.Duration {
+	d := baseBackoff
+	for i := int32(1); i < attempt; i++ {
+		d *= 2
+		if d >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return d
+}
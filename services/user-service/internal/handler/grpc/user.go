@@ -11,6 +11,7 @@ import (
 	"github.com/khoihuynh300/go-microservice/user-service/internal/service"
 	"github.com/khoihuynh300/go-microservice/user-service/internal/utils/convert"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type UserHandler struct {
@@ -18,17 +19,26 @@ type UserHandler struct {
 	authService    service.AuthService
 	userService    service.UserService
 	addressService service.AddressService
+	accountService service.AccountService
+	adminService   service.AdminService
+	auditService   service.AuditService
 }
 
 func NewUserHandler(
 	authService service.AuthService,
 	userService service.UserService,
 	addressService service.AddressService,
+	accountService service.AccountService,
+	adminService service.AdminService,
+	auditService service.AuditService,
 ) *UserHandler {
 	return &UserHandler{
 		authService:    authService,
 		userService:    userService,
 		addressService: addressService,
+		accountService: accountService,
+		adminService:   adminService,
+		auditService:   auditService,
 	}
 }
 
@@ -71,7 +81,53 @@ func (s *UserHandler) Login(ctx context.Context, req *userpb.LoginRequest) (*use
 		Password: req.Password,
 	}
 
-	_, accessToken, refreshToken, err := s.authService.Login(ctx, loginReq)
+	result, err := s.authService.Login(ctx, loginReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return toTokenResponse(result), nil
+}
+
+func (s *UserHandler) StartOAuthLogin(ctx context.Context, req *userpb.StartOAuthLoginRequest) (*userpb.StartOAuthLoginResponse, error) {
+	redirectURL, err := s.authService.StartOAuthLogin(ctx, req.Provider)
+	if err != nil {
+		return nil, err
+	}
+
+	return &userpb.StartOAuthLoginResponse{
+		RedirectUrl: redirectURL,
+	}, nil
+}
+
+func (s *UserHandler) CompleteOAuthLogin(ctx context.Context, req *userpb.CompleteOAuthLoginRequest) (*userpb.TokenResponse, error) {
+	result, err := s.authService.CompleteOAuthLogin(ctx, req.Provider, req.Code, req.State)
+	if err != nil {
+		return nil, err
+	}
+
+	return toTokenResponse(result), nil
+}
+
+func (s *UserHandler) RequestMagicLink(ctx context.Context, req *userpb.RequestMagicLinkRequest) (*emptypb.Empty, error) {
+	err := s.authService.RequestMagicLink(ctx, req.Email)
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *UserHandler) ConsumeMagicLink(ctx context.Context, req *userpb.ConsumeMagicLinkRequest) (*userpb.TokenResponse, error) {
+	result, err := s.authService.ConsumeMagicLink(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	return toTokenResponse(result), nil
+}
+
+func (s *UserHandler) VerifyMFA(ctx context.Context, req *userpb.VerifyMFARequest) (*userpb.TokenResponse, error) {
+	accessToken, refreshToken, err := s.authService.VerifyMFA(ctx, req.MfaToken, req.Code)
 	if err != nil {
 		return nil, err
 	}
@@ -94,6 +150,126 @@ func (s *UserHandler) Refresh(ctx context.Context, req *userpb.RefreshRequest) (
 	}, nil
 }
 
+func (s *UserHandler) Logout(ctx context.Context, req *userpb.LogoutRequest) (*emptypb.Empty, error) {
+	err := s.authService.Logout(ctx, req.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *UserHandler) LogoutAll(ctx context.Context, req *emptypb.Empty) (*emptypb.Empty, error) {
+	userID, ok := ctx.Value(contextkeys.UserIDKey).(string)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	err := s.authService.LogoutAll(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *UserHandler) ListSessions(ctx context.Context, req *emptypb.Empty) (*userpb.ListSessionsResponse, error) {
+	userID, ok := ctx.Value(contextkeys.UserIDKey).(string)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	sessions, err := s.authService.ListSessions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionResponses := make([]*userpb.Session, 0, len(sessions))
+	for _, session := range sessions {
+		sessionResponses = append(sessionResponses, toSessionResponse(session))
+	}
+
+	return &userpb.ListSessionsResponse{
+		Sessions: sessionResponses,
+	}, nil
+}
+
+func (s *UserHandler) RevokeSession(ctx context.Context, req *userpb.RevokeSessionRequest) (*emptypb.Empty, error) {
+	userID, ok := ctx.Value(contextkeys.UserIDKey).(string)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	err := s.authService.RevokeSession(ctx, userID, req.SessionId)
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *UserHandler) ListSecurityEvents(ctx context.Context, req *userpb.ListSecurityEventsRequest) (*userpb.ListSecurityEventsResponse, error) {
+	userID, ok := ctx.Value(contextkeys.UserIDKey).(string)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	entries, total, err := s.auditService.ListSecurityEvents(ctx, userID, req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := toSecurityEventsResponse(entries, total, req.Page, req.PageSize)
+	// the admin behind an action is not disclosed to the user
+	for _, event := range resp.Events {
+		event.ActorId = nil
+	}
+	return resp, nil
+}
+
+func (s *UserHandler) EnrollTOTP(ctx context.Context, req *emptypb.Empty) (*userpb.EnrollTOTPResponse, error) {
+	userID, ok := ctx.Value(contextkeys.UserIDKey).(string)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	secret, uri, err := s.authService.EnrollTOTP(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &userpb.EnrollTOTPResponse{
+		Secret:     secret,
+		OtpauthUri: uri,
+	}, nil
+}
+
+func (s *UserHandler) ConfirmTOTP(ctx context.Context, req *userpb.ConfirmTOTPRequest) (*userpb.ConfirmTOTPResponse, error) {
+	userID, ok := ctx.Value(contextkeys.UserIDKey).(string)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	recoveryCodes, err := s.authService.ConfirmTOTP(ctx, userID, req.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	return &userpb.ConfirmTOTPResponse{
+		RecoveryCodes: recoveryCodes,
+	}, nil
+}
+
+func (s *UserHandler) DisableTOTP(ctx context.Context, req *userpb.DisableTOTPRequest) (*emptypb.Empty, error) {
+	userID, ok := ctx.Value(contextkeys.UserIDKey).(string)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	err := s.authService.DisableTOTP(ctx, userID, req.Password, req.Code)
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
 func (s *UserHandler) GetMe(ctx context.Context, req *emptypb.Empty) (*userpb.GetUserResponse, error) {
 	userID, ok := ctx.Value(contextkeys.UserIDKey).(string)
 	if !ok {
@@ -174,6 +350,61 @@ func (s *UserHandler) UpdateAvatar(ctx context.Context, req *userpb.UpdateAvatar
 	}, nil
 }
 
+func (s *UserHandler) RequestPhoneVerification(ctx context.Context, req *userpb.RequestPhoneVerificationRequest) (*emptypb.Empty, error) {
+	userID, ok := ctx.Value(contextkeys.UserIDKey).(string)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	err := s.userService.RequestPhoneVerification(ctx, userID, req.Phone)
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *UserHandler) VerifyPhone(ctx context.Context, req *userpb.VerifyPhoneRequest) (*userpb.UpdateUserResponse, error) {
+	userID, ok := ctx.Value(contextkeys.UserIDKey).(string)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	updatedUser, err := s.userService.VerifyPhone(ctx, userID, req.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	return &userpb.UpdateUserResponse{
+		User: toUserResponse(updatedUser),
+	}, nil
+}
+
+func (s *UserHandler) DeleteAccount(ctx context.Context, req *userpb.DeleteAccountRequest) (*emptypb.Empty, error) {
+	userID, ok := ctx.Value(contextkeys.UserIDKey).(string)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	err := s.accountService.DeleteAccount(ctx, userID, req.Password)
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *UserHandler) ExportMyData(ctx context.Context, req *emptypb.Empty) (*emptypb.Empty, error) {
+	userID, ok := ctx.Value(contextkeys.UserIDKey).(string)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	err := s.accountService.ExportMyData(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
 func (s *UserHandler) ChangePassword(ctx context.Context, req *userpb.ChangePasswordRequest) (*emptypb.Empty, error) {
 	userID, ok := ctx.Value(contextkeys.UserIDKey).(string)
 	if !ok {
@@ -209,6 +440,35 @@ func (s *UserHandler) ResetPassword(ctx context.Context, req *userpb.ResetPasswo
 	return &emptypb.Empty{}, nil
 }
 
+func (s *UserHandler) RequestEmailChange(ctx context.Context, req *userpb.RequestEmailChangeRequest) (*emptypb.Empty, error) {
+	userID, ok := ctx.Value(contextkeys.UserIDKey).(string)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	err := s.authService.RequestEmailChange(ctx, userID, req.NewEmail, req.Password)
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *UserHandler) ConfirmEmailChange(ctx context.Context, req *userpb.ConfirmEmailChangeRequest) (*emptypb.Empty, error) {
+	err := s.authService.ConfirmEmailChange(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *UserHandler) UnlockAccount(ctx context.Context, req *userpb.UnlockAccountRequest) (*emptypb.Empty, error) {
+	err := s.authService.UnlockAccount(ctx, req.UnlockToken)
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
 func (s *UserHandler) CreateUserAddress(ctx context.Context, req *userpb.CreateUserAddressRequest) (*userpb.CreateUserAddressResponse, error) {
 	userID, ok := ctx.Value(contextkeys.UserIDKey).(string)
 	if !ok {
@@ -315,16 +575,173 @@ func (s *UserHandler) DeleteUserAddress(ctx context.Context, req *userpb.DeleteU
 	return &emptypb.Empty{}, nil
 }
 
+func (s *UserHandler) ListUsers(ctx context.Context, req *userpb.ListUsersRequest) (*userpb.ListUsersResponse, error) {
+	listReq := &request.ListUsersRequest{
+		Status:      convert.StringWrapperToPtr(req.Status),
+		Email:       convert.StringWrapperToPtr(req.Email),
+		CreatedFrom: convert.TimestampToTimePtr(req.CreatedFrom),
+		CreatedTo:   convert.TimestampToTimePtr(req.CreatedTo),
+		Page:        req.Page,
+		PageSize:    req.PageSize,
+	}
+
+	users, total, err := s.adminService.ListUsers(ctx, listReq)
+	if err != nil {
+		return nil, err
+	}
+
+	userResponses := make([]*userpb.UserDetail, 0, len(users))
+	for _, user := range users {
+		userResponses = append(userResponses, toUserDetailResponse(user))
+	}
+
+	totalPages := int32(total) / req.PageSize
+	if int32(total)%req.PageSize != 0 {
+		totalPages++
+	}
+
+	return &userpb.ListUsersResponse{
+		Users:      userResponses,
+		Total:      total,
+		Page:       req.Page,
+		PageSize:   req.PageSize,
+		TotalPages: totalPages,
+	}, nil
+}
+
+func (s *UserHandler) GetUserByID(ctx context.Context, req *userpb.GetUserByIDRequest) (*userpb.UserDetailResponse, error) {
+	user, err := s.adminService.GetUser(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &userpb.UserDetailResponse{
+		User: toUserDetailResponse(user),
+	}, nil
+}
+
+func (s *UserHandler) SuspendUser(ctx context.Context, req *userpb.SuspendUserRequest) (*userpb.UserDetailResponse, error) {
+	adminID, ok := ctx.Value(contextkeys.UserIDKey).(string)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	user, err := s.adminService.SuspendUser(ctx, adminID, req.UserId, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	return &userpb.UserDetailResponse{
+		User: toUserDetailResponse(user),
+	}, nil
+}
+
+func (s *UserHandler) ReactivateUser(ctx context.Context, req *userpb.ReactivateUserRequest) (*userpb.UserDetailResponse, error) {
+	adminID, ok := ctx.Value(contextkeys.UserIDKey).(string)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	user, err := s.adminService.ReactivateUser(ctx, adminID, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &userpb.UserDetailResponse{
+		User: toUserDetailResponse(user),
+	}, nil
+}
+
+func (s *UserHandler) ForceResetPassword(ctx context.Context, req *userpb.ForceResetPasswordRequest) (*emptypb.Empty, error) {
+	adminID, ok := ctx.Value(contextkeys.UserIDKey).(string)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	err := s.adminService.ForceResetPassword(ctx, adminID, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *UserHandler) RevokeAllSessions(ctx context.Context, req *userpb.RevokeAllSessionsRequest) (*emptypb.Empty, error) {
+	adminID, ok := ctx.Value(contextkeys.UserIDKey).(string)
+	if !ok {
+		return nil, apperr.ErrUnauthenticated
+	}
+
+	err := s.adminService.RevokeAllSessions(ctx, adminID, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *UserHandler) ListAuditLog(ctx context.Context, req *userpb.ListAuditLogRequest) (*userpb.ListSecurityEventsResponse, error) {
+	listReq := &request.ListAuditLogRequest{
+		UserID:      convert.StringWrapperToPtr(req.UserId),
+		EventType:   convert.StringWrapperToPtr(req.EventType),
+		IPAddress:   convert.StringWrapperToPtr(req.IpAddress),
+		CreatedFrom: convert.TimestampToTimePtr(req.CreatedFrom),
+		CreatedTo:   convert.TimestampToTimePtr(req.CreatedTo),
+		Page:        req.Page,
+		PageSize:    req.PageSize,
+	}
+
+	entries, total, err := s.auditService.ListAuditLog(ctx, listReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return toSecurityEventsResponse(entries, total, req.Page, req.PageSize), nil
+}
+
+func toTokenResponse(result *service.LoginResult) *userpb.TokenResponse {
+	if result.MFARequired() {
+		return &userpb.TokenResponse{
+			MfaRequired: true,
+			MfaToken:    result.MFAToken,
+		}
+	}
+
+	return &userpb.TokenResponse{
+		AccessToken:  result.AccessToken,
+		RefreshToken: result.RefreshToken,
+	}
+}
+
 func toUserResponse(user *models.User) *userpb.User {
 	return &userpb.User{
-		Id:          user.ID.String(),
-		FullName:    user.FullName,
-		Email:       user.Email,
-		Phone:       convert.GenericStringPtrToWrapper(user.Phone),
-		DateOfBirth: convert.TimePtrToDateStringWrapper(user.DateOfBirth),
-		AvatarUrl:   convert.GenericStringPtrToWrapper(user.AvatarURL),
-		Gender:      convert.GenericStringPtrToWrapper(user.Gender),
-		Status:      string(user.Status),
+		Id:            user.ID.String(),
+		FullName:      user.FullName,
+		Email:         user.Email,
+		Phone:         convert.GenericStringPtrToWrapper(user.Phone),
+		DateOfBirth:   convert.TimePtrToDateStringWrapper(user.DateOfBirth),
+		AvatarUrl:     convert.GenericStringPtrToWrapper(user.AvatarURL),
+		Gender:        convert.GenericStringPtrToWrapper(user.Gender),
+		Status:        string(user.Status),
+		Role:          string(user.Role),
+		PhoneVerified: user.IsPhoneVerified(),
+	}
+}
+
+func toUserDetailResponse(user *models.User) *userpb.UserDetail {
+	return &userpb.UserDetail{
+		Id:              user.ID.String(),
+		FullName:        user.FullName,
+		Email:           user.Email,
+		Phone:           convert.GenericStringPtrToWrapper(user.Phone),
+		AvatarUrl:       convert.GenericStringPtrToWrapper(user.AvatarURL),
+		DateOfBirth:     convert.TimePtrToDateStringWrapper(user.DateOfBirth),
+		Gender:          convert.GenericStringPtrToWrapper(user.Gender),
+		Status:          string(user.Status),
+		Role:            string(user.Role),
+		EmailVerifiedAt: convert.TimePtrToTimestamp(user.EmailVerifiedAt),
+		PhoneVerifiedAt: convert.TimePtrToTimestamp(user.PhoneVerifiedAt),
+		MfaEnabled:      user.IsTOTPEnabled(),
+		CreatedAt:       timestamppb.New(user.CreatedAt),
+		UpdatedAt:       timestamppb.New(user.UpdatedAt),
 	}
 }
 
@@ -351,3 +768,50 @@ func toAddressResponse(address *models.Address) *userpb.Address {
 		IsDefault:    address.IsDefault,
 	}
 }
+
+func toSessionResponse(session *models.Session) *userpb.Session {
+	return &userpb.Session{
+		Id:         session.ID.String(),
+		DeviceInfo: session.DeviceInfo,
+		IpAddress:  session.IPAddress,
+		UserAgent:  session.UserAgent,
+		CreatedAt:  timestamppb.New(session.CreatedAt),
+		LastUsedAt: convert.TimePtrToTimestamp(session.LastUsedAt),
+		ExpiresAt:  timestamppb.New(session.ExpiresAt),
+	}
+}
+
+func toSecurityEventResponse(entry *models.AuditLogEntry) *userpb.SecurityEvent {
+	return &userpb.SecurityEvent{
+		Id:        entry.ID,
+		UserId:    convert.UUIDPtrToStringWrapper(entry.UserID),
+		ActorId:   convert.UUIDPtrToStringWrapper(entry.ActorID),
+		EventType: string(entry.EventType),
+		Success:   entry.Success,
+		Reason:    convert.GenericStringPtrToWrapper(entry.Reason),
+		IpAddress: entry.IPAddress,
+		UserAgent: entry.UserAgent,
+		TraceId:   entry.TraceID,
+		CreatedAt: timestamppb.New(entry.CreatedAt),
+	}
+}
+
+func toSecurityEventsResponse(entries []*models.AuditLogEntry, total int64, page, pageSize int32) *userpb.ListSecurityEventsResponse {
+	events := make([]*userpb.SecurityEvent, 0, len(entries))
+	for _, entry := range entries {
+		events = append(events, toSecurityEventResponse(entry))
+	}
+
+	totalPages := int32(total) / pageSize
+	if int32(total)%pageSize != 0 {
+		totalPages++
+	}
+
+	return &userpb.ListSecurityEventsResponse{
+		Events:     events,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: totalPages,
+	}
+}
@@ -0,0 +1,42 @@
+package httphandler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/khoihuynh300/go-microservice/user-service/internal/security/jwtprovider"
+)
+
+const JWKSPath = "/.well-known/jwks.json"
+
+type JWKSHandler struct {
+	body []byte
+}
+
+// NewJWKSHandler renders the key set once; keys only change on restart.
+func NewJWKSHandler(keys *jwtprovider.KeySet) (*JWKSHandler, error) {
+	set, err := keys.JWKS()
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(set)
+	if err != nil {
+		return nil, err
+	}
+	return &JWKSHandler{body: body}, nil
+}
+
+func (h *JWKSHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodGet {
+		w.Write(h.body)
+	}
+}
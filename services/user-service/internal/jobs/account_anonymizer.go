@@ -0,0 +1,83 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/khoihuynh300/go-microservice/user-service/internal/repository"
+	"go.uber.org/zap"
+)
+
+type AnonymizerConfig struct {
+	Interval    time.Duration
+	GracePeriod time.Duration
+	BatchSize   int32
+}
+
+// AccountAnonymizer scrubs the personal data of accounts that were deleted
+// more than GracePeriod ago.
+type AccountAnonymizer struct {
+	userRepo repository.UserRepository
+	logger   *zap.Logger
+	cfg      AnonymizerConfig
+}
+
+func NewAccountAnonymizer(userRepo repository.UserRepository, logger *zap.Logger, cfg AnonymizerConfig) *AccountAnonymizer {
+	return &AccountAnonymizer{
+		userRepo: userRepo,
+		logger:   logger,
+		cfg:      cfg,
+	}
+}
+
+func (a *AccountAnonymizer) Run(ctx context.Context) {
+	ticker := time.NewTicker(a.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			a.tick(ctx)
+		}
+	}
+}
+
+func (a *AccountAnonymizer) tick(ctx context.Context) {
+	for {
+		anonymized, err := a.AnonymizeDue(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				a.logger.Error("failed to anonymize deleted accounts", zap.Error(err))
+			}
+			return
+		}
+		if anonymized < int(a.cfg.BatchSize) {
+			return
+		}
+	}
+}
+
+// AnonymizeDue runs a single pass and returns the number of accounts
+// anonymized. Concurrent passes are safe; an account is only scrubbed once.
+func (a *AccountAnonymizer) AnonymizeDue(ctx context.Context) (int, error) {
+	ids, err := a.userRepo.ListPendingAnonymization(ctx, time.Now().Add(-a.cfg.GracePeriod), a.cfg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	anonymized := 0
+	for _, id := range ids {
+		rows, err := a.userRepo.Anonymize(ctx, id)
+		if err != nil {
+			return anonymized, err
+		}
+		if rows > 0 {
+			anonymized++
+			a.logger.Info("anonymized deleted account", zap.String("user_id", id.String()))
+		}
+	}
+
+	return anonymized, nil
+}
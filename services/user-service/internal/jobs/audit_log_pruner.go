@@ -0,0 +1,72 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/khoihuynh300/go-microservice/user-service/internal/repository"
+	"go.uber.org/zap"
+)
+
+type AuditLogPrunerConfig struct {
+	Interval  time.Duration
+	Retention time.Duration
+	BatchSize int32
+}
+
+// AuditLogPruner deletes audit log entries older than Retention.
+type AuditLogPruner struct {
+	auditLogRepo repository.AuditLogRepository
+	logger       *zap.Logger
+	cfg          AuditLogPrunerConfig
+}
+
+func NewAuditLogPruner(auditLogRepo repository.AuditLogRepository, logger *zap.Logger, cfg AuditLogPrunerConfig) *AuditLogPruner {
+	return &AuditLogPruner{
+		auditLogRepo: auditLogRepo,
+		logger:       logger,
+		cfg:          cfg,
+	}
+}
+
+func (p *AuditLogPruner) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.tick(ctx)
+		}
+	}
+}
+
+func (p *AuditLogPruner) tick(ctx context.Context) {
+	var total int64
+	for {
+		deleted, err := p.PruneExpired(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				p.logger.Error("failed to prune audit log", zap.Error(err))
+			}
+			return
+		}
+		total += deleted
+		if deleted < int64(p.cfg.BatchSize) {
+			break
+		}
+	}
+
+	if total > 0 {
+		p.logger.Info("pruned audit log", zap.Int64("deleted", total))
+	}
+}
+
+// PruneExpired deletes a single batch and returns the number of entries
+// removed. The cutoff is taken on every call so a long backlog drains
+// against a current clock.
+func (p *AuditLogPruner) PruneExpired(ctx context.Context) (int64, error) {
+	return p.auditLogRepo.DeleteBefore(ctx, time.Now().Add(-p.cfg.Retention), p.cfg.BatchSize)
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/domain/models"
+)
+
+// AuditLogFilter narrows List. Nil fields match every entry.
+type AuditLogFilter struct {
+	UserID      *uuid.UUID
+	EventType   *models.AuditEventType
+	IPAddress   *string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+}
+
+type AuditLogRepository interface {
+	Create(ctx context.Context, entry *models.AuditLogEntry) error
+	// List returns a page of entries, newest first, and the total matching.
+	List(ctx context.Context, filter *AuditLogFilter, page, pageSize int32) ([]*models.AuditLogEntry, int64, error)
+	// DeleteBefore removes at most limit entries created before the cutoff.
+	DeleteBefore(ctx context.Context, before time.Time, limit int32) (int64, error)
+}
@@ -0,0 +1,101 @@
+package impl
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	sqlc "github.com/khoihuynh300/go-microservice/user-service/internal/db/generated"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/domain/models"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/repository"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/utils/convert"
+)
+
+type auditLogRepository struct {
+	baseRepository
+}
+
+func NewAuditLogRepository(db *pgxpool.Pool) repository.AuditLogRepository {
+	return &auditLogRepository{
+		baseRepository: baseRepository{
+			db: db,
+			q:  sqlc.New(db),
+		},
+	}
+}
+
+func (r *auditLogRepository) Create(ctx context.Context, entry *models.AuditLogEntry) error {
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+
+	return r.queries(ctx).CreateAuditLog(ctx, sqlc.CreateAuditLogParams{
+		UserID:    toNullableUUID(entry.UserID),
+		ActorID:   toNullableUUID(entry.ActorID),
+		EventType: string(entry.EventType),
+		Success:   entry.Success,
+		Reason:    convert.PtrToText(entry.Reason),
+		IpAddress: entry.IPAddress,
+		UserAgent: entry.UserAgent,
+		TraceID:   entry.TraceID,
+		CreatedAt: entry.CreatedAt,
+	})
+}
+
+func (r *auditLogRepository) List(ctx context.Context, filter *repository.AuditLogFilter, page, pageSize int32) ([]*models.AuditLogEntry, int64, error) {
+	total, err := r.queries(ctx).CountAuditLogs(ctx, sqlc.CountAuditLogsParams{
+		UserID:      toNullableUUID(filter.UserID),
+		EventType:   convert.PtrToText(filter.EventType),
+		IpAddress:   convert.PtrToText(filter.IPAddress),
+		CreatedFrom: convert.PtrToTimestamptz(filter.CreatedFrom),
+		CreatedTo:   convert.PtrToTimestamptz(filter.CreatedTo),
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.queries(ctx).ListAuditLogs(ctx, sqlc.ListAuditLogsParams{
+		UserID:      toNullableUUID(filter.UserID),
+		EventType:   convert.PtrToText(filter.EventType),
+		IpAddress:   convert.PtrToText(filter.IPAddress),
+		CreatedFrom: convert.PtrToTimestamptz(filter.CreatedFrom),
+		CreatedTo:   convert.PtrToTimestamptz(filter.CreatedTo),
+		Limit:       pageSize,
+		Offset:      (page - 1) * pageSize,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	entries := make([]*models.AuditLogEntry, len(rows))
+	for i, row := range rows {
+		entries[i] = &models.AuditLogEntry{
+			ID:        row.ID,
+			EventType: models.AuditEventType(row.EventType),
+			Success:   row.Success,
+			Reason:    convert.PtrIfValid(row.Reason.String, row.Reason.Valid),
+			IPAddress: row.IpAddress,
+			UserAgent: row.UserAgent,
+			TraceID:   row.TraceID,
+			CreatedAt: row.CreatedAt,
+		}
+		if row.UserID.Valid {
+			userID := uuid.UUID(row.UserID.Bytes)
+			entries[i].UserID = &userID
+		}
+		if row.ActorID.Valid {
+			actorID := uuid.UUID(row.ActorID.Bytes)
+			entries[i].ActorID = &actorID
+		}
+	}
+
+	return entries, total, nil
+}
+
+func (r *auditLogRepository) DeleteBefore(ctx context.Context, before time.Time, limit int32) (int64, error) {
+	return r.queries(ctx).DeleteAuditLogsBefore(ctx, sqlc.DeleteAuditLogsBeforeParams{
+		Before: before,
+		Limit:  limit,
+	})
+}
@@ -0,0 +1,153 @@
+package impl
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	sqlc "github.com/khoihuynh300/go-microservice/user-service/internal/db/generated"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/domain/models"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/repository"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/utils/convert"
+)
+
+const (
+	// outboxLockID is the advisory lock key that keeps a single relay draining the outbox.
+	outboxLockID int64 = 0x6f7574626f78
+
+	unlockTimeout = 5 * time.Second
+)
+
+type outboxRepository struct {
+	baseRepository
+}
+
+func NewOutboxRepository(db *pgxpool.Pool) repository.OutboxRepository {
+	return &outboxRepository{
+		baseRepository: baseRepository{
+			db: db,
+			q:  sqlc.New(db),
+		},
+	}
+}
+
+func (r *outboxRepository) Create(ctx context.Context, event *models.OutboxEvent) error {
+	traceContext, err := json.Marshal(event.TraceContext)
+	if err != nil {
+		return err
+	}
+	if event.TraceContext == nil {
+		traceContext = []byte("{}")
+	}
+
+	params := sqlc.CreateOutboxEventParams{
+		EventID:      event.EventID,
+		Topic:        event.Topic,
+		PartitionKey: event.PartitionKey,
+		EventType:    event.EventType,
+		EventVersion: event.EventVersion,
+		TraceID:      event.TraceID,
+		TraceContext: traceContext,
+		Payload:      event.Payload,
+		OccurredAt:   event.OccurredAt,
+		CreatedAt:    time.Now(),
+	}
+
+	return r.queries(ctx).CreateOutboxEvent(ctx, params)
+}
+
+// TryLock takes a session-level advisory lock on a connection set aside for
+// the relay pass, so Kafka is never called inside an open transaction.
+func (r *outboxRepository) TryLock(ctx context.Context) (func(), bool, error) {
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	q := sqlc.New(conn)
+	locked, err := q.TryLockOutbox(ctx, outboxLockID)
+	if err != nil || !locked {
+		conn.Release()
+		return nil, false, err
+	}
+
+	unlock := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), unlockTimeout)
+		defer cancel()
+
+		// closing the connection ends the session, which drops the lock too
+		if _, err := q.UnlockOutbox(ctx, outboxLockID); err != nil {
+			conn.Conn().Close(ctx)
+		}
+		conn.Release()
+	}
+	return unlock, true, nil
+}
+
+func (r *outboxRepository) ListPending(ctx context.Context, afterID int64, limit int32) ([]*models.OutboxEvent, error) {
+	rows, err := r.queries(ctx).ListPendingOutboxEvents(ctx, sqlc.ListPendingOutboxEventsParams{
+		AfterID: afterID,
+		Limit:   limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*models.OutboxEvent, len(rows))
+	for i, row := range rows {
+		var traceContext map[string]string
+		if err := json.Unmarshal(row.TraceContext, &traceContext); err != nil {
+			return nil, err
+		}
+
+		events[i] = &models.OutboxEvent{
+			ID:            row.ID,
+			EventID:       row.EventID,
+			Topic:         row.Topic,
+			PartitionKey:  row.PartitionKey,
+			EventType:     row.EventType,
+			EventVersion:  row.EventVersion,
+			TraceID:       row.TraceID,
+			TraceContext:  traceContext,
+			Payload:       row.Payload,
+			OccurredAt:    row.OccurredAt,
+			Attempts:      row.Attempts,
+			LastError:     convert.PtrIfValid(row.LastError.String, row.LastError.Valid),
+			NextAttemptAt: row.NextAttemptAt,
+			PublishedAt:   convert.PtrIfValid(row.PublishedAt.Time, row.PublishedAt.Valid),
+			FailedAt:      convert.PtrIfValid(row.FailedAt.Time, row.FailedAt.Valid),
+			CreatedAt:     row.CreatedAt,
+		}
+	}
+
+	return events, nil
+}
+
+func (r *outboxRepository) MarkPublished(ctx context.Context, id int64) (int64, error) {
+	return r.queries(ctx).MarkOutboxEventPublished(ctx, sqlc.MarkOutboxEventPublishedParams{
+		ID:          id,
+		PublishedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	})
+}
+
+func (r *outboxRepository) MarkFailed(ctx context.Context, id int64, lastError string, nextAttemptAt time.Time) (int64, error) {
+	return r.queries(ctx).MarkOutboxEventFailed(ctx, sqlc.MarkOutboxEventFailedParams{
+		ID:            id,
+		LastError:     pgtype.Text{String: lastError, Valid: true},
+		NextAttemptAt: nextAttemptAt,
+	})
+}
+
+func (r *outboxRepository) Park(ctx context.Context, id int64, lastError string) (int64, error) {
+	return r.queries(ctx).ParkOutboxEvent(ctx, sqlc.ParkOutboxEventParams{
+		ID:        id,
+		LastError: pgtype.Text{String: lastError, Valid: true},
+		FailedAt:  pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	})
+}
+
+func (r *outboxRepository) DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	return r.queries(ctx).DeletePublishedOutboxEvents(ctx, pgtype.Timestamptz{Time: before, Valid: true})
+}
@@ -4,14 +4,17 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"net/netip"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 	sqlc "github.com/khoihuynh300/go-microservice/user-service/internal/db/generated"
 	"github.com/khoihuynh300/go-microservice/user-service/internal/domain/models"
 	"github.com/khoihuynh300/go-microservice/user-service/internal/repository"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/utils/convert"
 )
 
 type refreshTokenRepository struct {
@@ -28,15 +31,37 @@ func NewRefreshTokenRepository(db *pgxpool.Pool) repository.RefreshTokenReposito
 }
 
 func (r *refreshTokenRepository) Create(ctx context.Context, refreshToken *models.RefreshToken) error {
+	now := time.Now()
+	id := uuid.New()
+	familyID := refreshToken.FamilyID
+	if familyID == uuid.Nil {
+		familyID = id
+	}
+
 	params := sqlc.CreateRefreshTokenParams{
-		ID:        uuid.New(),
-		UserID:    refreshToken.UserID,
-		TokenHash: refreshToken.TokenHash,
-		ExpiresAt: refreshToken.ExpiresAt,
-		CreatedAt: time.Now(),
+		ID:            id,
+		UserID:        refreshToken.UserID,
+		FamilyID:      familyID,
+		ParentID:      toNullableUUID(refreshToken.ParentID),
+		TokenHash:     refreshToken.TokenHash,
+		DeviceInfo:    toNullableText(refreshToken.DeviceInfo),
+		IpAddress:     toNullableAddr(refreshToken.IPAddress),
+		UserAgent:     toNullableText(refreshToken.UserAgent),
+		AccessTokenID: refreshToken.AccessTokenID,
+		ExpiresAt:     refreshToken.ExpiresAt,
+		LastUsedAt:    convert.PtrToTimestamptz(&now),
+		CreatedAt:     now,
+	}
+
+	if err := r.queries(ctx).CreateRefreshToken(ctx, params); err != nil {
+		return err
 	}
 
-	return r.queries(ctx).CreateRefreshToken(ctx, params)
+	refreshToken.ID = params.ID
+	refreshToken.FamilyID = familyID
+	refreshToken.LastUsedAt = &now
+	refreshToken.CreatedAt = now
+	return nil
 }
 
 func (r *refreshTokenRepository) GetByToken(ctx context.Context, refreshTokenStr string) (*models.RefreshToken, error) {
@@ -47,15 +72,127 @@ func (r *refreshTokenRepository) GetByToken(ctx context.Context, refreshTokenStr
 		}
 		return nil, err
 	}
-	return &models.RefreshToken{
-		ID:        row.ID,
-		UserID:    row.UserID,
-		TokenHash: row.TokenHash,
-		ExpiresAt: row.ExpiresAt,
-		CreatedAt: row.CreatedAt,
-	}, nil
+	return mapToRefreshToken(&row), nil
 }
 
 func (r *refreshTokenRepository) DeleteByID(ctx context.Context, id uuid.UUID) (int64, error) {
 	return r.queries(ctx).DeleteRefreshTokenByID(ctx, id)
 }
+
+func (r *refreshTokenRepository) MarkRotated(ctx context.Context, id uuid.UUID) (int64, error) {
+	return r.queries(ctx).MarkRefreshTokenRotated(ctx, sqlc.MarkRefreshTokenRotatedParams{
+		ID:        id,
+		RotatedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	})
+}
+
+func (r *refreshTokenRepository) ListSessionsByUserID(ctx context.Context, userID uuid.UUID) ([]*models.Session, error) {
+	rows, err := r.queries(ctx).ListSessionsByUserID(ctx, sqlc.ListSessionsByUserIDParams{
+		UserID:    userID,
+		ExpiresAt: time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]*models.Session, 0, len(rows))
+	for _, row := range rows {
+		session := &models.Session{
+			ID:         row.FamilyID,
+			DeviceInfo: row.DeviceInfo.String,
+			UserAgent:  row.UserAgent.String,
+			CreatedAt:  row.StartedAt,
+			LastUsedAt: convert.PtrIfValid(row.LastUsedAt.Time, row.LastUsedAt.Valid),
+			ExpiresAt:  row.ExpiresAt,
+		}
+		if row.IpAddress != nil {
+			session.IPAddress = row.IpAddress.String()
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, nil
+}
+
+func (r *refreshTokenRepository) ListByFamilyID(ctx context.Context, familyID uuid.UUID, createdAfter time.Time) ([]*models.RefreshToken, error) {
+	rows, err := r.queries(ctx).ListRefreshTokensByFamilyID(ctx, sqlc.ListRefreshTokensByFamilyIDParams{
+		FamilyID:  familyID,
+		CreatedAt: createdAfter,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := make([]*models.RefreshToken, len(rows))
+	for i := range rows {
+		tokens[i] = mapToRefreshToken(&rows[i])
+	}
+	return tokens, nil
+}
+
+func (r *refreshTokenRepository) RevokeFamily(ctx context.Context, familyID uuid.UUID) (int64, error) {
+	return r.queries(ctx).RevokeRefreshTokenFamily(ctx, sqlc.RevokeRefreshTokenFamilyParams{
+		FamilyID:  familyID,
+		RevokedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	})
+}
+
+func (r *refreshTokenRepository) RevokeFamilyByUserID(ctx context.Context, familyID uuid.UUID, userID uuid.UUID) (int64, error) {
+	return r.queries(ctx).RevokeRefreshTokenFamilyByUserID(ctx, sqlc.RevokeRefreshTokenFamilyByUserIDParams{
+		FamilyID:  familyID,
+		UserID:    userID,
+		RevokedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	})
+}
+
+func (r *refreshTokenRepository) RevokeAllByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	return r.queries(ctx).RevokeRefreshTokensByUserID(ctx, sqlc.RevokeRefreshTokensByUserIDParams{
+		UserID:    userID,
+		RevokedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	})
+}
+
+func mapToRefreshToken(row *sqlc.RefreshToken) *models.RefreshToken {
+	refreshToken := &models.RefreshToken{
+		ID:            row.ID,
+		UserID:        row.UserID,
+		FamilyID:      row.FamilyID,
+		TokenHash:     row.TokenHash,
+		DeviceInfo:    row.DeviceInfo.String,
+		UserAgent:     row.UserAgent.String,
+		AccessTokenID: row.AccessTokenID,
+		ExpiresAt:     row.ExpiresAt,
+		LastUsedAt:    convert.PtrIfValid(row.LastUsedAt.Time, row.LastUsedAt.Valid),
+		RotatedAt:     convert.PtrIfValid(row.RotatedAt.Time, row.RotatedAt.Valid),
+		RevokedAt:     convert.PtrIfValid(row.RevokedAt.Time, row.RevokedAt.Valid),
+		CreatedAt:     row.CreatedAt,
+	}
+	if row.ParentID.Valid {
+		parentID := uuid.UUID(row.ParentID.Bytes)
+		refreshToken.ParentID = &parentID
+	}
+	if row.IpAddress != nil {
+		refreshToken.IPAddress = row.IpAddress.String()
+	}
+	return refreshToken
+}
+
+func toNullableText(s string) pgtype.Text {
+	return pgtype.Text{String: s, Valid: s != ""}
+}
+
+func toNullableUUID(id *uuid.UUID) pgtype.UUID {
+	if id == nil {
+		return pgtype.UUID{}
+	}
+	return pgtype.UUID{Bytes: *id, Valid: true}
+}
+
+// toNullableAddr stores unparsable addresses as NULL rather than failing the
+// login, since the value comes from a forwarded header.
+func toNullableAddr(s string) *netip.Addr {
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return nil
+	}
+	return &addr
+}
@@ -0,0 +1,86 @@
+package impl
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	sqlc "github.com/khoihuynh300/go-microservice/user-service/internal/db/generated"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/domain/models"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/repository"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/utils/convert"
+)
+
+type userIdentityRepository struct {
+	baseRepository
+}
+
+func NewUserIdentityRepository(db *pgxpool.Pool) repository.UserIdentityRepository {
+	return &userIdentityRepository{
+		baseRepository: baseRepository{
+			db: db,
+			q:  sqlc.New(db),
+		},
+	}
+}
+
+func (r *userIdentityRepository) Create(ctx context.Context, identity *models.UserIdentity) error {
+	now := time.Now()
+
+	params := sqlc.CreateUserIdentityParams{
+		ID:          uuid.New(),
+		UserID:      identity.UserID,
+		Provider:    identity.Provider,
+		Subject:     identity.Subject,
+		Email:       toNullableText(identity.Email),
+		CreatedAt:   now,
+		LastLoginAt: convert.PtrToTimestamptz(&now),
+	}
+
+	if err := r.queries(ctx).CreateUserIdentity(ctx, params); err != nil {
+		return err
+	}
+
+	identity.ID = params.ID
+	identity.CreatedAt = now
+	identity.LastLoginAt = &now
+	return nil
+}
+
+func (r *userIdentityRepository) GetByProviderSubject(ctx context.Context, provider, subject string) (*models.UserIdentity, error) {
+	row, err := r.queries(ctx).GetUserIdentity(ctx, sqlc.GetUserIdentityParams{
+		Provider: provider,
+		Subject:  subject,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &models.UserIdentity{
+		ID:          row.ID,
+		UserID:      row.UserID,
+		Provider:    row.Provider,
+		Subject:     row.Subject,
+		Email:       row.Email.String,
+		CreatedAt:   row.CreatedAt,
+		LastLoginAt: convert.PtrIfValid(row.LastLoginAt.Time, row.LastLoginAt.Valid),
+	}, nil
+}
+
+// RecordLogin stamps the login time and keeps the email the provider last
+// reported for the account.
+func (r *userIdentityRepository) RecordLogin(ctx context.Context, id uuid.UUID, email string) (int64, error) {
+	now := time.Now()
+	return r.queries(ctx).RecordUserIdentityLogin(ctx, sqlc.RecordUserIdentityLoginParams{
+		ID:          id,
+		Email:       toNullableText(email),
+		LastLoginAt: convert.PtrToTimestamptz(&now),
+	})
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 	sqlc "github.com/khoihuynh300/go-microservice/user-service/internal/db/generated"
@@ -16,6 +18,11 @@ import (
 	"github.com/khoihuynh300/go-microservice/user-service/internal/utils/convert"
 )
 
+const uniqueViolationCode = "23505"
+
+// likeEscaper makes user input match literally inside a LIKE pattern.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
 type userRepository struct {
 	baseRepository
 }
@@ -48,6 +55,7 @@ func (r *userRepository) Create(ctx context.Context, user *models.User) error {
 	}
 
 	user.ID = result.ID
+	user.Role = models.UserRole(result.Role)
 
 	return nil
 }
@@ -77,6 +85,55 @@ func (r *userRepository) GetByEmail(ctx context.Context, email string) (*models.
 	return r.mapToUser(row), nil
 }
 
+func (r *userRepository) GetByPhone(ctx context.Context, phone string) (*models.User, error) {
+	row, err := r.queries(ctx).GetUserByPhone(ctx, convert.PtrToText(&phone))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+	return r.mapToUser(row), nil
+}
+
+func (r *userRepository) List(ctx context.Context, filter *repository.UserFilter, page, pageSize int32) ([]*models.User, int64, error) {
+	var email *string
+	if filter.Email != nil {
+		escaped := likeEscaper.Replace(*filter.Email)
+		email = &escaped
+	}
+
+	total, err := r.queries(ctx).CountUsers(ctx, sqlc.CountUsersParams{
+		Status:      convert.PtrToStatusEnum(filter.Status),
+		Email:       convert.PtrToText(email),
+		CreatedFrom: convert.PtrToTimestamptz(filter.CreatedFrom),
+		CreatedTo:   convert.PtrToTimestamptz(filter.CreatedTo),
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.queries(ctx).ListUsers(ctx, sqlc.ListUsersParams{
+		Status:      convert.PtrToStatusEnum(filter.Status),
+		Email:       convert.PtrToText(email),
+		CreatedFrom: convert.PtrToTimestamptz(filter.CreatedFrom),
+		CreatedTo:   convert.PtrToTimestamptz(filter.CreatedTo),
+		Limit:       pageSize,
+		Offset:      (page - 1) * pageSize,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	users := make([]*models.User, len(rows))
+	for i, row := range rows {
+		users[i] = r.mapToUser(row)
+	}
+
+	return users, total, nil
+}
+
 func (r *userRepository) Update(ctx context.Context, user *models.User) (int64, error) {
 	params := sqlc.UpdateUserParams{
 		ID:          user.ID,
@@ -121,6 +178,51 @@ func (r *userRepository) UpdatePassword(ctx context.Context, id uuid.UUID, hashe
 	return r.queries(ctx).UpdateUserPassword(ctx, params)
 }
 
+// UpdateEmail marks the new address verified, since the user proved ownership
+// by following the link sent to it.
+func (r *userRepository) UpdateEmail(ctx context.Context, id uuid.UUID, oldEmail, newEmail string) (int64, error) {
+	now := time.Now()
+	params := sqlc.UpdateUserEmailParams{
+		ID:              id,
+		OldEmail:        oldEmail,
+		NewEmail:        newEmail,
+		EmailVerifiedAt: pgtype.Timestamptz{Time: now, Valid: true},
+		UpdatedAt:       now,
+	}
+
+	rows, err := r.queries(ctx).UpdateUserEmail(ctx, params)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+			return 0, repository.ErrEmailTaken
+		}
+		return 0, err
+	}
+
+	return rows, nil
+}
+
+func (r *userRepository) VerifyPhone(ctx context.Context, id uuid.UUID, phone string) (int64, error) {
+	now := time.Now()
+	params := sqlc.VerifyUserPhoneParams{
+		ID:              id,
+		Phone:           convert.PtrToText(&phone),
+		PhoneVerifiedAt: pgtype.Timestamptz{Time: now, Valid: true},
+		UpdatedAt:       now,
+	}
+
+	rows, err := r.queries(ctx).VerifyUserPhone(ctx, params)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+			return 0, repository.ErrPhoneTaken
+		}
+		return 0, err
+	}
+
+	return rows, nil
+}
+
 func (r *userRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status models.UserStatus) (int64, error) {
 	params := sqlc.UpdateUserStatusParams{
 		ID:        id,
@@ -143,6 +245,119 @@ func (r *userRepository) SoftDelete(ctx context.Context, id uuid.UUID) (int64, e
 	return r.queries(ctx).SoftDeleteUser(ctx, params)
 }
 
+func (r *userRepository) ListPendingAnonymization(ctx context.Context, deletedBefore time.Time, limit int32) ([]uuid.UUID, error) {
+	params := sqlc.ListUsersPendingAnonymizationParams{
+		DeletedAt: pgtype.Timestamptz{Time: deletedBefore, Valid: true},
+		Limit:     limit,
+	}
+
+	return r.queries(ctx).ListUsersPendingAnonymization(ctx, params)
+}
+
+func (r *userRepository) Anonymize(ctx context.Context, id uuid.UUID) (int64, error) {
+	params := sqlc.AnonymizeUserParams{
+		ID:           id,
+		AnonymizedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	}
+
+	var rows int64
+	err := r.WithinTransaction(ctx, func(ctx context.Context) error {
+		q := r.queries(ctx)
+
+		var err error
+		rows, err = q.AnonymizeUser(ctx, params)
+		if err != nil || rows == 0 {
+			return err
+		}
+
+		if err := q.DeleteAddressesByUserID(ctx, id); err != nil {
+			return err
+		}
+		if err := q.DeleteRefreshTokensByUserID(ctx, id); err != nil {
+			return err
+		}
+		if err := q.DeleteUserIdentitiesByUserID(ctx, id); err != nil {
+			return err
+		}
+		return q.DeleteRecoveryCodesByUserID(ctx, id)
+	})
+	return rows, err
+}
+
+func (r *userRepository) SetTOTPSecret(ctx context.Context, id uuid.UUID, secret string) (int64, error) {
+	params := sqlc.SetUserTOTPSecretParams{
+		ID:         id,
+		TotpSecret: pgtype.Text{String: secret, Valid: true},
+		UpdatedAt:  time.Now(),
+	}
+
+	return r.queries(ctx).SetUserTOTPSecret(ctx, params)
+}
+
+func (r *userRepository) EnableTOTP(ctx context.Context, id uuid.UUID) (int64, error) {
+	now := time.Now()
+	params := sqlc.EnableUserTOTPParams{
+		ID:            id,
+		TotpEnabledAt: pgtype.Timestamptz{Time: now, Valid: true},
+		UpdatedAt:     now,
+	}
+
+	return r.queries(ctx).EnableUserTOTP(ctx, params)
+}
+
+func (r *userRepository) DisableTOTP(ctx context.Context, id uuid.UUID) (int64, error) {
+	params := sqlc.DisableUserTOTPParams{
+		ID:        id,
+		UpdatedAt: time.Now(),
+	}
+
+	var rows int64
+	err := r.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		rows, err = r.queries(ctx).DisableUserTOTP(ctx, params)
+		if err != nil {
+			return err
+		}
+		return r.queries(ctx).DeleteRecoveryCodesByUserID(ctx, id)
+	})
+	return rows, err
+}
+
+// ReplaceRecoveryCodes drops every existing recovery code of the user and
+// stores the given hashes.
+func (r *userRepository) ReplaceRecoveryCodes(ctx context.Context, id uuid.UUID, codeHashes []string) error {
+	return r.WithinTransaction(ctx, func(ctx context.Context) error {
+		q := r.queries(ctx)
+		if err := q.DeleteRecoveryCodesByUserID(ctx, id); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		for _, hash := range codeHashes {
+			err := q.CreateRecoveryCode(ctx, sqlc.CreateRecoveryCodeParams{
+				ID:        uuid.New(),
+				UserID:    id,
+				CodeHash:  hash,
+				CreatedAt: now,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *userRepository) UseRecoveryCode(ctx context.Context, id uuid.UUID, codeHash string) (int64, error) {
+	params := sqlc.UseRecoveryCodeParams{
+		UserID:   id,
+		CodeHash: codeHash,
+		UsedAt:   pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	}
+
+	return r.queries(ctx).UseRecoveryCode(ctx, params)
+}
+
 func (r *userRepository) mapToUser(row sqlc.User) *models.User {
 	user := &models.User{
 		ID:              row.ID,
@@ -154,7 +369,11 @@ func (r *userRepository) mapToUser(row sqlc.User) *models.User {
 		Gender:          convert.PtrIfValid(models.Gender(row.Gender.UserGenderEnum), row.Gender.Valid),
 		DateOfBirth:     convert.PtrIfValid(row.DateOfBirth.Time, row.DateOfBirth.Valid),
 		EmailVerifiedAt: convert.PtrIfValid(row.EmailVerifiedAt.Time, row.EmailVerifiedAt.Valid),
+		PhoneVerifiedAt: convert.PtrIfValid(row.PhoneVerifiedAt.Time, row.PhoneVerifiedAt.Valid),
+		TOTPSecret:      convert.PtrIfValid(row.TotpSecret.String, row.TotpSecret.Valid),
+		TOTPEnabledAt:   convert.PtrIfValid(row.TotpEnabledAt.Time, row.TotpEnabledAt.Valid),
 		Status:          models.UserStatus(row.Status),
+		Role:            models.UserRole(row.Role),
 		CreatedAt:       row.CreatedAt,
 		UpdatedAt:       row.UpdatedAt,
 	}
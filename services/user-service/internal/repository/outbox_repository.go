@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/khoihuynh300/go-microservice/user-service/internal/domain/models"
+)
+
+type OutboxRepository interface {
+	Repository
+	Create(ctx context.Context, event *models.OutboxEvent) error
+	// TryLock takes the relay lock without holding a transaction open. When
+	// locked is true, unlock must be called once the relay pass is over.
+	TryLock(ctx context.Context) (unlock func(), locked bool, err error)
+	// ListPending returns up to limit due events with an ID above afterID,
+	// leaving out keys whose earlier events are still waiting for a retry.
+	ListPending(ctx context.Context, afterID int64, limit int32) ([]*models.OutboxEvent, error)
+	MarkPublished(ctx context.Context, id int64) (int64, error)
+	MarkFailed(ctx context.Context, id int64, lastError string, nextAttemptAt time.Time) (int64, error)
+	// Park gives up on an event; it is kept for inspection but no longer relayed.
+	Park(ctx context.Context, id int64, lastError string) (int64, error)
+	DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error)
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/khoihuynh300/go-microservice/user-service/internal/domain/models"
@@ -12,4 +13,11 @@ type RefreshTokenRepository interface {
 	Create(ctx context.Context, refreshToken *models.RefreshToken) error
 	GetByToken(ctx context.Context, refreshTokenStr string) (*models.RefreshToken, error)
 	DeleteByID(ctx context.Context, id uuid.UUID) (int64, error)
+	MarkRotated(ctx context.Context, id uuid.UUID) (int64, error)
+	ListSessionsByUserID(ctx context.Context, userID uuid.UUID) ([]*models.Session, error)
+	// ListByFamilyID returns the tokens of a session created after createdAfter.
+	ListByFamilyID(ctx context.Context, familyID uuid.UUID, createdAfter time.Time) ([]*models.RefreshToken, error)
+	RevokeFamily(ctx context.Context, familyID uuid.UUID) (int64, error)
+	RevokeFamilyByUserID(ctx context.Context, familyID uuid.UUID, userID uuid.UUID) (int64, error)
+	RevokeAllByUserID(ctx context.Context, userID uuid.UUID) (int64, error)
 }
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/domain/models"
+)
+
+type UserIdentityRepository interface {
+	Repository
+	Create(ctx context.Context, identity *models.UserIdentity) error
+	GetByProviderSubject(ctx context.Context, provider, subject string) (*models.UserIdentity, error)
+	RecordLogin(ctx context.Context, id uuid.UUID, email string) (int64, error)
+}
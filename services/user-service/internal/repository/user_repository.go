@@ -2,20 +2,56 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/khoihuynh300/go-microservice/user-service/internal/domain/models"
 )
 
+var (
+	ErrEmailTaken = errors.New("email already taken")
+	ErrPhoneTaken = errors.New("phone already taken")
+)
+
+// UserFilter narrows List. Nil fields match every user; Email matches any
+// address containing it, ignoring case.
+type UserFilter struct {
+	Status      *models.UserStatus
+	Email       *string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+}
+
 type UserRepository interface {
 	Repository
 	Create(ctx context.Context, user *models.User) error
 	GetByID(ctx context.Context, id uuid.UUID) (*models.User, error)
 	GetByEmail(ctx context.Context, email string) (*models.User, error)
+	GetByPhone(ctx context.Context, phone string) (*models.User, error)
+	List(ctx context.Context, filter *UserFilter, page, pageSize int32) ([]*models.User, int64, error)
 	Update(ctx context.Context, user *models.User) (int64, error)
 	UpdateAvatar(ctx context.Context, id uuid.UUID, avatarURL string) (int64, error)
 	VerifyEmail(ctx context.Context, id uuid.UUID) (int64, error)
 	UpdatePassword(ctx context.Context, id uuid.UUID, hashedPassword string) (int64, error)
+	// UpdateEmail only applies while the user still has oldEmail and returns
+	// ErrEmailTaken if another active user holds newEmail.
+	UpdateEmail(ctx context.Context, id uuid.UUID, oldEmail, newEmail string) (int64, error)
+	// VerifyPhone sets the user's phone and marks it verified. It returns
+	// ErrPhoneTaken if another active user holds phone.
+	VerifyPhone(ctx context.Context, id uuid.UUID, phone string) (int64, error)
 	UpdateStatus(ctx context.Context, id uuid.UUID, status models.UserStatus) (int64, error)
 	SoftDelete(ctx context.Context, id uuid.UUID) (int64, error)
+	// ListPendingAnonymization returns users soft-deleted before deletedBefore
+	// whose personal data has not been scrubbed yet, oldest first.
+	ListPendingAnonymization(ctx context.Context, deletedBefore time.Time, limit int32) ([]uuid.UUID, error)
+	// Anonymize scrubs the personal data of a soft-deleted user and drops their
+	// addresses, sessions, linked identities and recovery codes.
+	Anonymize(ctx context.Context, id uuid.UUID) (int64, error)
+
+	SetTOTPSecret(ctx context.Context, id uuid.UUID, secret string) (int64, error)
+	EnableTOTP(ctx context.Context, id uuid.UUID) (int64, error)
+	DisableTOTP(ctx context.Context, id uuid.UUID) (int64, error)
+	ReplaceRecoveryCodes(ctx context.Context, id uuid.UUID, codeHashes []string) error
+	UseRecoveryCode(ctx context.Context, id uuid.UUID, codeHash string) (int64, error)
 }
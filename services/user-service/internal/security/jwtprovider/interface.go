@@ -7,7 +7,8 @@ import (
 )
 
 type JwtProvider interface {
-	GenerateAccessToken(user *models.User) (string, error)
+	// GenerateAccessToken returns the signed token and its jti.
+	GenerateAccessToken(user *models.User) (token string, tokenID string, err error)
 	GenerateRefreshToken(userID string) (string, error)
 
 	VerifyAccessToken(token string) (*AccessTokenClaims, error)
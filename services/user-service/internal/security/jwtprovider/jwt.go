@@ -5,10 +5,12 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/khoihuynh300/go-microservice/user-service/internal/domain/models"
 )
 
 type AccessTokenClaims struct {
+	Role string `json:"role"`
 	jwt.RegisteredClaims
 }
 
@@ -17,7 +19,7 @@ type RefreshTokenClaims struct {
 }
 
 type JwtService struct {
-	access_secret  []byte
+	access_keys    *KeySet
 	access_ttl     time.Duration
 	refresh_secret []byte
 	refresh_ttl    time.Duration
@@ -28,28 +30,37 @@ var (
 	ErrTokenInvalid = errors.New("token invalid")
 )
 
-func NewJwtService(accessSecret string, accessTTL time.Duration, refreshSecret string, refreshTTL time.Duration) JwtProvider {
+func NewJwtService(accessKeys *KeySet, accessTTL time.Duration, refreshSecret string, refreshTTL time.Duration) JwtProvider {
 	return &JwtService{
-		access_secret:  []byte(accessSecret),
+		access_keys:    accessKeys,
 		access_ttl:     accessTTL,
 		refresh_secret: []byte(refreshSecret),
 		refresh_ttl:    refreshTTL,
 	}
 }
 
-func (s *JwtService) GenerateAccessToken(user *models.User) (string, error) {
+func (s *JwtService) GenerateAccessToken(user *models.User) (string, string, error) {
 	now := time.Now()
+	tokenID := uuid.New().String()
 
 	claims := AccessTokenClaims{
+		Role: string(user.Role),
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID,
 			Subject:   user.ID.String(),
 			IssuedAt:  jwt.NewNumericDate(now),
 			ExpiresAt: jwt.NewNumericDate(now.Add(s.access_ttl)),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(s.access_secret)
+	key := s.access_keys.signing
+	token := jwt.NewWithClaims(key.method, claims)
+	token.Header["kid"] = key.kid
+	signed, err := token.SignedString(key.private)
+	if err != nil {
+		return "", "", err
+	}
+	return signed, tokenID, nil
 }
 
 func (s *JwtService) GenerateRefreshToken(userID string) (string, error) {
@@ -57,6 +68,7 @@ func (s *JwtService) GenerateRefreshToken(userID string) (string, error) {
 
 	claims := RefreshTokenClaims{
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
 			Subject:   userID,
 			IssuedAt:  jwt.NewNumericDate(now),
 			ExpiresAt: jwt.NewNumericDate(now.Add(s.refresh_ttl)),
@@ -70,10 +82,12 @@ func (s *JwtService) GenerateRefreshToken(userID string) (string, error) {
 
 func (s *JwtService) VerifyAccessToken(tokenString string) (*AccessTokenClaims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &AccessTokenClaims{}, func(token *jwt.Token) (any, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		kid, _ := token.Header["kid"].(string)
+		key, ok := s.access_keys.keys[kid]
+		if !ok || token.Method.Alg() != key.method.Alg() {
 			return nil, ErrTokenInvalid
 		}
-		return s.access_secret, nil
+		return key.private.Public(), nil
 	})
 
 	if err != nil || !token.Valid {
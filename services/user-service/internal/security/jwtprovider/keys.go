@@ -0,0 +1,158 @@
+package jwtprovider
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/jwks"
+)
+
+const KeyFileExt = ".pem"
+
+var ErrSigningKeyNotFound = errors.New("signing key not found")
+
+type signingKey struct {
+	kid     string
+	method  jwt.SigningMethod
+	private crypto.Signer
+}
+
+// KeySet holds every key in the keys directory. Only the signing key issues
+// tokens; the others are still published so tokens they signed keep
+// verifying until they expire.
+type KeySet struct {
+	signing *signingKey
+	keys    map[string]*signingKey
+}
+
+// LoadKeySet loads the keys in dir and selects signingKeyID for issuing tokens.
+func LoadKeySet(dir, signingKeyID string) (*KeySet, error) {
+	ks, err := LoadKeys(dir)
+	if err != nil {
+		return nil, err
+	}
+	if err := ks.SetSigningKey(signingKeyID); err != nil {
+		return nil, err
+	}
+	return ks, nil
+}
+
+// LoadKeys reads PKCS#8 PEM private keys named <kid>.pem from dir.
+func LoadKeys(dir string) (*KeySet, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+KeyFileExt))
+	if err != nil {
+		return nil, err
+	}
+
+	ks := &KeySet{keys: make(map[string]*signingKey, len(paths))}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read key %s: %w", path, err)
+		}
+		kid := strings.TrimSuffix(filepath.Base(path), KeyFileExt)
+		if err := ks.Add(kid, data); err != nil {
+			return nil, fmt.Errorf("failed to load key %s: %w", path, err)
+		}
+	}
+	return ks, nil
+}
+
+// Add parses a PKCS#8 PEM private key and adds it under kid.
+func (ks *KeySet) Add(kid string, pemData []byte) error {
+	block, _ := pem.Decode(pemData)
+	if block == nil {
+		return errors.New("no PEM block found")
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return err
+	}
+
+	var method jwt.SigningMethod
+	switch parsed.(type) {
+	case *rsa.PrivateKey:
+		method = jwt.SigningMethodRS256
+	case ed25519.PrivateKey:
+		method = jwt.SigningMethodEdDSA
+	default:
+		return jwks.ErrUnsupportedKey
+	}
+
+	if ks.keys == nil {
+		ks.keys = make(map[string]*signingKey)
+	}
+	ks.keys[kid] = &signingKey{kid: kid, method: method, private: parsed.(crypto.Signer)}
+	return nil
+}
+
+func (ks *KeySet) SetSigningKey(kid string) error {
+	key, ok := ks.keys[kid]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrSigningKeyNotFound, kid)
+	}
+	ks.signing = key
+	return nil
+}
+
+func (ks *KeySet) SigningKeyID() string {
+	if ks.signing == nil {
+		return ""
+	}
+	return ks.signing.kid
+}
+
+// JWKS returns the public half of every loaded key, ordered by kid.
+func (ks *KeySet) JWKS() (jwks.Set, error) {
+	kids := make([]string, 0, len(ks.keys))
+	for kid := range ks.keys {
+		kids = append(kids, kid)
+	}
+	sort.Strings(kids)
+
+	set := jwks.Set{Keys: make([]jwks.Key, 0, len(kids))}
+	for _, kid := range kids {
+		key, err := jwks.FromPublicKey(kid, ks.keys[kid].private.Public())
+		if err != nil {
+			return jwks.Set{}, err
+		}
+		set.Keys = append(set.Keys, key)
+	}
+	return set, nil
+}
+
+// GenerateKey creates a PKCS#8 PEM private key for alg (RS256 or EdDSA).
+func GenerateKey(alg string) ([]byte, error) {
+	var (
+		key any
+		err error
+	)
+	switch alg {
+	case jwks.AlgRS256:
+		key, err = rsa.GenerateKey(rand.Reader, 2048)
+	case jwks.AlgEdDSA:
+		_, key, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return nil, fmt.Errorf("%w: %q", jwks.ErrUnsupportedKey, alg)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
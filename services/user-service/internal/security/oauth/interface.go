@@ -0,0 +1,20 @@
+package oauth
+
+import "context"
+
+// Identity is what a provider asserts about the signed-in account.
+type Identity struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+type Provider interface {
+	Name() string
+	// AuthCodeURL builds the authorization request. The verifier never leaves
+	// the server; only its S256 challenge is sent.
+	AuthCodeURL(state, nonce, verifier string) string
+	// Exchange redeems the authorization code and verifies the returned ID token.
+	Exchange(ctx context.Context, code, verifier, nonce string) (*Identity, error)
+}
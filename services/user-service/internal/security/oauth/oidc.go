@@ -0,0 +1,134 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+var (
+	ErrProviderNotFound = errors.New("oauth provider not found")
+	ErrMissingIDToken   = errors.New("token response has no id_token")
+	ErrNonceMismatch    = errors.New("id token nonce mismatch")
+)
+
+type Config struct {
+	Name         string
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	// Scopes are requested in addition to openid, email and profile.
+	Scopes []string
+}
+
+type oidcProvider struct {
+	name     string
+	oauth2   *oauth2.Config
+	verifier *oidc.IDTokenVerifier
+}
+
+// NewOIDCProvider runs OIDC discovery against the issuer, so the provider must
+// be reachable at startup.
+func NewOIDCProvider(ctx context.Context, cfg Config) (Provider, error) {
+	provider, err := oidc.NewProvider(ctx, cfg.IssuerURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover oauth provider %s: %w", cfg.Name, err)
+	}
+
+	scopes := append([]string{oidc.ScopeOpenID, "email", "profile"}, cfg.Scopes...)
+
+	return &oidcProvider{
+		name: cfg.Name,
+		oauth2: &oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.RedirectURL,
+			Endpoint:     provider.Endpoint(),
+			Scopes:       scopes,
+		},
+		verifier: provider.Verifier(&oidc.Config{ClientID: cfg.ClientID}),
+	}, nil
+}
+
+func (p *oidcProvider) Name() string {
+	return p.name
+}
+
+func (p *oidcProvider) AuthCodeURL(state, nonce, verifier string) string {
+	return p.oauth2.AuthCodeURL(state, oidc.Nonce(nonce), oauth2.S256ChallengeOption(verifier))
+}
+
+func (p *oidcProvider) Exchange(ctx context.Context, code, verifier, nonce string) (*Identity, error) {
+	token, err := p.oauth2.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange authorization code: %w", err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return nil, ErrMissingIDToken
+	}
+
+	idToken, err := p.verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify id token: %w", err)
+	}
+	if idToken.Nonce != nonce {
+		return nil, ErrNonceMismatch
+	}
+
+	var claims struct {
+		Email         string `json:"email"`
+		EmailVerified bool   `json:"email_verified"`
+		Name          string `json:"name"`
+	}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("failed to parse id token claims: %w", err)
+	}
+
+	return &Identity{
+		Subject:       idToken.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+		Name:          claims.Name,
+	}, nil
+}
+
+// GenerateVerifier returns a fresh PKCE code verifier.
+func GenerateVerifier() string {
+	return oauth2.GenerateVerifier()
+}
+
+type Registry struct {
+	providers map[string]Provider
+}
+
+func NewRegistry(providers ...Provider) *Registry {
+	r := &Registry{providers: make(map[string]Provider, len(providers))}
+	for _, p := range providers {
+		r.providers[p.Name()] = p
+	}
+	return r
+}
+
+func (r *Registry) Get(name string) (Provider, error) {
+	p, ok := r.providers[name]
+	if !ok {
+		return nil, ErrProviderNotFound
+	}
+	return p, nil
+}
+
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.providers))
+	for name := range r.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
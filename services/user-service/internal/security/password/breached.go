@@ -0,0 +1,91 @@
+package passwordhasher
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// hashPrefixLen matches the range API of Have I Been Pwned, so the same
+// lookup works against a downloaded list or the online service.
+const hashPrefixLen = 5
+
+type BreachedPasswordChecker interface {
+	IsBreached(password string) bool
+}
+
+// HashPrefixList is an offline breached-password list. Hashes are bucketed
+// by their first five hex characters and a lookup only scans the bucket of
+// the candidate's prefix, the same way a k-anonymity range query does.
+type HashPrefixList struct {
+	buckets map[string][]string
+}
+
+// LoadHashPrefixList reads a list in the Pwned Passwords download format:
+// one upper-case SHA-1 hash per line, optionally followed by ":<count>".
+// Blank lines and lines starting with "#" are skipped.
+func LoadHashPrefixList(path string) (*HashPrefixList, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ReadHashPrefixList(f)
+}
+
+func ReadHashPrefixList(r io.Reader) (*HashPrefixList, error) {
+	list := &HashPrefixList{buckets: make(map[string][]string)}
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		hash, _, _ := strings.Cut(text, ":")
+		hash = strings.ToUpper(hash)
+		if len(hash) != sha1.Size*2 {
+			return nil, fmt.Errorf("line %d: invalid sha-1 hash %q", line, hash)
+		}
+		if _, err := hex.DecodeString(hash); err != nil {
+			return nil, fmt.Errorf("line %d: invalid sha-1 hash %q", line, hash)
+		}
+
+		prefix, suffix := hash[:hashPrefixLen], hash[hashPrefixLen:]
+		list.buckets[prefix] = append(list.buckets[prefix], suffix)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, suffixes := range list.buckets {
+		sort.Strings(suffixes)
+	}
+	return list, nil
+}
+
+func (l *HashPrefixList) IsBreached(password string) bool {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	suffixes := l.buckets[hash[:hashPrefixLen]]
+	suffix := hash[hashPrefixLen:]
+	i := sort.SearchStrings(suffixes, suffix)
+	return i < len(suffixes) && suffixes[i] == suffix
+}
+
+// Len returns the number of hashes in the list.
+func (l *HashPrefixList) Len() int {
+	n := 0
+	for _, suffixes := range l.buckets {
+		n += len(suffixes)
+	}
+	return n
+}
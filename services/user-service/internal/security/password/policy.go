@@ -0,0 +1,113 @@
+package passwordhasher
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	apperr "github.com/khoihuynh300/go-microservice/shared/pkg/errors"
+)
+
+// minPersonalInfoLen keeps short name parts such as "An" from rejecting
+// every password that happens to contain them.
+const minPersonalInfoLen = 3
+
+type PasswordPolicy interface {
+	// Validate checks a new password for the account with the given email and
+	// name. Every broken rule is reported as a detail on field.
+	Validate(field, password, email, fullName string) error
+}
+
+type PolicyConfig struct {
+	MinLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+}
+
+type Policy struct {
+	cfg      PolicyConfig
+	breached BreachedPasswordChecker
+}
+
+// NewPolicy builds a policy from cfg. breached may be nil to skip the
+// breached-password check.
+func NewPolicy(cfg PolicyConfig, breached BreachedPasswordChecker) *Policy {
+	return &Policy{
+		cfg:      cfg,
+		breached: breached,
+	}
+}
+
+func (p *Policy) Validate(field, password, email, fullName string) error {
+	var details []apperr.ErrorDetail
+	violate := func(code, message string) {
+		details = append(details, apperr.ErrorDetail{
+			Field:   field,
+			Code:    code,
+			Message: message,
+		})
+	}
+
+	if utf8.RuneCountInString(password) < p.cfg.MinLength {
+		violate(apperr.CodePasswordTooShort, fmt.Sprintf("Password must be at least %d characters", p.cfg.MinLength))
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case !unicode.IsLetter(r):
+			hasSymbol = true
+		}
+	}
+	if p.cfg.RequireUpper && !hasUpper {
+		violate(apperr.CodePasswordMissingUppercase, "Password must contain an uppercase letter")
+	}
+	if p.cfg.RequireLower && !hasLower {
+		violate(apperr.CodePasswordMissingLowercase, "Password must contain a lowercase letter")
+	}
+	if p.cfg.RequireDigit && !hasDigit {
+		violate(apperr.CodePasswordMissingDigit, "Password must contain a digit")
+	}
+	if p.cfg.RequireSymbol && !hasSymbol {
+		violate(apperr.CodePasswordMissingSymbol, "Password must contain a symbol")
+	}
+
+	if containsPersonalInfo(password, email, fullName) {
+		violate(apperr.CodePasswordContainsPersonalInfo, "Password must not contain your email or name")
+	}
+
+	if p.breached != nil && p.breached.IsBreached(password) {
+		violate(apperr.CodePasswordBreached, "Password has appeared in a data breach, choose a different one")
+	}
+
+	if len(details) > 0 {
+		return apperr.NewErrValidationFailed(details)
+	}
+	return nil
+}
+
+func containsPersonalInfo(password, email, fullName string) bool {
+	password = strings.ToLower(password)
+
+	fragments := strings.Fields(strings.ToLower(fullName))
+	fragments = append(fragments, strings.Join(fragments, ""))
+	if local, _, ok := strings.Cut(strings.ToLower(email), "@"); ok {
+		fragments = append(fragments, local)
+	}
+
+	for _, fragment := range fragments {
+		if utf8.RuneCountInString(fragment) >= minPersonalInfoLen && strings.Contains(password, fragment) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,9 @@
+package totp
+
+import "time"
+
+type TOTPProvider interface {
+	GenerateSecret() (string, error)
+	URI(secret, accountName string) string
+	Validate(secret, code string, at time.Time) bool
+}
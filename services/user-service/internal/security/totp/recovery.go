@@ -0,0 +1,46 @@
+package totp
+
+import (
+	"crypto/rand"
+	"strings"
+)
+
+const (
+	RecoveryCodeCount = 10
+
+	recoveryCodeLength = 10
+	// Crockford's base32: 32 symbols so every random byte maps without bias
+	recoveryCodeAlphabet = "0123456789abcdefghjkmnpqrstvwxyz"
+)
+
+// GenerateRecoveryCodes returns n codes formatted as xxxxx-xxxxx.
+func GenerateRecoveryCodes(n int) ([]string, error) {
+	codes := make([]string, n)
+	buf := make([]byte, recoveryCodeLength)
+	for i := range codes {
+		if _, err := rand.Read(buf); err != nil {
+			return nil, err
+		}
+		var sb strings.Builder
+		for j, b := range buf {
+			if j == recoveryCodeLength/2 {
+				sb.WriteByte('-')
+			}
+			sb.WriteByte(recoveryCodeAlphabet[b&31])
+		}
+		codes[i] = sb.String()
+	}
+	return codes, nil
+}
+
+// NormalizeRecoveryCode strips separators and case so users can type the
+// code however it was written down.
+func NormalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, code)
+}
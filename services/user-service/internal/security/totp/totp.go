@@ -0,0 +1,93 @@
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// RFC 6238 parameters understood by every common authenticator app.
+const (
+	secretSize = 20
+	digits     = 6
+	period     = 30 * time.Second
+	// skew accepts codes from one step before and after the current one
+	skew = 1
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+type Provider struct {
+	issuer string
+}
+
+func NewProvider(issuer string) *Provider {
+	return &Provider{issuer: issuer}
+}
+
+func (p *Provider) GenerateSecret() (string, error) {
+	b := make([]byte, secretSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return encoding.EncodeToString(b), nil
+}
+
+func (p *Provider) URI(secret, accountName string) string {
+	label := url.PathEscape(p.issuer + ":" + accountName)
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", p.issuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprint(digits))
+	params.Set("period", fmt.Sprint(int(period.Seconds())))
+	return "otpauth://totp/" + label + "?" + params.Encode()
+}
+
+func (p *Provider) Validate(secret, code string, at time.Time) bool {
+	if len(code) != digits {
+		return false
+	}
+	key, err := encoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return false
+	}
+
+	step := at.Unix() / int64(period.Seconds())
+	for i := -skew; i <= skew; i++ {
+		expected := generate(key, uint64(step+int64(i)))
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// Code returns the code for secret at the given time.
+func Code(secret string, at time.Time) (string, error) {
+	key, err := encoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", err
+	}
+	return generate(key, uint64(at.Unix()/int64(period.Seconds()))), nil
+}
+
+func generate(key []byte, counter uint64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], counter)
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", digits, value%1_000_000)
+}
@@ -2,27 +2,40 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
+	"net/http"
+	"sync"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/khoihuynh300/go-microservice/shared/pkg/cache"
 	"github.com/khoihuynh300/go-microservice/shared/pkg/interceptor"
 	"github.com/khoihuynh300/go-microservice/shared/pkg/messaging/kafka"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/metrics"
 	"github.com/khoihuynh300/go-microservice/shared/pkg/storage"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/telemetry"
 	userpb "github.com/khoihuynh300/go-microservice/shared/proto/user"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/audit"
 	"github.com/khoihuynh300/go-microservice/user-service/internal/caching"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/client"
 	"github.com/khoihuynh300/go-microservice/user-service/internal/config"
 	"github.com/khoihuynh300/go-microservice/user-service/internal/events/publisher"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/events/relay"
 	grpchandler "github.com/khoihuynh300/go-microservice/user-service/internal/handler/grpc"
+	httphandler "github.com/khoihuynh300/go-microservice/user-service/internal/handler/http"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/jobs"
 	"github.com/khoihuynh300/go-microservice/user-service/internal/repository/impl"
 	"github.com/khoihuynh300/go-microservice/user-service/internal/security/jwtprovider"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/security/oauth"
 	passwordhasher "github.com/khoihuynh300/go-microservice/user-service/internal/security/password"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/security/totp"
 	"github.com/khoihuynh300/go-microservice/user-service/internal/service"
 	"go.uber.org/zap"
 	"golang.org/x/crypto/bcrypt"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/health"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
@@ -30,8 +43,16 @@ import (
 
 type Server struct {
 	grpcServer    *grpc.Server
+	jwksServer    *http.Server
 	logger        *zap.Logger
 	dbPool        *pgxpool.Pool
+	producer      kafka.Producer
+	orderConn     *grpc.ClientConn
+	outboxRelay   *relay.OutboxRelay
+	anonymizer    *jobs.AccountAnonymizer
+	auditPruner   *jobs.AuditLogPruner
+	jobsCancel    context.CancelFunc
+	jobsDone      sync.WaitGroup
 	healthHandler *health.Server
 }
 
@@ -41,17 +62,42 @@ func New(logger *zap.Logger) (*Server, error) {
 		return nil, fmt.Errorf("failed to init db: %w", err)
 	}
 
+	accessKeys, err := jwtprovider.LoadKeySet(config.GetJwtKeysDir(), config.GetJwtSigningKeyID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load jwt keys: %w", err)
+	}
+	jwksHandler, err := httphandler.NewJWKSHandler(accessKeys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build jwks: %w", err)
+	}
+	logger.Info("jwt signing key loaded", zap.String("kid", accessKeys.SigningKeyID()))
+
 	hasher := passwordhasher.NewBcryptHasher(bcrypt.DefaultCost)
+	passwordPolicy, err := initPasswordPolicy(logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load breached passwords: %w", err)
+	}
+	totpProvider := totp.NewProvider(config.GetTOTPIssuer())
 	jwtService := jwtprovider.NewJwtService(
-		config.GetJwtAccessSecret(),
+		accessKeys,
 		config.GetAccessTokenTTL(),
 		config.GetJwtRefreshSecret(),
 		config.GetRefreshTokenTTL(),
 	)
 
+	oauthProviders, err := initOAuthProviders()
+	if err != nil {
+		return nil, err
+	}
+	logger.Info("oauth providers loaded", zap.Strings("providers", oauthProviders.Names()))
+
 	userRepository := impl.NewUserRepository(dbpool)
 	refreshTokenRepository := impl.NewRefreshTokenRepository(dbpool)
+	identityRepository := impl.NewUserIdentityRepository(dbpool)
 	addressRepository := impl.NewAddressRepository(dbpool)
+	outboxRepository := impl.NewOutboxRepository(dbpool)
+	auditLogRepository := impl.NewAuditLogRepository(dbpool)
+	auditLog := audit.NewRecorder(auditLogRepository)
 
 	redis, err := cache.NewClient(&cache.Config{
 		Host:     config.GetRedisHost(),
@@ -63,9 +109,27 @@ func New(logger *zap.Logger) (*Server, error) {
 		return nil, fmt.Errorf("failed to init redis: %w", err)
 	}
 	tokenCache := caching.NewTokenCache(redis)
+	tokenRevocation := caching.NewTokenRevocationCache(redis, config.GetAccessTokenTTL())
+	phoneOTP := caching.NewPhoneOTPCache(redis)
+	loginAttempts := caching.NewLoginAttemptCache(redis, caching.LoginAttemptPolicy{
+		MaxFailures:     config.GetLoginMaxFailures(),
+		DelayAfter:      config.GetLoginDelayAfter(),
+		BaseDelay:       config.GetLoginBaseDelay(),
+		MaxDelay:        config.GetLoginMaxDelay(),
+		LockoutDuration: config.GetLoginLockoutDuration(),
+		Window:          config.GetLoginFailureWindow(),
+		IPMaxFailures:   config.GetLoginIPMaxFailures(),
+	})
 
 	producer := kafka.NewProducer(config.GetKafkaBrokers())
-	eventPublisher := publisher.NewKafkaEventPublisher(producer)
+	producer.SetContentType(config.GetKafkaContentType())
+	eventPublisher := publisher.NewKafkaEventPublisher(outboxRepository)
+	outboxRelay := relay.NewOutboxRelay(outboxRepository, producer, logger, relay.Config{
+		PollInterval: config.GetOutboxPollInterval(),
+		BatchSize:    config.GetOutboxBatchSize(),
+		Retention:    config.GetOutboxRetention(),
+		MaxAttempts:  config.GetOutboxMaxAttempts(),
+	})
 
 	minioStorage, err := storage.NewMinIOStorage(storage.MinIOConfig{
 		Endpoint:   config.GetMinIOEndpoint(),
@@ -77,27 +141,88 @@ func New(logger *zap.Logger) (*Server, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to init minio storage: %w", err)
 	}
+	exportStorage, err := storage.NewMinIOStorage(storage.MinIOConfig{
+		Endpoint:   config.GetMinIOEndpoint(),
+		AccessKey:  config.GetMinIOAccessKey(),
+		SecretKey:  config.GetMinIOSecretKey(),
+		BucketName: config.GetMinIOExportBucketName(),
+		UseSSL:     config.GetMinIOUseSSL(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to init export storage: %w", err)
+	}
+
+	var orderConn *grpc.ClientConn
+	var orderClient client.OrderClient
+	if url := config.GetOrderServiceURL(); url != "" {
+		orderConn, err = initClientConn(url)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect order service: %w", err)
+		}
+		orderClient = client.NewOrderClient(orderConn)
+	}
 
 	authService := service.NewAuthService(
 		userRepository,
 		refreshTokenRepository,
+		identityRepository,
 		tokenCache,
+		loginAttempts,
+		tokenRevocation,
 		hasher,
+		passwordPolicy,
+		totpProvider,
+		oauthProviders,
 		jwtService,
 		eventPublisher,
+		auditLog,
 	)
-	userService := service.NewUserService(userRepository, minioStorage)
+	userService := service.NewUserService(userRepository, phoneOTP, minioStorage, eventPublisher)
 	addressService := service.NewAddressService(userRepository, addressRepository)
+	accountService := service.NewAccountService(
+		userRepository,
+		addressRepository,
+		refreshTokenRepository,
+		tokenRevocation,
+		hasher,
+		minioStorage,
+		exportStorage,
+		orderClient,
+		eventPublisher,
+		auditLog,
+	)
+	adminService := service.NewAdminService(
+		userRepository,
+		refreshTokenRepository,
+		tokenCache,
+		tokenRevocation,
+		hasher,
+		eventPublisher,
+		auditLog,
+	)
+	auditService := service.NewAuditService(auditLogRepository)
+	anonymizer := jobs.NewAccountAnonymizer(userRepository, logger, jobs.AnonymizerConfig{
+		Interval:    config.GetAccountAnonymizeInterval(),
+		GracePeriod: config.GetAccountDeletionGracePeriod(),
+		BatchSize:   config.GetAccountAnonymizeBatchSize(),
+	})
+	auditPruner := jobs.NewAuditLogPruner(auditLogRepository, logger, jobs.AuditLogPrunerConfig{
+		Interval:  config.GetAuditLogPruneInterval(),
+		Retention: config.GetAuditLogRetention(),
+		BatchSize: config.GetAuditLogPruneBatchSize(),
+	})
 
 	healthHandler := health.NewServer()
-	userHandler := grpchandler.NewUserHandler(authService, userService, addressService)
+	userHandler := grpchandler.NewUserHandler(authService, userService, addressService, accountService, adminService, auditService)
 
 	grpcServer := grpc.NewServer(
 		grpc.ChainUnaryInterceptor(
 			interceptor.TracingInterceptor(logger),
+			interceptor.MetricsInterceptor(),
 			interceptor.RecoveryUnaryInterceptor(),
 			interceptor.LoggingUnaryInterceptor(),
 			interceptor.AuthInterceptor(),
+			interceptor.AuthorizationInterceptor(),
 			interceptor.ValidationUnaryInterceptor(),
 			interceptor.ErrorHandlerInterceptor(),
 		),
@@ -110,10 +235,24 @@ func New(logger *zap.Logger) (*Server, error) {
 		reflection.Register(grpcServer)
 	}
 
+	jwksMux := http.NewServeMux()
+	jwksMux.Handle(httphandler.JWKSPath, jwksHandler)
+	jwksServer := &http.Server{
+		Addr:              config.GetJWKSAddr(),
+		Handler:           jwksMux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
 	return &Server{
 		grpcServer:    grpcServer,
+		jwksServer:    jwksServer,
 		logger:        logger,
 		dbPool:        dbpool,
+		producer:      producer,
+		orderConn:     orderConn,
+		outboxRelay:   outboxRelay,
+		anonymizer:    anonymizer,
+		auditPruner:   auditPruner,
 		healthHandler: healthHandler,
 	}, nil
 }
@@ -127,19 +266,70 @@ func (s *Server) Run() error {
 	s.logger.Info("user service listening on", zap.String("addr", config.GetGRPCAddr()))
 	s.healthHandler.SetServingStatus(config.GetServiceName(), healthpb.HealthCheckResponse_SERVING)
 
+	jobsCtx, cancel := context.WithCancel(context.Background())
+	s.jobsCancel = cancel
+	s.jobsDone.Add(3)
+	go func() {
+		defer s.jobsDone.Done()
+		s.outboxRelay.Run(jobsCtx)
+	}()
+	go func() {
+		defer s.jobsDone.Done()
+		s.anonymizer.Run(jobsCtx)
+	}()
+	go func() {
+		defer s.jobsDone.Done()
+		s.auditPruner.Run(jobsCtx)
+	}()
+
+	go func() {
+		s.logger.Info("jwks server listening on", zap.String("addr", s.jwksServer.Addr))
+		if err := s.jwksServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Error("jwks server failed", zap.Error(err))
+		}
+	}()
+
 	return s.grpcServer.Serve(lis)
 }
 
 func (s *Server) GracefulStop() {
 	s.healthHandler.SetServingStatus(config.GetServiceName(), healthpb.HealthCheckResponse_NOT_SERVING)
 	s.grpcServer.GracefulStop()
-	if s.dbPool != nil {
-		s.dbPool.Close()
-	}
+	s.stopJWKS(context.Background())
+	s.stopJobs()
+	s.close()
 }
 
 func (s *Server) Stop() {
 	s.grpcServer.Stop()
+	s.jwksServer.Close()
+	s.stopJobs()
+	s.close()
+}
+
+func (s *Server) stopJWKS(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if err := s.jwksServer.Shutdown(ctx); err != nil {
+		s.logger.Error("failed to stop jwks server", zap.Error(err))
+	}
+}
+
+func (s *Server) stopJobs() {
+	if s.jobsCancel == nil {
+		return
+	}
+	s.jobsCancel()
+	s.jobsDone.Wait()
+}
+
+func (s *Server) close() {
+	if s.producer != nil {
+		s.producer.Close()
+	}
+	if s.orderConn != nil {
+		s.orderConn.Close()
+	}
 	if s.dbPool != nil {
 		s.dbPool.Close()
 	}
@@ -149,12 +339,77 @@ func initDB(dbURL string) (*pgxpool.Pool, error) {
 	dbCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	pool, err := pgxpool.New(dbCtx, dbURL)
+	poolConfig, err := pgxpool.ParseConfig(dbURL)
+	if err != nil {
+		return nil, err
+	}
+	poolConfig.ConnConfig.Tracer = telemetry.NewPgxTracer()
+
+	pool, err := pgxpool.NewWithConfig(dbCtx, poolConfig)
 	if err != nil {
 		return nil, err
 	}
 	if err := pool.Ping(dbCtx); err != nil {
 		return nil, err
 	}
+	if err := metrics.RegisterPgxPool(pool); err != nil {
+		pool.Close()
+		return nil, err
+	}
 	return pool, nil
 }
+
+func initOAuthProviders() (*oauth.Registry, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var providers []oauth.Provider
+	for _, cfg := range config.GetOAuthProviders() {
+		provider, err := oauth.NewOIDCProvider(ctx, oauth.Config{
+			Name:         cfg.Name,
+			IssuerURL:    cfg.IssuerURL,
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  config.GetOAuthRedirectURL(cfg.Name),
+			Scopes:       cfg.Scopes,
+		})
+		if err != nil {
+			return nil, err
+		}
+		providers = append(providers, provider)
+	}
+	return oauth.NewRegistry(providers...), nil
+}
+
+func initPasswordPolicy(logger *zap.Logger) (*passwordhasher.Policy, error) {
+	cfg := passwordhasher.PolicyConfig{
+		MinLength:     config.GetPasswordMinLength(),
+		RequireUpper:  config.GetPasswordRequireUpper(),
+		RequireLower:  config.GetPasswordRequireLower(),
+		RequireDigit:  config.GetPasswordRequireDigit(),
+		RequireSymbol: config.GetPasswordRequireSymbol(),
+	}
+
+	path := config.GetBreachedPasswordsFile()
+	if path == "" {
+		logger.Warn("breached password check disabled, BREACHED_PASSWORDS_FILE is not set")
+		return passwordhasher.NewPolicy(cfg, nil), nil
+	}
+
+	breached, err := passwordhasher.LoadHashPrefixList(path)
+	if err != nil {
+		return nil, err
+	}
+	logger.Info("breached password list loaded", zap.Int("hashes", breached.Len()))
+	return passwordhasher.NewPolicy(cfg, breached), nil
+}
+
+func initClientConn(target string) (*grpc.ClientConn, error) {
+	return grpc.NewClient(target,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(
+			interceptor.TracingClientInterceptor(),
+			interceptor.MetadataForwardingClientInterceptor(),
+		),
+	)
+}
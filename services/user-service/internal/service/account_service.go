@@ -0,0 +1,10 @@
+package service
+
+import (
+	"context"
+)
+
+type AccountService interface {
+	DeleteAccount(ctx context.Context, userID string, password string) error
+	ExportMyData(ctx context.Context, userID string) error
+}
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	apperr "github.com/khoihuynh300/go-microservice/shared/pkg/errors"
+	zaplogger "github.com/khoihuynh300/go-microservice/shared/pkg/logger"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/storage"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/audit"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/caching"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/client"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/domain/models"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/dto/response"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/events/publisher"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/repository"
+	passwordhasher "github.com/khoihuynh300/go-microservice/user-service/internal/security/password"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	DataExportFolder  = "exports"
+	DataExportLinkTTL = 24 * time.Hour
+	// largest page the order service accepts
+	dataExportOrderPageSize = 100
+)
+
+type accountService struct {
+	userRepo         repository.UserRepository
+	addressRepo      repository.AddressRepository
+	refreshTokenRepo repository.RefreshTokenRepository
+	tokenRevocation  *caching.TokenRevocationCache
+	passwordHasher   passwordhasher.PasswordHasher
+	imageStorage     storage.Storage
+	exportStorage    storage.Storage
+	orderClient      client.OrderClient
+	eventPublisher   publisher.EventPublisher
+	auditLog         *audit.Recorder
+}
+
+// NewAccountService builds the service behind account deletion and personal
+// data export. orderClient may be nil, in which case exports carry no orders.
+func NewAccountService(
+	userRepo repository.UserRepository,
+	addressRepo repository.AddressRepository,
+	refreshTokenRepo repository.RefreshTokenRepository,
+	tokenRevocation *caching.TokenRevocationCache,
+	passwordHasher passwordhasher.PasswordHasher,
+	imageStorage storage.Storage,
+	exportStorage storage.Storage,
+	orderClient client.OrderClient,
+	eventPublisher publisher.EventPublisher,
+	auditLog *audit.Recorder,
+) AccountService {
+	return &accountService{
+		userRepo:         userRepo,
+		addressRepo:      addressRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		tokenRevocation:  tokenRevocation,
+		passwordHasher:   passwordHasher,
+		imageStorage:     imageStorage,
+		exportStorage:    exportStorage,
+		orderClient:      orderClient,
+		eventPublisher:   eventPublisher,
+		auditLog:         auditLog,
+	}
+}
+
+// DeleteAccount soft-deletes the user and signs them out everywhere. Their
+// personal data is scrubbed later by the anonymizer, once the grace period
+// has passed.
+func (s *accountService) DeleteAccount(ctx context.Context, userID string, password string) error {
+	logger := zaplogger.FromContext(ctx)
+
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if !s.passwordHasher.Compare(user.HashedPassword, password) {
+		logger.Warn("Delete account failed: invalid password", zap.String("user_id", userID))
+		s.auditLog.Record(ctx, models.AuditEventAccountDeleted, &user.ID, apperr.ErrInvalidCurrentPassword)
+		return apperr.ErrInvalidCurrentPassword
+	}
+
+	err = s.userRepo.WithinTransaction(ctx, func(ctx context.Context) error {
+		rowEffected, err := s.userRepo.SoftDelete(ctx, user.ID)
+		if err != nil {
+			return err
+		}
+		if rowEffected == 0 {
+			return apperr.ErrUserNotFound
+		}
+
+		_, err = s.refreshTokenRepo.RevokeAllByUserID(ctx, user.ID)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := s.tokenRevocation.RevokeUserTokens(ctx, userID); err != nil {
+		return err
+	}
+
+	if user.AvatarURL != nil && *user.AvatarURL != "" {
+		if err := s.imageStorage.Delete(ctx, *user.AvatarURL); err != nil {
+			logger.Error("Failed to delete avatar", zap.String("url", *user.AvatarURL), zap.Error(err))
+		}
+	}
+
+	s.auditLog.Record(ctx, models.AuditEventAccountDeleted, &user.ID, nil)
+	logger.Info("Account deleted", zap.String("user_id", userID))
+	return nil
+}
+
+// ExportMyData uploads a JSON archive of everything held about the user and
+// emails them a link to it that expires after DataExportLinkTTL.
+func (s *accountService) ExportMyData(ctx context.Context, userID string) error {
+	logger := zaplogger.FromContext(ctx)
+
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	addresses, err := s.addressRepo.ListByUserID(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	sessions, err := s.refreshTokenRepo.ListSessionsByUserID(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	orders, err := s.listOrders(ctx)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(response.NewDataExport(user, addresses, sessions, orders), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	output, err := s.exportStorage.Upload(ctx, &storage.UploadInput{
+		File:        bytes.NewReader(data),
+		Filename:    "data-export.json",
+		ContentType: "application/json",
+		Size:        int64(len(data)),
+		Folder:      DataExportFolder + "/" + userID,
+	})
+	if err != nil {
+		return err
+	}
+
+	expiresAt := time.Now().Add(DataExportLinkTTL)
+	downloadURL, err := s.exportStorage.GetPresignedDownloadURL(ctx, output.Key, DataExportLinkTTL)
+	if err != nil {
+		return err
+	}
+
+	if err := s.eventPublisher.PublishDataExportReady(ctx, user, downloadURL, expiresAt); err != nil {
+		return err
+	}
+
+	logger.Info("Data export ready",
+		zap.String("user_id", userID),
+		zap.String("key", output.Key),
+	)
+	return nil
+}
+
+// listOrders pages through the caller's orders. ctx must carry the user, as
+// the order service reads it from the forwarded metadata.
+func (s *accountService) listOrders(ctx context.Context) ([]json.RawMessage, error) {
+	if s.orderClient == nil {
+		return nil, nil
+	}
+
+	var orders []json.RawMessage
+	for page := int32(1); ; page++ {
+		resp, err := s.orderClient.ListMyOrders(ctx, page, dataExportOrderPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, order := range resp.Orders {
+			data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(order)
+			if err != nil {
+				return nil, err
+			}
+			orders = append(orders, data)
+		}
+
+		if page >= resp.TotalPages || len(resp.Orders) == 0 {
+			return orders, nil
+		}
+	}
+}
+
+func (s *accountService) getUser(ctx context.Context, userID string) (*models.User, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, apperr.ErrUserNotFound
+	}
+	return user, nil
+}
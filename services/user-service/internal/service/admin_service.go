@@ -0,0 +1,17 @@
+package service
+
+import (
+	"context"
+
+	"github.com/khoihuynh300/go-microservice/user-service/internal/domain/models"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/dto/request"
+)
+
+type AdminService interface {
+	ListUsers(ctx context.Context, req *request.ListUsersRequest) ([]*models.User, int64, error)
+	GetUser(ctx context.Context, userID string) (*models.User, error)
+	SuspendUser(ctx context.Context, adminID, userID, reason string) (*models.User, error)
+	ReactivateUser(ctx context.Context, adminID, userID string) (*models.User, error)
+	ForceResetPassword(ctx context.Context, adminID, userID string) error
+	RevokeAllSessions(ctx context.Context, adminID, userID string) error
+}
@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+
+	"github.com/google/uuid"
+	apperr "github.com/khoihuynh300/go-microservice/shared/pkg/errors"
+	zaplogger "github.com/khoihuynh300/go-microservice/shared/pkg/logger"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/audit"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/caching"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/domain/models"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/dto/request"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/events/publisher"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/repository"
+	passwordhasher "github.com/khoihuynh300/go-microservice/user-service/internal/security/password"
+	"go.uber.org/zap"
+)
+
+type adminService struct {
+	userRepo         repository.UserRepository
+	refreshTokenRepo repository.RefreshTokenRepository
+	tokenCache       *caching.TokenCache
+	tokenRevocation  *caching.TokenRevocationCache
+	passwordHasher   passwordhasher.PasswordHasher
+	eventPublisher   publisher.EventPublisher
+	auditLog         *audit.Recorder
+}
+
+func NewAdminService(
+	userRepo repository.UserRepository,
+	refreshTokenRepo repository.RefreshTokenRepository,
+	tokenCache *caching.TokenCache,
+	tokenRevocation *caching.TokenRevocationCache,
+	passwordHasher passwordhasher.PasswordHasher,
+	eventPublisher publisher.EventPublisher,
+	auditLog *audit.Recorder,
+) AdminService {
+	return &adminService{
+		userRepo:         userRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		tokenCache:       tokenCache,
+		tokenRevocation:  tokenRevocation,
+		passwordHasher:   passwordHasher,
+		eventPublisher:   eventPublisher,
+		auditLog:         auditLog,
+	}
+}
+
+func (s *adminService) ListUsers(ctx context.Context, req *request.ListUsersRequest) ([]*models.User, int64, error) {
+	filter := &repository.UserFilter{
+		Email:       req.Email,
+		CreatedFrom: req.CreatedFrom,
+		CreatedTo:   req.CreatedTo,
+	}
+	if req.Status != nil {
+		status := models.UserStatus(*req.Status)
+		filter.Status = &status
+	}
+
+	return s.userRepo.List(ctx, filter, req.Page, req.PageSize)
+}
+
+func (s *adminService) GetUser(ctx context.Context, userID string) (*models.User, error) {
+	return s.getUser(ctx, userID)
+}
+
+// SuspendUser blocks the user from signing in and ends all of their sessions.
+func (s *adminService) SuspendUser(ctx context.Context, adminID, userID, reason string) (*models.User, error) {
+	logger := zaplogger.FromContext(ctx)
+
+	user, err := s.getManagedUser(ctx, adminID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Status == models.UserStatusSuspended {
+		return nil, apperr.ErrUserAlreadySuspended
+	}
+
+	err = s.userRepo.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.updateStatus(ctx, user, models.UserStatusSuspended); err != nil {
+			return err
+		}
+
+		if _, err := s.refreshTokenRepo.RevokeAllByUserID(ctx, user.ID); err != nil {
+			return err
+		}
+
+		return s.eventPublisher.PublishUserSuspended(ctx, user, reason)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.tokenRevocation.RevokeUserTokens(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	s.auditLog.RecordAdminAction(ctx, models.AuditEventUserSuspended, adminID, user.ID)
+	logger.Info("User suspended",
+		zap.String("admin_id", adminID),
+		zap.String("user_id", userID),
+		zap.String("reason", reason),
+	)
+	return user, nil
+}
+
+// ReactivateUser returns a suspended or inactive user to active.
+func (s *adminService) ReactivateUser(ctx context.Context, adminID, userID string) (*models.User, error) {
+	logger := zaplogger.FromContext(ctx)
+
+	user, err := s.getManagedUser(ctx, adminID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Status != models.UserStatusSuspended && user.Status != models.UserStatusInactive {
+		return nil, apperr.ErrUserNotSuspended
+	}
+
+	err = s.userRepo.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.updateStatus(ctx, user, models.UserStatusActive); err != nil {
+			return err
+		}
+
+		return s.eventPublisher.PublishUserReactivated(ctx, user)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.auditLog.RecordAdminAction(ctx, models.AuditEventUserReactivated, adminID, user.ID)
+	logger.Info("User reactivated",
+		zap.String("admin_id", adminID),
+		zap.String("user_id", userID),
+	)
+	return user, nil
+}
+
+// ForceResetPassword replaces the user's password with a random one they
+// cannot know, signs them out everywhere and mails them a reset link.
+func (s *adminService) ForceResetPassword(ctx context.Context, adminID, userID string) error {
+	logger := zaplogger.FromContext(ctx)
+
+	user, err := s.getManagedUser(ctx, adminID, userID)
+	if err != nil {
+		return err
+	}
+
+	hashedPassword, err := s.passwordHasher.Hash(rand.Text())
+	if err != nil {
+		return err
+	}
+
+	token, err := s.tokenCache.SetPasswordResetToken(ctx, user.Email)
+	if err != nil {
+		return err
+	}
+
+	err = s.userRepo.WithinTransaction(ctx, func(ctx context.Context) error {
+		rowEffected, err := s.userRepo.UpdatePassword(ctx, user.ID, hashedPassword)
+		if err != nil {
+			return err
+		}
+		if rowEffected == 0 {
+			return apperr.ErrUserNotFound
+		}
+
+		if _, err := s.refreshTokenRepo.RevokeAllByUserID(ctx, user.ID); err != nil {
+			return err
+		}
+
+		return s.eventPublisher.PublishPasswordResetForced(ctx, user, token)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := s.tokenRevocation.RevokeUserTokens(ctx, userID); err != nil {
+		return err
+	}
+
+	s.auditLog.RecordAdminAction(ctx, models.AuditEventPasswordResetForced, adminID, user.ID)
+	logger.Info("Password reset forced",
+		zap.String("admin_id", adminID),
+		zap.String("user_id", userID),
+	)
+	return nil
+}
+
+func (s *adminService) RevokeAllSessions(ctx context.Context, adminID, userID string) error {
+	logger := zaplogger.FromContext(ctx)
+
+	user, err := s.getManagedUser(ctx, adminID, userID)
+	if err != nil {
+		return err
+	}
+
+	err = s.userRepo.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.refreshTokenRepo.RevokeAllByUserID(ctx, user.ID); err != nil {
+			return err
+		}
+
+		return s.eventPublisher.PublishSessionsRevoked(ctx, user)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := s.tokenRevocation.RevokeUserTokens(ctx, userID); err != nil {
+		return err
+	}
+
+	s.auditLog.RecordAdminAction(ctx, models.AuditEventAllSessionsRevoked, adminID, user.ID)
+	logger.Info("All sessions revoked by admin",
+		zap.String("admin_id", adminID),
+		zap.String("user_id", userID),
+	)
+	return nil
+}
+
+// getManagedUser loads the target of an admin action. Admins may not act on
+// their own account, so they cannot lock themselves out.
+func (s *adminService) getManagedUser(ctx context.Context, adminID, userID string) (*models.User, error) {
+	if adminID == userID {
+		return nil, apperr.ErrCannotManageSelf
+	}
+	return s.getUser(ctx, userID)
+}
+
+func (s *adminService) updateStatus(ctx context.Context, user *models.User, status models.UserStatus) error {
+	rowEffected, err := s.userRepo.UpdateStatus(ctx, user.ID, status)
+	if err != nil {
+		return err
+	}
+	if rowEffected == 0 {
+		return apperr.ErrUserNotFound
+	}
+
+	user.Status = status
+	return nil
+}
+
+func (s *adminService) getUser(ctx context.Context, userID string) (*models.User, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, apperr.ErrUserNotFound
+	}
+	return user, nil
+}
@@ -0,0 +1,13 @@
+package service
+
+import (
+	"context"
+
+	"github.com/khoihuynh300/go-microservice/user-service/internal/domain/models"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/dto/request"
+)
+
+type AuditService interface {
+	ListSecurityEvents(ctx context.Context, userID string, page, pageSize int32) ([]*models.AuditLogEntry, int64, error)
+	ListAuditLog(ctx context.Context, req *request.ListAuditLogRequest) ([]*models.AuditLogEntry, int64, error)
+}
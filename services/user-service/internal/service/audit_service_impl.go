@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/domain/models"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/dto/request"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/repository"
+)
+
+type auditService struct {
+	auditLogRepo repository.AuditLogRepository
+}
+
+func NewAuditService(auditLogRepo repository.AuditLogRepository) AuditService {
+	return &auditService{
+		auditLogRepo: auditLogRepo,
+	}
+}
+
+// ListSecurityEvents returns the user's own audit entries, newest first.
+func (s *auditService) ListSecurityEvents(ctx context.Context, userID string, page, pageSize int32) ([]*models.AuditLogEntry, int64, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return s.auditLogRepo.List(ctx, &repository.AuditLogFilter{UserID: &userUUID}, page, pageSize)
+}
+
+func (s *auditService) ListAuditLog(ctx context.Context, req *request.ListAuditLogRequest) ([]*models.AuditLogEntry, int64, error) {
+	filter := &repository.AuditLogFilter{
+		IPAddress:   req.IPAddress,
+		CreatedFrom: req.CreatedFrom,
+		CreatedTo:   req.CreatedTo,
+	}
+	if req.UserID != nil {
+		userUUID, err := uuid.Parse(*req.UserID)
+		if err != nil {
+			return nil, 0, err
+		}
+		filter.UserID = &userUUID
+	}
+	if req.EventType != nil {
+		eventType := models.AuditEventType(*req.EventType)
+		filter.EventType = &eventType
+	}
+
+	return s.auditLogRepo.List(ctx, filter, req.Page, req.PageSize)
+}
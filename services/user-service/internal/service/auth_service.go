@@ -7,13 +7,41 @@ import (
 	"github.com/khoihuynh300/go-microservice/user-service/internal/dto/request"
 )
 
+// LoginResult carries either a token pair or, for accounts with two-factor
+// authentication, the challenge token to pass to VerifyMFA.
+type LoginResult struct {
+	User         *models.User
+	AccessToken  string
+	RefreshToken string
+	MFAToken     string
+}
+
+func (r *LoginResult) MFARequired() bool {
+	return r.MFAToken != ""
+}
+
 type AuthService interface {
 	Register(ctx context.Context, req *request.RegisterRequest) (*models.User, error)
 	VerifyEmail(ctx context.Context, token string) error
 	ResendVerificationEmail(ctx context.Context, email string) error
-	Login(ctx context.Context, req *request.LoginRequest) (*models.User, string, string, error)
+	Login(ctx context.Context, req *request.LoginRequest) (*LoginResult, error)
+	VerifyMFA(ctx context.Context, mfaToken string, code string) (string, string, error)
+	StartOAuthLogin(ctx context.Context, provider string) (string, error)
+	CompleteOAuthLogin(ctx context.Context, provider string, code string, state string) (*LoginResult, error)
+	RequestMagicLink(ctx context.Context, email string) error
+	ConsumeMagicLink(ctx context.Context, token string) (*LoginResult, error)
 	RefreshToken(ctx context.Context, refreshTokenStr string) (string, string, error)
 	ChangePassword(ctx context.Context, userID string, req *request.ChangePasswordRequest) error
 	ForgotPassword(ctx context.Context, email string) error
 	ResetPassword(ctx context.Context, token string, newPassword string) error
+	RequestEmailChange(ctx context.Context, userID string, newEmail string, password string) error
+	ConfirmEmailChange(ctx context.Context, token string) error
+	UnlockAccount(ctx context.Context, token string) error
+	Logout(ctx context.Context, refreshTokenStr string) error
+	LogoutAll(ctx context.Context, userID string) error
+	ListSessions(ctx context.Context, userID string) ([]*models.Session, error)
+	RevokeSession(ctx context.Context, userID string, sessionID string) error
+	EnrollTOTP(ctx context.Context, userID string) (string, string, error)
+	ConfirmTOTP(ctx context.Context, userID string, code string) ([]string, error)
+	DisableTOTP(ctx context.Context, userID string, password string, code string) error
 }
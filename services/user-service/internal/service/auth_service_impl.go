@@ -2,19 +2,26 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/subtle"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/const/contextkeys"
 	apperr "github.com/khoihuynh300/go-microservice/shared/pkg/errors"
 	zaplogger "github.com/khoihuynh300/go-microservice/shared/pkg/logger"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/audit"
 	"github.com/khoihuynh300/go-microservice/user-service/internal/caching"
 	"github.com/khoihuynh300/go-microservice/user-service/internal/domain/models"
 	"github.com/khoihuynh300/go-microservice/user-service/internal/dto/request"
 	"github.com/khoihuynh300/go-microservice/user-service/internal/events/publisher"
 	"github.com/khoihuynh300/go-microservice/user-service/internal/repository"
 	"github.com/khoihuynh300/go-microservice/user-service/internal/security/jwtprovider"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/security/oauth"
 	passwordhasher "github.com/khoihuynh300/go-microservice/user-service/internal/security/password"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/security/totp"
 	"github.com/khoihuynh300/go-microservice/user-service/internal/utils"
 	"go.uber.org/zap"
 )
@@ -22,33 +29,58 @@ import (
 type authService struct {
 	userRepo         repository.UserRepository
 	refreshTokenRepo repository.RefreshTokenRepository
+	identityRepo     repository.UserIdentityRepository
 	tokenCache       *caching.TokenCache
+	loginAttempts    *caching.LoginAttemptCache
+	tokenRevocation  *caching.TokenRevocationCache
 	passwordHasher   passwordhasher.PasswordHasher
+	passwordPolicy   passwordhasher.PasswordPolicy
+	totpProvider     totp.TOTPProvider
+	oauthProviders   *oauth.Registry
 	jwtService       jwtprovider.JwtProvider
 	eventPublisher   publisher.EventPublisher
+	auditLog         *audit.Recorder
 }
 
 func NewAuthService(
 	userRepo repository.UserRepository,
 	refreshTokenRepo repository.RefreshTokenRepository,
+	identityRepo repository.UserIdentityRepository,
 	tokenCache *caching.TokenCache,
+	loginAttempts *caching.LoginAttemptCache,
+	tokenRevocation *caching.TokenRevocationCache,
 	passwordHasher passwordhasher.PasswordHasher,
+	passwordPolicy passwordhasher.PasswordPolicy,
+	totpProvider totp.TOTPProvider,
+	oauthProviders *oauth.Registry,
 	jwtService jwtprovider.JwtProvider,
 	eventPublisher publisher.EventPublisher,
+	auditLog *audit.Recorder,
 ) AuthService {
 	return &authService{
 		userRepo:         userRepo,
 		refreshTokenRepo: refreshTokenRepo,
+		identityRepo:     identityRepo,
 		tokenCache:       tokenCache,
+		loginAttempts:    loginAttempts,
+		tokenRevocation:  tokenRevocation,
 		passwordHasher:   passwordHasher,
+		passwordPolicy:   passwordPolicy,
+		totpProvider:     totpProvider,
+		oauthProviders:   oauthProviders,
 		jwtService:       jwtService,
 		eventPublisher:   eventPublisher,
+		auditLog:         auditLog,
 	}
 }
 
 func (s *authService) Register(ctx context.Context, req *request.RegisterRequest) (*models.User, error) {
 	logger := zaplogger.FromContext(ctx)
 
+	if err := s.passwordPolicy.Validate("password", req.Password, req.Email, req.FullName); err != nil {
+		return nil, err
+	}
+
 	existedUser, err := s.userRepo.GetByEmail(ctx, req.Email)
 	if err != nil {
 		return nil, err
@@ -124,27 +156,33 @@ func (s *authService) VerifyEmail(ctx context.Context, token string) error {
 		return apperr.ErrEmailAlreadyVerified
 	}
 
-	rowEffected, err := s.userRepo.VerifyEmail(ctx, user.ID)
-	if err != nil {
-		return err
-	}
-	if rowEffected == 0 {
-		return apperr.ErrUserNotFound
-	}
+	err = s.userRepo.WithinTransaction(ctx, func(ctx context.Context) error {
+		rowEffected, err := s.userRepo.VerifyEmail(ctx, user.ID)
+		if err != nil {
+			return err
+		}
+		if rowEffected == 0 {
+			return apperr.ErrUserNotFound
+		}
 
-	rowEffected, err = s.userRepo.UpdateStatus(ctx, user.ID, models.UserStatusActive)
-	if err != nil {
-		return err
-	}
-	if rowEffected == 0 {
-		return apperr.ErrUserNotFound
-	}
+		// a suspended account must stay suspended
+		if user.Status == models.UserStatusPending {
+			rowEffected, err = s.userRepo.UpdateStatus(ctx, user.ID, models.UserStatusActive)
+			if err != nil {
+				return err
+			}
+			if rowEffected == 0 {
+				return apperr.ErrUserNotFound
+			}
+		}
 
-	err = s.eventPublisher.PublishEmailVerifySuccess(ctx, user.Email)
+		return s.eventPublisher.PublishEmailVerifySuccess(ctx, user.Email)
+	})
 	if err != nil {
 		return err
 	}
 
+	s.auditLog.Record(ctx, models.AuditEventEmailVerification, &user.ID, nil)
 	logger.Info("Email verification success",
 		zap.String("user_id", user.ID.String()),
 	)
@@ -186,217 +224,1164 @@ func (s *authService) ResendVerificationEmail(ctx context.Context, email string)
 	return nil
 }
 
-func (s *authService) Login(ctx context.Context, req *request.LoginRequest) (*models.User, string, string, error) {
+func (s *authService) Login(ctx context.Context, req *request.LoginRequest) (*LoginResult, error) {
 	logger := zaplogger.FromContext(ctx)
+	clientIP, _ := ctx.Value(contextkeys.ClientIPKey).(string)
+
+	attempt, err := s.loginAttempts.Check(ctx, req.Email, clientIP)
+	if err != nil {
+		return nil, err
+	}
+	if attempt.Locked {
+		logger.Warn("Login rejected: account is locked", zap.Duration("retry_after", attempt.RetryAfter))
+		s.auditLog.Record(ctx, models.AuditEventLogin, nil, apperr.ErrAccountLocked)
+		return nil, apperr.ErrAccountLocked
+	}
+	if attempt.RetryAfter > 0 {
+		logger.Warn("Login rejected: too many failed attempts", zap.Duration("retry_after", attempt.RetryAfter))
+		s.auditLog.Record(ctx, models.AuditEventLogin, nil, apperr.ErrTooManyLoginAttempts)
+		return nil, apperr.ErrTooManyLoginAttempts
+	}
 
 	user, err := s.userRepo.GetByEmail(ctx, req.Email)
 	if err != nil {
-		return nil, "", "", err
+		return nil, err
 	}
-	if user == nil {
+	if user == nil || !s.passwordHasher.Compare(user.HashedPassword, req.Password) {
 		logger.Warn("Login failed: invalid credentials")
-		return nil, "", "", apperr.ErrInvalidCredentials
+		err := s.handleLoginFailure(ctx, user, req.Email, clientIP)
+		s.auditLog.Record(ctx, models.AuditEventLogin, auditUserID(user), err)
+		return nil, err
 	}
 
-	if !s.passwordHasher.Compare(user.HashedPassword, req.Password) {
-		logger.Warn("Login failed: invalid credentials")
-		return nil, "", "", apperr.ErrInvalidCredentials
+	if err := s.loginAttempts.Reset(ctx, user.Email); err != nil {
+		return nil, err
 	}
 
+	return s.completeLogin(ctx, user)
+}
+
+// completeLogin issues the token pair for an authenticated user, or the MFA
+// challenge if the account has a second factor.
+func (s *authService) completeLogin(ctx context.Context, user *models.User) (*LoginResult, error) {
+	logger := zaplogger.FromContext(ctx)
+
 	if !user.IsActive() {
 		logger.Warn("Login failed: account is inactive",
 			zap.String("user_id", user.ID.String()),
 		)
-		return nil, "", "", apperr.ErrAccountInactive
+		s.auditLog.Record(ctx, models.AuditEventLogin, &user.ID, apperr.ErrAccountInactive)
+		return nil, apperr.ErrAccountInactive
+	}
+
+	if user.IsTOTPEnabled() {
+		mfaToken, err := s.tokenCache.SetMFAChallengeToken(ctx, user.ID.String())
+		if err != nil {
+			return nil, err
+		}
+
+		logger.Info("Login requires second factor", zap.String("user_id", user.ID.String()))
+		return &LoginResult{User: user, MFAToken: mfaToken}, nil
 	}
 
 	accessToken, refreshToken, err := s.generateTokenPair(ctx, user)
 	if err != nil {
-		return nil, "", "", err
+		return nil, err
 	}
 
+	s.auditLog.Record(ctx, models.AuditEventLogin, &user.ID, nil)
 	logger.Info("Login success", zap.String("user_id", user.ID.String()))
-	return user, accessToken, refreshToken, nil
+	return &LoginResult{User: user, AccessToken: accessToken, RefreshToken: refreshToken}, nil
 }
 
-func (s *authService) RefreshToken(ctx context.Context, refreshTokenStr string) (string, string, error) {
-	claims, err := s.jwtService.VerifyRefreshToken(refreshTokenStr)
+// StartOAuthLogin returns the provider's authorization URL. The nonce and PKCE
+// verifier stay in Redis under the state until the provider redirects back.
+func (s *authService) StartOAuthLogin(ctx context.Context, providerName string) (string, error) {
+	provider, err := s.oauthProviders.Get(providerName)
 	if err != nil {
-		if errors.Is(err, jwtprovider.ErrTokenExpired) {
-			return "", "", apperr.ErrTokenExpired
-		}
-		if errors.Is(err, jwtprovider.ErrTokenInvalid) {
-			return "", "", apperr.ErrTokenInvalid
-		}
-		return "", "", err
+		return "", apperr.ErrOAuthProviderNotFound
 	}
 
-	refreshTokenModel, err := s.refreshTokenRepo.GetByToken(ctx, utils.HashToken(refreshTokenStr))
-	if err != nil {
-		return "", "", err
+	oauthState := &caching.OAuthState{
+		Provider:     provider.Name(),
+		Nonce:        uuid.New().String(),
+		CodeVerifier: oauth.GenerateVerifier(),
 	}
-	if refreshTokenModel == nil {
-		return "", "", apperr.ErrTokenInvalid
+	state, err := s.tokenCache.SetOAuthState(ctx, oauthState)
+	if err != nil {
+		return "", err
 	}
 
-	userID := uuid.MustParse(claims.Subject)
+	return provider.AuthCodeURL(state, oauthState.Nonce, oauthState.CodeVerifier), nil
+}
 
-	user, err := s.userRepo.GetByID(ctx, userID)
-	if err != nil {
-		return "", "", err
-	}
-	if user == nil {
-		return "", "", apperr.ErrTokenInvalid
-	}
+// CompleteOAuthLogin handles the provider's callback. The state is single use,
+// must have been issued for the same provider, and must be bound to the
+// browser that started the login. Without the binding an attacker could send
+// a victim the callback for their own login and sign the victim in to the
+// attacker's account.
+func (s *authService) CompleteOAuthLogin(ctx context.Context, providerName string, code string, state string) (*LoginResult, error) {
+	logger := zaplogger.FromContext(ctx)
 
-	if !user.IsActive() {
-		return "", "", apperr.ErrAccountInactive
+	binding, _ := ctx.Value(contextkeys.OAuthStateBindingKey).(string)
+	if subtle.ConstantTimeCompare([]byte(binding), []byte(utils.HashToken(state))) != 1 {
+		logger.Warn("OAuth state not bound to this browser", zap.String("provider", providerName))
+		return nil, apperr.ErrTokenInvalidOrExpired
 	}
 
-	rowEffected, err := s.refreshTokenRepo.DeleteByID(ctx, refreshTokenModel.ID)
+	oauthState, err := s.tokenCache.VerifyOAuthState(ctx, state)
 	if err != nil {
-		return "", "", err
+		if errors.Is(err, caching.ErrTokenInvalidOrExpired) {
+			return nil, apperr.ErrTokenInvalidOrExpired
+		}
+		return nil, err
 	}
-	if rowEffected == 0 {
-		return "", "", apperr.ErrTokenInvalid
+	if oauthState.Provider != providerName {
+		return nil, apperr.ErrTokenInvalidOrExpired
 	}
 
-	return s.generateTokenPair(ctx, user)
-}
-
-func (s *authService) generateTokenPair(ctx context.Context, user *models.User) (string, string, error) {
-	accessToken, err := s.jwtService.GenerateAccessToken(user)
+	provider, err := s.oauthProviders.Get(providerName)
 	if err != nil {
-		return "", "", err
+		return nil, apperr.ErrOAuthProviderNotFound
 	}
 
-	refreshToken, err := s.jwtService.GenerateRefreshToken(user.ID.String())
+	identity, err := provider.Exchange(ctx, code, oauthState.CodeVerifier, oauthState.Nonce)
 	if err != nil {
-		return "", "", err
-	}
-
-	refreshTokenModel := &models.RefreshToken{
-		UserID:    user.ID,
-		TokenHash: utils.HashToken(refreshToken),
-		ExpiresAt: time.Now().Add(s.jwtService.GetRefreshTTL()),
+		logger.Warn("OAuth login failed",
+			zap.String("provider", providerName),
+			zap.Error(err),
+		)
+		return nil, apperr.ErrOAuthLoginFailed
 	}
 
-	err = s.refreshTokenRepo.Create(ctx, refreshTokenModel)
+	user, err := s.resolveOAuthUser(ctx, providerName, identity)
 	if err != nil {
-		return "", "", err
+		return nil, err
 	}
 
-	return accessToken, refreshToken, nil
+	return s.completeLogin(ctx, user)
 }
 
-func (s *authService) ChangePassword(ctx context.Context, userID string, req *request.ChangePasswordRequest) error {
+// resolveOAuthUser finds the user behind a provider identity. A new identity
+// is linked to the account with the same email only when both sides have
+// verified that email, otherwise anyone could claim an account by signing up
+// at the provider with its address.
+func (s *authService) resolveOAuthUser(ctx context.Context, providerName string, identity *oauth.Identity) (*models.User, error) {
 	logger := zaplogger.FromContext(ctx)
 
-	userUUID, err := uuid.Parse(userID)
+	linked, err := s.identityRepo.GetByProviderSubject(ctx, providerName, identity.Subject)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	if linked != nil {
+		if _, err := s.identityRepo.RecordLogin(ctx, linked.ID, identity.Email); err != nil {
+			return nil, err
+		}
 
-	user, err := s.userRepo.GetByID(ctx, userUUID)
-	if err != nil {
-		return err
+		user, err := s.userRepo.GetByID(ctx, linked.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if user == nil {
+			return nil, apperr.ErrUserNotFound
+		}
+		return user, nil
 	}
-	if user == nil {
-		return apperr.ErrUserNotFound
+
+	if identity.Email == "" || !identity.EmailVerified {
+		logger.Warn("OAuth login rejected: email not verified by provider",
+			zap.String("provider", providerName),
+		)
+		return nil, apperr.ErrOAuthEmailNotVerified
 	}
 
-	if !s.passwordHasher.Compare(user.HashedPassword, req.CurrentPassword) {
-		logger.Warn("Change password failed: invalid current password",
+	user, err := s.userRepo.GetByEmail(ctx, identity.Email)
+	if err != nil {
+		return nil, err
+	}
+	if user != nil && !user.IsEmailVerified() {
+		logger.Warn("OAuth login rejected: local email not verified",
+			zap.String("provider", providerName),
 			zap.String("user_id", user.ID.String()),
 		)
-		return apperr.ErrInvalidCurrentPassword
+		return nil, apperr.ErrOAuthAccountLinkBlocked
 	}
 
-	newHashedPassword, err := s.passwordHasher.Hash(req.NewPassword)
+	err = s.userRepo.WithinTransaction(ctx, func(ctx context.Context) error {
+		if user == nil {
+			user, err = s.createOAuthUser(ctx, identity)
+			if err != nil {
+				return err
+			}
+		}
+
+		return s.identityRepo.Create(ctx, &models.UserIdentity{
+			UserID:   user.ID,
+			Provider: providerName,
+			Subject:  identity.Subject,
+			Email:    identity.Email,
+		})
+	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	rowEffected, err := s.userRepo.UpdatePassword(ctx, user.ID, newHashedPassword)
+	logger.Info("OAuth identity linked",
+		zap.String("provider", providerName),
+		zap.String("user_id", user.ID.String()),
+	)
+	return user, nil
+}
+
+// createOAuthUser registers an active, verified account with an unguessable
+// password. The owner can set a real one through the forgot password flow.
+func (s *authService) createOAuthUser(ctx context.Context, identity *oauth.Identity) (*models.User, error) {
+	hashedPassword, err := s.passwordHasher.Hash(rand.Text())
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if rowEffected == 0 {
-		return apperr.ErrUserNotFound
+
+	fullName := identity.Name
+	if fullName == "" {
+		fullName, _, _ = strings.Cut(identity.Email, "@")
 	}
 
-	logger.Info("Change password success",
-		zap.String("user_id", user.ID.String()),
-	)
-	return nil
+	user := &models.User{
+		Email:          identity.Email,
+		HashedPassword: hashedPassword,
+		FullName:       fullName,
+		Status:         models.UserStatusActive,
+	}
+	if err := s.userRepo.Create(ctx, user); err != nil {
+		return nil, err
+	}
+	if _, err := s.userRepo.VerifyEmail(ctx, user.ID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	user.EmailVerifiedAt = &now
+	return user, nil
 }
 
-func (s *authService) ForgotPassword(ctx context.Context, email string) error {
+// RequestMagicLink emails a single-use sign-in link. It returns nil whether or
+// not the address has an account, and requests over the limit are dropped
+// silently, so the response never tells a caller which emails are registered.
+func (s *authService) RequestMagicLink(ctx context.Context, email string) error {
 	logger := zaplogger.FromContext(ctx)
-	user, err := s.userRepo.GetByEmail(ctx, email)
+
+	allowed, err := s.tokenCache.AllowMagicLinkRequest(ctx, email)
 	if err != nil {
 		return err
 	}
-	if user == nil {
-		return apperr.ErrUserNotFound
+	if !allowed {
+		logger.Warn("Magic link request throttled")
+		return nil
 	}
 
-	token, err := s.tokenCache.SetPasswordResetToken(ctx, user.Email)
+	user, err := s.userRepo.GetByEmail(ctx, email)
 	if err != nil {
 		return err
 	}
+	if user == nil || !user.IsActive() {
+		logger.Info("Magic link request skipped: no active account")
+		return nil
+	}
 
-	err = s.eventPublisher.PublishForgotPassword(ctx, user, token)
+	token, err := s.tokenCache.SetMagicLinkToken(ctx, user.Email)
 	if err != nil {
 		return err
 	}
 
-	logger.Info("Forgot password email sent",
-		zap.String("user_id", user.ID.String()),
-	)
+	if err := s.eventPublisher.PublishMagicLinkRequested(ctx, user, token); err != nil {
+		return err
+	}
 
+	logger.Info("Magic link sent", zap.String("user_id", user.ID.String()))
 	return nil
 }
 
-func (s *authService) ResetPassword(ctx context.Context, token string, newPassword string) error {
-	logger := zaplogger.FromContext(ctx)
-
-	email, err := s.tokenCache.VerifyPasswordResetToken(ctx, token)
+// ConsumeMagicLink signs the user in with a link sent by RequestMagicLink.
+// Accounts with two-factor authentication still get an MFA challenge.
+func (s *authService) ConsumeMagicLink(ctx context.Context, token string) (*LoginResult, error) {
+	email, err := s.tokenCache.VerifyMagicLinkToken(ctx, token)
 	if err != nil {
 		if errors.Is(err, caching.ErrTokenInvalidOrExpired) {
-			return apperr.ErrTokenInvalidOrExpired
+			return nil, apperr.ErrTokenInvalidOrExpired
 		}
-		return err
+		return nil, err
 	}
 
 	user, err := s.userRepo.GetByEmail(ctx, email)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if user == nil {
-		return apperr.ErrUserNotFound
+		// the email was changed after the link was sent
+		return nil, apperr.ErrTokenInvalidOrExpired
 	}
 
-	newHashedPassword, err := s.passwordHasher.Hash(newPassword)
+	return s.completeLogin(ctx, user)
+}
+
+// VerifyMFA completes a login that was answered with an MFA challenge. Wrong
+// codes count as failed logins, so they share the lockout of password guesses.
+func (s *authService) VerifyMFA(ctx context.Context, mfaToken string, code string) (string, string, error) {
+	logger := zaplogger.FromContext(ctx)
+	clientIP, _ := ctx.Value(contextkeys.ClientIPKey).(string)
+
+	userIDStr, err := s.tokenCache.GetMFAChallengeToken(ctx, mfaToken)
 	if err != nil {
-		return err
+		if errors.Is(err, caching.ErrTokenInvalidOrExpired) {
+			return "", "", apperr.ErrTokenInvalidOrExpired
+		}
+		return "", "", err
 	}
 
-	rowEffected, err := s.userRepo.UpdatePassword(ctx, user.ID, newHashedPassword)
+	userID, err := uuid.Parse(userIDStr)
 	if err != nil {
-		return err
+		return "", "", apperr.ErrTokenInvalidOrExpired
+	}
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return "", "", err
+	}
+	if user == nil || !user.IsTOTPEnabled() {
+		return "", "", apperr.ErrTokenInvalidOrExpired
+	}
+
+	attempt, err := s.loginAttempts.Check(ctx, user.Email, clientIP)
+	if err != nil {
+		return "", "", err
+	}
+	if attempt.Locked {
+		s.auditLog.Record(ctx, models.AuditEventLogin, &user.ID, apperr.ErrAccountLocked)
+		return "", "", apperr.ErrAccountLocked
+	}
+	if attempt.RetryAfter > 0 {
+		s.auditLog.Record(ctx, models.AuditEventLogin, &user.ID, apperr.ErrTooManyLoginAttempts)
+		return "", "", apperr.ErrTooManyLoginAttempts
+	}
+
+	valid, err := s.verifySecondFactor(ctx, user, code)
+	if err != nil {
+		return "", "", err
+	}
+	if !valid {
+		logger.Warn("MFA verification failed", zap.String("user_id", user.ID.String()))
+		err := s.handleLoginFailure(ctx, user, user.Email, clientIP)
+		if errors.Is(err, apperr.ErrInvalidCredentials) {
+			err = apperr.ErrInvalidMFACode
+		}
+		if errors.Is(err, apperr.ErrAccountLocked) {
+			_ = s.tokenCache.DeleteMFAChallengeToken(ctx, mfaToken)
+		}
+		s.auditLog.Record(ctx, models.AuditEventLogin, &user.ID, err)
+		return "", "", err
+	}
+
+	if err := s.tokenCache.DeleteMFAChallengeToken(ctx, mfaToken); err != nil {
+		return "", "", err
+	}
+	if err := s.loginAttempts.Reset(ctx, user.Email); err != nil {
+		return "", "", err
+	}
+
+	if !user.IsActive() {
+		s.auditLog.Record(ctx, models.AuditEventLogin, &user.ID, apperr.ErrAccountInactive)
+		return "", "", apperr.ErrAccountInactive
+	}
+
+	accessToken, refreshToken, err := s.generateTokenPair(ctx, user)
+	if err != nil {
+		return "", "", err
+	}
+
+	s.auditLog.Record(ctx, models.AuditEventLogin, &user.ID, nil)
+	logger.Info("Login success", zap.String("user_id", user.ID.String()))
+	return accessToken, refreshToken, nil
+}
+
+// verifySecondFactor accepts a current TOTP code that has not been used yet,
+// or an unused recovery code, which is burned on success.
+func (s *authService) verifySecondFactor(ctx context.Context, user *models.User, code string) (bool, error) {
+	code = strings.TrimSpace(code)
+
+	if user.TOTPSecret != nil && s.totpProvider.Validate(*user.TOTPSecret, code, time.Now()) {
+		return s.tokenCache.MarkTOTPCodeUsed(ctx, user.ID.String(), code)
+	}
+
+	recoveryCode := totp.NormalizeRecoveryCode(code)
+	if recoveryCode == "" {
+		return false, nil
+	}
+	rowEffected, err := s.userRepo.UseRecoveryCode(ctx, user.ID, utils.HashToken(recoveryCode))
+	if err != nil {
+		return false, err
 	}
 	if rowEffected == 0 {
-		return apperr.ErrUserNotFound
+		return false, nil
 	}
 
-	err = s.eventPublisher.PublishPasswordResetSuccess(ctx, user.Email)
+	zaplogger.FromContext(ctx).Info("Recovery code used", zap.String("user_id", user.ID.String()))
+	return true, nil
+}
+
+// handleLoginFailure records a failed login and returns the error to report.
+// The failure that locks the account also sends the owner an unlock link.
+func (s *authService) handleLoginFailure(ctx context.Context, user *models.User, email, clientIP string) error {
+	logger := zaplogger.FromContext(ctx)
+
+	result, err := s.loginAttempts.RecordFailure(ctx, email, clientIP)
 	if err != nil {
 		return err
 	}
+	if !result.Locked {
+		return apperr.ErrInvalidCredentials
+	}
+	if user == nil {
+		return apperr.ErrAccountLocked
+	}
 
-	logger.Info("Reset password success",
+	logger.Warn("Account locked after repeated login failures",
 		zap.String("user_id", user.ID.String()),
+		zap.Time("locked_until", result.LockedUntil),
 	)
+
+	unlockToken, err := s.tokenCache.SetAccountUnlockToken(ctx, user.Email)
+	if err != nil {
+		return err
+	}
+	if err := s.eventPublisher.PublishAccountLocked(ctx, user, unlockToken, clientIP, result.LockedUntil); err != nil {
+		return err
+	}
+
+	return apperr.ErrAccountLocked
+}
+
+func (s *authService) UnlockAccount(ctx context.Context, token string) error {
+	logger := zaplogger.FromContext(ctx)
+
+	email, err := s.tokenCache.VerifyAccountUnlockToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, caching.ErrTokenInvalidOrExpired) {
+			return apperr.ErrTokenInvalidOrExpired
+		}
+		return err
+	}
+
+	if err := s.loginAttempts.Unlock(ctx, email); err != nil {
+		return err
+	}
+
+	logger.Info("Account unlocked")
 	return nil
 }
+
+func (s *authService) RefreshToken(ctx context.Context, refreshTokenStr string) (string, string, error) {
+	claims, err := s.jwtService.VerifyRefreshToken(refreshTokenStr)
+	if err != nil {
+		if errors.Is(err, jwtprovider.ErrTokenExpired) {
+			return "", "", apperr.ErrTokenExpired
+		}
+		if errors.Is(err, jwtprovider.ErrTokenInvalid) {
+			return "", "", apperr.ErrTokenInvalid
+		}
+		return "", "", err
+	}
+
+	refreshTokenModel, err := s.refreshTokenRepo.GetByToken(ctx, utils.HashToken(refreshTokenStr))
+	if err != nil {
+		return "", "", err
+	}
+	if refreshTokenModel == nil {
+		return "", "", apperr.ErrTokenInvalid
+	}
+	if refreshTokenModel.IsRotated() {
+		return "", "", s.handleRefreshTokenReuse(ctx, refreshTokenModel)
+	}
+	if refreshTokenModel.IsRevoked() {
+		return "", "", apperr.ErrTokenInvalid
+	}
+
+	userID := uuid.MustParse(claims.Subject)
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return "", "", err
+	}
+	if user == nil {
+		return "", "", apperr.ErrTokenInvalid
+	}
+
+	if !user.IsActive() {
+		s.auditLog.Record(ctx, models.AuditEventTokenRefresh, &user.ID, apperr.ErrAccountInactive)
+		return "", "", apperr.ErrAccountInactive
+	}
+
+	accessToken, refreshToken, err := s.rotateTokenPair(ctx, user, refreshTokenModel)
+	s.auditLog.Record(ctx, models.AuditEventTokenRefresh, &user.ID, err)
+	return accessToken, refreshToken, err
+}
+
+// handleRefreshTokenReuse is called when a token that was already rotated is
+// presented again. Either the legitimate client or an attacker holds a copy,
+// and we cannot tell which, so the whole family is revoked.
+func (s *authService) handleRefreshTokenReuse(ctx context.Context, refreshTokenModel *models.RefreshToken) error {
+	logger := zaplogger.FromContext(ctx)
+	clientIP, userAgent := clientInfo(ctx)
+
+	logger.Warn("Refresh token reuse detected",
+		zap.String("user_id", refreshTokenModel.UserID.String()),
+		zap.String("session_id", refreshTokenModel.FamilyID.String()),
+		zap.String("client_ip", clientIP),
+	)
+
+	var revoked int64
+	err := s.refreshTokenRepo.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		revoked, err = s.refreshTokenRepo.RevokeFamily(ctx, refreshTokenModel.FamilyID)
+		if err != nil {
+			return err
+		}
+		// the family was already revoked by an earlier replay
+		if revoked == 0 {
+			return nil
+		}
+
+		user, err := s.userRepo.GetByID(ctx, refreshTokenModel.UserID)
+		if err != nil {
+			return err
+		}
+		if user == nil {
+			return nil
+		}
+
+		return s.eventPublisher.PublishRefreshTokenReused(ctx, user, refreshTokenModel.FamilyID.String(), clientIP, userAgent)
+	})
+	if err != nil {
+		return err
+	}
+
+	if revoked > 0 {
+		s.auditLog.RecordFailure(ctx, models.AuditEventTokenRefresh, &refreshTokenModel.UserID, audit.ReasonRefreshTokenReused)
+	}
+
+	// the stolen token may already have been exchanged for an access token
+	if revoked > 0 {
+		if err := s.tokenRevocation.RevokeUserTokens(ctx, refreshTokenModel.UserID.String()); err != nil {
+			return err
+		}
+	}
+
+	return apperr.ErrTokenInvalid
+}
+
+// generateTokenPair signs a new token pair and starts a session for it,
+// recording the client details forwarded by the gateway.
+func (s *authService) generateTokenPair(ctx context.Context, user *models.User) (string, string, error) {
+	pair, err := s.signTokenPair(user)
+	if err != nil {
+		return "", "", err
+	}
+
+	clientIP, userAgent := clientInfo(ctx)
+	refreshTokenModel := &models.RefreshToken{
+		UserID:        user.ID,
+		TokenHash:     utils.HashToken(pair.refreshToken),
+		DeviceInfo:    utils.DeviceInfo(userAgent),
+		IPAddress:     clientIP,
+		UserAgent:     userAgent,
+		AccessTokenID: pair.accessTokenID,
+		ExpiresAt:     time.Now().Add(s.jwtService.GetRefreshTTL()),
+	}
+
+	err = s.refreshTokenRepo.Create(ctx, refreshTokenModel)
+	if err != nil {
+		return "", "", err
+	}
+
+	return pair.accessToken, pair.refreshToken, nil
+}
+
+// rotateTokenPair issues the next token in the session's family and marks
+// the presented one as rotated.
+func (s *authService) rotateTokenPair(ctx context.Context, user *models.User, parent *models.RefreshToken) (string, string, error) {
+	pair, err := s.signTokenPair(user)
+	if err != nil {
+		return "", "", err
+	}
+
+	clientIP, userAgent := clientInfo(ctx)
+	child := &models.RefreshToken{
+		UserID:        user.ID,
+		FamilyID:      parent.FamilyID,
+		ParentID:      &parent.ID,
+		TokenHash:     utils.HashToken(pair.refreshToken),
+		DeviceInfo:    parent.DeviceInfo,
+		IPAddress:     clientIP,
+		UserAgent:     userAgent,
+		AccessTokenID: pair.accessTokenID,
+		ExpiresAt:     time.Now().Add(s.jwtService.GetRefreshTTL()),
+	}
+
+	err = s.refreshTokenRepo.WithinTransaction(ctx, func(ctx context.Context) error {
+		rowEffected, err := s.refreshTokenRepo.MarkRotated(ctx, parent.ID)
+		if err != nil {
+			return err
+		}
+		// a concurrent refresh with the same token won the race
+		if rowEffected == 0 {
+			return apperr.ErrTokenInvalid
+		}
+
+		return s.refreshTokenRepo.Create(ctx, child)
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	return pair.accessToken, pair.refreshToken, nil
+}
+
+type tokenPair struct {
+	accessToken   string
+	accessTokenID string
+	refreshToken  string
+}
+
+func (s *authService) signTokenPair(user *models.User) (*tokenPair, error) {
+	accessToken, accessTokenID, err := s.jwtService.GenerateAccessToken(user)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := s.jwtService.GenerateRefreshToken(user.ID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return &tokenPair{
+		accessToken:   accessToken,
+		accessTokenID: accessTokenID,
+		refreshToken:  refreshToken,
+	}, nil
+}
+
+func auditUserID(user *models.User) *uuid.UUID {
+	if user == nil {
+		return nil
+	}
+	return &user.ID
+}
+
+func clientInfo(ctx context.Context) (string, string) {
+	clientIP, _ := ctx.Value(contextkeys.ClientIPKey).(string)
+	userAgent, _ := ctx.Value(contextkeys.UserAgentKey).(string)
+	return clientIP, userAgent
+}
+
+func (s *authService) Logout(ctx context.Context, refreshTokenStr string) error {
+	logger := zaplogger.FromContext(ctx)
+
+	refreshTokenModel, err := s.refreshTokenRepo.GetByToken(ctx, utils.HashToken(refreshTokenStr))
+	if err != nil {
+		return err
+	}
+	if refreshTokenModel == nil {
+		return apperr.ErrTokenInvalid
+	}
+	if refreshTokenModel.IsRevoked() {
+		return nil
+	}
+
+	if _, err := s.refreshTokenRepo.RevokeFamily(ctx, refreshTokenModel.FamilyID); err != nil {
+		return err
+	}
+	if err := s.revokeSessionAccessTokens(ctx, refreshTokenModel.FamilyID); err != nil {
+		return err
+	}
+
+	s.auditLog.Record(ctx, models.AuditEventLogout, &refreshTokenModel.UserID, nil)
+	logger.Info("Logout success",
+		zap.String("user_id", refreshTokenModel.UserID.String()),
+		zap.String("session_id", refreshTokenModel.FamilyID.String()),
+	)
+	return nil
+}
+
+func (s *authService) LogoutAll(ctx context.Context, userID string) error {
+	logger := zaplogger.FromContext(ctx)
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return err
+	}
+
+	revoked, err := s.refreshTokenRepo.RevokeAllByUserID(ctx, userUUID)
+	if err != nil {
+		return err
+	}
+	if err := s.tokenRevocation.RevokeUserTokens(ctx, userID); err != nil {
+		return err
+	}
+
+	s.auditLog.Record(ctx, models.AuditEventAllSessionsRevoked, &userUUID, nil)
+	logger.Info("Logout from all sessions success",
+		zap.String("user_id", userID),
+		zap.Int64("revoked_sessions", revoked),
+	)
+	return nil
+}
+
+func (s *authService) ListSessions(ctx context.Context, userID string) ([]*models.Session, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.refreshTokenRepo.ListSessionsByUserID(ctx, userUUID)
+}
+
+func (s *authService) RevokeSession(ctx context.Context, userID string, sessionID string) error {
+	logger := zaplogger.FromContext(ctx)
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return err
+	}
+	sessionUUID, err := uuid.Parse(sessionID)
+	if err != nil {
+		return apperr.ErrSessionNotFound
+	}
+
+	rowEffected, err := s.refreshTokenRepo.RevokeFamilyByUserID(ctx, sessionUUID, userUUID)
+	if err != nil {
+		return err
+	}
+	if rowEffected == 0 {
+		return apperr.ErrSessionNotFound
+	}
+	if err := s.revokeSessionAccessTokens(ctx, sessionUUID); err != nil {
+		return err
+	}
+
+	s.auditLog.Record(ctx, models.AuditEventSessionRevoked, &userUUID, nil)
+	logger.Info("Session revoked",
+		zap.String("user_id", userID),
+		zap.String("session_id", sessionID),
+	)
+	return nil
+}
+
+// revokeSessionAccessTokens denies the access tokens of the session that
+// have not expired yet, so ending a session takes effect at the gateway
+// immediately instead of when they expire.
+func (s *authService) revokeSessionAccessTokens(ctx context.Context, familyID uuid.UUID) error {
+	tokens, err := s.refreshTokenRepo.ListByFamilyID(ctx, familyID, s.tokenRevocation.LiveSince())
+	if err != nil {
+		return err
+	}
+
+	for _, token := range tokens {
+		if err := s.tokenRevocation.RevokeAccessToken(ctx, token.AccessTokenID, token.CreatedAt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *authService) ChangePassword(ctx context.Context, userID string, req *request.ChangePasswordRequest) error {
+	logger := zaplogger.FromContext(ctx)
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userUUID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return apperr.ErrUserNotFound
+	}
+
+	if !s.passwordHasher.Compare(user.HashedPassword, req.CurrentPassword) {
+		logger.Warn("Change password failed: invalid current password",
+			zap.String("user_id", user.ID.String()),
+		)
+		s.auditLog.Record(ctx, models.AuditEventPasswordChange, &user.ID, apperr.ErrInvalidCurrentPassword)
+		return apperr.ErrInvalidCurrentPassword
+	}
+
+	if err := s.passwordPolicy.Validate("new_password", req.NewPassword, user.Email, user.FullName); err != nil {
+		return err
+	}
+
+	newHashedPassword, err := s.passwordHasher.Hash(req.NewPassword)
+	if err != nil {
+		return err
+	}
+
+	err = s.userRepo.WithinTransaction(ctx, func(ctx context.Context) error {
+		rowEffected, err := s.userRepo.UpdatePassword(ctx, user.ID, newHashedPassword)
+		if err != nil {
+			return err
+		}
+		if rowEffected == 0 {
+			return apperr.ErrUserNotFound
+		}
+
+		_, err = s.refreshTokenRepo.RevokeAllByUserID(ctx, user.ID)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := s.tokenRevocation.RevokeUserTokens(ctx, user.ID.String()); err != nil {
+		return err
+	}
+
+	s.auditLog.Record(ctx, models.AuditEventPasswordChange, &user.ID, nil)
+	logger.Info("Change password success",
+		zap.String("user_id", user.ID.String()),
+	)
+	return nil
+}
+
+func (s *authService) ForgotPassword(ctx context.Context, email string) error {
+	logger := zaplogger.FromContext(ctx)
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return apperr.ErrUserNotFound
+	}
+
+	token, err := s.tokenCache.SetPasswordResetToken(ctx, user.Email)
+	if err != nil {
+		return err
+	}
+
+	err = s.eventPublisher.PublishForgotPassword(ctx, user, token)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Forgot password email sent",
+		zap.String("user_id", user.ID.String()),
+	)
+
+	return nil
+}
+
+func (s *authService) ResetPassword(ctx context.Context, token string, newPassword string) error {
+	logger := zaplogger.FromContext(ctx)
+
+	email, err := s.tokenCache.VerifyPasswordResetToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, caching.ErrTokenInvalidOrExpired) {
+			return apperr.ErrTokenInvalidOrExpired
+		}
+		return err
+	}
+
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return apperr.ErrUserNotFound
+	}
+
+	if err := s.passwordPolicy.Validate("new_password", newPassword, user.Email, user.FullName); err != nil {
+		return err
+	}
+
+	newHashedPassword, err := s.passwordHasher.Hash(newPassword)
+	if err != nil {
+		return err
+	}
+
+	err = s.userRepo.WithinTransaction(ctx, func(ctx context.Context) error {
+		rowEffected, err := s.userRepo.UpdatePassword(ctx, user.ID, newHashedPassword)
+		if err != nil {
+			return err
+		}
+		if rowEffected == 0 {
+			return apperr.ErrUserNotFound
+		}
+
+		if _, err := s.refreshTokenRepo.RevokeAllByUserID(ctx, user.ID); err != nil {
+			return err
+		}
+
+		return s.eventPublisher.PublishPasswordResetSuccess(ctx, user.Email)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := s.tokenRevocation.RevokeUserTokens(ctx, user.ID.String()); err != nil {
+		return err
+	}
+
+	s.auditLog.Record(ctx, models.AuditEventPasswordReset, &user.ID, nil)
+	logger.Info("Reset password success",
+		zap.String("user_id", user.ID.String()),
+	)
+	return nil
+}
+
+// RequestEmailChange mails a confirmation link to the new address and a notice
+// to the current one. Nothing changes until the link is followed.
+func (s *authService) RequestEmailChange(ctx context.Context, userID string, newEmail string, password string) error {
+	logger := zaplogger.FromContext(ctx)
+
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if strings.EqualFold(user.Email, newEmail) {
+		return apperr.NewErrValidationFailedWithDetail(
+			"new_email",
+			apperr.CodeEmailUnchanged,
+			"New email must differ from the current email",
+		)
+	}
+
+	if !s.passwordHasher.Compare(user.HashedPassword, password) {
+		logger.Warn("Request email change failed: invalid password",
+			zap.String("user_id", user.ID.String()),
+		)
+		return apperr.ErrInvalidCurrentPassword
+	}
+
+	existedUser, err := s.userRepo.GetByEmail(ctx, newEmail)
+	if err != nil {
+		return err
+	}
+	if existedUser != nil {
+		return apperr.ErrEmailAlreadyExists
+	}
+
+	token, err := s.tokenCache.SetEmailChangeToken(ctx, &caching.EmailChange{
+		UserID:   user.ID.String(),
+		OldEmail: user.Email,
+		NewEmail: newEmail,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := s.eventPublisher.PublishEmailChangeRequested(ctx, user, newEmail, token); err != nil {
+		return err
+	}
+
+	logger.Info("Email change requested",
+		zap.String("user_id", user.ID.String()),
+	)
+	return nil
+}
+
+// ConfirmEmailChange swaps the address and signs the user out everywhere. The
+// update is conditional on the old address, so a token issued before another
+// change was confirmed cannot be used.
+func (s *authService) ConfirmEmailChange(ctx context.Context, token string) error {
+	logger := zaplogger.FromContext(ctx)
+
+	change, err := s.tokenCache.VerifyEmailChangeToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, caching.ErrTokenInvalidOrExpired) {
+			return apperr.ErrTokenInvalidOrExpired
+		}
+		return err
+	}
+
+	user, err := s.getUser(ctx, change.UserID)
+	if err != nil {
+		return err
+	}
+
+	err = s.userRepo.WithinTransaction(ctx, func(ctx context.Context) error {
+		rowEffected, err := s.userRepo.UpdateEmail(ctx, user.ID, change.OldEmail, change.NewEmail)
+		if err != nil {
+			if errors.Is(err, repository.ErrEmailTaken) {
+				return apperr.ErrEmailAlreadyExists
+			}
+			return err
+		}
+		if rowEffected == 0 {
+			return apperr.ErrTokenInvalidOrExpired
+		}
+
+		if _, err := s.refreshTokenRepo.RevokeAllByUserID(ctx, user.ID); err != nil {
+			return err
+		}
+
+		user.Email = change.NewEmail
+		return s.eventPublisher.PublishEmailChanged(ctx, user, change.OldEmail)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := s.tokenRevocation.RevokeUserTokens(ctx, user.ID.String()); err != nil {
+		return err
+	}
+
+	s.auditLog.Record(ctx, models.AuditEventEmailChange, &user.ID, nil)
+	logger.Info("Email change confirmed",
+		zap.String("user_id", user.ID.String()),
+	)
+	return nil
+}
+
+// EnrollTOTP starts enrollment with a fresh secret. The secret stays pending,
+// and Login keeps working without a code, until ConfirmTOTP proves the
+// authenticator app was set up.
+func (s *authService) EnrollTOTP(ctx context.Context, userID string) (string, string, error) {
+	logger := zaplogger.FromContext(ctx)
+
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return "", "", err
+	}
+	if user.IsTOTPEnabled() {
+		return "", "", apperr.ErrMFAAlreadyEnabled
+	}
+
+	secret, err := s.totpProvider.GenerateSecret()
+	if err != nil {
+		return "", "", err
+	}
+
+	rowEffected, err := s.userRepo.SetTOTPSecret(ctx, user.ID, secret)
+	if err != nil {
+		return "", "", err
+	}
+	if rowEffected == 0 {
+		return "", "", apperr.ErrMFAAlreadyEnabled
+	}
+
+	logger.Info("TOTP enrollment started", zap.String("user_id", user.ID.String()))
+	return secret, s.totpProvider.URI(secret, user.Email), nil
+}
+
+// ConfirmTOTP enables two-factor authentication and returns the recovery
+// codes. Only their hashes are stored, so they are shown this one time.
+func (s *authService) ConfirmTOTP(ctx context.Context, userID string, code string) ([]string, error) {
+	logger := zaplogger.FromContext(ctx)
+
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.IsTOTPEnabled() {
+		return nil, apperr.ErrMFAAlreadyEnabled
+	}
+	if user.TOTPSecret == nil {
+		return nil, apperr.ErrMFAEnrollmentNotStarted
+	}
+
+	if !s.totpProvider.Validate(*user.TOTPSecret, code, time.Now()) {
+		return nil, apperr.ErrInvalidMFACode
+	}
+	fresh, err := s.tokenCache.MarkTOTPCodeUsed(ctx, user.ID.String(), code)
+	if err != nil {
+		return nil, err
+	}
+	if !fresh {
+		return nil, apperr.ErrInvalidMFACode
+	}
+
+	recoveryCodes, err := totp.GenerateRecoveryCodes(totp.RecoveryCodeCount)
+	if err != nil {
+		return nil, err
+	}
+	codeHashes := make([]string, len(recoveryCodes))
+	for i, recoveryCode := range recoveryCodes {
+		codeHashes[i] = utils.HashToken(totp.NormalizeRecoveryCode(recoveryCode))
+	}
+
+	err = s.userRepo.WithinTransaction(ctx, func(ctx context.Context) error {
+		rowEffected, err := s.userRepo.EnableTOTP(ctx, user.ID)
+		if err != nil {
+			return err
+		}
+		if rowEffected == 0 {
+			return apperr.ErrMFAAlreadyEnabled
+		}
+
+		return s.userRepo.ReplaceRecoveryCodes(ctx, user.ID, codeHashes)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.auditLog.Record(ctx, models.AuditEventTOTPEnabled, &user.ID, nil)
+	logger.Info("TOTP enabled", zap.String("user_id", user.ID.String()))
+	return recoveryCodes, nil
+}
+
+// DisableTOTP needs both the password and a second factor, so neither a
+// stolen session nor a stolen password alone can turn protection off.
+func (s *authService) DisableTOTP(ctx context.Context, userID string, password string, code string) error {
+	logger := zaplogger.FromContext(ctx)
+
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !user.IsTOTPEnabled() {
+		return apperr.ErrMFANotEnabled
+	}
+
+	if !s.passwordHasher.Compare(user.HashedPassword, password) {
+		logger.Warn("Disable TOTP failed: invalid password", zap.String("user_id", user.ID.String()))
+		s.auditLog.Record(ctx, models.AuditEventTOTPDisabled, &user.ID, apperr.ErrInvalidCurrentPassword)
+		return apperr.ErrInvalidCurrentPassword
+	}
+
+	valid, err := s.verifySecondFactor(ctx, user, code)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		logger.Warn("Disable TOTP failed: invalid code", zap.String("user_id", user.ID.String()))
+		s.auditLog.Record(ctx, models.AuditEventTOTPDisabled, &user.ID, apperr.ErrInvalidMFACode)
+		return apperr.ErrInvalidMFACode
+	}
+
+	rowEffected, err := s.userRepo.DisableTOTP(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	if rowEffected == 0 {
+		return apperr.ErrUserNotFound
+	}
+
+	s.auditLog.Record(ctx, models.AuditEventTOTPDisabled, &user.ID, nil)
+	logger.Info("TOTP disabled", zap.String("user_id", user.ID.String()))
+	return nil
+}
+
+func (s *authService) getUser(ctx context.Context, userID string) (*models.User, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, apperr.ErrUserNotFound
+	}
+	return user, nil
+}
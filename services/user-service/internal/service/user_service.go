@@ -11,4 +11,6 @@ type UserService interface {
 	GetUserByID(ctx context.Context, userID string) (*models.User, error)
 	UpdateUser(ctx context.Context, userID string, updateData *request.UpdateUserRequest) (*models.User, error)
 	UpdateAvatar(ctx context.Context, userID string, avatarURL string) (*models.User, error)
+	RequestPhoneVerification(ctx context.Context, userID string, phone string) error
+	VerifyPhone(ctx context.Context, userID string, code string) (*models.User, error)
 }
@@ -2,26 +2,39 @@ package service
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	apperr "github.com/khoihuynh300/go-microservice/shared/pkg/errors"
 	zaplogger "github.com/khoihuynh300/go-microservice/shared/pkg/logger"
 	"github.com/khoihuynh300/go-microservice/shared/pkg/storage"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/caching"
 	"github.com/khoihuynh300/go-microservice/user-service/internal/domain/models"
 	"github.com/khoihuynh300/go-microservice/user-service/internal/dto/request"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/events/publisher"
 	"github.com/khoihuynh300/go-microservice/user-service/internal/repository"
 	"go.uber.org/zap"
 )
 
 type userService struct {
-	userRepo     repository.UserRepository
-	imageStorage storage.Storage
+	userRepo       repository.UserRepository
+	phoneOTP       *caching.PhoneOTPCache
+	imageStorage   storage.Storage
+	eventPublisher publisher.EventPublisher
 }
 
-func NewUserService(userRepo repository.UserRepository, imageStorage storage.Storage) UserService {
+func NewUserService(
+	userRepo repository.UserRepository,
+	phoneOTP *caching.PhoneOTPCache,
+	imageStorage storage.Storage,
+	eventPublisher publisher.EventPublisher,
+) UserService {
 	return &userService{
-		userRepo:     userRepo,
-		imageStorage: imageStorage,
+		userRepo:       userRepo,
+		phoneOTP:       phoneOTP,
+		imageStorage:   imageStorage,
+		eventPublisher: eventPublisher,
 	}
 }
 
@@ -119,3 +132,75 @@ func (s *userService) UpdateAvatar(ctx context.Context, userID string, avatarURL
 
 	return user, nil
 }
+
+// RequestPhoneVerification texts a one-time code to phone. The number is only
+// saved on the profile once VerifyPhone proves the user receives it.
+func (s *userService) RequestPhoneVerification(ctx context.Context, userID string, phone string) error {
+	logger := zaplogger.FromContext(ctx)
+
+	user, err := s.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.IsPhoneVerified() && *user.Phone == phone {
+		return apperr.ErrPhoneAlreadyVerified
+	}
+
+	owner, err := s.userRepo.GetByPhone(ctx, phone)
+	if err != nil {
+		return err
+	}
+	if owner != nil && owner.ID != user.ID {
+		return apperr.ErrPhoneAlreadyExists
+	}
+
+	code, err := s.phoneOTP.Issue(ctx, userID, phone)
+	if err != nil {
+		if errors.Is(err, caching.ErrOTPCooldown) {
+			return apperr.ErrRateLimitExceeded
+		}
+		return err
+	}
+
+	if err := s.eventPublisher.PublishPhoneVerificationRequested(ctx, user, phone, code, time.Now().Add(caching.PhoneOTPTTL)); err != nil {
+		return err
+	}
+
+	logger.Info("Phone verification code sent", zap.String("userID", userID))
+	return nil
+}
+
+func (s *userService) VerifyPhone(ctx context.Context, userID string, code string) (*models.User, error) {
+	logger := zaplogger.FromContext(ctx)
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	phone, err := s.phoneOTP.Verify(ctx, userID, code)
+	if err != nil {
+		switch {
+		case errors.Is(err, caching.ErrOTPInvalid):
+			return nil, apperr.ErrInvalidOTP
+		case errors.Is(err, caching.ErrOTPAttemptsExceeded):
+			return nil, apperr.ErrOTPAttemptsExceeded
+		}
+		return nil, err
+	}
+
+	rowEffected, err := s.userRepo.VerifyPhone(ctx, userUUID, phone)
+	if err != nil {
+		if errors.Is(err, repository.ErrPhoneTaken) {
+			return nil, apperr.ErrPhoneAlreadyExists
+		}
+		return nil, err
+	}
+	if rowEffected == 0 {
+		return nil, apperr.ErrUserNotFound
+	}
+
+	logger.Info("Phone verified", zap.String("userID", userID))
+
+	return s.GetUserByID(ctx, userID)
+}
@@ -47,3 +47,13 @@ func PtrToGenderEnum(p *models.Gender) sqlc.NullUserGenderEnum {
 		Valid:          true,
 	}
 }
+
+func PtrToStatusEnum(p *models.UserStatus) sqlc.NullUserStatusEnum {
+	if p == nil {
+		return sqlc.NullUserStatusEnum{}
+	}
+	return sqlc.NullUserStatusEnum{
+		UserStatusEnum: sqlc.UserStatusEnum(*p),
+		Valid:          true,
+	}
+}
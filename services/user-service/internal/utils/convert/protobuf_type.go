@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/google/uuid"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
@@ -20,6 +21,15 @@ func TimestampToTimePtr(ts *timestamppb.Timestamp) *time.Time {
 	return &t
 }
 
+func StringWrapperToPtr(s *wrapperspb.StringValue) *string {
+	if s == nil {
+		return nil
+	}
+
+	v := s.Value
+	return &v
+}
+
 func TimePtrToTimestamp(t *time.Time) *timestamppb.Timestamp {
 	if t == nil {
 		return nil
@@ -51,3 +61,10 @@ func GenericStringPtrToWrapper[T ~string](val *T) *wrapperspb.StringValue {
 	}
 	return wrapperspb.String(string(*val))
 }
+
+func UUIDPtrToStringWrapper(id *uuid.UUID) *wrapperspb.StringValue {
+	if id == nil {
+		return nil
+	}
+	return wrapperspb.String(id.String())
+}
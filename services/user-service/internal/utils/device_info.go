@@ -0,0 +1,56 @@
+package utils
+
+import "strings"
+
+var browsers = []struct{ token, name string }{
+	{"Edg/", "Edge"},
+	{"OPR/", "Opera"},
+	{"Firefox/", "Firefox"},
+	{"Chrome/", "Chrome"},
+	{"Safari/", "Safari"},
+}
+
+var platforms = []struct{ token, name string }{
+	{"Android", "Android"},
+	{"iPhone", "iOS"},
+	{"iPad", "iPadOS"},
+	{"Windows", "Windows"},
+	{"Mac OS X", "macOS"},
+	{"Linux", "Linux"},
+}
+
+// DeviceInfo returns a short human readable description such as
+// "Chrome on Windows" for a User-Agent header.
+func DeviceInfo(userAgent string) string {
+	if userAgent == "" {
+		return ""
+	}
+
+	browser := matchToken(userAgent, browsers)
+	platform := matchToken(userAgent, platforms)
+
+	switch {
+	case browser != "" && platform != "":
+		return browser + " on " + platform
+	case browser != "":
+		return browser
+	case platform != "":
+		return platform
+	}
+
+	// non-browser clients usually send "name/version ..."
+	name, _, _ := strings.Cut(userAgent, " ")
+	if len(name) > 255 {
+		name = name[:255]
+	}
+	return name
+}
+
+func matchToken(userAgent string, candidates []struct{ token, name string }) string {
+	for _, c := range candidates {
+		if strings.Contains(userAgent, c.token) {
+			return c.name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_users_role;
+
+ALTER TABLE users DROP COLUMN IF EXISTS role;
+
+DROP TYPE IF EXISTS user_role_enum;
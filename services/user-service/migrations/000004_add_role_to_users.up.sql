@@ -0,0 +1,5 @@
+CREATE TYPE user_role_enum AS ENUM ('customer', 'staff', 'admin');
+
+ALTER TABLE users ADD COLUMN role user_role_enum NOT NULL DEFAULT 'customer';
+
+CREATE INDEX idx_users_role ON users(role);
@@ -0,0 +1,18 @@
+CREATE TABLE outbox_events (
+    id BIGSERIAL PRIMARY KEY,
+    event_id UUID NOT NULL UNIQUE,
+    topic VARCHAR(255) NOT NULL,
+    partition_key VARCHAR(255) NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    trace_id VARCHAR(100) NOT NULL DEFAULT '',
+    payload JSONB NOT NULL,
+    occurred_at TIMESTAMPTZ NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    published_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_outbox_events_pending ON outbox_events(id) WHERE published_at IS NULL;
+CREATE INDEX idx_outbox_events_published_at ON outbox_events(published_at) WHERE published_at IS NOT NULL;
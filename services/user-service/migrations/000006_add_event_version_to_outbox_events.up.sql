@@ -0,0 +1 @@
+ALTER TABLE outbox_events ADD COLUMN event_version INTEGER NOT NULL DEFAULT 1;
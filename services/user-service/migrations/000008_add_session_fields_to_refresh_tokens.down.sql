@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_refresh_tokens_user_id;
+
+ALTER TABLE refresh_tokens DROP COLUMN IF EXISTS last_used_at;
@@ -0,0 +1,3 @@
+ALTER TABLE refresh_tokens ADD COLUMN last_used_at TIMESTAMPTZ;
+
+CREATE INDEX idx_refresh_tokens_user_id ON refresh_tokens(user_id);
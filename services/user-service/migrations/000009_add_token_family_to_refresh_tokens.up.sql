@@ -0,0 +1,11 @@
+ALTER TABLE refresh_tokens
+    ADD COLUMN family_id UUID,
+    ADD COLUMN parent_id UUID REFERENCES refresh_tokens(id) ON DELETE SET NULL,
+    ADD COLUMN rotated_at TIMESTAMPTZ;
+
+-- every existing token starts its own family
+UPDATE refresh_tokens SET family_id = id;
+
+ALTER TABLE refresh_tokens ALTER COLUMN family_id SET NOT NULL;
+
+CREATE INDEX idx_refresh_tokens_family_id ON refresh_tokens(family_id);
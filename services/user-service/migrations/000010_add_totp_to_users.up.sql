@@ -0,0 +1,14 @@
+ALTER TABLE users
+    ADD COLUMN totp_secret VARCHAR(64),
+    ADD COLUMN totp_enabled_at TIMESTAMPTZ;
+
+CREATE TABLE user_recovery_codes (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    code_hash VARCHAR(64) NOT NULL,
+    used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX idx_user_recovery_codes_user_code ON user_recovery_codes(user_id, code_hash);
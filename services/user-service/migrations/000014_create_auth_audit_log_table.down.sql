@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS trg_auth_audit_log_append_only ON auth_audit_log;
+DROP FUNCTION IF EXISTS reject_auth_audit_log_update();
+DROP TABLE IF EXISTS auth_audit_log;
@@ -0,0 +1,26 @@
+CREATE TABLE auth_audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID,
+    actor_id UUID,
+    event_type VARCHAR(50) NOT NULL,
+    success BOOLEAN NOT NULL,
+    reason VARCHAR(100),
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    trace_id VARCHAR(100) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_auth_audit_log_user_id_created_at ON auth_audit_log(user_id, created_at DESC);
+CREATE INDEX idx_auth_audit_log_created_at ON auth_audit_log(created_at);
+
+-- entries may only be removed by the retention job, never rewritten
+CREATE FUNCTION reject_auth_audit_log_update() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'auth_audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_auth_audit_log_append_only
+    BEFORE UPDATE ON auth_audit_log
+    FOR EACH ROW EXECUTE FUNCTION reject_auth_audit_log_update();
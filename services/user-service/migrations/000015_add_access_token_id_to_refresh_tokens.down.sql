@@ -0,0 +1,2 @@
+ALTER TABLE refresh_tokens
+    DROP COLUMN IF EXISTS access_token_id;
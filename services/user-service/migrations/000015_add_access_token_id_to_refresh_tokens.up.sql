@@ -0,0 +1,4 @@
+-- jti of the access token issued alongside each refresh token, so ending a
+-- session can also deny its access tokens at the gateway
+ALTER TABLE refresh_tokens
+    ADD COLUMN access_token_id VARCHAR(64) NOT NULL DEFAULT '';
@@ -0,0 +1,6 @@
+-- events that keep failing are parked instead of being retried forever
+ALTER TABLE outbox_events ADD COLUMN failed_at TIMESTAMPTZ;
+
+DROP INDEX IF EXISTS idx_outbox_events_pending;
+CREATE INDEX idx_outbox_events_pending ON outbox_events(id) WHERE published_at IS NULL AND failed_at IS NULL;
+CREATE INDEX idx_outbox_events_pending_key ON outbox_events(partition_key, id) WHERE published_at IS NULL AND failed_at IS NULL;
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/khoihuynh300/go-microservice/user-service/internal/client (interfaces: OrderClient)
+
+// Package mock_client is a generated GoMock package.
+package mock_client
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	orderpb "github.com/khoihuynh300/go-microservice/shared/proto/order"
+)
+
+// MockOrderClient is a mock of OrderClient interface.
+type MockOrderClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderClientMockRecorder
+}
+
+// MockOrderClientMockRecorder is the mock recorder for MockOrderClient.
+type MockOrderClientMockRecorder struct {
+	mock *MockOrderClient
+}
+
+// NewMockOrderClient creates a new mock instance.
+func NewMockOrderClient(ctrl *gomock.Controller) *MockOrderClient {
+	mock := &MockOrderClient{ctrl: ctrl}
+	mock.recorder = &MockOrderClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderClient) EXPECT() *MockOrderClientMockRecorder {
+	return m.recorder
+}
+
+// ListMyOrders mocks base method.
+func (m *MockOrderClient) ListMyOrders(arg0 context.Context, arg1, arg2 int32) (*orderpb.ListOrdersResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMyOrders", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*orderpb.ListOrdersResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMyOrders indicates an expected call of ListMyOrders.
+func (mr *MockOrderClientMockRecorder) ListMyOrders(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMyOrders", reflect.TypeOf((*MockOrderClient)(nil).ListMyOrders), arg0, arg1, arg2)
+}
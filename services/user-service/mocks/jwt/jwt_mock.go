@@ -37,12 +37,13 @@ func (m *MockJwtProvider) EXPECT() *MockJwtProviderMockRecorder {
 }
 
 // GenerateAccessToken mocks base method.
-func (m *MockJwtProvider) GenerateAccessToken(arg0 *models.User) (string, error) {
+func (m *MockJwtProvider) GenerateAccessToken(arg0 *models.User) (string, string, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GenerateAccessToken", arg0)
 	ret0, _ := ret[0].(string)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // GenerateAccessToken indicates an expected call of GenerateAccessToken.
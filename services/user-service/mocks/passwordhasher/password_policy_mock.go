@@ -0,0 +1,48 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/khoihuynh300/go-microservice/user-service/internal/security/password (interfaces: PasswordPolicy)
+
+// Package mock_password_hasher is a generated GoMock package.
+package mock_password_hasher
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPasswordPolicy is a mock of PasswordPolicy interface.
+type MockPasswordPolicy struct {
+	ctrl     *gomock.Controller
+	recorder *MockPasswordPolicyMockRecorder
+}
+
+// MockPasswordPolicyMockRecorder is the mock recorder for MockPasswordPolicy.
+type MockPasswordPolicyMockRecorder struct {
+	mock *MockPasswordPolicy
+}
+
+// NewMockPasswordPolicy creates a new mock instance.
+func NewMockPasswordPolicy(ctrl *gomock.Controller) *MockPasswordPolicy {
+	mock := &MockPasswordPolicy{ctrl: ctrl}
+	mock.recorder = &MockPasswordPolicyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPasswordPolicy) EXPECT() *MockPasswordPolicyMockRecorder {
+	return m.recorder
+}
+
+// Validate mocks base method.
+func (m *MockPasswordPolicy) Validate(arg0, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validate", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Validate indicates an expected call of Validate.
+func (mr *MockPasswordPolicyMockRecorder) Validate(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockPasswordPolicy)(nil).Validate), arg0, arg1, arg2, arg3)
+}
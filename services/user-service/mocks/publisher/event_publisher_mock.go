@@ -0,0 +1,261 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/khoihuynh300/go-microservice/user-service/internal/events/publisher (interfaces: EventPublisher)
+
+// Package mock_publisher is a generated GoMock package.
+package mock_publisher
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/khoihuynh300/go-microservice/user-service/internal/domain/models"
+)
+
+// MockEventPublisher is a mock of EventPublisher interface.
+type MockEventPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventPublisherMockRecorder
+}
+
+// MockEventPublisherMockRecorder is the mock recorder for MockEventPublisher.
+type MockEventPublisherMockRecorder struct {
+	mock *MockEventPublisher
+}
+
+// NewMockEventPublisher creates a new mock instance.
+func NewMockEventPublisher(ctrl *gomock.Controller) *MockEventPublisher {
+	mock := &MockEventPublisher{ctrl: ctrl}
+	mock.recorder = &MockEventPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventPublisher) EXPECT() *MockEventPublisherMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockEventPublisher) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockEventPublisherMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockEventPublisher)(nil).Close))
+}
+
+// PublishAccountLocked mocks base method.
+func (m *MockEventPublisher) PublishAccountLocked(arg0 context.Context, arg1 *models.User, arg2, arg3 string, arg4 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishAccountLocked", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishAccountLocked indicates an expected call of PublishAccountLocked.
+func (mr *MockEventPublisherMockRecorder) PublishAccountLocked(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishAccountLocked", reflect.TypeOf((*MockEventPublisher)(nil).PublishAccountLocked), arg0, arg1, arg2, arg3, arg4)
+}
+
+// PublishDataExportReady mocks base method.
+func (m *MockEventPublisher) PublishDataExportReady(arg0 context.Context, arg1 *models.User, arg2 string, arg3 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishDataExportReady", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishDataExportReady indicates an expected call of PublishDataExportReady.
+func (mr *MockEventPublisherMockRecorder) PublishDataExportReady(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishDataExportReady", reflect.TypeOf((*MockEventPublisher)(nil).PublishDataExportReady), arg0, arg1, arg2, arg3)
+}
+
+// PublishEmailChangeRequested mocks base method.
+func (m *MockEventPublisher) PublishEmailChangeRequested(arg0 context.Context, arg1 *models.User, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishEmailChangeRequested", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishEmailChangeRequested indicates an expected call of PublishEmailChangeRequested.
+func (mr *MockEventPublisherMockRecorder) PublishEmailChangeRequested(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishEmailChangeRequested", reflect.TypeOf((*MockEventPublisher)(nil).PublishEmailChangeRequested), arg0, arg1, arg2, arg3)
+}
+
+// PublishEmailChanged mocks base method.
+func (m *MockEventPublisher) PublishEmailChanged(arg0 context.Context, arg1 *models.User, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishEmailChanged", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishEmailChanged indicates an expected call of PublishEmailChanged.
+func (mr *MockEventPublisherMockRecorder) PublishEmailChanged(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishEmailChanged", reflect.TypeOf((*MockEventPublisher)(nil).PublishEmailChanged), arg0, arg1, arg2)
+}
+
+// PublishEmailVerifySuccess mocks base method.
+func (m *MockEventPublisher) PublishEmailVerifySuccess(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishEmailVerifySuccess", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishEmailVerifySuccess indicates an expected call of PublishEmailVerifySuccess.
+func (mr *MockEventPublisherMockRecorder) PublishEmailVerifySuccess(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishEmailVerifySuccess", reflect.TypeOf((*MockEventPublisher)(nil).PublishEmailVerifySuccess), arg0, arg1)
+}
+
+// PublishForgotPassword mocks base method.
+func (m *MockEventPublisher) PublishForgotPassword(arg0 context.Context, arg1 *models.User, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishForgotPassword", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishForgotPassword indicates an expected call of PublishForgotPassword.
+func (mr *MockEventPublisherMockRecorder) PublishForgotPassword(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishForgotPassword", reflect.TypeOf((*MockEventPublisher)(nil).PublishForgotPassword), arg0, arg1, arg2)
+}
+
+// PublishMagicLinkRequested mocks base method.
+func (m *MockEventPublisher) PublishMagicLinkRequested(arg0 context.Context, arg1 *models.User, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishMagicLinkRequested", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishMagicLinkRequested indicates an expected call of PublishMagicLinkRequested.
+func (mr *MockEventPublisherMockRecorder) PublishMagicLinkRequested(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishMagicLinkRequested", reflect.TypeOf((*MockEventPublisher)(nil).PublishMagicLinkRequested), arg0, arg1, arg2)
+}
+
+// PublishPasswordResetForced mocks base method.
+func (m *MockEventPublisher) PublishPasswordResetForced(arg0 context.Context, arg1 *models.User, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishPasswordResetForced", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishPasswordResetForced indicates an expected call of PublishPasswordResetForced.
+func (mr *MockEventPublisherMockRecorder) PublishPasswordResetForced(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishPasswordResetForced", reflect.TypeOf((*MockEventPublisher)(nil).PublishPasswordResetForced), arg0, arg1, arg2)
+}
+
+// PublishPasswordResetSuccess mocks base method.
+func (m *MockEventPublisher) PublishPasswordResetSuccess(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishPasswordResetSuccess", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishPasswordResetSuccess indicates an expected call of PublishPasswordResetSuccess.
+func (mr *MockEventPublisherMockRecorder) PublishPasswordResetSuccess(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishPasswordResetSuccess", reflect.TypeOf((*MockEventPublisher)(nil).PublishPasswordResetSuccess), arg0, arg1)
+}
+
+// PublishPhoneVerificationRequested mocks base method.
+func (m *MockEventPublisher) PublishPhoneVerificationRequested(arg0 context.Context, arg1 *models.User, arg2, arg3 string, arg4 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishPhoneVerificationRequested", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishPhoneVerificationRequested indicates an expected call of PublishPhoneVerificationRequested.
+func (mr *MockEventPublisherMockRecorder) PublishPhoneVerificationRequested(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishPhoneVerificationRequested", reflect.TypeOf((*MockEventPublisher)(nil).PublishPhoneVerificationRequested), arg0, arg1, arg2, arg3, arg4)
+}
+
+// PublishRefreshTokenReused mocks base method.
+func (m *MockEventPublisher) PublishRefreshTokenReused(arg0 context.Context, arg1 *models.User, arg2, arg3, arg4 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishRefreshTokenReused", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishRefreshTokenReused indicates an expected call of PublishRefreshTokenReused.
+func (mr *MockEventPublisherMockRecorder) PublishRefreshTokenReused(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishRefreshTokenReused", reflect.TypeOf((*MockEventPublisher)(nil).PublishRefreshTokenReused), arg0, arg1, arg2, arg3, arg4)
+}
+
+// PublishSessionsRevoked mocks base method.
+func (m *MockEventPublisher) PublishSessionsRevoked(arg0 context.Context, arg1 *models.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishSessionsRevoked", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishSessionsRevoked indicates an expected call of PublishSessionsRevoked.
+func (mr *MockEventPublisherMockRecorder) PublishSessionsRevoked(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishSessionsRevoked", reflect.TypeOf((*MockEventPublisher)(nil).PublishSessionsRevoked), arg0, arg1)
+}
+
+// PublishUserReactivated mocks base method.
+func (m *MockEventPublisher) PublishUserReactivated(arg0 context.Context, arg1 *models.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishUserReactivated", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishUserReactivated indicates an expected call of PublishUserReactivated.
+func (mr *MockEventPublisherMockRecorder) PublishUserReactivated(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishUserReactivated", reflect.TypeOf((*MockEventPublisher)(nil).PublishUserReactivated), arg0, arg1)
+}
+
+// PublishUserSuspended mocks base method.
+func (m *MockEventPublisher) PublishUserSuspended(arg0 context.Context, arg1 *models.User, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishUserSuspended", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishUserSuspended indicates an expected call of PublishUserSuspended.
+func (mr *MockEventPublisherMockRecorder) PublishUserSuspended(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishUserSuspended", reflect.TypeOf((*MockEventPublisher)(nil).PublishUserSuspended), arg0, arg1, arg2)
+}
+
+// PublishVerifyEmail mocks base method.
+func (m *MockEventPublisher) PublishVerifyEmail(arg0 context.Context, arg1 *models.User, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishVerifyEmail", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishVerifyEmail indicates an expected call of PublishVerifyEmail.
+func (mr *MockEventPublisherMockRecorder) PublishVerifyEmail(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishVerifyEmail", reflect.TypeOf((*MockEventPublisher)(nil).PublishVerifyEmail), arg0, arg1, arg2)
+}
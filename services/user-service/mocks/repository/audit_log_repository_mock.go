@@ -0,0 +1,83 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/khoihuynh300/go-microservice/user-service/internal/repository (interfaces: AuditLogRepository)
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/khoihuynh300/go-microservice/user-service/internal/domain/models"
+	repository "github.com/khoihuynh300/go-microservice/user-service/internal/repository"
+)
+
+// MockAuditLogRepository is a mock of AuditLogRepository interface.
+type MockAuditLogRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditLogRepositoryMockRecorder
+}
+
+// MockAuditLogRepositoryMockRecorder is the mock recorder for MockAuditLogRepository.
+type MockAuditLogRepositoryMockRecorder struct {
+	mock *MockAuditLogRepository
+}
+
+// NewMockAuditLogRepository creates a new mock instance.
+func NewMockAuditLogRepository(ctrl *gomock.Controller) *MockAuditLogRepository {
+	mock := &MockAuditLogRepository{ctrl: ctrl}
+	mock.recorder = &MockAuditLogRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditLogRepository) EXPECT() *MockAuditLogRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAuditLogRepository) Create(arg0 context.Context, arg1 *models.AuditLogEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAuditLogRepositoryMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAuditLogRepository)(nil).Create), arg0, arg1)
+}
+
+// DeleteBefore mocks base method.
+func (m *MockAuditLogRepository) DeleteBefore(arg0 context.Context, arg1 time.Time, arg2 int32) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBefore", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteBefore indicates an expected call of DeleteBefore.
+func (mr *MockAuditLogRepositoryMockRecorder) DeleteBefore(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBefore", reflect.TypeOf((*MockAuditLogRepository)(nil).DeleteBefore), arg0, arg1, arg2)
+}
+
+// List mocks base method.
+func (m *MockAuditLogRepository) List(arg0 context.Context, arg1 *repository.AuditLogFilter, arg2, arg3 int32) ([]*models.AuditLogEntry, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*models.AuditLogEntry)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockAuditLogRepositoryMockRecorder) List(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAuditLogRepository)(nil).List), arg0, arg1, arg2, arg3)
+}
@@ -7,6 +7,7 @@ package mock_repository
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
@@ -80,6 +81,96 @@ func (mr *MockRefreshTokenRepositoryMockRecorder) GetByToken(arg0, arg1 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByToken", reflect.TypeOf((*MockRefreshTokenRepository)(nil).GetByToken), arg0, arg1)
 }
 
+// ListByFamilyID mocks base method.
+func (m *MockRefreshTokenRepository) ListByFamilyID(arg0 context.Context, arg1 uuid.UUID, arg2 time.Time) ([]*models.RefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByFamilyID", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*models.RefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByFamilyID indicates an expected call of ListByFamilyID.
+func (mr *MockRefreshTokenRepositoryMockRecorder) ListByFamilyID(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByFamilyID", reflect.TypeOf((*MockRefreshTokenRepository)(nil).ListByFamilyID), arg0, arg1, arg2)
+}
+
+// ListSessionsByUserID mocks base method.
+func (m *MockRefreshTokenRepository) ListSessionsByUserID(arg0 context.Context, arg1 uuid.UUID) ([]*models.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSessionsByUserID", arg0, arg1)
+	ret0, _ := ret[0].([]*models.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessionsByUserID indicates an expected call of ListSessionsByUserID.
+func (mr *MockRefreshTokenRepositoryMockRecorder) ListSessionsByUserID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessionsByUserID", reflect.TypeOf((*MockRefreshTokenRepository)(nil).ListSessionsByUserID), arg0, arg1)
+}
+
+// MarkRotated mocks base method.
+func (m *MockRefreshTokenRepository) MarkRotated(arg0 context.Context, arg1 uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRotated", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkRotated indicates an expected call of MarkRotated.
+func (mr *MockRefreshTokenRepositoryMockRecorder) MarkRotated(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRotated", reflect.TypeOf((*MockRefreshTokenRepository)(nil).MarkRotated), arg0, arg1)
+}
+
+// RevokeAllByUserID mocks base method.
+func (m *MockRefreshTokenRepository) RevokeAllByUserID(arg0 context.Context, arg1 uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAllByUserID", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeAllByUserID indicates an expected call of RevokeAllByUserID.
+func (mr *MockRefreshTokenRepositoryMockRecorder) RevokeAllByUserID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAllByUserID", reflect.TypeOf((*MockRefreshTokenRepository)(nil).RevokeAllByUserID), arg0, arg1)
+}
+
+// RevokeFamily mocks base method.
+func (m *MockRefreshTokenRepository) RevokeFamily(arg0 context.Context, arg1 uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeFamily", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeFamily indicates an expected call of RevokeFamily.
+func (mr *MockRefreshTokenRepositoryMockRecorder) RevokeFamily(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeFamily", reflect.TypeOf((*MockRefreshTokenRepository)(nil).RevokeFamily), arg0, arg1)
+}
+
+// RevokeFamilyByUserID mocks base method.
+func (m *MockRefreshTokenRepository) RevokeFamilyByUserID(arg0 context.Context, arg1, arg2 uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeFamilyByUserID", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeFamilyByUserID indicates an expected call of RevokeFamilyByUserID.
+func (mr *MockRefreshTokenRepositoryMockRecorder) RevokeFamilyByUserID(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeFamilyByUserID", reflect.TypeOf((*MockRefreshTokenRepository)(nil).RevokeFamilyByUserID), arg0, arg1, arg2)
+}
+
 // WithinTransaction mocks base method.
 func (m *MockRefreshTokenRepository) WithinTransaction(arg0 context.Context, arg1 func(context.Context) error) error {
 	m.ctrl.T.Helper()
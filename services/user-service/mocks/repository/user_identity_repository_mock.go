@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/khoihuynh300/go-microservice/user-service/internal/repository (interfaces: UserIdentityRepository)
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	models "github.com/khoihuynh300/go-microservice/user-service/internal/domain/models"
+)
+
+// MockUserIdentityRepository is a mock of UserIdentityRepository interface.
+type MockUserIdentityRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserIdentityRepositoryMockRecorder
+}
+
+// MockUserIdentityRepositoryMockRecorder is the mock recorder for MockUserIdentityRepository.
+type MockUserIdentityRepositoryMockRecorder struct {
+	mock *MockUserIdentityRepository
+}
+
+// NewMockUserIdentityRepository creates a new mock instance.
+func NewMockUserIdentityRepository(ctrl *gomock.Controller) *MockUserIdentityRepository {
+	mock := &MockUserIdentityRepository{ctrl: ctrl}
+	mock.recorder = &MockUserIdentityRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserIdentityRepository) EXPECT() *MockUserIdentityRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockUserIdentityRepository) Create(arg0 context.Context, arg1 *models.UserIdentity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockUserIdentityRepositoryMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUserIdentityRepository)(nil).Create), arg0, arg1)
+}
+
+// GetByProviderSubject mocks base method.
+func (m *MockUserIdentityRepository) GetByProviderSubject(arg0 context.Context, arg1, arg2 string) (*models.UserIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByProviderSubject", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.UserIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByProviderSubject indicates an expected call of GetByProviderSubject.
+func (mr *MockUserIdentityRepositoryMockRecorder) GetByProviderSubject(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByProviderSubject", reflect.TypeOf((*MockUserIdentityRepository)(nil).GetByProviderSubject), arg0, arg1, arg2)
+}
+
+// RecordLogin mocks base method.
+func (m *MockUserIdentityRepository) RecordLogin(arg0 context.Context, arg1 uuid.UUID, arg2 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordLogin", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordLogin indicates an expected call of RecordLogin.
+func (mr *MockUserIdentityRepositoryMockRecorder) RecordLogin(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordLogin", reflect.TypeOf((*MockUserIdentityRepository)(nil).RecordLogin), arg0, arg1, arg2)
+}
+
+// WithinTransaction mocks base method.
+func (m *MockUserIdentityRepository) WithinTransaction(arg0 context.Context, arg1 func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithinTransaction", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithinTransaction indicates an expected call of WithinTransaction.
+func (mr *MockUserIdentityRepositoryMockRecorder) WithinTransaction(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithinTransaction", reflect.TypeOf((*MockUserIdentityRepository)(nil).WithinTransaction), arg0, arg1)
+}
@@ -7,10 +7,12 @@ package mock_repository
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
 	models "github.com/khoihuynh300/go-microservice/user-service/internal/domain/models"
+	repository "github.com/khoihuynh300/go-microservice/user-service/internal/repository"
 )
 
 // MockUserRepository is a mock of UserRepository interface.
@@ -36,6 +38,21 @@ func (m *MockUserRepository) EXPECT() *MockUserRepositoryMockRecorder {
 	return m.recorder
 }
 
+// Anonymize mocks base method.
+func (m *MockUserRepository) Anonymize(arg0 context.Context, arg1 uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Anonymize", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Anonymize indicates an expected call of Anonymize.
+func (mr *MockUserRepositoryMockRecorder) Anonymize(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Anonymize", reflect.TypeOf((*MockUserRepository)(nil).Anonymize), arg0, arg1)
+}
+
 // Create mocks base method.
 func (m *MockUserRepository) Create(arg0 context.Context, arg1 *models.User) error {
 	m.ctrl.T.Helper()
@@ -50,6 +67,36 @@ func (mr *MockUserRepositoryMockRecorder) Create(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUserRepository)(nil).Create), arg0, arg1)
 }
 
+// DisableTOTP mocks base method.
+func (m *MockUserRepository) DisableTOTP(arg0 context.Context, arg1 uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableTOTP", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisableTOTP indicates an expected call of DisableTOTP.
+func (mr *MockUserRepositoryMockRecorder) DisableTOTP(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableTOTP", reflect.TypeOf((*MockUserRepository)(nil).DisableTOTP), arg0, arg1)
+}
+
+// EnableTOTP mocks base method.
+func (m *MockUserRepository) EnableTOTP(arg0 context.Context, arg1 uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableTOTP", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnableTOTP indicates an expected call of EnableTOTP.
+func (mr *MockUserRepositoryMockRecorder) EnableTOTP(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableTOTP", reflect.TypeOf((*MockUserRepository)(nil).EnableTOTP), arg0, arg1)
+}
+
 // GetByEmail mocks base method.
 func (m *MockUserRepository) GetByEmail(arg0 context.Context, arg1 string) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -80,6 +127,81 @@ func (mr *MockUserRepositoryMockRecorder) GetByID(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockUserRepository)(nil).GetByID), arg0, arg1)
 }
 
+// GetByPhone mocks base method.
+func (m *MockUserRepository) GetByPhone(arg0 context.Context, arg1 string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByPhone", arg0, arg1)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByPhone indicates an expected call of GetByPhone.
+func (mr *MockUserRepositoryMockRecorder) GetByPhone(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByPhone", reflect.TypeOf((*MockUserRepository)(nil).GetByPhone), arg0, arg1)
+}
+
+// List mocks base method.
+func (m *MockUserRepository) List(arg0 context.Context, arg1 *repository.UserFilter, arg2, arg3 int32) ([]*models.User, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*models.User)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockUserRepositoryMockRecorder) List(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUserRepository)(nil).List), arg0, arg1, arg2, arg3)
+}
+
+// ListPendingAnonymization mocks base method.
+func (m *MockUserRepository) ListPendingAnonymization(arg0 context.Context, arg1 time.Time, arg2 int32) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingAnonymization", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingAnonymization indicates an expected call of ListPendingAnonymization.
+func (mr *MockUserRepositoryMockRecorder) ListPendingAnonymization(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingAnonymization", reflect.TypeOf((*MockUserRepository)(nil).ListPendingAnonymization), arg0, arg1, arg2)
+}
+
+// ReplaceRecoveryCodes mocks base method.
+func (m *MockUserRepository) ReplaceRecoveryCodes(arg0 context.Context, arg1 uuid.UUID, arg2 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceRecoveryCodes", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceRecoveryCodes indicates an expected call of ReplaceRecoveryCodes.
+func (mr *MockUserRepositoryMockRecorder) ReplaceRecoveryCodes(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceRecoveryCodes", reflect.TypeOf((*MockUserRepository)(nil).ReplaceRecoveryCodes), arg0, arg1, arg2)
+}
+
+// SetTOTPSecret mocks base method.
+func (m *MockUserRepository) SetTOTPSecret(arg0 context.Context, arg1 uuid.UUID, arg2 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTOTPSecret", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetTOTPSecret indicates an expected call of SetTOTPSecret.
+func (mr *MockUserRepositoryMockRecorder) SetTOTPSecret(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTOTPSecret", reflect.TypeOf((*MockUserRepository)(nil).SetTOTPSecret), arg0, arg1, arg2)
+}
+
 // SoftDelete mocks base method.
 func (m *MockUserRepository) SoftDelete(arg0 context.Context, arg1 uuid.UUID) (int64, error) {
 	m.ctrl.T.Helper()
@@ -125,6 +247,21 @@ func (mr *MockUserRepositoryMockRecorder) UpdateAvatar(arg0, arg1, arg2 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAvatar", reflect.TypeOf((*MockUserRepository)(nil).UpdateAvatar), arg0, arg1, arg2)
 }
 
+// UpdateEmail mocks base method.
+func (m *MockUserRepository) UpdateEmail(arg0 context.Context, arg1 uuid.UUID, arg2, arg3 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEmail", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateEmail indicates an expected call of UpdateEmail.
+func (mr *MockUserRepositoryMockRecorder) UpdateEmail(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEmail", reflect.TypeOf((*MockUserRepository)(nil).UpdateEmail), arg0, arg1, arg2, arg3)
+}
+
 // UpdatePassword mocks base method.
 func (m *MockUserRepository) UpdatePassword(arg0 context.Context, arg1 uuid.UUID, arg2 string) (int64, error) {
 	m.ctrl.T.Helper()
@@ -155,6 +292,21 @@ func (mr *MockUserRepositoryMockRecorder) UpdateStatus(arg0, arg1, arg2 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockUserRepository)(nil).UpdateStatus), arg0, arg1, arg2)
 }
 
+// UseRecoveryCode mocks base method.
+func (m *MockUserRepository) UseRecoveryCode(arg0 context.Context, arg1 uuid.UUID, arg2 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseRecoveryCode", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MockUserRepositoryMockRecorder) UseRecoveryCode(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*MockUserRepository)(nil).UseRecoveryCode), arg0, arg1, arg2)
+}
+
 // VerifyEmail mocks base method.
 func (m *MockUserRepository) VerifyEmail(arg0 context.Context, arg1 uuid.UUID) (int64, error) {
 	m.ctrl.T.Helper()
@@ -170,6 +322,21 @@ func (mr *MockUserRepositoryMockRecorder) VerifyEmail(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockUserRepository)(nil).VerifyEmail), arg0, arg1)
 }
 
+// VerifyPhone mocks base method.
+func (m *MockUserRepository) VerifyPhone(arg0 context.Context, arg1 uuid.UUID, arg2 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyPhone", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyPhone indicates an expected call of VerifyPhone.
+func (mr *MockUserRepositoryMockRecorder) VerifyPhone(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyPhone", reflect.TypeOf((*MockUserRepository)(nil).VerifyPhone), arg0, arg1, arg2)
+}
+
 // WithinTransaction mocks base method.
 func (m *MockUserRepository) WithinTransaction(arg0 context.Context, arg1 func(context.Context) error) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,78 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/khoihuynh300/go-microservice/user-service/internal/security/totp (interfaces: TOTPProvider)
+
+// Package mock_totp is a generated GoMock package.
+package mock_totp
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTOTPProvider is a mock of TOTPProvider interface.
+type MockTOTPProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockTOTPProviderMockRecorder
+}
+
+// MockTOTPProviderMockRecorder is the mock recorder for MockTOTPProvider.
+type MockTOTPProviderMockRecorder struct {
+	mock *MockTOTPProvider
+}
+
+// NewMockTOTPProvider creates a new mock instance.
+func NewMockTOTPProvider(ctrl *gomock.Controller) *MockTOTPProvider {
+	mock := &MockTOTPProvider{ctrl: ctrl}
+	mock.recorder = &MockTOTPProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTOTPProvider) EXPECT() *MockTOTPProviderMockRecorder {
+	return m.recorder
+}
+
+// GenerateSecret mocks base method.
+func (m *MockTOTPProvider) GenerateSecret() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateSecret")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateSecret indicates an expected call of GenerateSecret.
+func (mr *MockTOTPProviderMockRecorder) GenerateSecret() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateSecret", reflect.TypeOf((*MockTOTPProvider)(nil).GenerateSecret))
+}
+
+// URI mocks base method.
+func (m *MockTOTPProvider) URI(arg0, arg1 string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "URI", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// URI indicates an expected call of URI.
+func (mr *MockTOTPProviderMockRecorder) URI(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "URI", reflect.TypeOf((*MockTOTPProvider)(nil).URI), arg0, arg1)
+}
+
+// Validate mocks base method.
+func (m *MockTOTPProvider) Validate(arg0, arg1 string, arg2 time.Time) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validate", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Validate indicates an expected call of Validate.
+func (mr *MockTOTPProviderMockRecorder) Validate(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockTOTPProvider)(nil).Validate), arg0, arg1, arg2)
+}
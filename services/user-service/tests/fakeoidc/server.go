@@ -0,0 +1,247 @@
+// Package fakeoidc is a minimal OpenID Connect provider for tests. It supports
+// discovery, the authorization code flow with S256 PKCE, and a JWKS.
+package fakeoidc
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/jwks"
+)
+
+const keyID = "fake-oidc"
+
+// User is the account the provider signs in as.
+type User struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+type authRequest struct {
+	user          User
+	redirectURI   string
+	nonce         string
+	codeChallenge string
+}
+
+type Server struct {
+	*httptest.Server
+	ClientID     string
+	ClientSecret string
+
+	key *rsa.PrivateKey
+
+	mu    sync.Mutex
+	user  User
+	codes map[string]authRequest
+}
+
+func NewServer(clientID, clientSecret string) (*Server, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		key:          key,
+		codes:        make(map[string]authRequest),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", s.discovery)
+	mux.HandleFunc("GET /authorize", s.authorize)
+	mux.HandleFunc("POST /token", s.token)
+	mux.HandleFunc("GET /jwks", s.jwks)
+	s.Server = httptest.NewServer(mux)
+
+	return s, nil
+}
+
+// SetUser selects the account that the next authorization signs in as.
+func (s *Server) SetUser(user User) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.user = user
+}
+
+// Authorize plays the browser: it follows the authorization URL and returns
+// the code and state the provider redirects back with.
+func (s *Server) Authorize(authURL string) (string, string, error) {
+	client := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	resp, err := client.Get(authURL)
+	if err != nil {
+		return "", "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusFound {
+		return "", "", fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	location, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		return "", "", err
+	}
+	return location.Query().Get("code"), location.Query().Get("state"), nil
+}
+
+func (s *Server) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"issuer":                                s.URL,
+		"authorization_endpoint":                s.URL + "/authorize",
+		"token_endpoint":                        s.URL + "/token",
+		"jwks_uri":                              s.URL + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{jwks.AlgRS256},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+func (s *Server) authorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if q.Get("client_id") != s.ClientID || q.Get("response_type") != "code" {
+		http.Error(w, "invalid_request", http.StatusBadRequest)
+		return
+	}
+	if q.Get("code_challenge_method") != "S256" || q.Get("code_challenge") == "" {
+		http.Error(w, "invalid_request: pkce required", http.StatusBadRequest)
+		return
+	}
+
+	redirectURI, err := url.Parse(q.Get("redirect_uri"))
+	if err != nil || redirectURI.String() == "" {
+		http.Error(w, "invalid_request: redirect_uri", http.StatusBadRequest)
+		return
+	}
+
+	code := uuid.New().String()
+	s.mu.Lock()
+	s.codes[code] = authRequest{
+		user:          s.user,
+		redirectURI:   redirectURI.String(),
+		nonce:         q.Get("nonce"),
+		codeChallenge: q.Get("code_challenge"),
+	}
+	s.mu.Unlock()
+
+	params := redirectURI.Query()
+	params.Set("code", code)
+	params.Set("state", q.Get("state"))
+	redirectURI.RawQuery = params.Encode()
+	http.Redirect(w, r, redirectURI.String(), http.StatusFound)
+}
+
+func (s *Server) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeTokenError(w, "invalid_request")
+		return
+	}
+
+	clientID, clientSecret, ok := r.BasicAuth()
+	if !ok {
+		clientID, clientSecret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	if clientID != s.ClientID || clientSecret != s.ClientSecret {
+		w.Header().Set("WWW-Authenticate", "Basic")
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	if r.PostForm.Get("grant_type") != "authorization_code" {
+		writeTokenError(w, "unsupported_grant_type")
+		return
+	}
+
+	// codes are single use, whether or not the exchange succeeds
+	s.mu.Lock()
+	req, ok := s.codes[r.PostForm.Get("code")]
+	delete(s.codes, r.PostForm.Get("code"))
+	s.mu.Unlock()
+	if !ok || req.redirectURI != r.PostForm.Get("redirect_uri") {
+		writeTokenError(w, "invalid_grant")
+		return
+	}
+
+	challenge := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if base64.RawURLEncoding.EncodeToString(challenge[:]) != req.codeChallenge {
+		writeTokenError(w, "invalid_grant")
+		return
+	}
+
+	idToken, err := s.signIDToken(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": uuid.New().String(),
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     idToken,
+	})
+}
+
+func (s *Server) signIDToken(req authRequest) (string, error) {
+	if req.user.Subject == "" {
+		return "", errors.New("no user set")
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":            s.URL,
+		"sub":            req.user.Subject,
+		"aud":            s.ClientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Hour).Unix(),
+		"email":          req.user.Email,
+		"email_verified": req.user.EmailVerified,
+		"name":           req.user.Name,
+	}
+	if req.nonce != "" {
+		claims["nonce"] = req.nonce
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = keyID
+	return token.SignedString(s.key)
+}
+
+func (s *Server) jwks(w http.ResponseWriter, r *http.Request) {
+	key, err := jwks.FromPublicKey(keyID, &s.key.PublicKey)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusOK, jwks.Set{Keys: []jwks.Key{key}})
+}
+
+func writeTokenError(w http.ResponseWriter, code string) {
+	writeJSON(w, http.StatusBadRequest, map[string]string{"error": code})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
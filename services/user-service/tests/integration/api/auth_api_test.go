@@ -3,14 +3,21 @@ package api_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
+	mdkeys "github.com/khoihuynh300/go-microservice/shared/pkg/const/metadata"
 	userpb "github.com/khoihuynh300/go-microservice/shared/proto/user"
 	"github.com/khoihuynh300/go-microservice/user-service/internal/domain/models"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/security/totp"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/utils"
+	"github.com/khoihuynh300/go-microservice/user-service/tests/fakeoidc"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 type TestUser struct {
@@ -56,6 +63,17 @@ func TestAuthAPI_Register(t *testing.T) {
 			expectedCode: codes.AlreadyExists,
 			checkFunc:    nil,
 		},
+		{
+			name:  "Register with password containing email",
+			setup: nil,
+			request: &userpb.RegisterRequest{
+				Email:    "khoihuynh@test.com",
+				Password: "khoihuynh2024",
+				FullName: "New User",
+			},
+			expectedCode: codes.InvalidArgument,
+			checkFunc:    nil,
+		},
 	}
 
 	for _, tt := range tests {
@@ -237,6 +255,214 @@ func TestAuthAPI_RefreshToken(t *testing.T) {
 	}
 }
 
+func TestAuthAPI_Logout(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, cleanupTestData(ctx))
+
+	user := CreateVerifiedUser(ctx, t, "logout@test.com", "Password123!", "Logout User")
+	tokens := LoginUser(ctx, t, user.Email, user.Password)
+
+	_, err := client.Logout(ctx, &userpb.LogoutRequest{RefreshToken: tokens.RefreshToken})
+	require.NoError(t, err)
+
+	_, err = client.Refresh(ctx, &userpb.RefreshRequest{RefreshToken: tokens.RefreshToken})
+	require.Error(t, err)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.Unauthenticated, st.Code())
+
+	// logging out twice is not an error
+	_, err = client.Logout(ctx, &userpb.LogoutRequest{RefreshToken: tokens.RefreshToken})
+	require.NoError(t, err)
+}
+
+func TestAuthAPI_RefreshTokenReuse(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, cleanupTestData(ctx))
+
+	user := CreateVerifiedUser(ctx, t, "reuse@test.com", "Password123!", "Reuse User")
+	tokens := LoginUser(ctx, t, user.Email, user.Password)
+
+	rotated, err := client.Refresh(ctx, &userpb.RefreshRequest{RefreshToken: tokens.RefreshToken})
+	require.NoError(t, err)
+
+	// replaying the first token revokes the whole family
+	_, err = client.Refresh(ctx, &userpb.RefreshRequest{RefreshToken: tokens.RefreshToken})
+	require.Error(t, err)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = client.Refresh(ctx, &userpb.RefreshRequest{RefreshToken: rotated.RefreshToken})
+	require.Error(t, err)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestAuthAPI_TOTPLogin(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, cleanupTestData(ctx))
+
+	user := CreateVerifiedUser(ctx, t, "totp@test.com", "Password123!", "TOTP User")
+	authCtx := ContextWithUserID(ctx, user.ID.String())
+
+	enrollment, err := client.EnrollTOTP(authCtx, &emptypb.Empty{})
+	require.NoError(t, err)
+	assert.Contains(t, enrollment.OtpauthUri, "otpauth://totp/")
+
+	code, err := totp.Code(enrollment.Secret, time.Now())
+	require.NoError(t, err)
+	confirmed, err := client.ConfirmTOTP(authCtx, &userpb.ConfirmTOTPRequest{Code: code})
+	require.NoError(t, err)
+	require.Len(t, confirmed.RecoveryCodes, totp.RecoveryCodeCount)
+
+	challenge := LoginUser(ctx, t, user.Email, user.Password)
+	assert.True(t, challenge.MfaRequired)
+	assert.Empty(t, challenge.AccessToken)
+	require.NotEmpty(t, challenge.MfaToken)
+
+	_, err = client.VerifyMFA(ctx, &userpb.VerifyMFARequest{MfaToken: challenge.MfaToken, Code: "000000"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// the code spent on confirmation cannot be replayed
+	_, err = client.VerifyMFA(ctx, &userpb.VerifyMFARequest{MfaToken: challenge.MfaToken, Code: code})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	tokens, err := client.VerifyMFA(ctx, &userpb.VerifyMFARequest{MfaToken: challenge.MfaToken, Code: confirmed.RecoveryCodes[0]})
+	require.NoError(t, err)
+	assert.NotEmpty(t, tokens.AccessToken)
+	assert.NotEmpty(t, tokens.RefreshToken)
+
+	// the challenge is single use
+	_, err = client.VerifyMFA(ctx, &userpb.VerifyMFARequest{MfaToken: challenge.MfaToken, Code: confirmed.RecoveryCodes[1]})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+// ContextWithOAuthState sends the binding the gateway derives from the state
+// cookie of the browser that started the login.
+func ContextWithOAuthState(ctx context.Context, state string) context.Context {
+	md := metadata.Pairs(mdkeys.OAuthStateBindingHeader, utils.HashToken(state))
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+func TestAuthAPI_OAuthLogin(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, cleanupTestData(ctx))
+
+	oidcServer.SetUser(fakeoidc.User{
+		Subject:       "oauth-subject",
+		Email:         "oauth@test.com",
+		EmailVerified: true,
+		Name:          "OAuth User",
+	})
+
+	oauthLogin := func(t *testing.T) {
+		start, err := client.StartOAuthLogin(ctx, &userpb.StartOAuthLoginRequest{Provider: oauthProviderName})
+		require.NoError(t, err)
+
+		code, state, err := oidcServer.Authorize(start.RedirectUrl)
+		require.NoError(t, err)
+
+		tokens, err := client.CompleteOAuthLogin(ContextWithOAuthState(ctx, state), &userpb.CompleteOAuthLoginRequest{
+			Provider: oauthProviderName,
+			Code:     code,
+			State:    state,
+		})
+		require.NoError(t, err)
+		assert.NotEmpty(t, tokens.AccessToken)
+		assert.NotEmpty(t, tokens.RefreshToken)
+	}
+
+	oauthLogin(t)
+	oauthLogin(t)
+
+	// the second login reuses the account created by the first
+	var users int
+	require.NoError(t, testDB.Pool.QueryRow(ctx, `SELECT COUNT(*) FROM users WHERE email = $1`, "oauth@test.com").Scan(&users))
+	assert.Equal(t, 1, users)
+
+	_, err := client.StartOAuthLogin(ctx, &userpb.StartOAuthLoginRequest{Provider: "unknown"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// a replayed callback is rejected because the state is single use
+	start, err := client.StartOAuthLogin(ctx, &userpb.StartOAuthLoginRequest{Provider: oauthProviderName})
+	require.NoError(t, err)
+	code, state, err := oidcServer.Authorize(start.RedirectUrl)
+	require.NoError(t, err)
+	_, err = client.CompleteOAuthLogin(ContextWithOAuthState(ctx, state), &userpb.CompleteOAuthLoginRequest{Provider: oauthProviderName, Code: code, State: state})
+	require.NoError(t, err)
+	_, err = client.CompleteOAuthLogin(ContextWithOAuthState(ctx, state), &userpb.CompleteOAuthLoginRequest{Provider: oauthProviderName, Code: code, State: state})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestAuthAPI_OAuthLoginRequiresStateBinding(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, cleanupTestData(ctx))
+
+	oidcServer.SetUser(fakeoidc.User{
+		Subject:       "attacker-subject",
+		Email:         "attacker@test.com",
+		EmailVerified: true,
+	})
+
+	start, err := client.StartOAuthLogin(ctx, &userpb.StartOAuthLoginRequest{Provider: oauthProviderName})
+	require.NoError(t, err)
+	code, state, err := oidcServer.Authorize(start.RedirectUrl)
+	require.NoError(t, err)
+
+	// a browser that did not start this login has no cookie, or one for another state
+	_, err = client.CompleteOAuthLogin(ctx, &userpb.CompleteOAuthLoginRequest{Provider: oauthProviderName, Code: code, State: state})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = client.CompleteOAuthLogin(ContextWithOAuthState(ctx, "other-state"), &userpb.CompleteOAuthLoginRequest{Provider: oauthProviderName, Code: code, State: state})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// the rejected callbacks did not spend the state
+	_, err = client.CompleteOAuthLogin(ContextWithOAuthState(ctx, state), &userpb.CompleteOAuthLoginRequest{Provider: oauthProviderName, Code: code, State: state})
+	require.NoError(t, err)
+}
+
+func TestAuthAPI_OAuthLoginLinksVerifiedAccount(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, cleanupTestData(ctx))
+
+	user := CreateVerifiedUser(ctx, t, "linked@test.com", "Password123!", "Linked User")
+	oidcServer.SetUser(fakeoidc.User{
+		Subject:       "linked-subject",
+		Email:         "linked@test.com",
+		EmailVerified: true,
+	})
+
+	start, err := client.StartOAuthLogin(ctx, &userpb.StartOAuthLoginRequest{Provider: oauthProviderName})
+	require.NoError(t, err)
+	code, state, err := oidcServer.Authorize(start.RedirectUrl)
+	require.NoError(t, err)
+
+	_, err = client.CompleteOAuthLogin(ContextWithOAuthState(ctx, state), &userpb.CompleteOAuthLoginRequest{Provider: oauthProviderName, Code: code, State: state})
+	require.NoError(t, err)
+
+	var linkedUserID uuid.UUID
+	require.NoError(t, testDB.Pool.QueryRow(ctx, `SELECT user_id FROM user_identities WHERE subject = $1`, "linked-subject").Scan(&linkedUserID))
+	assert.Equal(t, user.ID, linkedUserID)
+
+	// the password keeps working after linking
+	LoginUser(ctx, t, user.Email, user.Password)
+}
+
+func TestAuthAPI_MagicLink(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, cleanupTestData(ctx))
+
+	CreateVerifiedUser(ctx, t, "magic@test.com", "Password123!", "Magic User")
+
+	// known and unknown addresses get the same answer, also once throttled
+	for i := 0; i < 5; i++ {
+		_, err := client.RequestMagicLink(ctx, &userpb.RequestMagicLinkRequest{Email: "magic@test.com"})
+		assert.NoError(t, err)
+		_, err = client.RequestMagicLink(ctx, &userpb.RequestMagicLinkRequest{Email: "nobody@test.com"})
+		assert.NoError(t, err)
+	}
+
+	_, err := client.ConsumeMagicLink(ctx, &userpb.ConsumeMagicLinkRequest{Token: uuid.NewString()})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
 func CreateVerifiedUser(ctx context.Context, t *testing.T, email, password, fullName string) *TestUser {
 	resp, err := client.Register(ctx, &userpb.RegisterRequest{
 		Email:    email,
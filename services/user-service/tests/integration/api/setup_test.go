@@ -7,13 +7,18 @@ import (
 	"testing"
 
 	userpb "github.com/khoihuynh300/go-microservice/shared/proto/user"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/security/oauth"
+	"github.com/khoihuynh300/go-microservice/user-service/tests/fakeoidc"
 	"github.com/khoihuynh300/go-microservice/user-service/tests/integration/testutil"
 )
 
+const oauthProviderName = "fake"
+
 var (
 	testDB     *testutil.TestDatabase
 	testRedis  *testutil.TestRedis
 	testServer *testutil.TestGRPCServer
+	oidcServer *fakeoidc.Server
 	client     userpb.UserServiceClient
 )
 
@@ -31,7 +36,21 @@ func TestMain(m *testing.M) {
 		log.Fatalf("Failed to start redis container: %v", err)
 	}
 
-	testServer, err = testutil.NewTestGRPCServer(ctx, testDB, testRedis, nil)
+	oidcServer, err = fakeoidc.NewServer("test-client", "test-secret")
+	if err != nil {
+		log.Fatalf("Failed to start oidc server: %v", err)
+	}
+
+	cfg := testutil.DefaultGRPCServerConfig()
+	cfg.OAuthProviders = []oauth.Config{{
+		Name:         oauthProviderName,
+		IssuerURL:    oidcServer.URL,
+		ClientID:     oidcServer.ClientID,
+		ClientSecret: oidcServer.ClientSecret,
+		RedirectURL:  "http://localhost:8080/v1/auth/oauth/" + oauthProviderName + "/callback",
+	}}
+
+	testServer, err = testutil.NewTestGRPCServer(ctx, testDB, testRedis, cfg)
 	if err != nil {
 		log.Fatalf("Failed to start grpc server: %v", err)
 	}
@@ -41,6 +60,7 @@ func TestMain(m *testing.M) {
 	code := m.Run()
 
 	testServer.TearDown()
+	oidcServer.Close()
 	testRedis.TearDown(ctx)
 	testDB.TearDown(ctx)
 
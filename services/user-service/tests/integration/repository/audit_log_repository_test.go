@@ -0,0 +1,110 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/domain/models"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/repository"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/repository/impl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditLogRepository_List(t *testing.T) {
+	ctx := context.Background()
+	repo := impl.NewAuditLogRepository(testDB.Pool)
+	require.NoError(t, testDB.CleanupTestData(ctx))
+
+	userID := uuid.New()
+	adminID := uuid.New()
+	reason := "INVALID_CREDENTIALS"
+	now := time.Now()
+
+	for _, entry := range []*models.AuditLogEntry{
+		{UserID: &userID, EventType: models.AuditEventLogin, Success: true, IPAddress: "10.0.0.1", CreatedAt: now.Add(-3 * time.Hour)},
+		{UserID: &userID, EventType: models.AuditEventLogin, Success: false, Reason: &reason, IPAddress: "10.0.0.2", CreatedAt: now.Add(-2 * time.Hour)},
+		{UserID: &userID, ActorID: &adminID, EventType: models.AuditEventUserSuspended, Success: true, CreatedAt: now.Add(-time.Hour)},
+		{EventType: models.AuditEventLogin, Success: false, Reason: &reason, IPAddress: "10.0.0.2"},
+	} {
+		require.NoError(t, repo.Create(ctx, entry))
+	}
+
+	login := models.AuditEventLogin
+	ip := "10.0.0.2"
+	from := now.Add(-90 * time.Minute)
+
+	tests := []struct {
+		name          string
+		filter        *repository.AuditLogFilter
+		page          int32
+		pageSize      int32
+		expectedTotal int64
+		expectedLen   int
+	}{
+		{name: "No Filter", filter: &repository.AuditLogFilter{}, page: 1, pageSize: 10, expectedTotal: 4, expectedLen: 4},
+		{name: "Second Page", filter: &repository.AuditLogFilter{}, page: 2, pageSize: 3, expectedTotal: 4, expectedLen: 1},
+		{name: "By User", filter: &repository.AuditLogFilter{UserID: &userID}, page: 1, pageSize: 10, expectedTotal: 3, expectedLen: 3},
+		{name: "By Event Type", filter: &repository.AuditLogFilter{EventType: &login}, page: 1, pageSize: 10, expectedTotal: 3, expectedLen: 3},
+		{name: "By IP Address", filter: &repository.AuditLogFilter{IPAddress: &ip}, page: 1, pageSize: 10, expectedTotal: 2, expectedLen: 2},
+		{name: "Created From", filter: &repository.AuditLogFilter{CreatedFrom: &from}, page: 1, pageSize: 10, expectedTotal: 2, expectedLen: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, total, err := repo.List(ctx, tt.filter, tt.page, tt.pageSize)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedTotal, total)
+			assert.Len(t, entries, tt.expectedLen)
+		})
+	}
+
+	t.Run("Newest First", func(t *testing.T) {
+		entries, _, err := repo.List(ctx, &repository.AuditLogFilter{UserID: &userID}, 1, 10)
+		require.NoError(t, err)
+		require.Len(t, entries, 3)
+		assert.Equal(t, models.AuditEventUserSuspended, entries[0].EventType)
+		assert.Equal(t, &adminID, entries[0].ActorID)
+		assert.Equal(t, &reason, entries[1].Reason)
+		assert.Nil(t, entries[2].Reason)
+	})
+}
+
+func TestAuditLogRepository_DeleteBefore(t *testing.T) {
+	ctx := context.Background()
+	repo := impl.NewAuditLogRepository(testDB.Pool)
+	require.NoError(t, testDB.CleanupTestData(ctx))
+
+	old := time.Now().Add(-100 * 24 * time.Hour)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, repo.Create(ctx, &models.AuditLogEntry{EventType: models.AuditEventLogin, Success: true, CreatedAt: old}))
+	}
+	require.NoError(t, repo.Create(ctx, &models.AuditLogEntry{EventType: models.AuditEventLogin, Success: true}))
+
+	cutoff := time.Now().Add(-90 * 24 * time.Hour)
+
+	deleted, err := repo.DeleteBefore(ctx, cutoff, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+
+	deleted, err = repo.DeleteBefore(ctx, cutoff, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	_, total, err := repo.List(ctx, &repository.AuditLogFilter{}, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+}
+
+func TestAuditLogRepository_AppendOnly(t *testing.T) {
+	ctx := context.Background()
+	repo := impl.NewAuditLogRepository(testDB.Pool)
+	require.NoError(t, testDB.CleanupTestData(ctx))
+
+	require.NoError(t, repo.Create(ctx, &models.AuditLogEntry{EventType: models.AuditEventLogin, Success: false}))
+
+	_, err := testDB.Pool.Exec(ctx, `UPDATE auth_audit_log SET success = TRUE`)
+	assert.Error(t, err)
+}
@@ -244,3 +244,115 @@ func TestRefreshTokenRepository_DeleteByID(t *testing.T) {
 		})
 	}
 }
+
+func TestRefreshTokenRepository_TokenFamily(t *testing.T) {
+	ctx := context.Background()
+	repo := impl.NewRefreshTokenRepository(testDB.Pool)
+	require.NoError(t, testDB.CleanupTestData(ctx))
+
+	user := createTestUser(t, ctx)
+	root := &models.RefreshToken{
+		UserID:     user.ID,
+		TokenHash:  "family-root",
+		DeviceInfo: "Chrome on Windows",
+		IPAddress:  "10.0.0.1",
+		ExpiresAt:  time.Now().Add(24 * time.Hour),
+	}
+	require.NoError(t, repo.Create(ctx, root))
+	assert.Equal(t, root.ID, root.FamilyID, "a new token starts its own family")
+
+	rowsAffected, err := repo.MarkRotated(ctx, root.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rowsAffected)
+
+	rowsAffected, err = repo.MarkRotated(ctx, root.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), rowsAffected, "a token can only be rotated once")
+
+	child := &models.RefreshToken{
+		UserID:     user.ID,
+		FamilyID:   root.FamilyID,
+		ParentID:   &root.ID,
+		TokenHash:  "family-child",
+		DeviceInfo: root.DeviceInfo,
+		IPAddress:  "10.0.0.2",
+		ExpiresAt:  time.Now().Add(24 * time.Hour),
+	}
+	require.NoError(t, repo.Create(ctx, child))
+
+	found, err := repo.GetByToken(ctx, "family-root")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.True(t, found.IsRotated())
+
+	found, err = repo.GetByToken(ctx, "family-child")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, root.FamilyID, found.FamilyID)
+	require.NotNil(t, found.ParentID)
+	assert.Equal(t, root.ID, *found.ParentID)
+
+	sessions, err := repo.ListSessionsByUserID(ctx, user.ID)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.Equal(t, root.FamilyID, sessions[0].ID)
+	assert.Equal(t, "10.0.0.2", sessions[0].IPAddress)
+	assert.WithinDuration(t, root.CreatedAt, sessions[0].CreatedAt, time.Second)
+
+	rowsAffected, err = repo.RevokeFamily(ctx, root.FamilyID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), rowsAffected)
+
+	found, err = repo.GetByToken(ctx, "family-child")
+	require.NoError(t, err)
+	assert.True(t, found.IsRevoked())
+}
+
+func TestRefreshTokenRepository_ListAndRevokeSessions(t *testing.T) {
+	ctx := context.Background()
+	repo := impl.NewRefreshTokenRepository(testDB.Pool)
+	require.NoError(t, testDB.CleanupTestData(ctx))
+
+	user := createTestUser(t, ctx)
+	otherUser := createTestUser(t, ctx)
+
+	var tokens []*models.RefreshToken
+	for _, hash := range []string{"session-a", "session-b", "session-c"} {
+		token := &models.RefreshToken{
+			UserID:    user.ID,
+			TokenHash: hash,
+			ExpiresAt: time.Now().Add(24 * time.Hour),
+		}
+		require.NoError(t, repo.Create(ctx, token))
+		tokens = append(tokens, token)
+	}
+	require.NoError(t, repo.Create(ctx, &models.RefreshToken{
+		UserID:    user.ID,
+		TokenHash: "session-expired",
+		ExpiresAt: time.Now().Add(-time.Hour),
+	}))
+
+	sessions, err := repo.ListSessionsByUserID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Len(t, sessions, 3)
+
+	rowsAffected, err := repo.RevokeFamilyByUserID(ctx, tokens[0].FamilyID, otherUser.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), rowsAffected, "sessions of other users must not be revoked")
+
+	rowsAffected, err = repo.RevokeFamilyByUserID(ctx, tokens[0].FamilyID, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rowsAffected)
+
+	sessions, err = repo.ListSessionsByUserID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Len(t, sessions, 2)
+
+	rowsAffected, err = repo.RevokeAllByUserID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), rowsAffected)
+
+	sessions, err = repo.ListSessionsByUserID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Empty(t, sessions)
+}
@@ -3,9 +3,11 @@ package repository_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/khoihuynh300/go-microservice/user-service/internal/domain/models"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/repository"
 	"github.com/khoihuynh300/go-microservice/user-service/internal/repository/impl"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -619,3 +621,269 @@ func TestUserRepository_SoftDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestUserRepository_TOTP(t *testing.T) {
+	ctx := context.Background()
+	repo := impl.NewUserRepository(testDB.Pool)
+	require.NoError(t, testDB.CleanupTestData(ctx))
+
+	user := &models.User{
+		Email:          "mfa@gmail.com",
+		HashedPassword: "hashedpassword123",
+		FullName:       "MFA User",
+		Status:         models.UserStatusActive,
+	}
+	require.NoError(t, repo.Create(ctx, user))
+
+	rows, err := repo.SetTOTPSecret(ctx, user.ID, "JBSWY3DPEHPK3PXP")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+
+	pending, err := repo.GetByID(ctx, user.ID)
+	require.NoError(t, err)
+	require.NotNil(t, pending.TOTPSecret)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", *pending.TOTPSecret)
+	assert.False(t, pending.IsTOTPEnabled())
+
+	rows, err = repo.EnableTOTP(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+	require.NoError(t, repo.ReplaceRecoveryCodes(ctx, user.ID, []string{"hash-1", "hash-2"}))
+
+	enabled, err := repo.GetByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.True(t, enabled.IsTOTPEnabled())
+
+	// the secret cannot be swapped while 2FA is on
+	rows, err = repo.SetTOTPSecret(ctx, user.ID, "OTHERSECRET")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), rows)
+
+	// recovery codes work exactly once
+	rows, err = repo.UseRecoveryCode(ctx, user.ID, "hash-1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+	rows, err = repo.UseRecoveryCode(ctx, user.ID, "hash-1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), rows)
+
+	rows, err = repo.DisableTOTP(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+
+	disabled, err := repo.GetByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Nil(t, disabled.TOTPSecret)
+	assert.False(t, disabled.IsTOTPEnabled())
+
+	rows, err = repo.UseRecoveryCode(ctx, user.ID, "hash-2")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), rows)
+}
+
+func TestUserRepository_UpdateEmail(t *testing.T) {
+	ctx := context.Background()
+	repo := impl.NewUserRepository(testDB.Pool)
+	require.NoError(t, testDB.CleanupTestData(ctx))
+
+	user := &models.User{
+		Email:          "old@gmail.com",
+		HashedPassword: "hashedpassword123",
+		FullName:       "Email User",
+		Status:         models.UserStatusActive,
+	}
+	require.NoError(t, repo.Create(ctx, user))
+
+	other := &models.User{
+		Email:          "taken@gmail.com",
+		HashedPassword: "hashedpassword123",
+		FullName:       "Other User",
+		Status:         models.UserStatusActive,
+	}
+	require.NoError(t, repo.Create(ctx, other))
+
+	_, err := repo.UpdateEmail(ctx, user.ID, "old@gmail.com", "taken@gmail.com")
+	assert.ErrorIs(t, err, repository.ErrEmailTaken)
+
+	rows, err := repo.UpdateEmail(ctx, user.ID, "old@gmail.com", "new@gmail.com")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+
+	updated, err := repo.GetByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "new@gmail.com", updated.Email)
+	assert.NotNil(t, updated.EmailVerifiedAt)
+
+	// a stale request no longer matches the current address
+	rows, err = repo.UpdateEmail(ctx, user.ID, "old@gmail.com", "newer@gmail.com")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), rows)
+
+	// addresses of soft-deleted users can be reused
+	_, err = repo.SoftDelete(ctx, other.ID)
+	require.NoError(t, err)
+	rows, err = repo.UpdateEmail(ctx, user.ID, "new@gmail.com", "taken@gmail.com")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+}
+
+func TestUserRepository_VerifyPhone(t *testing.T) {
+	ctx := context.Background()
+	repo := impl.NewUserRepository(testDB.Pool)
+	require.NoError(t, testDB.CleanupTestData(ctx))
+
+	user := &models.User{
+		Email:          "phone@gmail.com",
+		HashedPassword: "hashedpassword123",
+		FullName:       "Phone User",
+		Status:         models.UserStatusActive,
+	}
+	require.NoError(t, repo.Create(ctx, user))
+
+	other := &models.User{
+		Email:          "other@gmail.com",
+		HashedPassword: "hashedpassword123",
+		FullName:       "Other User",
+		Status:         models.UserStatusActive,
+	}
+	require.NoError(t, repo.Create(ctx, other))
+
+	rows, err := repo.VerifyPhone(ctx, other.ID, "0901234567")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+
+	_, err = repo.VerifyPhone(ctx, user.ID, "0901234567")
+	assert.ErrorIs(t, err, repository.ErrPhoneTaken)
+
+	rows, err = repo.VerifyPhone(ctx, user.ID, "0907654321")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+
+	found, err := repo.GetByPhone(ctx, "0907654321")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, user.ID, found.ID)
+	assert.True(t, found.IsPhoneVerified())
+
+	missing, err := repo.GetByPhone(ctx, "0900000000")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestUserRepository_Anonymize(t *testing.T) {
+	ctx := context.Background()
+	repo := impl.NewUserRepository(testDB.Pool)
+	addressRepo := impl.NewAddressRepository(testDB.Pool)
+	require.NoError(t, testDB.CleanupTestData(ctx))
+
+	user := &models.User{
+		Email:          "deleted@gmail.com",
+		HashedPassword: "hashedpassword123",
+		FullName:       "Deleted User",
+		Status:         models.UserStatusActive,
+	}
+	require.NoError(t, repo.Create(ctx, user))
+	require.NoError(t, addressRepo.Create(ctx, createTestAddress(user.ID)))
+
+	// an active account is never picked up
+	active := &models.User{
+		Email:          "active@gmail.com",
+		HashedPassword: "hashedpassword123",
+		FullName:       "Active User",
+		Status:         models.UserStatusActive,
+	}
+	require.NoError(t, repo.Create(ctx, active))
+
+	rows, err := repo.SoftDelete(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+
+	pending, err := repo.ListPendingAnonymization(ctx, time.Now().Add(-time.Hour), 10)
+	require.NoError(t, err)
+	assert.Empty(t, pending, "still within the grace period")
+
+	pending, err = repo.ListPendingAnonymization(ctx, time.Now().Add(time.Second), 10)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{user.ID}, pending)
+
+	rows, err = repo.Anonymize(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+
+	var email, fullName string
+	require.NoError(t, testDB.Pool.QueryRow(ctx, `SELECT email, full_name FROM users WHERE id = $1`, user.ID).Scan(&email, &fullName))
+	assert.NotContains(t, email, "deleted@gmail.com")
+	assert.Empty(t, fullName)
+
+	addresses, err := addressRepo.ListByUserID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Empty(t, addresses)
+
+	rows, err = repo.Anonymize(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), rows)
+
+	pending, err = repo.ListPendingAnonymization(ctx, time.Now().Add(time.Second), 10)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	// the address is free to register again
+	reused := &models.User{
+		Email:          "deleted@gmail.com",
+		HashedPassword: "hashedpassword123",
+		FullName:       "New User",
+		Status:         models.UserStatusPending,
+	}
+	assert.NoError(t, repo.Create(ctx, reused))
+}
+
+func TestUserRepository_List(t *testing.T) {
+	ctx := context.Background()
+	repo := impl.NewUserRepository(testDB.Pool)
+	require.NoError(t, testDB.CleanupTestData(ctx))
+
+	for _, user := range []*models.User{
+		{Email: "alice@gmail.com", HashedPassword: "hashedpassword123", FullName: "Alice", Status: models.UserStatusActive},
+		{Email: "bob@gmail.com", HashedPassword: "hashedpassword123", FullName: "Bob", Status: models.UserStatusSuspended},
+		{Email: "carol@example.com", HashedPassword: "hashedpassword123", FullName: "Carol", Status: models.UserStatusActive},
+		{Email: "under_score@example.com", HashedPassword: "hashedpassword123", FullName: "Dave", Status: models.UserStatusPending},
+	} {
+		require.NoError(t, repo.Create(ctx, user))
+	}
+
+	deleted := &models.User{Email: "deleted@gmail.com", HashedPassword: "hashedpassword123", FullName: "Deleted", Status: models.UserStatusActive}
+	require.NoError(t, repo.Create(ctx, deleted))
+	_, err := repo.SoftDelete(ctx, deleted.ID)
+	require.NoError(t, err)
+
+	active := models.UserStatusActive
+	gmail := "GMAIL"
+	underscore := "_"
+	future := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name          string
+		filter        *repository.UserFilter
+		page          int32
+		pageSize      int32
+		expectedTotal int64
+		expectedLen   int
+	}{
+		{name: "No Filter", filter: &repository.UserFilter{}, page: 1, pageSize: 10, expectedTotal: 4, expectedLen: 4},
+		{name: "Second Page", filter: &repository.UserFilter{}, page: 2, pageSize: 3, expectedTotal: 4, expectedLen: 1},
+		{name: "By Status", filter: &repository.UserFilter{Status: &active}, page: 1, pageSize: 10, expectedTotal: 2, expectedLen: 2},
+		{name: "By Email Case Insensitive", filter: &repository.UserFilter{Email: &gmail}, page: 1, pageSize: 10, expectedTotal: 2, expectedLen: 2},
+		{name: "Email Wildcards Are Literal", filter: &repository.UserFilter{Email: &underscore}, page: 1, pageSize: 10, expectedTotal: 1, expectedLen: 1},
+		{name: "Created From Future", filter: &repository.UserFilter{CreatedFrom: &future}, page: 1, pageSize: 10, expectedTotal: 0, expectedLen: 0},
+		{name: "Created To Future", filter: &repository.UserFilter{CreatedTo: &future}, page: 1, pageSize: 10, expectedTotal: 4, expectedLen: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users, total, err := repo.List(ctx, tt.filter, tt.page, tt.pageSize)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedTotal, total)
+			assert.Len(t, users, tt.expectedLen)
+		})
+	}
+}
@@ -59,14 +59,14 @@ func StartPostgresContainer(ctx context.Context) (*TestDatabase, error) {
 func runMigrations(ctx context.Context, pool *pgxpool.Pool) error {
 	migrationsDir := filepath.Join("..", "..", "..", "migrations")
 
-	files := []string{
-		"000001_create_users_table.up.sql",
-		"000002_create_user_addresses_table.up.sql",
-		"000003_create_refresh_tokens_table.up.sql",
+	// Glob returns the files sorted, which is the order they must run in
+	files, err := filepath.Glob(filepath.Join(migrationsDir, "*.up.sql"))
+	if err != nil {
+		return err
 	}
 
 	for _, file := range files {
-		content, err := os.ReadFile(filepath.Join(migrationsDir, file))
+		content, err := os.ReadFile(file)
 		if err != nil {
 			return fmt.Errorf("read migration %s: %w", file, err)
 		}
@@ -90,7 +90,7 @@ func (td *TestDatabase) TearDown(ctx context.Context) {
 
 func (td *TestDatabase) CleanupTestData(ctx context.Context) error {
 	_, err := td.Pool.Exec(ctx, `
-        TRUNCATE TABLE refresh_tokens, user_addresses, users RESTART IDENTITY CASCADE
+        TRUNCATE TABLE auth_audit_log, refresh_tokens, user_addresses, user_identities, users RESTART IDENTITY CASCADE
     `)
 	return err
 }
@@ -10,12 +10,16 @@ import (
 	"github.com/khoihuynh300/go-microservice/shared/pkg/cache"
 	mdkeys "github.com/khoihuynh300/go-microservice/shared/pkg/const/metadata"
 	"github.com/khoihuynh300/go-microservice/shared/pkg/interceptor"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/jwks"
 	userpb "github.com/khoihuynh300/go-microservice/shared/proto/user"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/audit"
 	"github.com/khoihuynh300/go-microservice/user-service/internal/caching"
 	grpchandler "github.com/khoihuynh300/go-microservice/user-service/internal/handler/grpc"
 	"github.com/khoihuynh300/go-microservice/user-service/internal/repository/impl"
 	"github.com/khoihuynh300/go-microservice/user-service/internal/security/jwtprovider"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/security/oauth"
 	passwordhasher "github.com/khoihuynh300/go-microservice/user-service/internal/security/password"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/security/totp"
 	"github.com/khoihuynh300/go-microservice/user-service/internal/service"
 	"go.uber.org/zap"
 	"golang.org/x/crypto/bcrypt"
@@ -35,15 +39,16 @@ type TestGRPCServer struct {
 }
 
 type GRPCServerConfig struct {
-	JwtAccessSecret  string
+	JwtAccessAlg     string
 	JwtRefreshSecret string
 	AccessTokenTTL   time.Duration
 	RefreshTokenTTL  time.Duration
+	OAuthProviders   []oauth.Config
 }
 
 func DefaultGRPCServerConfig() *GRPCServerConfig {
 	return &GRPCServerConfig{
-		JwtAccessSecret:  "test-access-secret-key-for-testing",
+		JwtAccessAlg:     jwks.AlgEdDSA,
 		JwtRefreshSecret: "test-refresh-secret-key-for-testing",
 		AccessTokenTTL:   15 * time.Minute,
 		RefreshTokenTTL:  7 * 24 * time.Hour,
@@ -102,12 +107,35 @@ func setupGRPCServer(db *TestDatabase, cacheClient cache.Cache, cfg *GRPCServerC
 	// Repositories
 	userRepo := impl.NewUserRepository(db.Pool)
 	refreshTokenRepo := impl.NewRefreshTokenRepository(db.Pool)
+	identityRepo := impl.NewUserIdentityRepository(db.Pool)
 	addressRepo := impl.NewAddressRepository(db.Pool)
+	auditLogRepo := impl.NewAuditLogRepository(db.Pool)
+	auditLog := audit.NewRecorder(auditLogRepo)
 
 	// Security
 	hasher := passwordhasher.NewBcryptHasher(bcrypt.DefaultCost)
+	accessKey, err := jwtprovider.GenerateKey(cfg.JwtAccessAlg)
+	if err != nil {
+		return nil, err
+	}
+	accessKeys := &jwtprovider.KeySet{}
+	if err := accessKeys.Add("test", accessKey); err != nil {
+		return nil, err
+	}
+	if err := accessKeys.SetSigningKey("test"); err != nil {
+		return nil, err
+	}
+	totpProvider := totp.NewProvider("go-microservice-test")
+	var oauthProviders []oauth.Provider
+	for _, oauthCfg := range cfg.OAuthProviders {
+		provider, err := oauth.NewOIDCProvider(context.Background(), oauthCfg)
+		if err != nil {
+			return nil, err
+		}
+		oauthProviders = append(oauthProviders, provider)
+	}
 	jwtService := jwtprovider.NewJwtService(
-		cfg.JwtAccessSecret,
+		accessKeys,
 		cfg.AccessTokenTTL,
 		cfg.JwtRefreshSecret,
 		cfg.RefreshTokenTTL,
@@ -115,20 +143,61 @@ func setupGRPCServer(db *TestDatabase, cacheClient cache.Cache, cfg *GRPCServerC
 
 	// Caching
 	tokenCache := caching.NewTokenCache(cacheClient)
+	tokenRevocation := caching.NewTokenRevocationCache(cacheClient, cfg.AccessTokenTTL)
+	phoneOTP := caching.NewPhoneOTPCache(cacheClient)
+	loginAttempts := caching.NewLoginAttemptCache(cacheClient, caching.LoginAttemptPolicy{
+		MaxFailures:     10,
+		DelayAfter:      3,
+		BaseDelay:       time.Second,
+		MaxDelay:        30 * time.Second,
+		LockoutDuration: 15 * time.Minute,
+		Window:          15 * time.Minute,
+		IPMaxFailures:   50,
+	})
 
 	// Services
 	authService := service.NewAuthService(
 		userRepo,
 		refreshTokenRepo,
+		identityRepo,
 		tokenCache,
+		loginAttempts,
+		tokenRevocation,
 		hasher,
+		passwordhasher.NewPolicy(passwordhasher.PolicyConfig{MinLength: 8}, nil),
+		totpProvider,
+		oauth.NewRegistry(oauthProviders...),
 		jwtService,
+		&nopEventPublisher{},
+		auditLog,
 	)
-	userService := service.NewUserService(userRepo)
+	userService := service.NewUserService(userRepo, phoneOTP, &nopStorage{}, &nopEventPublisher{})
 	addressService := service.NewAddressService(userRepo, addressRepo)
+	accountService := service.NewAccountService(
+		userRepo,
+		addressRepo,
+		refreshTokenRepo,
+		tokenRevocation,
+		hasher,
+		&nopStorage{},
+		&nopStorage{},
+		nil,
+		&nopEventPublisher{},
+		auditLog,
+	)
+	adminService := service.NewAdminService(
+		userRepo,
+		refreshTokenRepo,
+		tokenCache,
+		tokenRevocation,
+		hasher,
+		&nopEventPublisher{},
+		auditLog,
+	)
+	auditService := service.NewAuditService(auditLogRepo)
 
 	// Handler
-	userHandler := grpchandler.NewUserHandler(authService, userService, addressService)
+	userHandler := grpchandler.NewUserHandler(authService, userService, addressService, accountService, adminService, auditService)
 
 	grpcServer := grpc.NewServer(
 		grpc.ChainUnaryInterceptor(
@@ -136,6 +205,7 @@ func setupGRPCServer(db *TestDatabase, cacheClient cache.Cache, cfg *GRPCServerC
 			interceptor.RecoveryUnaryInterceptor(),
 			// interceptor.LoggingUnaryInterceptor(),
 			interceptor.AuthInterceptor(),
+			interceptor.AuthorizationInterceptor(),
 			interceptor.ValidationUnaryInterceptor(),
 			interceptor.ErrorHandlerInterceptor(),
 		),
@@ -0,0 +1,97 @@
+package testutil
+
+import (
+	"context"
+	"time"
+
+	"github.com/khoihuynh300/go-microservice/shared/pkg/storage"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/domain/models"
+)
+
+type nopEventPublisher struct{}
+
+func (p *nopEventPublisher) PublishVerifyEmail(ctx context.Context, user *models.User, token string) error {
+	return nil
+}
+
+func (p *nopEventPublisher) PublishEmailVerifySuccess(ctx context.Context, email string) error {
+	return nil
+}
+
+func (p *nopEventPublisher) PublishForgotPassword(ctx context.Context, user *models.User, token string) error {
+	return nil
+}
+
+func (p *nopEventPublisher) PublishPasswordResetSuccess(ctx context.Context, email string) error {
+	return nil
+}
+
+func (p *nopEventPublisher) PublishAccountLocked(ctx context.Context, user *models.User, token string, ipAddress string, lockedUntil time.Time) error {
+	return nil
+}
+
+func (p *nopEventPublisher) PublishRefreshTokenReused(ctx context.Context, user *models.User, sessionID string, ipAddress string, userAgent string) error {
+	return nil
+}
+
+func (p *nopEventPublisher) PublishEmailChangeRequested(ctx context.Context, user *models.User, newEmail string, token string) error {
+	return nil
+}
+
+func (p *nopEventPublisher) PublishEmailChanged(ctx context.Context, user *models.User, oldEmail string) error {
+	return nil
+}
+
+func (p *nopEventPublisher) PublishMagicLinkRequested(ctx context.Context, user *models.User, token string) error {
+	return nil
+}
+
+func (p *nopEventPublisher) PublishPhoneVerificationRequested(ctx context.Context, user *models.User, phone string, code string, expiresAt time.Time) error {
+	return nil
+}
+
+func (p *nopEventPublisher) PublishDataExportReady(ctx context.Context, user *models.User, downloadURL string, expiresAt time.Time) error {
+	return nil
+}
+
+func (p *nopEventPublisher) PublishUserSuspended(ctx context.Context, user *models.User, reason string) error {
+	return nil
+}
+
+func (p *nopEventPublisher) PublishUserReactivated(ctx context.Context, user *models.User) error {
+	return nil
+}
+
+func (p *nopEventPublisher) PublishPasswordResetForced(ctx context.Context, user *models.User, token string) error {
+	return nil
+}
+
+func (p *nopEventPublisher) PublishSessionsRevoked(ctx context.Context, user *models.User) error {
+	return nil
+}
+
+func (p *nopEventPublisher) Close() error {
+	return nil
+}
+
+type nopStorage struct{}
+
+func (s *nopStorage) Upload(ctx context.Context, input *storage.UploadInput) (*storage.UploadOutput, error) {
+	return &storage.UploadOutput{}, nil
+}
+
+func (s *nopStorage) Delete(ctx context.Context, url string) error {
+	return nil
+}
+
+func (s *nopStorage) GetURL(key string) string {
+	return key
+}
+
+func (s *nopStorage) GetPresignedUploadURL(ctx context.Context, input *storage.PresignedUploadInput) (*storage.PresignedUploadOutput, error) {
+	return &storage.PresignedUploadOutput{}, nil
+}
+
+func (s *nopStorage) GetPresignedDownloadURL(ctx context.Context, key string, expiresIn time.Duration) (string, error) {
+	return key, nil
+}
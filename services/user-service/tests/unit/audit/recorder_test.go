@@ -0,0 +1,82 @@
+package audit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/const/contextkeys"
+	apperr "github.com/khoihuynh300/go-microservice/shared/pkg/errors"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/audit"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/domain/models"
+	mock_repository "github.com/khoihuynh300/go-microservice/user-service/mocks/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newContext(clientIP string) context.Context {
+	ctx := context.WithValue(context.Background(), contextkeys.LoggerKey, zap.NewNop())
+	ctx = context.WithValue(ctx, contextkeys.ClientIPKey, clientIP)
+	ctx = context.WithValue(ctx, contextkeys.UserAgentKey, "Mozilla/5.0")
+	return context.WithValue(ctx, contextkeys.TraceIDKey, "trace-1")
+}
+
+func TestRecorder_Record(t *testing.T) {
+	userID := uuid.New()
+	reason := func(s string) *string { return &s }
+
+	tests := []struct {
+		name           string
+		clientIP       string
+		err            error
+		expectedReason *string
+		expectedIP     string
+	}{
+		{name: "Success", clientIP: "10.0.0.1", err: nil, expectedReason: nil, expectedIP: "10.0.0.1"},
+		{name: "App Error Code", clientIP: "10.0.0.1", err: apperr.ErrInvalidCredentials, expectedReason: reason(apperr.CodeInvalidCredentials), expectedIP: "10.0.0.1"},
+		{name: "Unknown Error", clientIP: "10.0.0.1", err: errors.New("db down"), expectedReason: reason(apperr.CodeInternal), expectedIP: "10.0.0.1"},
+		{name: "Invalid IP Dropped", clientIP: "not-an-ip", err: nil, expectedReason: nil, expectedIP: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock_repository.NewMockAuditLogRepository(ctrl)
+			var saved *models.AuditLogEntry
+			repo.EXPECT().
+				Create(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, entry *models.AuditLogEntry) error {
+					saved = entry
+					return nil
+				})
+
+			audit.NewRecorder(repo).Record(newContext(tt.clientIP), models.AuditEventLogin, &userID, tt.err)
+
+			require.NotNil(t, saved)
+			assert.Equal(t, models.AuditEventLogin, saved.EventType)
+			assert.Equal(t, tt.err == nil, saved.Success)
+			assert.Equal(t, tt.expectedReason, saved.Reason)
+			assert.Equal(t, &userID, saved.UserID)
+			assert.Equal(t, tt.expectedIP, saved.IPAddress)
+			assert.Equal(t, "Mozilla/5.0", saved.UserAgent)
+			assert.Equal(t, "trace-1", saved.TraceID)
+		})
+	}
+}
+
+func TestRecorder_WriteFailureIsSwallowed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_repository.NewMockAuditLogRepository(ctrl)
+	repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("db down"))
+
+	assert.NotPanics(t, func() {
+		audit.NewRecorder(repo).RecordFailure(newContext("10.0.0.1"), models.AuditEventTokenRefresh, nil, audit.ReasonRefreshTokenReused)
+	})
+}
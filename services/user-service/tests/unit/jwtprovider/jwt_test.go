@@ -0,0 +1,161 @@
+package jwtprovider_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/jwks"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/domain/models"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/security/jwtprovider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeKey(t *testing.T, dir, kid, alg string) {
+	t.Helper()
+	pemData, err := jwtprovider.GenerateKey(alg)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, kid+jwtprovider.KeyFileExt), pemData, 0o600))
+}
+
+func newTestUser() *models.User {
+	return &models.User{ID: uuid.New(), Role: models.UserRoleCustomer}
+}
+
+func TestJwtService_AccessTokenRoundTrip(t *testing.T) {
+	for _, alg := range []string{jwks.AlgRS256, jwks.AlgEdDSA} {
+		t.Run(alg, func(t *testing.T) {
+			dir := t.TempDir()
+			writeKey(t, dir, "k1", alg)
+			keys, err := jwtprovider.LoadKeySet(dir, "k1")
+			require.NoError(t, err)
+
+			svc := jwtprovider.NewJwtService(keys, time.Minute, "refresh-secret", time.Hour)
+			user := newTestUser()
+
+			token, tokenID, err := svc.GenerateAccessToken(user)
+			require.NoError(t, err)
+
+			parsed, _, err := jwt.NewParser().ParseUnverified(token, &jwt.RegisteredClaims{})
+			require.NoError(t, err)
+			assert.Equal(t, "k1", parsed.Header["kid"])
+			assert.Equal(t, alg, parsed.Header["alg"])
+
+			claims, err := svc.VerifyAccessToken(token)
+			require.NoError(t, err)
+			assert.Equal(t, user.ID.String(), claims.Subject)
+			assert.Equal(t, string(models.UserRoleCustomer), claims.Role)
+			assert.Equal(t, tokenID, claims.ID)
+		})
+	}
+}
+
+func TestJwtService_KeyRotationOverlap(t *testing.T) {
+	dir := t.TempDir()
+	writeKey(t, dir, "old", jwks.AlgRS256)
+	writeKey(t, dir, "new", jwks.AlgEdDSA)
+
+	oldKeys, err := jwtprovider.LoadKeySet(dir, "old")
+	require.NoError(t, err)
+	oldToken, _, err := jwtprovider.NewJwtService(oldKeys, time.Minute, "s", time.Hour).GenerateAccessToken(newTestUser())
+	require.NoError(t, err)
+
+	newKeys, err := jwtprovider.LoadKeySet(dir, "new")
+	require.NoError(t, err)
+	svc := jwtprovider.NewJwtService(newKeys, time.Minute, "s", time.Hour)
+
+	_, err = svc.VerifyAccessToken(oldToken)
+	assert.NoError(t, err)
+
+	// once the old key is removed its tokens stop verifying
+	require.NoError(t, os.Remove(filepath.Join(dir, "old"+jwtprovider.KeyFileExt)))
+	prunedKeys, err := jwtprovider.LoadKeySet(dir, "new")
+	require.NoError(t, err)
+	_, err = jwtprovider.NewJwtService(prunedKeys, time.Minute, "s", time.Hour).VerifyAccessToken(oldToken)
+	assert.ErrorIs(t, err, jwtprovider.ErrTokenInvalid)
+}
+
+func TestJwtService_RejectsUnsignedAndHMACTokens(t *testing.T) {
+	dir := t.TempDir()
+	writeKey(t, dir, "k1", jwks.AlgEdDSA)
+	keys, err := jwtprovider.LoadKeySet(dir, "k1")
+	require.NoError(t, err)
+	svc := jwtprovider.NewJwtService(keys, time.Minute, "s", time.Hour)
+
+	claims := jwt.RegisteredClaims{Subject: uuid.NewString(), ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute))}
+
+	hmac := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	hmac.Header["kid"] = "k1"
+	hmacToken, err := hmac.SignedString([]byte("s"))
+	require.NoError(t, err)
+	_, err = svc.VerifyAccessToken(hmacToken)
+	assert.ErrorIs(t, err, jwtprovider.ErrTokenInvalid)
+
+	none := jwt.NewWithClaims(jwt.SigningMethodNone, claims)
+	none.Header["kid"] = "k1"
+	noneToken, err := none.SignedString(jwt.UnsafeAllowNoneSignatureType)
+	require.NoError(t, err)
+	_, err = svc.VerifyAccessToken(noneToken)
+	assert.ErrorIs(t, err, jwtprovider.ErrTokenInvalid)
+}
+
+func TestJwtService_RefreshTokensAreUnique(t *testing.T) {
+	dir := t.TempDir()
+	writeKey(t, dir, "k1", jwks.AlgEdDSA)
+	keys, err := jwtprovider.LoadKeySet(dir, "k1")
+	require.NoError(t, err)
+
+	svc := jwtprovider.NewJwtService(keys, time.Minute, "refresh-secret", time.Hour)
+	userID := uuid.New().String()
+
+	// issued within the same second, so only the jti tells them apart
+	first, err := svc.GenerateRefreshToken(userID)
+	require.NoError(t, err)
+	second, err := svc.GenerateRefreshToken(userID)
+	require.NoError(t, err)
+	assert.NotEqual(t, first, second)
+
+	claims, err := svc.VerifyRefreshToken(first)
+	require.NoError(t, err)
+	assert.NotEmpty(t, claims.ID)
+	assert.Equal(t, userID, claims.Subject)
+}
+
+func TestLoadKeySet_UnknownSigningKey(t *testing.T) {
+	dir := t.TempDir()
+	writeKey(t, dir, "k1", jwks.AlgEdDSA)
+
+	_, err := jwtprovider.LoadKeySet(dir, "missing")
+	assert.ErrorIs(t, err, jwtprovider.ErrSigningKeyNotFound)
+}
+
+func TestKeySet_JWKS(t *testing.T) {
+	dir := t.TempDir()
+	writeKey(t, dir, "a-rsa", jwks.AlgRS256)
+	writeKey(t, dir, "b-ed", jwks.AlgEdDSA)
+	keys, err := jwtprovider.LoadKeySet(dir, "b-ed")
+	require.NoError(t, err)
+
+	set, err := keys.JWKS()
+	require.NoError(t, err)
+	require.Len(t, set.Keys, 2)
+
+	assert.Equal(t, "a-rsa", set.Keys[0].Kid)
+	assert.Equal(t, "RSA", set.Keys[0].Kty)
+	assert.Equal(t, jwks.AlgRS256, set.Keys[0].Alg)
+	assert.Equal(t, "b-ed", set.Keys[1].Kid)
+	assert.Equal(t, "OKP", set.Keys[1].Kty)
+	assert.Equal(t, "Ed25519", set.Keys[1].Crv)
+
+	// a verifier holding only the JWKS can check tokens
+	token, _, err := jwtprovider.NewJwtService(keys, time.Minute, "s", time.Hour).GenerateAccessToken(newTestUser())
+	require.NoError(t, err)
+	pub, err := set.Keys[1].PublicKey()
+	require.NoError(t, err)
+	_, err = jwt.Parse(token, func(*jwt.Token) (any, error) { return pub, nil }, jwt.WithValidMethods([]string{jwks.AlgEdDSA}))
+	assert.NoError(t, err)
+}
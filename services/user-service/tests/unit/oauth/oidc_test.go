@@ -0,0 +1,114 @@
+package oauth_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/khoihuynh300/go-microservice/user-service/internal/security/oauth"
+	"github.com/khoihuynh300/go-microservice/user-service/tests/fakeoidc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const redirectURL = "http://localhost:8080/v1/auth/oauth/fake/callback"
+
+func newProvider(t *testing.T) (*fakeoidc.Server, oauth.Provider) {
+	t.Helper()
+	server, err := fakeoidc.NewServer("client-id", "client-secret")
+	require.NoError(t, err)
+	t.Cleanup(server.Close)
+
+	server.SetUser(fakeoidc.User{
+		Subject:       "subject-1",
+		Email:         "oauth@test.com",
+		EmailVerified: true,
+		Name:          "OAuth User",
+	})
+
+	provider, err := oauth.NewOIDCProvider(context.Background(), oauth.Config{
+		Name:         "fake",
+		IssuerURL:    server.URL,
+		ClientID:     "client-id",
+		ClientSecret: "client-secret",
+		RedirectURL:  redirectURL,
+	})
+	require.NoError(t, err)
+	return server, provider
+}
+
+func TestOIDCProvider_AuthCodeURL(t *testing.T) {
+	_, provider := newProvider(t)
+	verifier := oauth.GenerateVerifier()
+
+	authURL, err := url.Parse(provider.AuthCodeURL("state-1", "nonce-1", verifier))
+	require.NoError(t, err)
+
+	q := authURL.Query()
+	assert.Equal(t, "state-1", q.Get("state"))
+	assert.Equal(t, "nonce-1", q.Get("nonce"))
+	assert.Equal(t, redirectURL, q.Get("redirect_uri"))
+	assert.Equal(t, "S256", q.Get("code_challenge_method"))
+	assert.NotEmpty(t, q.Get("code_challenge"))
+	assert.NotContains(t, authURL.String(), verifier)
+	assert.Contains(t, q.Get("scope"), "openid")
+}
+
+func TestOIDCProvider_Exchange(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		server, provider := newProvider(t)
+		verifier := oauth.GenerateVerifier()
+
+		code, state, err := server.Authorize(provider.AuthCodeURL("state-1", "nonce-1", verifier))
+		require.NoError(t, err)
+		assert.Equal(t, "state-1", state)
+
+		identity, err := provider.Exchange(ctx, code, verifier, "nonce-1")
+		require.NoError(t, err)
+		assert.Equal(t, &oauth.Identity{
+			Subject:       "subject-1",
+			Email:         "oauth@test.com",
+			EmailVerified: true,
+			Name:          "OAuth User",
+		}, identity)
+
+		// codes are single use
+		_, err = provider.Exchange(ctx, code, verifier, "nonce-1")
+		assert.Error(t, err)
+	})
+
+	t.Run("Wrong Verifier", func(t *testing.T) {
+		server, provider := newProvider(t)
+
+		code, _, err := server.Authorize(provider.AuthCodeURL("state-1", "nonce-1", oauth.GenerateVerifier()))
+		require.NoError(t, err)
+
+		_, err = provider.Exchange(ctx, code, oauth.GenerateVerifier(), "nonce-1")
+		assert.Error(t, err)
+	})
+
+	t.Run("Nonce Mismatch", func(t *testing.T) {
+		server, provider := newProvider(t)
+		verifier := oauth.GenerateVerifier()
+
+		code, _, err := server.Authorize(provider.AuthCodeURL("state-1", "nonce-1", verifier))
+		require.NoError(t, err)
+
+		_, err = provider.Exchange(ctx, code, verifier, "other-nonce")
+		assert.ErrorIs(t, err, oauth.ErrNonceMismatch)
+	})
+}
+
+func TestRegistry_Get(t *testing.T) {
+	_, provider := newProvider(t)
+	registry := oauth.NewRegistry(provider)
+
+	got, err := registry.Get("fake")
+	require.NoError(t, err)
+	assert.Equal(t, provider, got)
+
+	_, err = registry.Get("unknown")
+	assert.ErrorIs(t, err, oauth.ErrProviderNotFound)
+}
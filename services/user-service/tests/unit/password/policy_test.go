@@ -0,0 +1,115 @@
+package passwordhasher_test
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	apperr "github.com/khoihuynh300/go-microservice/shared/pkg/errors"
+	passwordhasher "github.com/khoihuynh300/go-microservice/user-service/internal/security/password"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sha1Hex(password string) string {
+	sum := sha1.Sum([]byte(password))
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
+
+func writeBreachedList(t *testing.T, lines ...string) string {
+	path := filepath.Join(t.TempDir(), "breached.txt")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0o600))
+	return path
+}
+
+func TestPolicy_Validate(t *testing.T) {
+	breached, err := passwordhasher.LoadHashPrefixList(writeBreachedList(t, sha1Hex("Summer2024!")))
+	require.NoError(t, err)
+
+	policy := passwordhasher.NewPolicy(passwordhasher.PolicyConfig{
+		MinLength:     10,
+		RequireUpper:  true,
+		RequireLower:  true,
+		RequireDigit:  true,
+		RequireSymbol: true,
+	}, breached)
+
+	tests := []struct {
+		name          string
+		password      string
+		expectedCodes []string
+	}{
+		{name: "Valid", password: "Correct-Horse-7", expectedCodes: nil},
+		{name: "Too Short", password: "Ab1!", expectedCodes: []string{apperr.CodePasswordTooShort}},
+		{name: "Counts Runes", password: "Mật-khẩu-1", expectedCodes: nil},
+		{
+			name:     "Missing Classes",
+			password: "lowercaseonly",
+			expectedCodes: []string{
+				apperr.CodePasswordMissingUppercase,
+				apperr.CodePasswordMissingDigit,
+				apperr.CodePasswordMissingSymbol,
+			},
+		},
+		{name: "Split Email Allowed", password: "Khoi.Huynh-99", expectedCodes: nil},
+		{name: "Contains Email Local Part", password: "KhoiHuynh300-x", expectedCodes: []string{apperr.CodePasswordContainsPersonalInfo}},
+		{name: "Contains Name Part", password: "Nguyen-Pass-1", expectedCodes: []string{apperr.CodePasswordContainsPersonalInfo}},
+		{name: "Short Name Part Allowed", password: "An-Pass-1234", expectedCodes: nil},
+		{name: "Breached", password: "Summer2024!", expectedCodes: []string{apperr.CodePasswordBreached}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Validate("password", tt.password, "khoihuynh300@gmail.com", "Nguyen Van An")
+
+			if tt.expectedCodes == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			var appErr *apperr.AppError
+			require.True(t, errors.As(err, &appErr))
+			assert.Equal(t, apperr.CodeValidationFailed, appErr.Code)
+
+			codes := make([]string, 0, len(appErr.Details))
+			for _, detail := range appErr.Details {
+				assert.Equal(t, "password", detail.Field)
+				codes = append(codes, detail.Code)
+			}
+			assert.Equal(t, tt.expectedCodes, codes)
+		})
+	}
+}
+
+func TestPolicy_WithoutBreachedList(t *testing.T) {
+	policy := passwordhasher.NewPolicy(passwordhasher.PolicyConfig{MinLength: 8}, nil)
+
+	assert.NoError(t, policy.Validate("new_password", "password123", "test@gmail.com", "Test User"))
+}
+
+func TestHashPrefixList(t *testing.T) {
+	path := writeBreachedList(t,
+		"# comment",
+		"",
+		sha1Hex("password")+":9545824",
+		strings.ToLower(sha1Hex("123456")),
+	)
+
+	list, err := passwordhasher.LoadHashPrefixList(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, list.Len())
+	assert.True(t, list.IsBreached("password"))
+	assert.True(t, list.IsBreached("123456"))
+	assert.False(t, list.IsBreached("Password"))
+	assert.False(t, list.IsBreached("correct horse battery staple"))
+}
+
+func TestHashPrefixList_InvalidLine(t *testing.T) {
+	_, err := passwordhasher.LoadHashPrefixList(writeBreachedList(t, "not-a-hash"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,178 @@
+package relay_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	mock_kafka "github.com/khoihuynh300/go-microservice/shared/mocks/kafka"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/domain/models"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/events/relay"
+	mock_repository "github.com/khoihuynh300/go-microservice/user-service/mocks/repository"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+var errDatabase = errors.New("database error")
+
+type OutboxRelayTestSuite struct {
+	ctrl       *gomock.Controller
+	outboxRepo *mock_repository.MockOutboxRepository
+	producer   *mock_kafka.MockProducer
+	relay      *relay.OutboxRelay
+}
+
+func NewOutboxRelayTestSuite(t *testing.T) *OutboxRelayTestSuite {
+	ctrl := gomock.NewController(t)
+	outboxRepo := mock_repository.NewMockOutboxRepository(ctrl)
+	producer := mock_kafka.NewMockProducer(ctrl)
+	outboxRelay := relay.NewOutboxRelay(outboxRepo, producer, zap.NewNop(), relay.Config{
+		PollInterval: time.Second,
+		BatchSize:    3,
+		Retention:    time.Hour,
+		MaxAttempts:  5,
+	})
+	return &OutboxRelayTestSuite{
+		ctrl:       ctrl,
+		outboxRepo: outboxRepo,
+		producer:   producer,
+		relay:      outboxRelay,
+	}
+}
+
+func newOutboxEvent(id int64, key string) *models.OutboxEvent {
+	return &models.OutboxEvent{
+		ID:            id,
+		EventID:       uuid.New(),
+		Topic:         "user-events",
+		PartitionKey:  key,
+		EventType:     "user.registered",
+		Payload:       []byte(`{}`),
+		OccurredAt:    time.Now(),
+		NextAttemptAt: time.Now().Add(-time.Second),
+	}
+}
+
+func TestOutboxRelay_Drain(t *testing.T) {
+	tests := []struct {
+		name              string
+		setupMock         func(s *OutboxRelayTestSuite)
+		expectedPublished int
+		expectedError     error
+	}{
+		{
+			name: "Publish All Pending",
+			setupMock: func(s *OutboxRelayTestSuite) {
+				first := newOutboxEvent(1, "a@gmail.com")
+				second := newOutboxEvent(2, "b@gmail.com")
+				s.outboxRepo.EXPECT().ListPending(gomock.Any(), int64(0), int32(3)).Return([]*models.OutboxEvent{first, second}, nil)
+				gomock.InOrder(
+					s.producer.EXPECT().PublishWithKey(gomock.Any(), "user-events", "a@gmail.com", gomock.Any()).Return(nil),
+					s.outboxRepo.EXPECT().MarkPublished(gomock.Any(), int64(1)).Return(int64(1), nil),
+					s.producer.EXPECT().PublishWithKey(gomock.Any(), "user-events", "b@gmail.com", gomock.Any()).Return(nil),
+					s.outboxRepo.EXPECT().MarkPublished(gomock.Any(), int64(2)).Return(int64(1), nil),
+				)
+			},
+			expectedPublished: 2,
+			expectedError:     nil,
+		},
+		{
+			name: "Failed Event Blocks Same Key",
+			setupMock: func(s *OutboxRelayTestSuite) {
+				first := newOutboxEvent(1, "a@gmail.com")
+				second := newOutboxEvent(2, "a@gmail.com")
+				third := newOutboxEvent(3, "b@gmail.com")
+				s.outboxRepo.EXPECT().ListPending(gomock.Any(), int64(0), int32(3)).Return([]*models.OutboxEvent{first, second, third}, nil)
+				s.producer.EXPECT().PublishWithKey(gomock.Any(), "user-events", "a@gmail.com", gomock.Any()).Return(errors.New("broker down"))
+				s.outboxRepo.EXPECT().MarkFailed(gomock.Any(), int64(1), "broker down", gomock.Any()).Return(int64(1), nil)
+				s.producer.EXPECT().PublishWithKey(gomock.Any(), "user-events", "b@gmail.com", gomock.Any()).Return(nil)
+				s.outboxRepo.EXPECT().MarkPublished(gomock.Any(), int64(3)).Return(int64(1), nil)
+				s.outboxRepo.EXPECT().ListPending(gomock.Any(), int64(3), int32(3)).Return(nil, nil)
+			},
+			expectedPublished: 1,
+			expectedError:     nil,
+		},
+		{
+			name: "Pages Past Full Batch",
+			setupMock: func(s *OutboxRelayTestSuite) {
+				page := []*models.OutboxEvent{
+					newOutboxEvent(1, "a@gmail.com"),
+					newOutboxEvent(2, "a@gmail.com"),
+					newOutboxEvent(3, "a@gmail.com"),
+				}
+				last := newOutboxEvent(7, "b@gmail.com")
+				gomock.InOrder(
+					s.outboxRepo.EXPECT().ListPending(gomock.Any(), int64(0), int32(3)).Return(page, nil),
+					s.producer.EXPECT().PublishWithKey(gomock.Any(), "user-events", "a@gmail.com", gomock.Any()).Return(errors.New("broker down")),
+					s.outboxRepo.EXPECT().MarkFailed(gomock.Any(), int64(1), "broker down", gomock.Any()).Return(int64(1), nil),
+					s.outboxRepo.EXPECT().ListPending(gomock.Any(), int64(3), int32(3)).Return([]*models.OutboxEvent{last}, nil),
+					s.producer.EXPECT().PublishWithKey(gomock.Any(), "user-events", "b@gmail.com", gomock.Any()).Return(nil),
+					s.outboxRepo.EXPECT().MarkPublished(gomock.Any(), int64(7)).Return(int64(1), nil),
+				)
+			},
+			expectedPublished: 1,
+			expectedError:     nil,
+		},
+		{
+			name: "Parks After Max Attempts",
+			setupMock: func(s *OutboxRelayTestSuite) {
+				first := newOutboxEvent(1, "a@gmail.com")
+				first.Attempts = 4
+				second := newOutboxEvent(2, "a@gmail.com")
+				s.outboxRepo.EXPECT().ListPending(gomock.Any(), int64(0), int32(3)).Return([]*models.OutboxEvent{first, second}, nil)
+				gomock.InOrder(
+					s.producer.EXPECT().PublishWithKey(gomock.Any(), "user-events", "a@gmail.com", gomock.Any()).Return(errors.New("message too large")),
+					s.outboxRepo.EXPECT().Park(gomock.Any(), int64(1), "message too large").Return(int64(1), nil),
+					s.producer.EXPECT().PublishWithKey(gomock.Any(), "user-events", "a@gmail.com", gomock.Any()).Return(nil),
+					s.outboxRepo.EXPECT().MarkPublished(gomock.Any(), int64(2)).Return(int64(1), nil),
+				)
+			},
+			expectedPublished: 1,
+			expectedError:     nil,
+		},
+		{
+			name: "List Error",
+			setupMock: func(s *OutboxRelayTestSuite) {
+				s.outboxRepo.EXPECT().ListPending(gomock.Any(), int64(0), int32(3)).Return(nil, errDatabase)
+			},
+			expectedPublished: 0,
+			expectedError:     errDatabase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewOutboxRelayTestSuite(t)
+			defer s.ctrl.Finish()
+
+			unlocked := false
+			s.outboxRepo.EXPECT().TryLock(gomock.Any()).Return(func() { unlocked = true }, true, nil)
+			tt.setupMock(s)
+
+			published, err := s.relay.Drain(context.Background())
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedPublished, published)
+			assert.True(t, unlocked)
+		})
+	}
+}
+
+func TestOutboxRelay_Drain_LockHeldByAnotherRelay(t *testing.T) {
+	s := NewOutboxRelayTestSuite(t)
+	defer s.ctrl.Finish()
+
+	s.outboxRepo.EXPECT().TryLock(gomock.Any()).Return(nil, false, nil)
+
+	published, err := s.relay.Drain(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, published)
+}
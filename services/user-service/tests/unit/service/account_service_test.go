@@ -0,0 +1,296 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	mock_cache "github.com/khoihuynh300/go-microservice/shared/mocks/cache"
+	mock_storage "github.com/khoihuynh300/go-microservice/shared/mocks/storage"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/const/contextkeys"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/const/rediskeys"
+	apperr "github.com/khoihuynh300/go-microservice/shared/pkg/errors"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/storage"
+	orderpb "github.com/khoihuynh300/go-microservice/shared/proto/order"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/audit"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/caching"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/domain/models"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/dto/response"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/service"
+	mock_client "github.com/khoihuynh300/go-microservice/user-service/mocks/client"
+	mock_password_hasher "github.com/khoihuynh300/go-microservice/user-service/mocks/passwordhasher"
+	mock_publisher "github.com/khoihuynh300/go-microservice/user-service/mocks/publisher"
+	mock_repository "github.com/khoihuynh300/go-microservice/user-service/mocks/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type AccountServiceTestSuite struct {
+	ctrl             *gomock.Controller
+	cache            *mock_cache.MockCache
+	userRepo         *mock_repository.MockUserRepository
+	addressRepo      *mock_repository.MockAddressRepository
+	refreshTokenRepo *mock_repository.MockRefreshTokenRepository
+	passwordHasher   *mock_password_hasher.MockPasswordHasher
+	imageStorage     *mock_storage.MockStorage
+	exportStorage    *mock_storage.MockStorage
+	orderClient      *mock_client.MockOrderClient
+	eventPublisher   *mock_publisher.MockEventPublisher
+	auditLogRepo     *mock_repository.MockAuditLogRepository
+	accountService   service.AccountService
+
+	// auditEntries collects everything written to the audit log
+	auditEntries []*models.AuditLogEntry
+}
+
+func NewAccountServiceTestSuite(t *testing.T) *AccountServiceTestSuite {
+	ctrl := gomock.NewController(t)
+	cache := mock_cache.NewMockCache(ctrl)
+	userRepo := mock_repository.NewMockUserRepository(ctrl)
+	addressRepo := mock_repository.NewMockAddressRepository(ctrl)
+	refreshTokenRepo := mock_repository.NewMockRefreshTokenRepository(ctrl)
+	passwordHasher := mock_password_hasher.NewMockPasswordHasher(ctrl)
+	imageStorage := mock_storage.NewMockStorage(ctrl)
+	exportStorage := mock_storage.NewMockStorage(ctrl)
+	orderClient := mock_client.NewMockOrderClient(ctrl)
+	eventPublisher := mock_publisher.NewMockEventPublisher(ctrl)
+	auditLogRepo := mock_repository.NewMockAuditLogRepository(ctrl)
+	accountService := service.NewAccountService(
+		userRepo,
+		addressRepo,
+		refreshTokenRepo,
+		caching.NewTokenRevocationCache(cache, 15*time.Minute),
+		passwordHasher,
+		imageStorage,
+		exportStorage,
+		orderClient,
+		eventPublisher,
+		audit.NewRecorder(auditLogRepo),
+	)
+	suite := &AccountServiceTestSuite{
+		ctrl:             ctrl,
+		cache:            cache,
+		userRepo:         userRepo,
+		addressRepo:      addressRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		passwordHasher:   passwordHasher,
+		imageStorage:     imageStorage,
+		exportStorage:    exportStorage,
+		orderClient:      orderClient,
+		eventPublisher:   eventPublisher,
+		auditLogRepo:     auditLogRepo,
+		accountService:   accountService,
+	}
+	auditLogRepo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, entry *models.AuditLogEntry) error {
+			suite.auditEntries = append(suite.auditEntries, entry)
+			return nil
+		}).
+		AnyTimes()
+	return suite
+}
+
+func TestAccountService_DeleteAccount(t *testing.T) {
+	testUserID := uuid.New()
+	avatarURL := "http://minio/avatars/avatar-1234.png"
+
+	tests := []struct {
+		name          string
+		password      string
+		setupMock     func(suite *AccountServiceTestSuite)
+		expectedError error
+		checkAudit    func(t *testing.T, entries []*models.AuditLogEntry)
+	}{
+		{
+			name:     "Delete Success",
+			password: "password123",
+			setupMock: func(s *AccountServiceTestSuite) {
+				s.userRepo.EXPECT().GetByID(gomock.Any(), testUserID).Return(&models.User{
+					ID:             testUserID,
+					HashedPassword: "hashed",
+					AvatarURL:      &avatarURL,
+				}, nil)
+				s.passwordHasher.EXPECT().Compare("hashed", "password123").Return(true)
+				s.userRepo.EXPECT().
+					WithinTransaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+						return fn(ctx)
+					})
+				s.userRepo.EXPECT().SoftDelete(gomock.Any(), testUserID).Return(int64(1), nil)
+				s.refreshTokenRepo.EXPECT().RevokeAllByUserID(gomock.Any(), testUserID).Return(int64(2), nil)
+				s.cache.EXPECT().Set(gomock.Any(), rediskeys.TokensValidAfterPrefix+testUserID.String(), gomock.Any(), 15*time.Minute).Return(nil)
+				s.imageStorage.EXPECT().Delete(gomock.Any(), avatarURL).Return(nil)
+			},
+			expectedError: nil,
+			checkAudit: func(t *testing.T, entries []*models.AuditLogEntry) {
+				require.Len(t, entries, 1)
+				assert.Equal(t, models.AuditEventAccountDeleted, entries[0].EventType)
+				assert.Equal(t, testUserID, *entries[0].UserID)
+				assert.True(t, entries[0].Success)
+			},
+		},
+		{
+			name:     "Avatar Delete Failure Is Not Fatal",
+			password: "password123",
+			setupMock: func(s *AccountServiceTestSuite) {
+				s.userRepo.EXPECT().GetByID(gomock.Any(), testUserID).Return(&models.User{
+					ID:             testUserID,
+					HashedPassword: "hashed",
+					AvatarURL:      &avatarURL,
+				}, nil)
+				s.passwordHasher.EXPECT().Compare("hashed", "password123").Return(true)
+				s.userRepo.EXPECT().
+					WithinTransaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+						return fn(ctx)
+					})
+				s.userRepo.EXPECT().SoftDelete(gomock.Any(), testUserID).Return(int64(1), nil)
+				s.refreshTokenRepo.EXPECT().RevokeAllByUserID(gomock.Any(), testUserID).Return(int64(0), nil)
+				s.cache.EXPECT().Set(gomock.Any(), rediskeys.TokensValidAfterPrefix+testUserID.String(), gomock.Any(), 15*time.Minute).Return(nil)
+				s.imageStorage.EXPECT().Delete(gomock.Any(), avatarURL).Return(errors.New("minio down"))
+			},
+			expectedError: nil,
+		},
+		{
+			name:     "Invalid Password",
+			password: "wrong",
+			setupMock: func(s *AccountServiceTestSuite) {
+				s.userRepo.EXPECT().GetByID(gomock.Any(), testUserID).Return(&models.User{
+					ID:             testUserID,
+					HashedPassword: "hashed",
+				}, nil)
+				s.passwordHasher.EXPECT().Compare("hashed", "wrong").Return(false)
+			},
+			expectedError: apperr.ErrInvalidCurrentPassword,
+			checkAudit: func(t *testing.T, entries []*models.AuditLogEntry) {
+				require.Len(t, entries, 1)
+				assert.Equal(t, models.AuditEventAccountDeleted, entries[0].EventType)
+				assert.False(t, entries[0].Success)
+				require.NotNil(t, entries[0].Reason)
+				assert.Equal(t, apperr.ErrInvalidCurrentPassword.Code, *entries[0].Reason)
+			},
+		},
+		{
+			name:     "User Not Found",
+			password: "password123",
+			setupMock: func(s *AccountServiceTestSuite) {
+				s.userRepo.EXPECT().GetByID(gomock.Any(), testUserID).Return(nil, nil)
+			},
+			expectedError: apperr.ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suite := NewAccountServiceTestSuite(t)
+			defer suite.ctrl.Finish()
+
+			ctx := context.WithValue(context.Background(), contextkeys.LoggerKey, zap.NewNop())
+			tt.setupMock(suite)
+
+			err := suite.accountService.DeleteAccount(ctx, testUserID.String(), tt.password)
+
+			assert.True(t, errors.Is(err, tt.expectedError))
+
+			if tt.checkAudit != nil {
+				tt.checkAudit(t, suite.auditEntries)
+			}
+		})
+	}
+}
+
+func TestAccountService_ExportMyData(t *testing.T) {
+	testUserID := uuid.New()
+	user := &models.User{
+		ID:       testUserID,
+		Email:    "test@gmail.com",
+		FullName: "Test User",
+		Status:   models.UserStatusActive,
+	}
+	addresses := []*models.Address{{ID: uuid.New(), UserID: testUserID, City: "Ho Chi Minh"}}
+	sessions := []*models.Session{{ID: uuid.New(), IPAddress: "10.0.0.1"}}
+
+	tests := []struct {
+		name          string
+		setupMock     func(t *testing.T, suite *AccountServiceTestSuite)
+		expectedError error
+	}{
+		{
+			name: "Export Success",
+			setupMock: func(t *testing.T, s *AccountServiceTestSuite) {
+				s.userRepo.EXPECT().GetByID(gomock.Any(), testUserID).Return(user, nil)
+				s.addressRepo.EXPECT().ListByUserID(gomock.Any(), testUserID).Return(addresses, nil)
+				s.refreshTokenRepo.EXPECT().ListSessionsByUserID(gomock.Any(), testUserID).Return(sessions, nil)
+				s.orderClient.EXPECT().ListMyOrders(gomock.Any(), int32(1), int32(100)).Return(&orderpb.ListOrdersResponse{
+					Orders:     []*orderpb.Order{{Id: "order-1"}},
+					TotalPages: 2,
+				}, nil)
+				s.orderClient.EXPECT().ListMyOrders(gomock.Any(), int32(2), int32(100)).Return(&orderpb.ListOrdersResponse{
+					Orders:     []*orderpb.Order{{Id: "order-2"}},
+					TotalPages: 2,
+				}, nil)
+				s.exportStorage.EXPECT().
+					Upload(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, input *storage.UploadInput) (*storage.UploadOutput, error) {
+						assert.Equal(t, service.DataExportFolder+"/"+testUserID.String(), input.Folder)
+						assert.Equal(t, "application/json", input.ContentType)
+
+						data, err := io.ReadAll(input.File)
+						require.NoError(t, err)
+						assert.Equal(t, input.Size, int64(len(data)))
+
+						var export response.DataExport
+						require.NoError(t, json.Unmarshal(data, &export))
+						assert.Equal(t, "test@gmail.com", export.Profile.Email)
+						assert.Len(t, export.Addresses, 1)
+						assert.Len(t, export.Sessions, 1)
+						assert.Len(t, export.Orders, 2)
+						assert.JSONEq(t, `{"id":"order-2"}`, string(export.Orders[1]))
+
+						return &storage.UploadOutput{Key: "exports/key.json"}, nil
+					})
+				s.exportStorage.EXPECT().GetPresignedDownloadURL(gomock.Any(), "exports/key.json", service.DataExportLinkTTL).Return("https://minio/exports/key.json?sig", nil)
+				s.eventPublisher.EXPECT().PublishDataExportReady(gomock.Any(), user, "https://minio/exports/key.json?sig", gomock.Any()).Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "Order Service Unavailable",
+			setupMock: func(t *testing.T, s *AccountServiceTestSuite) {
+				s.userRepo.EXPECT().GetByID(gomock.Any(), testUserID).Return(user, nil)
+				s.addressRepo.EXPECT().ListByUserID(gomock.Any(), testUserID).Return(addresses, nil)
+				s.refreshTokenRepo.EXPECT().ListSessionsByUserID(gomock.Any(), testUserID).Return(sessions, nil)
+				s.orderClient.EXPECT().ListMyOrders(gomock.Any(), int32(1), int32(100)).Return(nil, apperr.ErrInternal)
+			},
+			expectedError: apperr.ErrInternal,
+		},
+		{
+			name: "User Not Found",
+			setupMock: func(t *testing.T, s *AccountServiceTestSuite) {
+				s.userRepo.EXPECT().GetByID(gomock.Any(), testUserID).Return(nil, nil)
+			},
+			expectedError: apperr.ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suite := NewAccountServiceTestSuite(t)
+			defer suite.ctrl.Finish()
+
+			ctx := context.WithValue(context.Background(), contextkeys.LoggerKey, zap.NewNop())
+			tt.setupMock(t, suite)
+
+			err := suite.accountService.ExportMyData(ctx, testUserID.String())
+
+			assert.True(t, errors.Is(err, tt.expectedError))
+		})
+	}
+}
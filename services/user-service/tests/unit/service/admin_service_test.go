@@ -0,0 +1,338 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	mock_cache "github.com/khoihuynh300/go-microservice/shared/mocks/cache"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/const/contextkeys"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/const/rediskeys"
+	apperr "github.com/khoihuynh300/go-microservice/shared/pkg/errors"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/audit"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/caching"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/domain/models"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/dto/request"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/repository"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/service"
+	mock_password_hasher "github.com/khoihuynh300/go-microservice/user-service/mocks/passwordhasher"
+	mock_publisher "github.com/khoihuynh300/go-microservice/user-service/mocks/publisher"
+	mock_repository "github.com/khoihuynh300/go-microservice/user-service/mocks/repository"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type AdminServiceTestSuite struct {
+	ctrl             *gomock.Controller
+	cache            *mock_cache.MockCache
+	userRepo         *mock_repository.MockUserRepository
+	refreshTokenRepo *mock_repository.MockRefreshTokenRepository
+	passwordHasher   *mock_password_hasher.MockPasswordHasher
+	eventPublisher   *mock_publisher.MockEventPublisher
+	auditLogRepo     *mock_repository.MockAuditLogRepository
+	adminService     service.AdminService
+}
+
+func NewAdminServiceTestSuite(t *testing.T) *AdminServiceTestSuite {
+	ctrl := gomock.NewController(t)
+	cache := mock_cache.NewMockCache(ctrl)
+	userRepo := mock_repository.NewMockUserRepository(ctrl)
+	refreshTokenRepo := mock_repository.NewMockRefreshTokenRepository(ctrl)
+	passwordHasher := mock_password_hasher.NewMockPasswordHasher(ctrl)
+	eventPublisher := mock_publisher.NewMockEventPublisher(ctrl)
+	auditLogRepo := mock_repository.NewMockAuditLogRepository(ctrl)
+	adminService := service.NewAdminService(
+		userRepo,
+		refreshTokenRepo,
+		caching.NewTokenCache(cache),
+		caching.NewTokenRevocationCache(cache, 15*time.Minute),
+		passwordHasher,
+		eventPublisher,
+		audit.NewRecorder(auditLogRepo),
+	)
+	return &AdminServiceTestSuite{
+		ctrl:             ctrl,
+		cache:            cache,
+		userRepo:         userRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		passwordHasher:   passwordHasher,
+		eventPublisher:   eventPublisher,
+		auditLogRepo:     auditLogRepo,
+		adminService:     adminService,
+	}
+}
+
+func (s *AdminServiceTestSuite) expectTransaction() {
+	s.userRepo.EXPECT().
+		WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+}
+
+// expectAudit expects a single audit entry attributing the action to the admin.
+func (s *AdminServiceTestSuite) expectAudit(t *testing.T, eventType models.AuditEventType, adminID, userID uuid.UUID) {
+	s.auditLogRepo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, entry *models.AuditLogEntry) error {
+			assert.Equal(t, eventType, entry.EventType)
+			assert.True(t, entry.Success)
+			assert.Equal(t, &adminID, entry.ActorID)
+			assert.Equal(t, &userID, entry.UserID)
+			return nil
+		})
+}
+
+func TestAdminService_ListUsers(t *testing.T) {
+	suite := NewAdminServiceTestSuite(t)
+	defer suite.ctrl.Finish()
+
+	status := "suspended"
+	email := "gmail"
+	users := []*models.User{{ID: uuid.New(), Status: models.UserStatusSuspended}}
+
+	suspended := models.UserStatusSuspended
+	suite.userRepo.EXPECT().
+		List(gomock.Any(), &repository.UserFilter{Status: &suspended, Email: &email}, int32(2), int32(20)).
+		Return(users, int64(21), nil)
+
+	ctx := context.WithValue(context.Background(), contextkeys.LoggerKey, zap.NewNop())
+	result, total, err := suite.adminService.ListUsers(ctx, &request.ListUsersRequest{
+		Status:   &status,
+		Email:    &email,
+		Page:     2,
+		PageSize: 20,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, users, result)
+	assert.Equal(t, int64(21), total)
+}
+
+func TestAdminService_SuspendUser(t *testing.T) {
+	adminID := uuid.New()
+	testUserID := uuid.New()
+
+	tests := []struct {
+		name          string
+		adminID       uuid.UUID
+		setupMock     func(t *testing.T, suite *AdminServiceTestSuite)
+		expectedError error
+	}{
+		{
+			name:    "Suspend Success",
+			adminID: adminID,
+			setupMock: func(t *testing.T, s *AdminServiceTestSuite) {
+				s.userRepo.EXPECT().GetByID(gomock.Any(), testUserID).Return(&models.User{
+					ID:     testUserID,
+					Status: models.UserStatusActive,
+				}, nil)
+				s.expectTransaction()
+				s.userRepo.EXPECT().UpdateStatus(gomock.Any(), testUserID, models.UserStatusSuspended).Return(int64(1), nil)
+				s.refreshTokenRepo.EXPECT().RevokeAllByUserID(gomock.Any(), testUserID).Return(int64(2), nil)
+				s.eventPublisher.EXPECT().
+					PublishUserSuspended(gomock.Any(), gomock.Any(), "spam").
+					DoAndReturn(func(ctx context.Context, user *models.User, reason string) error {
+						assert.Equal(t, models.UserStatusSuspended, user.Status)
+						return nil
+					})
+				s.cache.EXPECT().Set(gomock.Any(), rediskeys.TokensValidAfterPrefix+testUserID.String(), gomock.Any(), 15*time.Minute).Return(nil)
+				s.expectAudit(t, models.AuditEventUserSuspended, adminID, testUserID)
+			},
+			expectedError: nil,
+		},
+		{
+			name:    "Already Suspended",
+			adminID: adminID,
+			setupMock: func(t *testing.T, s *AdminServiceTestSuite) {
+				s.userRepo.EXPECT().GetByID(gomock.Any(), testUserID).Return(&models.User{
+					ID:     testUserID,
+					Status: models.UserStatusSuspended,
+				}, nil)
+			},
+			expectedError: apperr.ErrUserAlreadySuspended,
+		},
+		{
+			name:          "Cannot Suspend Self",
+			adminID:       testUserID,
+			setupMock:     func(t *testing.T, s *AdminServiceTestSuite) {},
+			expectedError: apperr.ErrCannotManageSelf,
+		},
+		{
+			name:    "User Not Found",
+			adminID: adminID,
+			setupMock: func(t *testing.T, s *AdminServiceTestSuite) {
+				s.userRepo.EXPECT().GetByID(gomock.Any(), testUserID).Return(nil, nil)
+			},
+			expectedError: apperr.ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suite := NewAdminServiceTestSuite(t)
+			defer suite.ctrl.Finish()
+
+			ctx := context.WithValue(context.Background(), contextkeys.LoggerKey, zap.NewNop())
+			tt.setupMock(t, suite)
+
+			user, err := suite.adminService.SuspendUser(ctx, tt.adminID.String(), testUserID.String(), "spam")
+
+			assert.True(t, errors.Is(err, tt.expectedError))
+			if tt.expectedError == nil {
+				assert.Equal(t, models.UserStatusSuspended, user.Status)
+			}
+		})
+	}
+}
+
+func TestAdminService_ReactivateUser(t *testing.T) {
+	adminID := uuid.New()
+	testUserID := uuid.New()
+
+	tests := []struct {
+		name          string
+		status        models.UserStatus
+		setupMock     func(t *testing.T, suite *AdminServiceTestSuite)
+		expectedError error
+	}{
+		{
+			name:   "Reactivate Suspended",
+			status: models.UserStatusSuspended,
+			setupMock: func(t *testing.T, s *AdminServiceTestSuite) {
+				s.expectTransaction()
+				s.userRepo.EXPECT().UpdateStatus(gomock.Any(), testUserID, models.UserStatusActive).Return(int64(1), nil)
+				s.eventPublisher.EXPECT().PublishUserReactivated(gomock.Any(), gomock.Any()).Return(nil)
+				s.expectAudit(t, models.AuditEventUserReactivated, adminID, testUserID)
+			},
+			expectedError: nil,
+		},
+		{
+			name:   "Reactivate Inactive",
+			status: models.UserStatusInactive,
+			setupMock: func(t *testing.T, s *AdminServiceTestSuite) {
+				s.expectTransaction()
+				s.userRepo.EXPECT().UpdateStatus(gomock.Any(), testUserID, models.UserStatusActive).Return(int64(1), nil)
+				s.eventPublisher.EXPECT().PublishUserReactivated(gomock.Any(), gomock.Any()).Return(nil)
+				s.expectAudit(t, models.AuditEventUserReactivated, adminID, testUserID)
+			},
+			expectedError: nil,
+		},
+		{
+			name:          "Already Active",
+			status:        models.UserStatusActive,
+			setupMock:     func(t *testing.T, s *AdminServiceTestSuite) {},
+			expectedError: apperr.ErrUserNotSuspended,
+		},
+		{
+			name:          "Pending Is Not Reactivated",
+			status:        models.UserStatusPending,
+			setupMock:     func(t *testing.T, s *AdminServiceTestSuite) {},
+			expectedError: apperr.ErrUserNotSuspended,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suite := NewAdminServiceTestSuite(t)
+			defer suite.ctrl.Finish()
+
+			ctx := context.WithValue(context.Background(), contextkeys.LoggerKey, zap.NewNop())
+			suite.userRepo.EXPECT().GetByID(gomock.Any(), testUserID).Return(&models.User{
+				ID:     testUserID,
+				Status: tt.status,
+			}, nil)
+			tt.setupMock(t, suite)
+
+			user, err := suite.adminService.ReactivateUser(ctx, adminID.String(), testUserID.String())
+
+			assert.True(t, errors.Is(err, tt.expectedError))
+			if tt.expectedError == nil {
+				assert.Equal(t, models.UserStatusActive, user.Status)
+			}
+		})
+	}
+}
+
+func TestAdminService_ForceResetPassword(t *testing.T) {
+	adminID := uuid.New()
+	testUserID := uuid.New()
+	user := &models.User{
+		ID:     testUserID,
+		Email:  "test@gmail.com",
+		Status: models.UserStatusActive,
+	}
+
+	tests := []struct {
+		name          string
+		setupMock     func(t *testing.T, suite *AdminServiceTestSuite)
+		expectedError error
+	}{
+		{
+			name: "Force Reset Success",
+			setupMock: func(t *testing.T, s *AdminServiceTestSuite) {
+				s.userRepo.EXPECT().GetByID(gomock.Any(), testUserID).Return(user, nil)
+				s.passwordHasher.EXPECT().Hash(gomock.Any()).Return("random_hashed", nil)
+				s.cache.EXPECT().Set(gomock.Any(), gomock.Any(), user.Email, caching.PasswordResetTTL).Return(nil)
+				s.expectTransaction()
+				s.userRepo.EXPECT().UpdatePassword(gomock.Any(), testUserID, "random_hashed").Return(int64(1), nil)
+				s.refreshTokenRepo.EXPECT().RevokeAllByUserID(gomock.Any(), testUserID).Return(int64(1), nil)
+				s.eventPublisher.EXPECT().PublishPasswordResetForced(gomock.Any(), user, gomock.Any()).Return(nil)
+				s.cache.EXPECT().Set(gomock.Any(), rediskeys.TokensValidAfterPrefix+testUserID.String(), gomock.Any(), 15*time.Minute).Return(nil)
+				s.expectAudit(t, models.AuditEventPasswordResetForced, adminID, testUserID)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "Publish Failure Rolls Back",
+			setupMock: func(t *testing.T, s *AdminServiceTestSuite) {
+				s.userRepo.EXPECT().GetByID(gomock.Any(), testUserID).Return(user, nil)
+				s.passwordHasher.EXPECT().Hash(gomock.Any()).Return("random_hashed", nil)
+				s.cache.EXPECT().Set(gomock.Any(), gomock.Any(), user.Email, caching.PasswordResetTTL).Return(nil)
+				s.expectTransaction()
+				s.userRepo.EXPECT().UpdatePassword(gomock.Any(), testUserID, "random_hashed").Return(int64(1), nil)
+				s.refreshTokenRepo.EXPECT().RevokeAllByUserID(gomock.Any(), testUserID).Return(int64(1), nil)
+				s.eventPublisher.EXPECT().PublishPasswordResetForced(gomock.Any(), user, gomock.Any()).Return(apperr.ErrInternal)
+			},
+			expectedError: apperr.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suite := NewAdminServiceTestSuite(t)
+			defer suite.ctrl.Finish()
+
+			ctx := context.WithValue(context.Background(), contextkeys.LoggerKey, zap.NewNop())
+			tt.setupMock(t, suite)
+
+			err := suite.adminService.ForceResetPassword(ctx, adminID.String(), testUserID.String())
+
+			assert.True(t, errors.Is(err, tt.expectedError))
+		})
+	}
+}
+
+func TestAdminService_RevokeAllSessions(t *testing.T) {
+	adminID := uuid.New()
+	testUserID := uuid.New()
+
+	suite := NewAdminServiceTestSuite(t)
+	defer suite.ctrl.Finish()
+
+	user := &models.User{ID: testUserID, Email: "test@gmail.com"}
+	suite.userRepo.EXPECT().GetByID(gomock.Any(), testUserID).Return(user, nil)
+	suite.expectTransaction()
+	suite.refreshTokenRepo.EXPECT().RevokeAllByUserID(gomock.Any(), testUserID).Return(int64(3), nil)
+	suite.eventPublisher.EXPECT().PublishSessionsRevoked(gomock.Any(), user).Return(nil)
+	suite.cache.EXPECT().Set(gomock.Any(), rediskeys.TokensValidAfterPrefix+testUserID.String(), gomock.Any(), 15*time.Minute).Return(nil)
+	suite.expectAudit(t, models.AuditEventAllSessionsRevoked, adminID, testUserID)
+
+	ctx := context.WithValue(context.Background(), contextkeys.LoggerKey, zap.NewNop())
+	err := suite.adminService.RevokeAllSessions(ctx, adminID.String(), testUserID.String())
+
+	assert.NoError(t, err)
+}
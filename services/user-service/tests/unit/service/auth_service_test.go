@@ -2,6 +2,7 @@ package service_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"testing"
 	"time"
@@ -10,16 +11,25 @@ import (
 	"github.com/google/uuid"
 	mock_cache "github.com/khoihuynh300/go-microservice/shared/mocks/cache"
 	"github.com/khoihuynh300/go-microservice/shared/pkg/const/contextkeys"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/const/rediskeys"
 	apperr "github.com/khoihuynh300/go-microservice/shared/pkg/errors"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/audit"
 	"github.com/khoihuynh300/go-microservice/user-service/internal/caching"
 	"github.com/khoihuynh300/go-microservice/user-service/internal/domain/models"
 	"github.com/khoihuynh300/go-microservice/user-service/internal/dto/request"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/repository"
 	"github.com/khoihuynh300/go-microservice/user-service/internal/security/jwtprovider"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/security/oauth"
 	"github.com/khoihuynh300/go-microservice/user-service/internal/service"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/utils"
 	mock_jwt "github.com/khoihuynh300/go-microservice/user-service/mocks/jwt"
+	mock_oauth "github.com/khoihuynh300/go-microservice/user-service/mocks/oauth"
 	mock_password_hasher "github.com/khoihuynh300/go-microservice/user-service/mocks/passwordhasher"
+	mock_publisher "github.com/khoihuynh300/go-microservice/user-service/mocks/publisher"
 	mock_repository "github.com/khoihuynh300/go-microservice/user-service/mocks/repository"
+	mock_totp "github.com/khoihuynh300/go-microservice/user-service/mocks/totp"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
 
@@ -29,10 +39,21 @@ type AuthServiceTestSuite struct {
 
 	userRepo         *mock_repository.MockUserRepository
 	refreshTokenRepo *mock_repository.MockRefreshTokenRepository
+	identityRepo     *mock_repository.MockUserIdentityRepository
 	passwordHasher   *mock_password_hasher.MockPasswordHasher
+	passwordPolicy   *mock_password_hasher.MockPasswordPolicy
+	totpProvider     *mock_totp.MockTOTPProvider
+	oauthProvider    *mock_oauth.MockProvider
 	jwtService       *mock_jwt.MockJwtProvider
+	eventPublisher   *mock_publisher.MockEventPublisher
+	auditLogRepo     *mock_repository.MockAuditLogRepository
 
-	tokenCache *caching.TokenCache
+	// auditEntries collects everything written to the audit log
+	auditEntries []*models.AuditLogEntry
+
+	tokenCache      *caching.TokenCache
+	loginAttempts   *caching.LoginAttemptCache
+	tokenRevocation *caching.TokenRevocationCache
 
 	authService service.AuthService
 }
@@ -43,25 +64,61 @@ func setupAuthServiceTestSuite(t *testing.T) *AuthServiceTestSuite {
 
 	userRepo := mock_repository.NewMockUserRepository(ctrl)
 	refreshTokenRepo := mock_repository.NewMockRefreshTokenRepository(ctrl)
+	identityRepo := mock_repository.NewMockUserIdentityRepository(ctrl)
 	passwordHasher := mock_password_hasher.NewMockPasswordHasher(ctrl)
+	passwordPolicy := mock_password_hasher.NewMockPasswordPolicy(ctrl)
+	totpProvider := mock_totp.NewMockTOTPProvider(ctrl)
+	oauthProvider := mock_oauth.NewMockProvider(ctrl)
+	oauthProvider.EXPECT().Name().Return("fake").AnyTimes()
 	jwtService := mock_jwt.NewMockJwtProvider(ctrl)
+	eventPublisher := mock_publisher.NewMockEventPublisher(ctrl)
+	auditLogRepo := mock_repository.NewMockAuditLogRepository(ctrl)
 
 	tokenCache := caching.NewTokenCache(cache)
+	loginAttempts := caching.NewLoginAttemptCache(cache, caching.LoginAttemptPolicy{
+		MaxFailures:     5,
+		DelayAfter:      3,
+		BaseDelay:       time.Second,
+		MaxDelay:        30 * time.Second,
+		LockoutDuration: 15 * time.Minute,
+		Window:          15 * time.Minute,
+		IPMaxFailures:   50,
+	})
+
+	tokenRevocation := caching.NewTokenRevocationCache(cache, 15*time.Minute)
 
-	authService := service.NewAuthService(userRepo, refreshTokenRepo, tokenCache, passwordHasher, jwtService)
-	return &AuthServiceTestSuite{
+	authService := service.NewAuthService(userRepo, refreshTokenRepo, identityRepo, tokenCache, loginAttempts, tokenRevocation, passwordHasher, passwordPolicy, totpProvider, oauth.NewRegistry(oauthProvider), jwtService, eventPublisher, audit.NewRecorder(auditLogRepo))
+	suite := &AuthServiceTestSuite{
 		ctrl:             ctrl,
 		cache:            cache,
 		userRepo:         userRepo,
 		refreshTokenRepo: refreshTokenRepo,
+		identityRepo:     identityRepo,
 		passwordHasher:   passwordHasher,
+		passwordPolicy:   passwordPolicy,
+		totpProvider:     totpProvider,
+		oauthProvider:    oauthProvider,
 		jwtService:       jwtService,
+		eventPublisher:   eventPublisher,
+		auditLogRepo:     auditLogRepo,
 		tokenCache:       tokenCache,
+		loginAttempts:    loginAttempts,
+		tokenRevocation:  tokenRevocation,
 		authService:      authService,
 	}
+	auditLogRepo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, entry *models.AuditLogEntry) error {
+			suite.auditEntries = append(suite.auditEntries, entry)
+			return nil
+		}).
+		AnyTimes()
+	return suite
 }
 
 func TestAuthService_Register(t *testing.T) {
+	weakPasswordErr := apperr.NewErrValidationFailedWithDetail("password", apperr.CodePasswordBreached, "breached")
+
 	tests := []struct {
 		name          string
 		req           *request.RegisterRequest
@@ -77,6 +134,7 @@ func TestAuthService_Register(t *testing.T) {
 				FullName: "testuser",
 			},
 			setupMock: func(s *AuthServiceTestSuite) {
+				s.passwordPolicy.EXPECT().Validate("password", "passwrod123", "test@gmail.com", "testuser").Return(nil)
 				s.userRepo.EXPECT().
 					WithinTransaction(gomock.Any(), gomock.Any()).
 					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
@@ -91,6 +149,7 @@ func TestAuthService_Register(t *testing.T) {
 						return nil
 					})
 				s.cache.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				s.eventPublisher.EXPECT().PublishVerifyEmail(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 			},
 			expectedError: nil,
 			checkFunc: func(t *testing.T, user *models.User, err error) {
@@ -108,6 +167,7 @@ func TestAuthService_Register(t *testing.T) {
 				FullName: "testuser",
 			},
 			setupMock: func(s *AuthServiceTestSuite) {
+				s.passwordPolicy.EXPECT().Validate("password", "passwrod123", "existing@gmail.com", "testuser").Return(nil)
 				existingUser := &models.User{
 					ID:    uuid.New(),
 					Email: "existing@gmail.com",
@@ -121,6 +181,21 @@ func TestAuthService_Register(t *testing.T) {
 				assert.Nil(t, user)
 			},
 		},
+		{
+			name: "Password Rejected By Policy",
+			req: &request.RegisterRequest{
+				Email:    "test@gmail.com",
+				Password: "password123",
+				FullName: "testuser",
+			},
+			setupMock: func(s *AuthServiceTestSuite) {
+				s.passwordPolicy.EXPECT().Validate("password", "password123", "test@gmail.com", "testuser").Return(weakPasswordErr)
+			},
+			expectedError: weakPasswordErr,
+			checkFunc: func(t *testing.T, user *models.User, err error) {
+				assert.Nil(t, user)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -162,8 +237,36 @@ func TestAuthService_VerifyEmail(t *testing.T) {
 					Status: models.UserStatusPending,
 				}
 				s.userRepo.EXPECT().GetByEmail(gomock.Any(), "test@gmail.com").Return(user, nil)
+				s.userRepo.EXPECT().
+					WithinTransaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+						return fn(ctx)
+					})
 				s.userRepo.EXPECT().VerifyEmail(gomock.Any(), user.ID).Return(int64(1), nil)
 				s.userRepo.EXPECT().UpdateStatus(gomock.Any(), user.ID, models.UserStatusActive).Return(int64(1), nil)
+				s.eventPublisher.EXPECT().PublishEmailVerifySuccess(gomock.Any(), "test@gmail.com").Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name:  "Suspended User Stays Suspended",
+			token: "valid-token",
+			setupMock: func(s *AuthServiceTestSuite) {
+				s.cache.EXPECT().Get(gomock.Any(), gomock.Any()).Return("test@gmail.com", nil)
+				s.cache.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
+				user := &models.User{
+					ID:     uuid.New(),
+					Email:  "test@gmail.com",
+					Status: models.UserStatusSuspended,
+				}
+				s.userRepo.EXPECT().GetByEmail(gomock.Any(), "test@gmail.com").Return(user, nil)
+				s.userRepo.EXPECT().
+					WithinTransaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+						return fn(ctx)
+					})
+				s.userRepo.EXPECT().VerifyEmail(gomock.Any(), user.ID).Return(int64(1), nil)
+				s.eventPublisher.EXPECT().PublishEmailVerifySuccess(gomock.Any(), "test@gmail.com").Return(nil)
 			},
 			expectedError: nil,
 		},
@@ -237,6 +340,7 @@ func TestAuthService_ResendVerificationEmail(t *testing.T) {
 				}
 				s.userRepo.EXPECT().GetByEmail(gomock.Any(), "pending@gmail.com").Return(user, nil)
 				s.cache.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				s.eventPublisher.EXPECT().PublishVerifyEmail(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 			},
 			expectedError: nil,
 		},
@@ -280,6 +384,8 @@ func TestAuthService_ResendVerificationEmail(t *testing.T) {
 	}
 }
 
+var testTOTPSecret = "JBSWY3DPEHPK3PXP"
+
 func TestAuthService_Login(t *testing.T) {
 	testUserID := uuid.New()
 	verifiedAt := time.Now()
@@ -289,7 +395,8 @@ func TestAuthService_Login(t *testing.T) {
 		req           *request.LoginRequest
 		setupMock     func(suite *AuthServiceTestSuite)
 		expectedError error
-		checkFunc     func(t *testing.T, user *models.User, accessToken, refreshToken string, err error)
+		checkFunc     func(t *testing.T, result *service.LoginResult, err error)
+		checkAudit    func(t *testing.T, entries []*models.AuditLogEntry)
 	}{
 		{
 			name: "Login Success",
@@ -305,18 +412,58 @@ func TestAuthService_Login(t *testing.T) {
 					Status:          models.UserStatusActive,
 					EmailVerifiedAt: &verifiedAt,
 				}
+				s.cache.EXPECT().TTL(gomock.Any(), gomock.Any()).Return(time.Duration(-2), nil).Times(2)
 				s.userRepo.EXPECT().GetByEmail(gomock.Any(), "active@gmail.com").Return(user, nil)
 				s.passwordHasher.EXPECT().Compare("hashedpassword", "password123").Return(true)
-				s.jwtService.EXPECT().GenerateAccessToken(gomock.Any()).Return("access-token", nil)
+				s.cache.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
+				s.jwtService.EXPECT().GenerateAccessToken(gomock.Any()).Return("access-token", "access-token-id", nil)
 				s.jwtService.EXPECT().GenerateRefreshToken(testUserID.String()).Return("refresh-token", nil)
 				s.jwtService.EXPECT().GetRefreshTTL().Return(7 * 24 * time.Hour)
 				s.refreshTokenRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
 			},
 			expectedError: nil,
-			checkFunc: func(t *testing.T, user *models.User, accessToken, refreshToken string, err error) {
-				assert.NotNil(t, user)
-				assert.Equal(t, "access-token", accessToken)
-				assert.Equal(t, "refresh-token", refreshToken)
+			checkFunc: func(t *testing.T, result *service.LoginResult, err error) {
+				assert.NotNil(t, result.User)
+				assert.False(t, result.MFARequired())
+				assert.Equal(t, "access-token", result.AccessToken)
+				assert.Equal(t, "refresh-token", result.RefreshToken)
+			},
+			checkAudit: func(t *testing.T, entries []*models.AuditLogEntry) {
+				require.Len(t, entries, 1)
+				assert.Equal(t, models.AuditEventLogin, entries[0].EventType)
+				assert.True(t, entries[0].Success)
+				assert.Equal(t, &testUserID, entries[0].UserID)
+			},
+		},
+		{
+			name: "Login Requires MFA",
+			req: &request.LoginRequest{
+				Email:    "active@gmail.com",
+				Password: "password123",
+			},
+			setupMock: func(s *AuthServiceTestSuite) {
+				user := &models.User{
+					ID:             testUserID,
+					Email:          "active@gmail.com",
+					HashedPassword: "hashedpassword",
+					Status:         models.UserStatusActive,
+					TOTPSecret:     &testTOTPSecret,
+					TOTPEnabledAt:  &verifiedAt,
+				}
+				s.cache.EXPECT().TTL(gomock.Any(), gomock.Any()).Return(time.Duration(-2), nil).Times(2)
+				s.userRepo.EXPECT().GetByEmail(gomock.Any(), "active@gmail.com").Return(user, nil)
+				s.passwordHasher.EXPECT().Compare("hashedpassword", "password123").Return(true)
+				s.cache.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
+				s.cache.EXPECT().Set(gomock.Any(), gomock.Any(), testUserID.String(), caching.MFAChallengeTTL).Return(nil)
+			},
+			expectedError: nil,
+			checkFunc: func(t *testing.T, result *service.LoginResult, err error) {
+				assert.True(t, result.MFARequired())
+				assert.Empty(t, result.AccessToken)
+				assert.Empty(t, result.RefreshToken)
+			},
+			checkAudit: func(t *testing.T, entries []*models.AuditLogEntry) {
+				assert.Empty(t, entries)
 			},
 		},
 		{
@@ -326,10 +473,19 @@ func TestAuthService_Login(t *testing.T) {
 				Password: "password123",
 			},
 			setupMock: func(s *AuthServiceTestSuite) {
+				s.cache.EXPECT().TTL(gomock.Any(), gomock.Any()).Return(time.Duration(-2), nil).Times(2)
 				s.userRepo.EXPECT().GetByEmail(gomock.Any(), "notfound@gmail.com").Return(nil, nil)
+				s.cache.EXPECT().Incr(gomock.Any(), gomock.Any()).Return(int64(1), nil)
+				s.cache.EXPECT().Expire(gomock.Any(), gomock.Any(), 15*time.Minute).Return(nil)
 			},
 			expectedError: apperr.ErrInvalidCredentials,
 			checkFunc:     nil,
+			checkAudit: func(t *testing.T, entries []*models.AuditLogEntry) {
+				require.Len(t, entries, 1)
+				assert.False(t, entries[0].Success)
+				assert.Nil(t, entries[0].UserID)
+				assert.Equal(t, apperr.CodeInvalidCredentials, *entries[0].Reason)
+			},
 		},
 		{
 			name: "Wrong Password",
@@ -344,12 +500,73 @@ func TestAuthService_Login(t *testing.T) {
 					HashedPassword: "hashedpassword",
 					Status:         models.UserStatusActive,
 				}
+				s.cache.EXPECT().TTL(gomock.Any(), gomock.Any()).Return(time.Duration(-2), nil).Times(2)
 				s.userRepo.EXPECT().GetByEmail(gomock.Any(), "active@gmail.com").Return(user, nil)
 				s.passwordHasher.EXPECT().Compare("hashedpassword", "wrongpassword").Return(false)
+				s.cache.EXPECT().Incr(gomock.Any(), gomock.Any()).Return(int64(3), nil)
+				s.cache.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), time.Second).Return(nil)
 			},
 			expectedError: apperr.ErrInvalidCredentials,
 			checkFunc:     nil,
 		},
+		{
+			name: "Wrong Password Locks Account",
+			req: &request.LoginRequest{
+				Email:    "active@gmail.com",
+				Password: "wrongpassword",
+			},
+			setupMock: func(s *AuthServiceTestSuite) {
+				user := &models.User{
+					ID:             testUserID,
+					Email:          "active@gmail.com",
+					HashedPassword: "hashedpassword",
+					Status:         models.UserStatusActive,
+				}
+				s.cache.EXPECT().TTL(gomock.Any(), gomock.Any()).Return(time.Duration(-2), nil).Times(2)
+				s.userRepo.EXPECT().GetByEmail(gomock.Any(), "active@gmail.com").Return(user, nil)
+				s.passwordHasher.EXPECT().Compare("hashedpassword", "wrongpassword").Return(false)
+				s.cache.EXPECT().Incr(gomock.Any(), gomock.Any()).Return(int64(5), nil)
+				s.cache.EXPECT().SetNX(gomock.Any(), gomock.Any(), gomock.Any(), 15*time.Minute).Return(true, nil)
+				s.cache.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
+				s.cache.EXPECT().Set(gomock.Any(), gomock.Any(), "active@gmail.com", caching.AccountUnlockTTL).Return(nil)
+				s.eventPublisher.EXPECT().PublishAccountLocked(gomock.Any(), user, gomock.Any(), "", gomock.Any()).Return(nil)
+			},
+			expectedError: apperr.ErrAccountLocked,
+			checkFunc:     nil,
+			checkAudit: func(t *testing.T, entries []*models.AuditLogEntry) {
+				require.Len(t, entries, 1)
+				assert.False(t, entries[0].Success)
+				assert.Equal(t, &testUserID, entries[0].UserID)
+				assert.Equal(t, apperr.CodeAccountLocked, *entries[0].Reason)
+			},
+		},
+		{
+			name: "Account Locked",
+			req: &request.LoginRequest{
+				Email:    "locked@gmail.com",
+				Password: "password123",
+			},
+			setupMock: func(s *AuthServiceTestSuite) {
+				s.cache.EXPECT().TTL(gomock.Any(), gomock.Any()).Return(10*time.Minute, nil)
+			},
+			expectedError: apperr.ErrAccountLocked,
+			checkFunc:     nil,
+		},
+		{
+			name: "Login Delayed After Failures",
+			req: &request.LoginRequest{
+				Email:    "active@gmail.com",
+				Password: "password123",
+			},
+			setupMock: func(s *AuthServiceTestSuite) {
+				gomock.InOrder(
+					s.cache.EXPECT().TTL(gomock.Any(), gomock.Any()).Return(time.Duration(-2), nil),
+					s.cache.EXPECT().TTL(gomock.Any(), gomock.Any()).Return(2*time.Second, nil),
+				)
+			},
+			expectedError: apperr.ErrTooManyLoginAttempts,
+			checkFunc:     nil,
+		},
 		{
 			name: "Account Inactive",
 			req: &request.LoginRequest{
@@ -363,8 +580,10 @@ func TestAuthService_Login(t *testing.T) {
 					HashedPassword: "hashedpassword",
 					Status:         models.UserStatusPending,
 				}
+				s.cache.EXPECT().TTL(gomock.Any(), gomock.Any()).Return(time.Duration(-2), nil).Times(2)
 				s.userRepo.EXPECT().GetByEmail(gomock.Any(), "inactive@gmail.com").Return(user, nil)
 				s.passwordHasher.EXPECT().Compare("hashedpassword", "password123").Return(true)
+				s.cache.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
 			},
 			expectedError: apperr.ErrAccountInactive,
 			checkFunc:     nil,
@@ -379,12 +598,15 @@ func TestAuthService_Login(t *testing.T) {
 			ctx := context.WithValue(context.Background(), contextkeys.LoggerKey, zap.NewNop())
 			tt.setupMock(suite)
 
-			user, accessToken, refreshToken, err := suite.authService.Login(ctx, tt.req)
+			result, err := suite.authService.Login(ctx, tt.req)
 
 			assert.True(t, errors.Is(err, tt.expectedError))
 
 			if tt.checkFunc != nil {
-				tt.checkFunc(t, user, accessToken, refreshToken, err)
+				tt.checkFunc(t, result, err)
+			}
+			if tt.checkAudit != nil {
+				tt.checkAudit(t, suite.auditEntries)
 			}
 		})
 	}
@@ -393,6 +615,7 @@ func TestAuthService_Login(t *testing.T) {
 func TestAuthService_RefreshToken(t *testing.T) {
 	testUserID := uuid.New()
 	testTokenID := uuid.New()
+	testFamilyID := uuid.New()
 	verifiedAt := time.Now()
 
 	tests := []struct {
@@ -401,6 +624,7 @@ func TestAuthService_RefreshToken(t *testing.T) {
 		setupMock     func(suite *AuthServiceTestSuite)
 		expectedError error
 		checkFunc     func(t *testing.T, accessToken, refreshToken string, err error)
+		checkAudit    func(t *testing.T, entries []*models.AuditLogEntry)
 	}{
 		{
 			name:         "Refresh Token Success",
@@ -413,6 +637,7 @@ func TestAuthService_RefreshToken(t *testing.T) {
 				s.refreshTokenRepo.EXPECT().GetByToken(gomock.Any(), gomock.Any()).Return(&models.RefreshToken{
 					ID:        testTokenID,
 					UserID:    testUserID,
+					FamilyID:  testFamilyID,
 					ExpiresAt: time.Now().Add(24 * time.Hour),
 				}, nil)
 				s.userRepo.EXPECT().GetByID(gomock.Any(), testUserID).Return(&models.User{
@@ -421,17 +646,34 @@ func TestAuthService_RefreshToken(t *testing.T) {
 					Status:          models.UserStatusActive,
 					EmailVerifiedAt: &verifiedAt,
 				}, nil)
-				s.refreshTokenRepo.EXPECT().DeleteByID(gomock.Any(), testTokenID).Return(int64(1), nil)
-				s.jwtService.EXPECT().GenerateAccessToken(gomock.Any()).Return("new-access-token", nil)
+				s.jwtService.EXPECT().GenerateAccessToken(gomock.Any()).Return("new-access-token", "new-access-token-id", nil)
 				s.jwtService.EXPECT().GenerateRefreshToken(testUserID.String()).Return("new-refresh-token", nil)
 				s.jwtService.EXPECT().GetRefreshTTL().Return(7 * 24 * time.Hour)
-				s.refreshTokenRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+				s.refreshTokenRepo.EXPECT().
+					WithinTransaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+						return fn(ctx)
+					})
+				s.refreshTokenRepo.EXPECT().MarkRotated(gomock.Any(), testTokenID).Return(int64(1), nil)
+				s.refreshTokenRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, rt *models.RefreshToken) error {
+						assert.Equal(t, testFamilyID, rt.FamilyID)
+						assert.Equal(t, &testTokenID, rt.ParentID)
+						assert.Equal(t, utils.HashToken("new-refresh-token"), rt.TokenHash)
+						return nil
+					})
 			},
 			expectedError: nil,
 			checkFunc: func(t *testing.T, accessToken, refreshToken string, err error) {
 				assert.Equal(t, "new-access-token", accessToken)
 				assert.Equal(t, "new-refresh-token", refreshToken)
 			},
+			checkAudit: func(t *testing.T, entries []*models.AuditLogEntry) {
+				require.Len(t, entries, 1)
+				assert.Equal(t, models.AuditEventTokenRefresh, entries[0].EventType)
+				assert.True(t, entries[0].Success)
+			},
 		},
 		{
 			name:         "Token Expired",
@@ -464,6 +706,92 @@ func TestAuthService_RefreshToken(t *testing.T) {
 			expectedError: apperr.ErrTokenInvalid,
 			checkFunc:     nil,
 		},
+		{
+			name:         "Rotated Token Reused",
+			refreshToken: "rotated-token",
+			setupMock: func(s *AuthServiceTestSuite) {
+				claims := &jwtprovider.RefreshTokenClaims{}
+				claims.Subject = testUserID.String()
+				rotatedAt := time.Now()
+				user := &models.User{
+					ID:     testUserID,
+					Email:  "test@gmail.com",
+					Status: models.UserStatusActive,
+				}
+
+				s.jwtService.EXPECT().VerifyRefreshToken("rotated-token").Return(claims, nil)
+				s.refreshTokenRepo.EXPECT().GetByToken(gomock.Any(), gomock.Any()).Return(&models.RefreshToken{
+					ID:        testTokenID,
+					UserID:    testUserID,
+					FamilyID:  testFamilyID,
+					ExpiresAt: time.Now().Add(24 * time.Hour),
+					RotatedAt: &rotatedAt,
+				}, nil)
+				s.refreshTokenRepo.EXPECT().
+					WithinTransaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+						return fn(ctx)
+					})
+				s.refreshTokenRepo.EXPECT().RevokeFamily(gomock.Any(), testFamilyID).Return(int64(3), nil)
+				s.userRepo.EXPECT().GetByID(gomock.Any(), testUserID).Return(user, nil)
+				s.eventPublisher.EXPECT().PublishRefreshTokenReused(gomock.Any(), user, testFamilyID.String(), "", "").Return(nil)
+				s.cache.EXPECT().Set(gomock.Any(), rediskeys.TokensValidAfterPrefix+testUserID.String(), gomock.Any(), 15*time.Minute).Return(nil)
+			},
+			expectedError: apperr.ErrTokenInvalid,
+			checkFunc:     nil,
+			checkAudit: func(t *testing.T, entries []*models.AuditLogEntry) {
+				require.Len(t, entries, 1)
+				assert.False(t, entries[0].Success)
+				assert.Equal(t, &testUserID, entries[0].UserID)
+				assert.Equal(t, audit.ReasonRefreshTokenReused, *entries[0].Reason)
+			},
+		},
+		{
+			name:         "Rotated Token Reused After Family Revoked",
+			refreshToken: "rotated-token",
+			setupMock: func(s *AuthServiceTestSuite) {
+				claims := &jwtprovider.RefreshTokenClaims{}
+				claims.Subject = testUserID.String()
+				rotatedAt := time.Now()
+
+				s.jwtService.EXPECT().VerifyRefreshToken("rotated-token").Return(claims, nil)
+				s.refreshTokenRepo.EXPECT().GetByToken(gomock.Any(), gomock.Any()).Return(&models.RefreshToken{
+					ID:        testTokenID,
+					UserID:    testUserID,
+					FamilyID:  testFamilyID,
+					ExpiresAt: time.Now().Add(24 * time.Hour),
+					RotatedAt: &rotatedAt,
+					RevokedAt: &rotatedAt,
+				}, nil)
+				s.refreshTokenRepo.EXPECT().
+					WithinTransaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+						return fn(ctx)
+					})
+				s.refreshTokenRepo.EXPECT().RevokeFamily(gomock.Any(), testFamilyID).Return(int64(0), nil)
+			},
+			expectedError: apperr.ErrTokenInvalid,
+			checkFunc:     nil,
+		},
+		{
+			name:         "Token Revoked",
+			refreshToken: "revoked-token",
+			setupMock: func(s *AuthServiceTestSuite) {
+				claims := &jwtprovider.RefreshTokenClaims{}
+				claims.Subject = testUserID.String()
+				revokedAt := time.Now()
+
+				s.jwtService.EXPECT().VerifyRefreshToken("revoked-token").Return(claims, nil)
+				s.refreshTokenRepo.EXPECT().GetByToken(gomock.Any(), gomock.Any()).Return(&models.RefreshToken{
+					ID:        testTokenID,
+					UserID:    testUserID,
+					ExpiresAt: time.Now().Add(24 * time.Hour),
+					RevokedAt: &revokedAt,
+				}, nil)
+			},
+			expectedError: apperr.ErrTokenInvalid,
+			checkFunc:     nil,
+		},
 		{
 			name:         "User Not Found",
 			refreshToken: "valid-refresh-token",
@@ -521,12 +849,16 @@ func TestAuthService_RefreshToken(t *testing.T) {
 			if tt.checkFunc != nil {
 				tt.checkFunc(t, accessToken, refreshToken, err)
 			}
+			if tt.checkAudit != nil {
+				tt.checkAudit(t, suite.auditEntries)
+			}
 		})
 	}
 }
 
 func TestAuthService_ChangePassword(t *testing.T) {
 	testUserID := uuid.New()
+	weakPasswordErr := apperr.NewErrValidationFailedWithDetail("new_password", apperr.CodePasswordTooShort, "too short")
 
 	tests := []struct {
 		name          string
@@ -551,8 +883,16 @@ func TestAuthService_ChangePassword(t *testing.T) {
 				}
 				s.userRepo.EXPECT().GetByID(gomock.Any(), testUserID).Return(user, nil)
 				s.passwordHasher.EXPECT().Compare("hashedoldpassword", "oldpassword").Return(true)
+				s.passwordPolicy.EXPECT().Validate("new_password", "newpassword", "test@gmail.com", "").Return(nil)
 				s.passwordHasher.EXPECT().Hash("newpassword").Return("hashednewpassword", nil)
+				s.userRepo.EXPECT().
+					WithinTransaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+						return fn(ctx)
+					})
 				s.userRepo.EXPECT().UpdatePassword(gomock.Any(), testUserID, "hashednewpassword").Return(int64(1), nil)
+				s.refreshTokenRepo.EXPECT().RevokeAllByUserID(gomock.Any(), testUserID).Return(int64(2), nil)
+				s.cache.EXPECT().Set(gomock.Any(), rediskeys.TokensValidAfterPrefix+testUserID.String(), gomock.Any(), 15*time.Minute).Return(nil)
 			},
 			expectedError: nil,
 		},
@@ -587,6 +927,26 @@ func TestAuthService_ChangePassword(t *testing.T) {
 			},
 			expectedError: apperr.ErrInvalidCurrentPassword,
 		},
+		{
+			name:   "New Password Rejected By Policy",
+			userID: testUserID.String(),
+			req: &request.ChangePasswordRequest{
+				CurrentPassword: "oldpassword",
+				NewPassword:     "short",
+			},
+			setupMock: func(s *AuthServiceTestSuite) {
+				user := &models.User{
+					ID:             testUserID,
+					Email:          "test@gmail.com",
+					HashedPassword: "hashedoldpassword",
+					Status:         models.UserStatusActive,
+				}
+				s.userRepo.EXPECT().GetByID(gomock.Any(), testUserID).Return(user, nil)
+				s.passwordHasher.EXPECT().Compare("hashedoldpassword", "oldpassword").Return(true)
+				s.passwordPolicy.EXPECT().Validate("new_password", "short", "test@gmail.com", "").Return(weakPasswordErr)
+			},
+			expectedError: weakPasswordErr,
+		},
 	}
 
 	for _, tt := range tests {
@@ -624,6 +984,7 @@ func TestAuthService_ForgotPassword(t *testing.T) {
 				}
 				s.userRepo.EXPECT().GetByEmail(gomock.Any(), "test@gmail.com").Return(user, nil)
 				s.cache.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				s.eventPublisher.EXPECT().PublishForgotPassword(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 			},
 			expectedError: nil,
 		},
@@ -675,8 +1036,17 @@ func TestAuthService_ResetPassword(t *testing.T) {
 					Status: models.UserStatusActive,
 				}
 				s.userRepo.EXPECT().GetByEmail(gomock.Any(), "test@gmail.com").Return(user, nil)
+				s.passwordPolicy.EXPECT().Validate("new_password", "newpassword123", "test@gmail.com", "").Return(nil)
 				s.passwordHasher.EXPECT().Hash("newpassword123").Return("hashednewpassword", nil)
+				s.userRepo.EXPECT().
+					WithinTransaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+						return fn(ctx)
+					})
 				s.userRepo.EXPECT().UpdatePassword(gomock.Any(), testUserID, "hashednewpassword").Return(int64(1), nil)
+				s.refreshTokenRepo.EXPECT().RevokeAllByUserID(gomock.Any(), testUserID).Return(int64(1), nil)
+				s.eventPublisher.EXPECT().PublishPasswordResetSuccess(gomock.Any(), "test@gmail.com").Return(nil)
+				s.cache.EXPECT().Set(gomock.Any(), rediskeys.TokensValidAfterPrefix+testUserID.String(), gomock.Any(), 15*time.Minute).Return(nil)
 			},
 			expectedError: nil,
 		},
@@ -716,3 +1086,1111 @@ func TestAuthService_ResetPassword(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthService_UnlockAccount(t *testing.T) {
+	tests := []struct {
+		name          string
+		token         string
+		setupMock     func(suite *AuthServiceTestSuite)
+		expectedError error
+	}{
+		{
+			name:  "Unlock Account Success",
+			token: "valid-unlock-token",
+			setupMock: func(s *AuthServiceTestSuite) {
+				s.cache.EXPECT().Get(gomock.Any(), gomock.Any()).Return("test@gmail.com", nil)
+				s.cache.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
+				s.cache.EXPECT().Delete(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name:  "Token Invalid Or Expired",
+			token: "invalid-token",
+			setupMock: func(s *AuthServiceTestSuite) {
+				s.cache.EXPECT().Get(gomock.Any(), gomock.Any()).Return("", errors.New("not found"))
+			},
+			expectedError: apperr.ErrTokenInvalidOrExpired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suite := setupAuthServiceTestSuite(t)
+			defer suite.ctrl.Finish()
+
+			ctx := context.WithValue(context.Background(), contextkeys.LoggerKey, zap.NewNop())
+			tt.setupMock(suite)
+
+			err := suite.authService.UnlockAccount(ctx, tt.token)
+
+			assert.True(t, errors.Is(err, tt.expectedError))
+		})
+	}
+}
+
+func TestAuthService_Logout(t *testing.T) {
+	testUserID := uuid.New()
+	testTokenID := uuid.New()
+	testFamilyID := uuid.New()
+
+	tests := []struct {
+		name          string
+		refreshToken  string
+		setupMock     func(suite *AuthServiceTestSuite)
+		expectedError error
+	}{
+		{
+			name:         "Logout Success",
+			refreshToken: "valid-refresh-token",
+			setupMock: func(s *AuthServiceTestSuite) {
+				s.refreshTokenRepo.EXPECT().GetByToken(gomock.Any(), utils.HashToken("valid-refresh-token")).Return(&models.RefreshToken{
+					ID:       testTokenID,
+					UserID:   testUserID,
+					FamilyID: testFamilyID,
+				}, nil)
+				s.refreshTokenRepo.EXPECT().RevokeFamily(gomock.Any(), testFamilyID).Return(int64(2), nil)
+				s.refreshTokenRepo.EXPECT().ListByFamilyID(gomock.Any(), testFamilyID, gomock.Any()).Return([]*models.RefreshToken{
+					{AccessTokenID: "live-jti", CreatedAt: time.Now().Add(-time.Minute)},
+					{AccessTokenID: "expired-jti", CreatedAt: time.Now().Add(-time.Hour)},
+				}, nil)
+				s.cache.EXPECT().Set(gomock.Any(), rediskeys.RevokedAccessTokenPrefix+"live-jti", "1", gomock.Any()).Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name:         "Already Logged Out",
+			refreshToken: "revoked-refresh-token",
+			setupMock: func(s *AuthServiceTestSuite) {
+				revokedAt := time.Now()
+				s.refreshTokenRepo.EXPECT().GetByToken(gomock.Any(), gomock.Any()).Return(&models.RefreshToken{
+					ID:        testTokenID,
+					UserID:    testUserID,
+					RevokedAt: &revokedAt,
+				}, nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name:         "Token Not Found",
+			refreshToken: "unknown-token",
+			setupMock: func(s *AuthServiceTestSuite) {
+				s.refreshTokenRepo.EXPECT().GetByToken(gomock.Any(), gomock.Any()).Return(nil, nil)
+			},
+			expectedError: apperr.ErrTokenInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suite := setupAuthServiceTestSuite(t)
+			defer suite.ctrl.Finish()
+
+			ctx := context.WithValue(context.Background(), contextkeys.LoggerKey, zap.NewNop())
+			tt.setupMock(suite)
+
+			err := suite.authService.Logout(ctx, tt.refreshToken)
+
+			assert.True(t, errors.Is(err, tt.expectedError))
+		})
+	}
+}
+
+func TestAuthService_LogoutAll(t *testing.T) {
+	suite := setupAuthServiceTestSuite(t)
+	defer suite.ctrl.Finish()
+
+	testUserID := uuid.New()
+	ctx := context.WithValue(context.Background(), contextkeys.LoggerKey, zap.NewNop())
+
+	suite.refreshTokenRepo.EXPECT().RevokeAllByUserID(gomock.Any(), testUserID).Return(int64(3), nil)
+	suite.cache.EXPECT().Set(gomock.Any(), rediskeys.TokensValidAfterPrefix+testUserID.String(), gomock.Any(), 15*time.Minute).Return(nil)
+
+	err := suite.authService.LogoutAll(ctx, testUserID.String())
+
+	assert.NoError(t, err)
+}
+
+func TestAuthService_ListSessions(t *testing.T) {
+	suite := setupAuthServiceTestSuite(t)
+	defer suite.ctrl.Finish()
+
+	testUserID := uuid.New()
+	ctx := context.WithValue(context.Background(), contextkeys.LoggerKey, zap.NewNop())
+
+	sessions := []*models.Session{
+		{ID: uuid.New(), DeviceInfo: "Chrome on Windows"},
+		{ID: uuid.New(), DeviceInfo: "Safari on iOS"},
+	}
+	suite.refreshTokenRepo.EXPECT().ListSessionsByUserID(gomock.Any(), testUserID).Return(sessions, nil)
+
+	result, err := suite.authService.ListSessions(ctx, testUserID.String())
+
+	assert.NoError(t, err)
+	assert.Equal(t, sessions, result)
+}
+
+func TestAuthService_RevokeSession(t *testing.T) {
+	testUserID := uuid.New()
+	testSessionID := uuid.New()
+
+	tests := []struct {
+		name          string
+		sessionID     string
+		setupMock     func(suite *AuthServiceTestSuite)
+		expectedError error
+	}{
+		{
+			name:      "Revoke Session Success",
+			sessionID: testSessionID.String(),
+			setupMock: func(s *AuthServiceTestSuite) {
+				s.refreshTokenRepo.EXPECT().RevokeFamilyByUserID(gomock.Any(), testSessionID, testUserID).Return(int64(1), nil)
+				s.refreshTokenRepo.EXPECT().ListByFamilyID(gomock.Any(), testSessionID, gomock.Any()).Return([]*models.RefreshToken{
+					{AccessTokenID: "session-jti", CreatedAt: time.Now()},
+				}, nil)
+				s.cache.EXPECT().Set(gomock.Any(), rediskeys.RevokedAccessTokenPrefix+"session-jti", "1", gomock.Any()).Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name:      "Session Not Found",
+			sessionID: testSessionID.String(),
+			setupMock: func(s *AuthServiceTestSuite) {
+				s.refreshTokenRepo.EXPECT().RevokeFamilyByUserID(gomock.Any(), testSessionID, testUserID).Return(int64(0), nil)
+			},
+			expectedError: apperr.ErrSessionNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suite := setupAuthServiceTestSuite(t)
+			defer suite.ctrl.Finish()
+
+			ctx := context.WithValue(context.Background(), contextkeys.LoggerKey, zap.NewNop())
+			tt.setupMock(suite)
+
+			err := suite.authService.RevokeSession(ctx, testUserID.String(), tt.sessionID)
+
+			assert.True(t, errors.Is(err, tt.expectedError))
+		})
+	}
+}
+
+func TestAuthService_VerifyMFA(t *testing.T) {
+	testUserID := uuid.New()
+	enabledAt := time.Now()
+
+	newUser := func() *models.User {
+		return &models.User{
+			ID:            testUserID,
+			Email:         "mfa@gmail.com",
+			Status:        models.UserStatusActive,
+			TOTPSecret:    &testTOTPSecret,
+			TOTPEnabledAt: &enabledAt,
+		}
+	}
+	expectChallenge := func(s *AuthServiceTestSuite, user *models.User) {
+		s.cache.EXPECT().Get(gomock.Any(), gomock.Any()).Return(testUserID.String(), nil)
+		s.userRepo.EXPECT().GetByID(gomock.Any(), testUserID).Return(user, nil)
+		s.cache.EXPECT().TTL(gomock.Any(), gomock.Any()).Return(time.Duration(-2), nil).Times(2)
+	}
+	expectTokens := func(s *AuthServiceTestSuite) {
+		s.cache.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+		s.jwtService.EXPECT().GenerateAccessToken(gomock.Any()).Return("access-token", "access-token-id", nil)
+		s.jwtService.EXPECT().GenerateRefreshToken(testUserID.String()).Return("refresh-token", nil)
+		s.jwtService.EXPECT().GetRefreshTTL().Return(7 * 24 * time.Hour)
+		s.refreshTokenRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	}
+
+	tests := []struct {
+		name          string
+		code          string
+		setupMock     func(suite *AuthServiceTestSuite)
+		expectedError error
+	}{
+		{
+			name: "TOTP Code Success",
+			code: "123456",
+			setupMock: func(s *AuthServiceTestSuite) {
+				expectChallenge(s, newUser())
+				s.totpProvider.EXPECT().Validate(testTOTPSecret, "123456", gomock.Any()).Return(true)
+				s.cache.EXPECT().SetNX(gomock.Any(), gomock.Any(), gomock.Any(), caching.TOTPUsedCodeTTL).Return(true, nil)
+				expectTokens(s)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "Recovery Code Success",
+			code: "ABCDE-12345",
+			setupMock: func(s *AuthServiceTestSuite) {
+				expectChallenge(s, newUser())
+				s.totpProvider.EXPECT().Validate(testTOTPSecret, "ABCDE-12345", gomock.Any()).Return(false)
+				s.userRepo.EXPECT().UseRecoveryCode(gomock.Any(), testUserID, utils.HashToken("abcde12345")).Return(int64(1), nil)
+				expectTokens(s)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "Invalid Code",
+			code: "000000",
+			setupMock: func(s *AuthServiceTestSuite) {
+				expectChallenge(s, newUser())
+				s.totpProvider.EXPECT().Validate(testTOTPSecret, "000000", gomock.Any()).Return(false)
+				s.userRepo.EXPECT().UseRecoveryCode(gomock.Any(), testUserID, gomock.Any()).Return(int64(0), nil)
+				s.cache.EXPECT().Incr(gomock.Any(), gomock.Any()).Return(int64(1), nil)
+				s.cache.EXPECT().Expire(gomock.Any(), gomock.Any(), 15*time.Minute).Return(nil)
+			},
+			expectedError: apperr.ErrInvalidMFACode,
+		},
+		{
+			name: "Replayed TOTP Code",
+			code: "123456",
+			setupMock: func(s *AuthServiceTestSuite) {
+				expectChallenge(s, newUser())
+				s.totpProvider.EXPECT().Validate(testTOTPSecret, "123456", gomock.Any()).Return(true)
+				s.cache.EXPECT().SetNX(gomock.Any(), gomock.Any(), gomock.Any(), caching.TOTPUsedCodeTTL).Return(false, nil)
+				s.cache.EXPECT().Incr(gomock.Any(), gomock.Any()).Return(int64(1), nil)
+				s.cache.EXPECT().Expire(gomock.Any(), gomock.Any(), 15*time.Minute).Return(nil)
+			},
+			expectedError: apperr.ErrInvalidMFACode,
+		},
+		{
+			name: "Challenge Invalid Or Expired",
+			code: "123456",
+			setupMock: func(s *AuthServiceTestSuite) {
+				s.cache.EXPECT().Get(gomock.Any(), gomock.Any()).Return("", errors.New("not found"))
+			},
+			expectedError: apperr.ErrTokenInvalidOrExpired,
+		},
+		{
+			name: "Account Locked",
+			code: "123456",
+			setupMock: func(s *AuthServiceTestSuite) {
+				s.cache.EXPECT().Get(gomock.Any(), gomock.Any()).Return(testUserID.String(), nil)
+				s.userRepo.EXPECT().GetByID(gomock.Any(), testUserID).Return(newUser(), nil)
+				s.cache.EXPECT().TTL(gomock.Any(), gomock.Any()).Return(10*time.Minute, nil)
+			},
+			expectedError: apperr.ErrAccountLocked,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suite := setupAuthServiceTestSuite(t)
+			defer suite.ctrl.Finish()
+
+			ctx := context.WithValue(context.Background(), contextkeys.LoggerKey, zap.NewNop())
+			tt.setupMock(suite)
+
+			accessToken, refreshToken, err := suite.authService.VerifyMFA(ctx, "mfa-token", tt.code)
+
+			assert.True(t, errors.Is(err, tt.expectedError))
+			if tt.expectedError == nil {
+				assert.Equal(t, "access-token", accessToken)
+				assert.Equal(t, "refresh-token", refreshToken)
+			}
+		})
+	}
+}
+
+func TestAuthService_EnrollTOTP(t *testing.T) {
+	testUserID := uuid.New()
+	enabledAt := time.Now()
+
+	tests := []struct {
+		name          string
+		setupMock     func(suite *AuthServiceTestSuite)
+		expectedError error
+	}{
+		{
+			name: "Enroll Success",
+			setupMock: func(s *AuthServiceTestSuite) {
+				s.userRepo.EXPECT().GetByID(gomock.Any(), testUserID).Return(&models.User{ID: testUserID, Email: "mfa@gmail.com"}, nil)
+				s.totpProvider.EXPECT().GenerateSecret().Return(testTOTPSecret, nil)
+				s.userRepo.EXPECT().SetTOTPSecret(gomock.Any(), testUserID, testTOTPSecret).Return(int64(1), nil)
+				s.totpProvider.EXPECT().URI(testTOTPSecret, "mfa@gmail.com").Return("otpauth://totp/test")
+			},
+			expectedError: nil,
+		},
+		{
+			name: "Already Enabled",
+			setupMock: func(s *AuthServiceTestSuite) {
+				s.userRepo.EXPECT().GetByID(gomock.Any(), testUserID).Return(&models.User{ID: testUserID, TOTPEnabledAt: &enabledAt}, nil)
+			},
+			expectedError: apperr.ErrMFAAlreadyEnabled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suite := setupAuthServiceTestSuite(t)
+			defer suite.ctrl.Finish()
+
+			ctx := context.WithValue(context.Background(), contextkeys.LoggerKey, zap.NewNop())
+			tt.setupMock(suite)
+
+			secret, uri, err := suite.authService.EnrollTOTP(ctx, testUserID.String())
+
+			assert.True(t, errors.Is(err, tt.expectedError))
+			if tt.expectedError == nil {
+				assert.Equal(t, testTOTPSecret, secret)
+				assert.Equal(t, "otpauth://totp/test", uri)
+			}
+		})
+	}
+}
+
+func TestAuthService_ConfirmTOTP(t *testing.T) {
+	testUserID := uuid.New()
+
+	tests := []struct {
+		name          string
+		setupMock     func(suite *AuthServiceTestSuite)
+		expectedError error
+		checkAudit    func(t *testing.T, entries []*models.AuditLogEntry)
+	}{
+		{
+			name: "Confirm Success",
+			setupMock: func(s *AuthServiceTestSuite) {
+				s.userRepo.EXPECT().GetByID(gomock.Any(), testUserID).Return(&models.User{ID: testUserID, TOTPSecret: &testTOTPSecret}, nil)
+				s.totpProvider.EXPECT().Validate(testTOTPSecret, "123456", gomock.Any()).Return(true)
+				s.cache.EXPECT().SetNX(gomock.Any(), gomock.Any(), gomock.Any(), caching.TOTPUsedCodeTTL).Return(true, nil)
+				s.userRepo.EXPECT().
+					WithinTransaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+						return fn(ctx)
+					})
+				s.userRepo.EXPECT().EnableTOTP(gomock.Any(), testUserID).Return(int64(1), nil)
+				s.userRepo.EXPECT().ReplaceRecoveryCodes(gomock.Any(), testUserID, gomock.Len(10)).Return(nil)
+			},
+			expectedError: nil,
+			checkAudit: func(t *testing.T, entries []*models.AuditLogEntry) {
+				require.Len(t, entries, 1)
+				assert.Equal(t, models.AuditEventTOTPEnabled, entries[0].EventType)
+				assert.Equal(t, testUserID, *entries[0].UserID)
+				assert.True(t, entries[0].Success)
+			},
+		},
+		{
+			name: "Enrollment Not Started",
+			setupMock: func(s *AuthServiceTestSuite) {
+				s.userRepo.EXPECT().GetByID(gomock.Any(), testUserID).Return(&models.User{ID: testUserID}, nil)
+			},
+			expectedError: apperr.ErrMFAEnrollmentNotStarted,
+		},
+		{
+			name: "Invalid Code",
+			setupMock: func(s *AuthServiceTestSuite) {
+				s.userRepo.EXPECT().GetByID(gomock.Any(), testUserID).Return(&models.User{ID: testUserID, TOTPSecret: &testTOTPSecret}, nil)
+				s.totpProvider.EXPECT().Validate(testTOTPSecret, "123456", gomock.Any()).Return(false)
+			},
+			expectedError: apperr.ErrInvalidMFACode,
+			checkAudit: func(t *testing.T, entries []*models.AuditLogEntry) {
+				assert.Empty(t, entries)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suite := setupAuthServiceTestSuite(t)
+			defer suite.ctrl.Finish()
+
+			ctx := context.WithValue(context.Background(), contextkeys.LoggerKey, zap.NewNop())
+			tt.setupMock(suite)
+
+			recoveryCodes, err := suite.authService.ConfirmTOTP(ctx, testUserID.String(), "123456")
+
+			assert.True(t, errors.Is(err, tt.expectedError))
+			if tt.expectedError == nil {
+				assert.Len(t, recoveryCodes, 10)
+			}
+			if tt.checkAudit != nil {
+				tt.checkAudit(t, suite.auditEntries)
+			}
+		})
+	}
+}
+
+func TestAuthService_DisableTOTP(t *testing.T) {
+	testUserID := uuid.New()
+	enabledAt := time.Now()
+
+	newUser := func() *models.User {
+		return &models.User{
+			ID:             testUserID,
+			HashedPassword: "hashedpassword",
+			TOTPSecret:     &testTOTPSecret,
+			TOTPEnabledAt:  &enabledAt,
+		}
+	}
+
+	tests := []struct {
+		name          string
+		setupMock     func(suite *AuthServiceTestSuite)
+		expectedError error
+		checkAudit    func(t *testing.T, entries []*models.AuditLogEntry)
+	}{
+		{
+			name: "Disable Success",
+			setupMock: func(s *AuthServiceTestSuite) {
+				s.userRepo.EXPECT().GetByID(gomock.Any(), testUserID).Return(newUser(), nil)
+				s.passwordHasher.EXPECT().Compare("hashedpassword", "password123").Return(true)
+				s.totpProvider.EXPECT().Validate(testTOTPSecret, "123456", gomock.Any()).Return(true)
+				s.cache.EXPECT().SetNX(gomock.Any(), gomock.Any(), gomock.Any(), caching.TOTPUsedCodeTTL).Return(true, nil)
+				s.userRepo.EXPECT().DisableTOTP(gomock.Any(), testUserID).Return(int64(1), nil)
+			},
+			expectedError: nil,
+			checkAudit: func(t *testing.T, entries []*models.AuditLogEntry) {
+				require.Len(t, entries, 1)
+				assert.Equal(t, models.AuditEventTOTPDisabled, entries[0].EventType)
+				assert.Equal(t, testUserID, *entries[0].UserID)
+				assert.True(t, entries[0].Success)
+			},
+		},
+		{
+			name: "Not Enabled",
+			setupMock: func(s *AuthServiceTestSuite) {
+				s.userRepo.EXPECT().GetByID(gomock.Any(), testUserID).Return(&models.User{ID: testUserID}, nil)
+			},
+			expectedError: apperr.ErrMFANotEnabled,
+		},
+		{
+			name: "Wrong Password",
+			setupMock: func(s *AuthServiceTestSuite) {
+				s.userRepo.EXPECT().GetByID(gomock.Any(), testUserID).Return(newUser(), nil)
+				s.passwordHasher.EXPECT().Compare("hashedpassword", "password123").Return(false)
+			},
+			expectedError: apperr.ErrInvalidCurrentPassword,
+			checkAudit: func(t *testing.T, entries []*models.AuditLogEntry) {
+				require.Len(t, entries, 1)
+				assert.Equal(t, models.AuditEventTOTPDisabled, entries[0].EventType)
+				assert.Equal(t, testUserID, *entries[0].UserID)
+				assert.False(t, entries[0].Success)
+				require.NotNil(t, entries[0].Reason)
+				assert.Equal(t, apperr.ErrInvalidCurrentPassword.Code, *entries[0].Reason)
+			},
+		},
+		{
+			name: "Wrong Code",
+			setupMock: func(s *AuthServiceTestSuite) {
+				s.userRepo.EXPECT().GetByID(gomock.Any(), testUserID).Return(newUser(), nil)
+				s.passwordHasher.EXPECT().Compare("hashedpassword", "password123").Return(true)
+				s.totpProvider.EXPECT().Validate(testTOTPSecret, "123456", gomock.Any()).Return(false)
+				s.userRepo.EXPECT().UseRecoveryCode(gomock.Any(), testUserID, gomock.Any()).Return(int64(0), nil)
+			},
+			expectedError: apperr.ErrInvalidMFACode,
+			checkAudit: func(t *testing.T, entries []*models.AuditLogEntry) {
+				require.Len(t, entries, 1)
+				assert.Equal(t, models.AuditEventTOTPDisabled, entries[0].EventType)
+				assert.False(t, entries[0].Success)
+				require.NotNil(t, entries[0].Reason)
+				assert.Equal(t, apperr.ErrInvalidMFACode.Code, *entries[0].Reason)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suite := setupAuthServiceTestSuite(t)
+			defer suite.ctrl.Finish()
+
+			ctx := context.WithValue(context.Background(), contextkeys.LoggerKey, zap.NewNop())
+			tt.setupMock(suite)
+
+			err := suite.authService.DisableTOTP(ctx, testUserID.String(), "password123", "123456")
+
+			assert.True(t, errors.Is(err, tt.expectedError))
+			if tt.checkAudit != nil {
+				tt.checkAudit(t, suite.auditEntries)
+			}
+		})
+	}
+}
+
+func TestAuthService_RequestEmailChange(t *testing.T) {
+	testUserID := uuid.New()
+
+	newUser := func() *models.User {
+		return &models.User{
+			ID:             testUserID,
+			Email:          "old@gmail.com",
+			FullName:       "Test User",
+			HashedPassword: "hashedpassword",
+			Status:         models.UserStatusActive,
+		}
+	}
+
+	tests := []struct {
+		name          string
+		newEmail      string
+		setupMock     func(suite *AuthServiceTestSuite)
+		expectedError error
+		expectedCode  string
+	}{
+		{
+			name:     "Request Success",
+			newEmail: "new@gmail.com",
+			setupMock: func(s *AuthServiceTestSuite) {
+				s.userRepo.EXPECT().GetByID(gomock.Any(), testUserID).Return(newUser(), nil)
+				s.passwordHasher.EXPECT().Compare("hashedpassword", "password123").Return(true)
+				s.userRepo.EXPECT().GetByEmail(gomock.Any(), "new@gmail.com").Return(nil, nil)
+				s.cache.EXPECT().
+					Set(gomock.Any(), gomock.Any(), gomock.Any(), caching.EmailChangeTTL).
+					DoAndReturn(func(ctx context.Context, key string, value any, ttl time.Duration) error {
+						assert.Contains(t, key, caching.EmailChangePrefix)
+						assert.JSONEq(t, `{"user_id":"`+testUserID.String()+`","old_email":"old@gmail.com","new_email":"new@gmail.com"}`, value.(string))
+						return nil
+					})
+				s.eventPublisher.EXPECT().PublishEmailChangeRequested(gomock.Any(), gomock.Any(), "new@gmail.com", gomock.Any()).Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name:     "Same Email",
+			newEmail: "OLD@gmail.com",
+			setupMock: func(s *AuthServiceTestSuite) {
+				s.userRepo.EXPECT().GetByID(gomock.Any(), testUserID).Return(newUser(), nil)
+			},
+			expectedCode: apperr.CodeValidationFailed,
+		},
+		{
+			name:     "Wrong Password",
+			newEmail: "new@gmail.com",
+			setupMock: func(s *AuthServiceTestSuite) {
+				s.userRepo.EXPECT().GetByID(gomock.Any(), testUserID).Return(newUser(), nil)
+				s.passwordHasher.EXPECT().Compare("hashedpassword", "password123").Return(false)
+			},
+			expectedError: apperr.ErrInvalidCurrentPassword,
+		},
+		{
+			name:     "Email Already Exists",
+			newEmail: "taken@gmail.com",
+			setupMock: func(s *AuthServiceTestSuite) {
+				s.userRepo.EXPECT().GetByID(gomock.Any(), testUserID).Return(newUser(), nil)
+				s.passwordHasher.EXPECT().Compare("hashedpassword", "password123").Return(true)
+				s.userRepo.EXPECT().GetByEmail(gomock.Any(), "taken@gmail.com").Return(&models.User{ID: uuid.New()}, nil)
+			},
+			expectedError: apperr.ErrEmailAlreadyExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suite := setupAuthServiceTestSuite(t)
+			defer suite.ctrl.Finish()
+
+			ctx := context.WithValue(context.Background(), contextkeys.LoggerKey, zap.NewNop())
+			tt.setupMock(suite)
+
+			err := suite.authService.RequestEmailChange(ctx, testUserID.String(), tt.newEmail, "password123")
+
+			if tt.expectedCode != "" {
+				var appErr *apperr.AppError
+				assert.True(t, errors.As(err, &appErr))
+				assert.Equal(t, tt.expectedCode, appErr.Code)
+				return
+			}
+			assert.True(t, errors.Is(err, tt.expectedError))
+		})
+	}
+}
+
+func TestAuthService_ConfirmEmailChange(t *testing.T) {
+	testUserID := uuid.New()
+	change := `{"user_id":"` + testUserID.String() + `","old_email":"old@gmail.com","new_email":"new@gmail.com"}`
+
+	withinTransaction := func(s *AuthServiceTestSuite) {
+		s.userRepo.EXPECT().
+			WithinTransaction(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+	}
+
+	tests := []struct {
+		name          string
+		setupMock     func(suite *AuthServiceTestSuite)
+		expectedError error
+		checkAudit    func(t *testing.T, entries []*models.AuditLogEntry)
+	}{
+		{
+			name: "Confirm Success",
+			setupMock: func(s *AuthServiceTestSuite) {
+				s.cache.EXPECT().Get(gomock.Any(), gomock.Any()).Return(change, nil)
+				s.cache.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
+				s.userRepo.EXPECT().GetByID(gomock.Any(), testUserID).Return(&models.User{ID: testUserID, Email: "old@gmail.com"}, nil)
+				withinTransaction(s)
+				s.userRepo.EXPECT().UpdateEmail(gomock.Any(), testUserID, "old@gmail.com", "new@gmail.com").Return(int64(1), nil)
+				s.refreshTokenRepo.EXPECT().RevokeAllByUserID(gomock.Any(), testUserID).Return(int64(2), nil)
+				s.eventPublisher.EXPECT().
+					PublishEmailChanged(gomock.Any(), gomock.Any(), "old@gmail.com").
+					DoAndReturn(func(ctx context.Context, user *models.User, oldEmail string) error {
+						assert.Equal(t, "new@gmail.com", user.Email)
+						return nil
+					})
+				s.cache.EXPECT().Set(gomock.Any(), rediskeys.TokensValidAfterPrefix+testUserID.String(), gomock.Any(), 15*time.Minute).Return(nil)
+			},
+			expectedError: nil,
+			checkAudit: func(t *testing.T, entries []*models.AuditLogEntry) {
+				require.Len(t, entries, 1)
+				assert.Equal(t, models.AuditEventEmailChange, entries[0].EventType)
+				assert.Equal(t, testUserID, *entries[0].UserID)
+				assert.True(t, entries[0].Success)
+			},
+		},
+		{
+			name: "Token Invalid Or Expired",
+			setupMock: func(s *AuthServiceTestSuite) {
+				s.cache.EXPECT().Get(gomock.Any(), gomock.Any()).Return("", errors.New("not found"))
+			},
+			expectedError: apperr.ErrTokenInvalidOrExpired,
+			checkAudit: func(t *testing.T, entries []*models.AuditLogEntry) {
+				assert.Empty(t, entries)
+			},
+		},
+		{
+			name: "Email Taken Meanwhile",
+			setupMock: func(s *AuthServiceTestSuite) {
+				s.cache.EXPECT().Get(gomock.Any(), gomock.Any()).Return(change, nil)
+				s.cache.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
+				s.userRepo.EXPECT().GetByID(gomock.Any(), testUserID).Return(&models.User{ID: testUserID, Email: "old@gmail.com"}, nil)
+				withinTransaction(s)
+				s.userRepo.EXPECT().UpdateEmail(gomock.Any(), testUserID, "old@gmail.com", "new@gmail.com").Return(int64(0), repository.ErrEmailTaken)
+			},
+			expectedError: apperr.ErrEmailAlreadyExists,
+		},
+		{
+			name: "Email Changed Since Request",
+			setupMock: func(s *AuthServiceTestSuite) {
+				s.cache.EXPECT().Get(gomock.Any(), gomock.Any()).Return(change, nil)
+				s.cache.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
+				s.userRepo.EXPECT().GetByID(gomock.Any(), testUserID).Return(&models.User{ID: testUserID, Email: "other@gmail.com"}, nil)
+				withinTransaction(s)
+				s.userRepo.EXPECT().UpdateEmail(gomock.Any(), testUserID, "old@gmail.com", "new@gmail.com").Return(int64(0), nil)
+			},
+			expectedError: apperr.ErrTokenInvalidOrExpired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suite := setupAuthServiceTestSuite(t)
+			defer suite.ctrl.Finish()
+
+			ctx := context.WithValue(context.Background(), contextkeys.LoggerKey, zap.NewNop())
+			tt.setupMock(suite)
+
+			err := suite.authService.ConfirmEmailChange(ctx, "email-change-token")
+
+			assert.True(t, errors.Is(err, tt.expectedError))
+			if tt.checkAudit != nil {
+				tt.checkAudit(t, suite.auditEntries)
+			}
+		})
+	}
+}
+
+func TestAuthService_StartOAuthLogin(t *testing.T) {
+	t.Run("Start Success", func(t *testing.T) {
+		suite := setupAuthServiceTestSuite(t)
+		defer suite.ctrl.Finish()
+
+		ctx := context.WithValue(context.Background(), contextkeys.LoggerKey, zap.NewNop())
+		var stored caching.OAuthState
+		suite.cache.EXPECT().
+			Set(gomock.Any(), gomock.Any(), gomock.Any(), caching.OAuthStateTTL).
+			DoAndReturn(func(ctx context.Context, key string, value any, ttl time.Duration) error {
+				assert.Contains(t, key, caching.OAuthStatePrefix)
+				assert.NoError(t, json.Unmarshal([]byte(value.(string)), &stored))
+				return nil
+			})
+		suite.oauthProvider.EXPECT().
+			AuthCodeURL(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(state, nonce, verifier string) string {
+				assert.NotEmpty(t, state)
+				assert.Equal(t, stored.Nonce, nonce)
+				assert.Equal(t, stored.CodeVerifier, verifier)
+				return "https://idp.example.com/authorize?state=" + state
+			})
+
+		url, err := suite.authService.StartOAuthLogin(ctx, "fake")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "fake", stored.Provider)
+		assert.Contains(t, url, "https://idp.example.com/authorize")
+	})
+
+	t.Run("Unknown Provider", func(t *testing.T) {
+		suite := setupAuthServiceTestSuite(t)
+		defer suite.ctrl.Finish()
+
+		ctx := context.WithValue(context.Background(), contextkeys.LoggerKey, zap.NewNop())
+
+		_, err := suite.authService.StartOAuthLogin(ctx, "unknown")
+
+		assert.True(t, errors.Is(err, apperr.ErrOAuthProviderNotFound))
+	})
+}
+
+func TestAuthService_CompleteOAuthLogin(t *testing.T) {
+	testUserID := uuid.New()
+	identityID := uuid.New()
+	verifiedAt := time.Now()
+	state := `{"provider":"fake","nonce":"nonce","code_verifier":"verifier"}`
+
+	verifiedIdentity := &oauth.Identity{Subject: "sub-1", Email: "oauth@gmail.com", EmailVerified: true, Name: "OAuth User"}
+
+	expectState := func(s *AuthServiceTestSuite, value string) {
+		s.cache.EXPECT().Get(gomock.Any(), gomock.Any()).Return(value, nil)
+		s.cache.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
+	}
+	expectExchange := func(s *AuthServiceTestSuite, identity *oauth.Identity) {
+		s.oauthProvider.EXPECT().Exchange(gomock.Any(), "code", "verifier", "nonce").Return(identity, nil)
+	}
+	withinTransaction := func(s *AuthServiceTestSuite) {
+		s.userRepo.EXPECT().
+			WithinTransaction(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+	}
+	expectTokenPair := func(s *AuthServiceTestSuite) {
+		s.jwtService.EXPECT().GenerateAccessToken(gomock.Any()).Return("access-token", "access-token-id", nil)
+		s.jwtService.EXPECT().GenerateRefreshToken(gomock.Any()).Return("refresh-token", nil)
+		s.jwtService.EXPECT().GetRefreshTTL().Return(7 * 24 * time.Hour)
+		s.refreshTokenRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	}
+
+	tests := []struct {
+		name          string
+		provider      string
+		binding       string
+		setupMock     func(suite *AuthServiceTestSuite)
+		expectedError error
+	}{
+		{
+			name:     "Linked Identity",
+			provider: "fake",
+			binding:  utils.HashToken("state"),
+			setupMock: func(s *AuthServiceTestSuite) {
+				expectState(s, state)
+				expectExchange(s, verifiedIdentity)
+				s.identityRepo.EXPECT().
+					GetByProviderSubject(gomock.Any(), "fake", "sub-1").
+					Return(&models.UserIdentity{ID: identityID, UserID: testUserID, Provider: "fake", Subject: "sub-1"}, nil)
+				s.identityRepo.EXPECT().RecordLogin(gomock.Any(), identityID, "oauth@gmail.com").Return(int64(1), nil)
+				s.userRepo.EXPECT().GetByID(gomock.Any(), testUserID).Return(&models.User{ID: testUserID, Status: models.UserStatusActive}, nil)
+				expectTokenPair(s)
+			},
+			expectedError: nil,
+		},
+		{
+			name:     "Link Verified Local Account",
+			provider: "fake",
+			binding:  utils.HashToken("state"),
+			setupMock: func(s *AuthServiceTestSuite) {
+				expectState(s, state)
+				expectExchange(s, verifiedIdentity)
+				s.identityRepo.EXPECT().GetByProviderSubject(gomock.Any(), "fake", "sub-1").Return(nil, nil)
+				s.userRepo.EXPECT().GetByEmail(gomock.Any(), "oauth@gmail.com").Return(&models.User{
+					ID:              testUserID,
+					Email:           "oauth@gmail.com",
+					Status:          models.UserStatusActive,
+					EmailVerifiedAt: &verifiedAt,
+				}, nil)
+				withinTransaction(s)
+				s.identityRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, identity *models.UserIdentity) error {
+						assert.Equal(t, testUserID, identity.UserID)
+						assert.Equal(t, "sub-1", identity.Subject)
+						return nil
+					})
+				expectTokenPair(s)
+			},
+			expectedError: nil,
+		},
+		{
+			name:     "Create New User",
+			provider: "fake",
+			binding:  utils.HashToken("state"),
+			setupMock: func(s *AuthServiceTestSuite) {
+				expectState(s, state)
+				expectExchange(s, verifiedIdentity)
+				s.identityRepo.EXPECT().GetByProviderSubject(gomock.Any(), "fake", "sub-1").Return(nil, nil)
+				s.userRepo.EXPECT().GetByEmail(gomock.Any(), "oauth@gmail.com").Return(nil, nil)
+				withinTransaction(s)
+				s.passwordHasher.EXPECT().Hash(gomock.Any()).Return("hashedpassword", nil)
+				s.userRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, user *models.User) error {
+						assert.Equal(t, "OAuth User", user.FullName)
+						assert.Equal(t, models.UserStatusActive, user.Status)
+						user.ID = testUserID
+						return nil
+					})
+				s.userRepo.EXPECT().VerifyEmail(gomock.Any(), testUserID).Return(int64(1), nil)
+				s.identityRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+				expectTokenPair(s)
+			},
+			expectedError: nil,
+		},
+		{
+			name:     "Local Email Not Verified",
+			provider: "fake",
+			binding:  utils.HashToken("state"),
+			setupMock: func(s *AuthServiceTestSuite) {
+				expectState(s, state)
+				expectExchange(s, verifiedIdentity)
+				s.identityRepo.EXPECT().GetByProviderSubject(gomock.Any(), "fake", "sub-1").Return(nil, nil)
+				s.userRepo.EXPECT().GetByEmail(gomock.Any(), "oauth@gmail.com").Return(&models.User{
+					ID:     testUserID,
+					Email:  "oauth@gmail.com",
+					Status: models.UserStatusPending,
+				}, nil)
+			},
+			expectedError: apperr.ErrOAuthAccountLinkBlocked,
+		},
+		{
+			name:     "Provider Email Not Verified",
+			provider: "fake",
+			binding:  utils.HashToken("state"),
+			setupMock: func(s *AuthServiceTestSuite) {
+				expectState(s, state)
+				expectExchange(s, &oauth.Identity{Subject: "sub-1", Email: "oauth@gmail.com"})
+				s.identityRepo.EXPECT().GetByProviderSubject(gomock.Any(), "fake", "sub-1").Return(nil, nil)
+			},
+			expectedError: apperr.ErrOAuthEmailNotVerified,
+		},
+		{
+			name:     "State Invalid Or Expired",
+			provider: "fake",
+			binding:  utils.HashToken("state"),
+			setupMock: func(s *AuthServiceTestSuite) {
+				s.cache.EXPECT().Get(gomock.Any(), gomock.Any()).Return("", errors.New("not found"))
+			},
+			expectedError: apperr.ErrTokenInvalidOrExpired,
+		},
+		{
+			name:     "State Issued For Other Provider",
+			provider: "other",
+			binding:  utils.HashToken("state"),
+			setupMock: func(s *AuthServiceTestSuite) {
+				expectState(s, state)
+			},
+			expectedError: apperr.ErrTokenInvalidOrExpired,
+		},
+		{
+			name:     "Exchange Failed",
+			provider: "fake",
+			binding:  utils.HashToken("state"),
+			setupMock: func(s *AuthServiceTestSuite) {
+				expectState(s, state)
+				s.oauthProvider.EXPECT().Exchange(gomock.Any(), "code", "verifier", "nonce").Return(nil, oauth.ErrNonceMismatch)
+			},
+			expectedError: apperr.ErrOAuthLoginFailed,
+		},
+		{
+			name:          "State Not Bound To Browser",
+			provider:      "fake",
+			binding:       "",
+			setupMock:     func(s *AuthServiceTestSuite) {},
+			expectedError: apperr.ErrTokenInvalidOrExpired,
+		},
+		{
+			name:          "State Bound To Other Login",
+			provider:      "fake",
+			binding:       utils.HashToken("attacker-state"),
+			setupMock:     func(s *AuthServiceTestSuite) {},
+			expectedError: apperr.ErrTokenInvalidOrExpired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suite := setupAuthServiceTestSuite(t)
+			defer suite.ctrl.Finish()
+
+			ctx := context.WithValue(context.Background(), contextkeys.LoggerKey, zap.NewNop())
+			if tt.binding != "" {
+				ctx = context.WithValue(ctx, contextkeys.OAuthStateBindingKey, tt.binding)
+			}
+			tt.setupMock(suite)
+
+			result, err := suite.authService.CompleteOAuthLogin(ctx, tt.provider, "code", "state")
+
+			assert.True(t, errors.Is(err, tt.expectedError))
+			if tt.expectedError == nil {
+				assert.Equal(t, testUserID, result.User.ID)
+				assert.Equal(t, "access-token", result.AccessToken)
+			}
+		})
+	}
+}
+
+func TestAuthService_RequestMagicLink(t *testing.T) {
+	testUserID := uuid.New()
+
+	expectRate := func(s *AuthServiceTestSuite, count int64) {
+		s.cache.EXPECT().Incr(gomock.Any(), caching.MagicLinkRatePrefix+":user@gmail.com").Return(count, nil)
+		if count == 1 {
+			s.cache.EXPECT().Expire(gomock.Any(), gomock.Any(), caching.MagicLinkWindow).Return(nil)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		email     string
+		setupMock func(suite *AuthServiceTestSuite)
+	}{
+		{
+			name:  "Request Success",
+			email: "user@gmail.com",
+			setupMock: func(s *AuthServiceTestSuite) {
+				expectRate(s, 1)
+				s.userRepo.EXPECT().GetByEmail(gomock.Any(), "user@gmail.com").Return(&models.User{
+					ID:     testUserID,
+					Email:  "user@gmail.com",
+					Status: models.UserStatusActive,
+				}, nil)
+				s.cache.EXPECT().
+					Set(gomock.Any(), gomock.Any(), "user@gmail.com", caching.MagicLinkTTL).
+					DoAndReturn(func(ctx context.Context, key string, value any, ttl time.Duration) error {
+						assert.Contains(t, key, caching.MagicLinkPrefix)
+						return nil
+					})
+				s.eventPublisher.EXPECT().PublishMagicLinkRequested(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:  "Unknown Email",
+			email: "User@gmail.com",
+			setupMock: func(s *AuthServiceTestSuite) {
+				expectRate(s, 2)
+				s.userRepo.EXPECT().GetByEmail(gomock.Any(), "User@gmail.com").Return(nil, nil)
+			},
+		},
+		{
+			name:  "Inactive Account",
+			email: "user@gmail.com",
+			setupMock: func(s *AuthServiceTestSuite) {
+				expectRate(s, 2)
+				s.userRepo.EXPECT().GetByEmail(gomock.Any(), "user@gmail.com").Return(&models.User{
+					ID:     testUserID,
+					Email:  "user@gmail.com",
+					Status: models.UserStatusSuspended,
+				}, nil)
+			},
+		},
+		{
+			name:  "Throttled",
+			email: "user@gmail.com",
+			setupMock: func(s *AuthServiceTestSuite) {
+				expectRate(s, caching.MagicLinkMaxRequests+1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suite := setupAuthServiceTestSuite(t)
+			defer suite.ctrl.Finish()
+
+			ctx := context.WithValue(context.Background(), contextkeys.LoggerKey, zap.NewNop())
+			tt.setupMock(suite)
+
+			// every case answers the same so callers cannot probe for accounts
+			err := suite.authService.RequestMagicLink(ctx, tt.email)
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestAuthService_ConsumeMagicLink(t *testing.T) {
+	testUserID := uuid.New()
+	enabledAt := time.Now()
+
+	tests := []struct {
+		name          string
+		setupMock     func(suite *AuthServiceTestSuite)
+		expectedError error
+		checkFunc     func(t *testing.T, result *service.LoginResult)
+	}{
+		{
+			name: "Consume Success",
+			setupMock: func(s *AuthServiceTestSuite) {
+				s.cache.EXPECT().Get(gomock.Any(), gomock.Any()).Return("user@gmail.com", nil)
+				s.cache.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
+				s.userRepo.EXPECT().GetByEmail(gomock.Any(), "user@gmail.com").Return(&models.User{
+					ID:     testUserID,
+					Email:  "user@gmail.com",
+					Status: models.UserStatusActive,
+				}, nil)
+				s.jwtService.EXPECT().GenerateAccessToken(gomock.Any()).Return("access-token", "access-token-id", nil)
+				s.jwtService.EXPECT().GenerateRefreshToken(testUserID.String()).Return("refresh-token", nil)
+				s.jwtService.EXPECT().GetRefreshTTL().Return(7 * 24 * time.Hour)
+				s.refreshTokenRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedError: nil,
+			checkFunc: func(t *testing.T, result *service.LoginResult) {
+				assert.Equal(t, "access-token", result.AccessToken)
+				assert.Equal(t, "refresh-token", result.RefreshToken)
+			},
+		},
+		{
+			name: "Requires MFA",
+			setupMock: func(s *AuthServiceTestSuite) {
+				s.cache.EXPECT().Get(gomock.Any(), gomock.Any()).Return("user@gmail.com", nil)
+				s.cache.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
+				s.userRepo.EXPECT().GetByEmail(gomock.Any(), "user@gmail.com").Return(&models.User{
+					ID:            testUserID,
+					Email:         "user@gmail.com",
+					Status:        models.UserStatusActive,
+					TOTPEnabledAt: &enabledAt,
+				}, nil)
+				s.cache.EXPECT().Set(gomock.Any(), gomock.Any(), testUserID.String(), caching.MFAChallengeTTL).Return(nil)
+			},
+			expectedError: nil,
+			checkFunc: func(t *testing.T, result *service.LoginResult) {
+				assert.True(t, result.MFARequired())
+				assert.Empty(t, result.AccessToken)
+			},
+		},
+		{
+			name: "Token Invalid Or Expired",
+			setupMock: func(s *AuthServiceTestSuite) {
+				s.cache.EXPECT().Get(gomock.Any(), gomock.Any()).Return("", errors.New("not found"))
+			},
+			expectedError: apperr.ErrTokenInvalidOrExpired,
+		},
+		{
+			name: "Email Changed Since Request",
+			setupMock: func(s *AuthServiceTestSuite) {
+				s.cache.EXPECT().Get(gomock.Any(), gomock.Any()).Return("user@gmail.com", nil)
+				s.cache.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
+				s.userRepo.EXPECT().GetByEmail(gomock.Any(), "user@gmail.com").Return(nil, nil)
+			},
+			expectedError: apperr.ErrTokenInvalidOrExpired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suite := setupAuthServiceTestSuite(t)
+			defer suite.ctrl.Finish()
+
+			ctx := context.WithValue(context.Background(), contextkeys.LoggerKey, zap.NewNop())
+			tt.setupMock(suite)
+
+			result, err := suite.authService.ConsumeMagicLink(ctx, "magic-link-token")
+
+			assert.True(t, errors.Is(err, tt.expectedError))
+			if tt.checkFunc != nil {
+				tt.checkFunc(t, result)
+			}
+		})
+	}
+}
@@ -8,30 +8,45 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
+	mock_cache "github.com/khoihuynh300/go-microservice/shared/mocks/cache"
+	mock_storage "github.com/khoihuynh300/go-microservice/shared/mocks/storage"
 	"github.com/khoihuynh300/go-microservice/shared/pkg/const/contextkeys"
 	apperr "github.com/khoihuynh300/go-microservice/shared/pkg/errors"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/caching"
 	"github.com/khoihuynh300/go-microservice/user-service/internal/domain/models"
 	"github.com/khoihuynh300/go-microservice/user-service/internal/dto/request"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/repository"
 	"github.com/khoihuynh300/go-microservice/user-service/internal/service"
+	"github.com/khoihuynh300/go-microservice/user-service/internal/utils"
+	mock_publisher "github.com/khoihuynh300/go-microservice/user-service/mocks/publisher"
 	mock_repository "github.com/khoihuynh300/go-microservice/user-service/mocks/repository"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap"
 )
 
 type UserServiceTestSuite struct {
-	ctrl        *gomock.Controller
-	userRepo    *mock_repository.MockUserRepository
-	userService service.UserService
+	ctrl           *gomock.Controller
+	cache          *mock_cache.MockCache
+	userRepo       *mock_repository.MockUserRepository
+	imageStorage   *mock_storage.MockStorage
+	eventPublisher *mock_publisher.MockEventPublisher
+	userService    service.UserService
 }
 
 func NewUserServiceTestSuite(t *testing.T) *UserServiceTestSuite {
 	ctrl := gomock.NewController(t)
+	cache := mock_cache.NewMockCache(ctrl)
 	userRepo := mock_repository.NewMockUserRepository(ctrl)
-	userService := service.NewUserService(userRepo)
+	imageStorage := mock_storage.NewMockStorage(ctrl)
+	eventPublisher := mock_publisher.NewMockEventPublisher(ctrl)
+	userService := service.NewUserService(userRepo, caching.NewPhoneOTPCache(cache), imageStorage, eventPublisher)
 	return &UserServiceTestSuite{
-		ctrl:        ctrl,
-		userRepo:    userRepo,
-		userService: userService,
+		ctrl:           ctrl,
+		cache:          cache,
+		userRepo:       userRepo,
+		imageStorage:   imageStorage,
+		eventPublisher: eventPublisher,
+		userService:    userService,
 	}
 }
 
@@ -193,3 +208,168 @@ func ptrString(s string) *string {
 func ptrTime(t time.Time) *time.Time {
 	return &t
 }
+
+func TestUserService_RequestPhoneVerification(t *testing.T) {
+	testUserID := uuid.New()
+	verifiedAt := time.Now()
+	verifiedPhone := "0901234567"
+
+	tests := []struct {
+		name          string
+		phone         string
+		setupMock     func(suite *UserServiceTestSuite)
+		expectedError error
+	}{
+		{
+			name:  "Request Success",
+			phone: "0907654321",
+			setupMock: func(s *UserServiceTestSuite) {
+				s.userRepo.EXPECT().GetByID(gomock.Any(), testUserID).Return(&models.User{ID: testUserID}, nil)
+				s.userRepo.EXPECT().GetByPhone(gomock.Any(), "0907654321").Return(nil, nil)
+				s.cache.EXPECT().SetNX(gomock.Any(), caching.PhoneOTPCooldownPrefix+":"+testUserID.String(), gomock.Any(), caching.PhoneOTPCooldown).Return(true, nil)
+				s.cache.EXPECT().
+					Set(gomock.Any(), caching.PhoneOTPPrefix+":"+testUserID.String(), gomock.Any(), caching.PhoneOTPTTL).
+					DoAndReturn(func(ctx context.Context, key string, value any, ttl time.Duration) error {
+						assert.Contains(t, value.(string), `"phone":"0907654321"`)
+						return nil
+					})
+				s.cache.EXPECT().Delete(gomock.Any(), caching.PhoneOTPAttemptsPrefix+":"+testUserID.String()).Return(nil)
+				s.eventPublisher.EXPECT().
+					PublishPhoneVerificationRequested(gomock.Any(), gomock.Any(), "0907654321", gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, user *models.User, phone string, code string, expiresAt time.Time) error {
+						assert.Regexp(t, `^[0-9]{6}$`, code)
+						return nil
+					})
+			},
+			expectedError: nil,
+		},
+		{
+			name:  "Phone Already Verified",
+			phone: verifiedPhone,
+			setupMock: func(s *UserServiceTestSuite) {
+				s.userRepo.EXPECT().GetByID(gomock.Any(), testUserID).Return(&models.User{
+					ID:              testUserID,
+					Phone:           &verifiedPhone,
+					PhoneVerifiedAt: &verifiedAt,
+				}, nil)
+			},
+			expectedError: apperr.ErrPhoneAlreadyVerified,
+		},
+		{
+			name:  "Phone Used By Another Account",
+			phone: "0907654321",
+			setupMock: func(s *UserServiceTestSuite) {
+				s.userRepo.EXPECT().GetByID(gomock.Any(), testUserID).Return(&models.User{ID: testUserID}, nil)
+				s.userRepo.EXPECT().GetByPhone(gomock.Any(), "0907654321").Return(&models.User{ID: uuid.New()}, nil)
+			},
+			expectedError: apperr.ErrPhoneAlreadyExists,
+		},
+		{
+			name:  "Requested Too Recently",
+			phone: "0907654321",
+			setupMock: func(s *UserServiceTestSuite) {
+				s.userRepo.EXPECT().GetByID(gomock.Any(), testUserID).Return(&models.User{ID: testUserID}, nil)
+				s.userRepo.EXPECT().GetByPhone(gomock.Any(), "0907654321").Return(nil, nil)
+				s.cache.EXPECT().SetNX(gomock.Any(), gomock.Any(), gomock.Any(), caching.PhoneOTPCooldown).Return(false, nil)
+			},
+			expectedError: apperr.ErrRateLimitExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suite := NewUserServiceTestSuite(t)
+			defer suite.ctrl.Finish()
+
+			ctx := context.WithValue(context.Background(), contextkeys.LoggerKey, zap.NewNop())
+			tt.setupMock(suite)
+
+			err := suite.userService.RequestPhoneVerification(ctx, testUserID.String(), tt.phone)
+
+			assert.True(t, errors.Is(err, tt.expectedError))
+		})
+	}
+}
+
+func TestUserService_VerifyPhone(t *testing.T) {
+	testUserID := uuid.New()
+	otpKey := caching.PhoneOTPPrefix + ":" + testUserID.String()
+	attemptsKey := caching.PhoneOTPAttemptsPrefix + ":" + testUserID.String()
+	pending := `{"phone":"0907654321","code_hash":"` + utils.HashToken("123456") + `"}`
+
+	expectAttempt := func(s *UserServiceTestSuite, attempt int64) {
+		s.cache.EXPECT().Get(gomock.Any(), otpKey).Return(pending, nil)
+		s.cache.EXPECT().Incr(gomock.Any(), attemptsKey).Return(attempt, nil)
+		if attempt == 1 {
+			s.cache.EXPECT().Expire(gomock.Any(), attemptsKey, caching.PhoneOTPTTL).Return(nil)
+		}
+	}
+
+	tests := []struct {
+		name          string
+		code          string
+		setupMock     func(suite *UserServiceTestSuite)
+		expectedError error
+	}{
+		{
+			name: "Verify Success",
+			code: "123456",
+			setupMock: func(s *UserServiceTestSuite) {
+				expectAttempt(s, 1)
+				s.cache.EXPECT().Delete(gomock.Any(), otpKey, attemptsKey).Return(nil)
+				s.userRepo.EXPECT().VerifyPhone(gomock.Any(), testUserID, "0907654321").Return(int64(1), nil)
+				s.userRepo.EXPECT().GetByID(gomock.Any(), testUserID).Return(&models.User{ID: testUserID}, nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "Wrong Code",
+			code: "000000",
+			setupMock: func(s *UserServiceTestSuite) {
+				expectAttempt(s, 1)
+			},
+			expectedError: apperr.ErrInvalidOTP,
+		},
+		{
+			name: "Last Attempt Discards Code",
+			code: "000000",
+			setupMock: func(s *UserServiceTestSuite) {
+				expectAttempt(s, caching.PhoneOTPMaxAttempts)
+				s.cache.EXPECT().Delete(gomock.Any(), otpKey, attemptsKey).Return(nil)
+			},
+			expectedError: apperr.ErrOTPAttemptsExceeded,
+		},
+		{
+			name: "No Pending Code",
+			code: "123456",
+			setupMock: func(s *UserServiceTestSuite) {
+				s.cache.EXPECT().Get(gomock.Any(), otpKey).Return("", errors.New("not found"))
+			},
+			expectedError: apperr.ErrInvalidOTP,
+		},
+		{
+			name: "Phone Taken Meanwhile",
+			code: "123456",
+			setupMock: func(s *UserServiceTestSuite) {
+				expectAttempt(s, 2)
+				s.cache.EXPECT().Delete(gomock.Any(), otpKey, attemptsKey).Return(nil)
+				s.userRepo.EXPECT().VerifyPhone(gomock.Any(), testUserID, "0907654321").Return(int64(0), repository.ErrPhoneTaken)
+			},
+			expectedError: apperr.ErrPhoneAlreadyExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suite := NewUserServiceTestSuite(t)
+			defer suite.ctrl.Finish()
+
+			ctx := context.WithValue(context.Background(), contextkeys.LoggerKey, zap.NewNop())
+			tt.setupMock(suite)
+
+			_, err := suite.userService.VerifyPhone(ctx, testUserID.String(), tt.code)
+
+			assert.True(t, errors.Is(err, tt.expectedError))
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package totp_test
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/khoihuynh300/go-microservice/user-service/internal/security/totp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// base32 of the RFC 6238 SHA1 test key "12345678901234567890"
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestCode_RFC6238Vectors(t *testing.T) {
+	// the RFC lists 8 digit codes; ours are their last 6 digits
+	vectors := map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1234567890: "005924",
+		2000000000: "279037",
+	}
+
+	for unix, want := range vectors {
+		code, err := totp.Code(rfcSecret, time.Unix(unix, 0))
+		require.NoError(t, err)
+		assert.Equal(t, want, code, "t=%d", unix)
+	}
+}
+
+func TestProvider_Validate(t *testing.T) {
+	p := totp.NewProvider("test")
+	now := time.Unix(1111111109, 0)
+
+	assert.True(t, p.Validate(rfcSecret, "081804", now))
+	assert.True(t, p.Validate(rfcSecret, "081804", now.Add(30*time.Second)), "previous step is accepted")
+	assert.False(t, p.Validate(rfcSecret, "081804", now.Add(90*time.Second)))
+	assert.False(t, p.Validate(rfcSecret, "000000", now))
+	assert.False(t, p.Validate(rfcSecret, "81804", now))
+	assert.False(t, p.Validate("not base32!", "081804", now))
+}
+
+func TestProvider_GenerateSecretAndURI(t *testing.T) {
+	p := totp.NewProvider("Shop")
+
+	secret, err := p.GenerateSecret()
+	require.NoError(t, err)
+	assert.Len(t, secret, 32)
+
+	u, err := url.Parse(p.URI(secret, "user@gmail.com"))
+	require.NoError(t, err)
+	assert.Equal(t, "otpauth", u.Scheme)
+	assert.Equal(t, "totp", u.Host)
+	assert.Equal(t, "/Shop:user@gmail.com", u.Path)
+	assert.Equal(t, secret, u.Query().Get("secret"))
+	assert.Equal(t, "Shop", u.Query().Get("issuer"))
+
+	code, err := totp.Code(secret, time.Now())
+	require.NoError(t, err)
+	assert.True(t, p.Validate(secret, code, time.Now()))
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, err := totp.GenerateRecoveryCodes(totp.RecoveryCodeCount)
+	require.NoError(t, err)
+	require.Len(t, codes, totp.RecoveryCodeCount)
+
+	seen := map[string]bool{}
+	for _, code := range codes {
+		assert.Len(t, code, 11)
+		assert.Equal(t, "-", code[5:6])
+		assert.False(t, seen[code])
+		seen[code] = true
+
+		assert.Equal(t, strings.ReplaceAll(code, "-", ""), totp.NormalizeRecoveryCode(" "+strings.ToUpper(code)+" "))
+	}
+}
@@ -126,6 +126,21 @@ func (mr *MockCacheMockRecorder) GetObject(arg0, arg1, arg2 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObject", reflect.TypeOf((*MockCache)(nil).GetObject), arg0, arg1, arg2)
 }
 
+// Incr mocks base method.
+func (m *MockCache) Incr(arg0 context.Context, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Incr", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Incr indicates an expected call of Incr.
+func (mr *MockCacheMockRecorder) Incr(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Incr", reflect.TypeOf((*MockCache)(nil).Incr), arg0, arg1)
+}
+
 // Keys mocks base method.
 func (m *MockCache) Keys(arg0 context.Context, arg1 string) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,90 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/khoihuynh300/go-microservice/shared/pkg/messaging/kafka (interfaces: Producer)
+
+// Package mock_kafka is a generated GoMock package.
+package mock_kafka
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	events "github.com/khoihuynh300/go-microservice/shared/pkg/messaging/events"
+)
+
+// MockProducer is a mock of Producer interface.
+type MockProducer struct {
+	ctrl     *gomock.Controller
+	recorder *MockProducerMockRecorder
+}
+
+// MockProducerMockRecorder is the mock recorder for MockProducer.
+type MockProducerMockRecorder struct {
+	mock *MockProducer
+}
+
+// NewMockProducer creates a new mock instance.
+func NewMockProducer(ctrl *gomock.Controller) *MockProducer {
+	mock := &MockProducer{ctrl: ctrl}
+	mock.recorder = &MockProducerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProducer) EXPECT() *MockProducerMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockProducer) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockProducerMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockProducer)(nil).Close))
+}
+
+// Publish mocks base method.
+func (m *MockProducer) Publish(arg0 context.Context, arg1 string, arg2 *events.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockProducerMockRecorder) Publish(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockProducer)(nil).Publish), arg0, arg1, arg2)
+}
+
+// PublishWithKey mocks base method.
+func (m *MockProducer) PublishWithKey(arg0 context.Context, arg1, arg2 string, arg3 *events.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishWithKey", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishWithKey indicates an expected call of PublishWithKey.
+func (mr *MockProducerMockRecorder) PublishWithKey(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishWithKey", reflect.TypeOf((*MockProducer)(nil).PublishWithKey), arg0, arg1, arg2, arg3)
+}
+
+// SetContentType mocks base method.
+func (m *MockProducer) SetContentType(arg0 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetContentType", arg0)
+}
+
+// SetContentType indicates an expected call of SetContentType.
+func (mr *MockProducerMockRecorder) SetContentType(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetContentType", reflect.TypeOf((*MockProducer)(nil).SetContentType), arg0)
+}
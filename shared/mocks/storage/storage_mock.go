@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/khoihuynh300/go-microservice/shared/pkg/storage (interfaces: Storage)
+
+// Package mock_storage is a generated GoMock package.
+package mock_storage
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	storage "github.com/khoihuynh300/go-microservice/shared/pkg/storage"
+)
+
+// MockStorage is a mock of Storage interface.
+type MockStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockStorageMockRecorder
+}
+
+// MockStorageMockRecorder is the mock recorder for MockStorage.
+type MockStorageMockRecorder struct {
+	mock *MockStorage
+}
+
+// NewMockStorage creates a new mock instance.
+func NewMockStorage(ctrl *gomock.Controller) *MockStorage {
+	mock := &MockStorage{ctrl: ctrl}
+	mock.recorder = &MockStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStorage) EXPECT() *MockStorageMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockStorage) Delete(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockStorageMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockStorage)(nil).Delete), arg0, arg1)
+}
+
+// GetPresignedDownloadURL mocks base method.
+func (m *MockStorage) GetPresignedDownloadURL(arg0 context.Context, arg1 string, arg2 time.Duration) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPresignedDownloadURL", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPresignedDownloadURL indicates an expected call of GetPresignedDownloadURL.
+func (mr *MockStorageMockRecorder) GetPresignedDownloadURL(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPresignedDownloadURL", reflect.TypeOf((*MockStorage)(nil).GetPresignedDownloadURL), arg0, arg1, arg2)
+}
+
+// GetPresignedUploadURL mocks base method.
+func (m *MockStorage) GetPresignedUploadURL(arg0 context.Context, arg1 *storage.PresignedUploadInput) (*storage.PresignedUploadOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPresignedUploadURL", arg0, arg1)
+	ret0, _ := ret[0].(*storage.PresignedUploadOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPresignedUploadURL indicates an expected call of GetPresignedUploadURL.
+func (mr *MockStorageMockRecorder) GetPresignedUploadURL(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPresignedUploadURL", reflect.TypeOf((*MockStorage)(nil).GetPresignedUploadURL), arg0, arg1)
+}
+
+// GetURL mocks base method.
+func (m *MockStorage) GetURL(arg0 string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetURL", arg0)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetURL indicates an expected call of GetURL.
+func (mr *MockStorageMockRecorder) GetURL(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetURL", reflect.TypeOf((*MockStorage)(nil).GetURL), arg0)
+}
+
+// Upload mocks base method.
+func (m *MockStorage) Upload(arg0 context.Context, arg1 *storage.UploadInput) (*storage.UploadOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upload", arg0, arg1)
+	ret0, _ := ret[0].(*storage.UploadOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upload indicates an expected call of Upload.
+func (mr *MockStorageMockRecorder) Upload(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockStorage)(nil).Upload), arg0, arg1)
+}
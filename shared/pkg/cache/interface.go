@@ -18,6 +18,8 @@ type Cache interface {
 
 	SetNX(ctx context.Context, key string, value any, ttl time.Duration) (bool, error)
 
+	Incr(ctx context.Context, key string) (int64, error)
+
 	TTL(ctx context.Context, key string) (time.Duration, error)
 
 	Expire(ctx context.Context, key string, ttl time.Duration) error
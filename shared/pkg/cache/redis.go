@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/khoihuynh300/go-microservice/shared/pkg/metrics"
 	"github.com/redis/go-redis/v9"
 )
 
@@ -21,6 +22,7 @@ func NewClient(cfg *Config) (*Client, error) {
 		Password: cfg.Password,
 		DB:       cfg.DB,
 	})
+	rdb.AddHook(metrics.RedisHook{})
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -91,3 +93,7 @@ func (c *Client) Keys(ctx context.Context, pattern string) ([]string, error) {
 func (c *Client) SetNX(ctx context.Context, key string, value any, expiration time.Duration) (bool, error) {
 	return c.client.SetNX(ctx, key, value, expiration).Result()
 }
+
+func (c *Client) Incr(ctx context.Context, key string) (int64, error) {
+	return c.client.Incr(ctx, key).Result()
+}
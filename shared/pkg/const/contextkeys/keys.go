@@ -1,7 +1,12 @@
 package contextkeys
 
 const (
-	UserIDKey  = "user_id"
-	TraceIDKey = "trace_id"
-	LoggerKey  = "logger"
+	UserIDKey    = "user_id"
+	UserRoleKey  = "user_role"
+	TraceIDKey   = "trace_id"
+	ClientIPKey  = "client_ip"
+	UserAgentKey = "user_agent"
+	LoggerKey    = "logger"
+
+	OAuthStateBindingKey = "oauth_state_binding"
 )
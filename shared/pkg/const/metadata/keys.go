@@ -1,6 +1,13 @@
 package mdkeys
 
 const (
-	UserIDHeader  = "x-user-id"
-	TraceIDHeader = "x-trace-id"
+	UserIDHeader    = "x-user-id"
+	UserRoleHeader  = "x-user-role"
+	TraceIDHeader   = "x-trace-id"
+	ClientIPHeader  = "x-client-ip"
+	UserAgentHeader = "x-user-agent"
+
+	// OAuthStateBindingHeader carries the hash of the OAuth state from the
+	// cookie of the browser that started the login.
+	OAuthStateBindingHeader = "x-oauth-state-binding"
 )
@@ -4,4 +4,15 @@ const (
 	VerifyEmailPrefix   = "user:verify_email:"
 	ResetPasswordPrefix = "user:reset_password:"
 	ChangeEmailPrefix   = "user:change_email:"
+
+	ProcessedEventPrefix = "event:processed:"
+
+	RateLimitPrefix = "ratelimit:"
+
+	// TokensValidAfterPrefix holds, per user ID, the unix time before which
+	// issued access tokens are rejected by the gateway.
+	TokensValidAfterPrefix = "auth:tokens_valid_after:"
+	// RevokedAccessTokenPrefix marks, per jti, a single access token rejected
+	// by the gateway until it expires.
+	RevokedAccessTokenPrefix = "auth:revoked_access_token:"
 )
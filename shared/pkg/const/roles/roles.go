@@ -0,0 +1,7 @@
+package roles
+
+const (
+	Customer = "customer"
+	Staff    = "staff"
+	Admin    = "admin"
+)
@@ -27,6 +27,7 @@ const (
 	CodeInvalidCredentials = "INVALID_CREDENTIALS"
 	CodeTokenExpired       = "TOKEN_EXPIRED"
 	CodeTokenInvalid       = "TOKEN_INVALID"
+	CodeTokenRevoked       = "TOKEN_REVOKED"
 
 	//// business error codes
 	// user
@@ -34,6 +35,44 @@ const (
 	CodeAccountInactive      = "ACCOUNT_INACTIVE"
 	CodeUserNotFound         = "USER_NOT_FOUND"
 	CodeEmailAlreadyVerified = "EMAIL_ALREADY_VERIFIED"
+	CodeAccountLocked        = "ACCOUNT_LOCKED"
+	CodeTooManyLoginAttempts = "TOO_MANY_LOGIN_ATTEMPTS"
+	CodeEmailUnchanged       = "EMAIL_UNCHANGED"
+
+	// password policy
+	CodePasswordTooShort             = "PASSWORD_TOO_SHORT"
+	CodePasswordMissingUppercase     = "PASSWORD_MISSING_UPPERCASE"
+	CodePasswordMissingLowercase     = "PASSWORD_MISSING_LOWERCASE"
+	CodePasswordMissingDigit         = "PASSWORD_MISSING_DIGIT"
+	CodePasswordMissingSymbol        = "PASSWORD_MISSING_SYMBOL"
+	CodePasswordContainsPersonalInfo = "PASSWORD_CONTAINS_PERSONAL_INFO"
+	CodePasswordBreached             = "PASSWORD_BREACHED"
+
+	// session
+	CodeSessionNotFound = "SESSION_NOT_FOUND"
+
+	// mfa
+	CodeInvalidMFACode          = "INVALID_MFA_CODE"
+	CodeMFAAlreadyEnabled       = "MFA_ALREADY_ENABLED"
+	CodeMFANotEnabled           = "MFA_NOT_ENABLED"
+	CodeMFAEnrollmentNotStarted = "MFA_ENROLLMENT_NOT_STARTED"
+
+	// oauth
+	CodeOAuthProviderNotFound   = "OAUTH_PROVIDER_NOT_FOUND"
+	CodeOAuthLoginFailed        = "OAUTH_LOGIN_FAILED"
+	CodeOAuthEmailNotVerified   = "OAUTH_EMAIL_NOT_VERIFIED"
+	CodeOAuthAccountLinkBlocked = "OAUTH_ACCOUNT_LINK_BLOCKED"
+
+	// phone verification
+	CodePhoneAlreadyExists   = "PHONE_ALREADY_EXISTS"
+	CodePhoneAlreadyVerified = "PHONE_ALREADY_VERIFIED"
+	CodeInvalidOTP           = "INVALID_OTP"
+	CodeOTPAttemptsExceeded  = "OTP_ATTEMPTS_EXCEEDED"
+
+	// user administration
+	CodeUserAlreadySuspended = "USER_ALREADY_SUSPENDED"
+	CodeUserNotSuspended     = "USER_NOT_SUSPENDED"
+	CodeCannotManageSelf     = "CANNOT_MANAGE_SELF"
 
 	// address
 	CodeAddressNotFound = "ADDRESS_NOT_FOUND"
@@ -54,13 +93,23 @@ const (
 	CodeCategorySlugExists        = "CATEGORY_SLUG_EXISTS"
 	CodeCategoryHasProducts       = "CATEGORY_HAS_PRODUCTS"
 	CodeCategoryHasChildren       = "CATEGORY_HAS_CHILDREN"
+
+	// order
+	CodeOrderNotFound          = "ORDER_NOT_FOUND"
+	CodeOrderCannotBeCancelled = "ORDER_CANNOT_BE_CANCELLED"
+	CodeProductUnavailable     = "PRODUCT_UNAVAILABLE"
+
+	// cart
+	CodeCartItemNotFound = "CART_ITEM_NOT_FOUND"
+	CodeCartFull         = "CART_FULL"
 )
 
 var (
 	// common errors
-	ErrNotFound      = New(CodeNotFound, "Resource not found", nil, http.StatusNotFound, codes.NotFound)
-	ErrAlreadyExists = New(CodeAlreadyExists, "Resource already exists", nil, http.StatusConflict, codes.AlreadyExists)
-	ErrInternal      = New(CodeInternal, "Internal server error", nil, http.StatusInternalServerError, codes.Internal)
+	ErrNotFound          = New(CodeNotFound, "Resource not found", nil, http.StatusNotFound, codes.NotFound)
+	ErrAlreadyExists     = New(CodeAlreadyExists, "Resource already exists", nil, http.StatusConflict, codes.AlreadyExists)
+	ErrInternal          = New(CodeInternal, "Internal server error", nil, http.StatusInternalServerError, codes.Internal)
+	ErrRateLimitExceeded = New(CodeRateLimitExceeded, "Too many requests, please try again later", nil, http.StatusTooManyRequests, codes.ResourceExhausted)
 
 	// auth errors
 	ErrUnauthenticated        = New(CodeUnauthenticated, "Authentication required", nil, http.StatusUnauthorized, codes.Unauthenticated)
@@ -71,6 +120,9 @@ var (
 	ErrTokenExpired           = New(CodeTokenExpired, "Token has expired", nil, http.StatusUnauthorized, codes.Unauthenticated)
 	ErrTokenInvalid           = New(CodeTokenInvalid, "Token is invalid", nil, http.StatusUnauthorized, codes.Unauthenticated)
 	ErrTokenInvalidOrExpired  = New(CodeTokenInvalid, "Token is invalid or expired", nil, http.StatusUnauthorized, codes.Unauthenticated)
+	ErrTokenRevoked           = New(CodeTokenRevoked, "Token has been revoked", nil, http.StatusUnauthorized, codes.Unauthenticated)
+	ErrAccountLocked          = New(CodeAccountLocked, "Account is temporarily locked due to too many failed login attempts", nil, http.StatusLocked, codes.PermissionDenied)
+	ErrTooManyLoginAttempts   = New(CodeTooManyLoginAttempts, "Too many failed login attempts, please try again later", nil, http.StatusTooManyRequests, codes.ResourceExhausted)
 
 	//// business errors
 	// user
@@ -79,6 +131,32 @@ var (
 	ErrUserNotFound         = New(CodeUserNotFound, "User not found", nil, http.StatusNotFound, codes.NotFound)
 	ErrEmailAlreadyVerified = New(CodeEmailAlreadyVerified, "Email already verified", nil, http.StatusConflict, codes.FailedPrecondition)
 
+	// session
+	ErrSessionNotFound = New(CodeSessionNotFound, "Session not found", nil, http.StatusNotFound, codes.NotFound)
+
+	// mfa
+	ErrInvalidMFACode          = New(CodeInvalidMFACode, "Verification code is invalid", nil, http.StatusUnauthorized, codes.Unauthenticated)
+	ErrMFAAlreadyEnabled       = New(CodeMFAAlreadyEnabled, "Two-factor authentication is already enabled", nil, http.StatusConflict, codes.FailedPrecondition)
+	ErrMFANotEnabled           = New(CodeMFANotEnabled, "Two-factor authentication is not enabled", nil, http.StatusConflict, codes.FailedPrecondition)
+	ErrMFAEnrollmentNotStarted = New(CodeMFAEnrollmentNotStarted, "Two-factor authentication enrollment has not been started", nil, http.StatusConflict, codes.FailedPrecondition)
+
+	// oauth
+	ErrOAuthProviderNotFound   = New(CodeOAuthProviderNotFound, "Login provider not found", nil, http.StatusNotFound, codes.NotFound)
+	ErrOAuthLoginFailed        = New(CodeOAuthLoginFailed, "Login with the provider failed", nil, http.StatusUnauthorized, codes.Unauthenticated)
+	ErrOAuthEmailNotVerified   = New(CodeOAuthEmailNotVerified, "The provider did not confirm a verified email", nil, http.StatusForbidden, codes.PermissionDenied)
+	ErrOAuthAccountLinkBlocked = New(CodeOAuthAccountLinkBlocked, "An account with this email exists but its email is not verified", nil, http.StatusConflict, codes.FailedPrecondition)
+
+	// phone verification
+	ErrPhoneAlreadyExists   = New(CodePhoneAlreadyExists, "Phone number is already used by another account", nil, http.StatusConflict, codes.AlreadyExists)
+	ErrPhoneAlreadyVerified = New(CodePhoneAlreadyVerified, "Phone number is already verified", nil, http.StatusConflict, codes.FailedPrecondition)
+	ErrInvalidOTP           = New(CodeInvalidOTP, "Verification code is invalid or expired", nil, http.StatusBadRequest, codes.InvalidArgument)
+	ErrOTPAttemptsExceeded  = New(CodeOTPAttemptsExceeded, "Too many wrong codes, please request a new one", nil, http.StatusTooManyRequests, codes.ResourceExhausted)
+
+	// user administration
+	ErrUserAlreadySuspended = New(CodeUserAlreadySuspended, "User is already suspended", nil, http.StatusConflict, codes.FailedPrecondition)
+	ErrUserNotSuspended     = New(CodeUserNotSuspended, "User is not suspended or inactive", nil, http.StatusConflict, codes.FailedPrecondition)
+	ErrCannotManageSelf     = New(CodeCannotManageSelf, "This action cannot be performed on your own account", nil, http.StatusForbidden, codes.PermissionDenied)
+
 	// address
 	ErrAddressNotFound = New(CodeAddressNotFound, "Address not found", nil, http.StatusNotFound, codes.NotFound)
 
@@ -97,12 +175,24 @@ var (
 	ErrCategorySlugExists        = New(CodeCategorySlugExists, "Category with the given slug already exists", nil, http.StatusConflict, codes.AlreadyExists)
 	ErrCategoryHasProducts       = New(CodeCategoryHasProducts, "Category has associated products and cannot be deleted", nil, http.StatusConflict, codes.FailedPrecondition)
 	ErrCategoryHasChildren       = New(CodeCategoryHasChildren, "Category has child categories and cannot be deleted", nil, http.StatusConflict, codes.FailedPrecondition)
+
+	// order
+	ErrOrderNotFound          = New(CodeOrderNotFound, "Order not found", nil, http.StatusNotFound, codes.NotFound)
+	ErrOrderCannotBeCancelled = New(CodeOrderCannotBeCancelled, "Order can no longer be cancelled", nil, http.StatusConflict, codes.FailedPrecondition)
+
+	// cart
+	ErrCartItemNotFound = New(CodeCartItemNotFound, "Item not found in cart", nil, http.StatusNotFound, codes.NotFound)
+	ErrCartFull         = New(CodeCartFull, "Cart has reached the maximum number of items", nil, http.StatusConflict, codes.FailedPrecondition)
 )
 
 func NewErrValidationFailed(details []ErrorDetail) *AppError {
 	return New(CodeValidationFailed, "Validation failed", details, http.StatusBadRequest, codes.InvalidArgument)
 }
 
+func NewErrProductUnavailable(details []ErrorDetail) *AppError {
+	return New(CodeProductUnavailable, "Some products are unavailable", details, http.StatusUnprocessableEntity, codes.FailedPrecondition)
+}
+
 func NewErrValidationFailedWithDetail(field, code, message string) *AppError {
 	return NewErrValidationFailed([]ErrorDetail{
 		{
@@ -19,6 +19,14 @@ var publicMethods = []string{
 	"/user.UserService/Refresh",
 	"/user.UserService/ForgotPassword",
 	"/user.UserService/ResetPassword",
+	"/user.UserService/ConfirmEmailChange",
+	"/user.UserService/UnlockAccount",
+	"/user.UserService/Logout",
+	"/user.UserService/VerifyMFA",
+	"/user.UserService/StartOAuthLogin",
+	"/user.UserService/CompleteOAuthLogin",
+	"/user.UserService/RequestMagicLink",
+	"/user.UserService/ConsumeMagicLink",
 }
 
 func AuthInterceptor() grpc.UnaryServerInterceptor {
@@ -33,6 +41,16 @@ func AuthInterceptor() grpc.UnaryServerInterceptor {
 			return nil, status.Error(codes.Unauthenticated, "missing metadata")
 		}
 
+		if clientIP, err := extractMetadata(md, mdkeys.ClientIPHeader); err == nil && clientIP != "" {
+			ctx = context.WithValue(ctx, contextkeys.ClientIPKey, clientIP)
+		}
+		if userAgent, err := extractMetadata(md, mdkeys.UserAgentHeader); err == nil && userAgent != "" {
+			ctx = context.WithValue(ctx, contextkeys.UserAgentKey, userAgent)
+		}
+		if binding, err := extractMetadata(md, mdkeys.OAuthStateBindingHeader); err == nil && binding != "" {
+			ctx = context.WithValue(ctx, contextkeys.OAuthStateBindingKey, binding)
+		}
+
 		if isPublicMethod(info.FullMethod) {
 			return handler(ctx, req)
 		}
@@ -47,6 +65,10 @@ func AuthInterceptor() grpc.UnaryServerInterceptor {
 
 		ctx = context.WithValue(ctx, contextkeys.UserIDKey, userID)
 
+		if role, err := extractMetadata(md, mdkeys.UserRoleHeader); err == nil && role != "" {
+			ctx = context.WithValue(ctx, contextkeys.UserRoleKey, role)
+		}
+
 		return handler(ctx, req)
 	}
 }
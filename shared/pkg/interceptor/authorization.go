@@ -0,0 +1,53 @@
+package interceptor
+
+import (
+	"context"
+	"slices"
+
+	"github.com/khoihuynh300/go-microservice/shared/pkg/const/contextkeys"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/const/roles"
+	apperr "github.com/khoihuynh300/go-microservice/shared/pkg/errors"
+	"google.golang.org/grpc"
+)
+
+var catalogManagers = []string{roles.Admin, roles.Staff}
+var userAdmins = []string{roles.Admin}
+
+// methodPolicies lists the roles allowed to call a method. Methods that are
+// not listed are open to any authenticated user.
+var methodPolicies = map[string][]string{
+	"/product.ProductService/CreateProduct":  catalogManagers,
+	"/product.ProductService/UpdateProduct":  catalogManagers,
+	"/product.ProductService/DeleteProduct":  catalogManagers,
+	"/product.ProductService/CreateCategory": catalogManagers,
+	"/product.ProductService/UpdateCategory": catalogManagers,
+	"/product.ProductService/DeleteCategory": catalogManagers,
+	"/user.UserService/ListUsers":            userAdmins,
+	"/user.UserService/GetUserByID":          userAdmins,
+	"/user.UserService/SuspendUser":          userAdmins,
+	"/user.UserService/ReactivateUser":       userAdmins,
+	"/user.UserService/ForceResetPassword":   userAdmins,
+	"/user.UserService/RevokeAllSessions":    userAdmins,
+	"/user.UserService/ListAuditLog":         userAdmins,
+}
+
+func AuthorizationInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		allowedRoles, ok := methodPolicies[info.FullMethod]
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		role, _ := ctx.Value(contextkeys.UserRoleKey).(string)
+		if !slices.Contains(allowedRoles, role) {
+			return nil, apperr.ToGRPC(apperr.ErrUnauthorized)
+		}
+
+		return handler(ctx, req)
+	}
+}
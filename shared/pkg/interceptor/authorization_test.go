@@ -0,0 +1,63 @@
+package interceptor_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/khoihuynh300/go-microservice/shared/pkg/const/contextkeys"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/const/roles"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/interceptor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuthorizationInterceptor(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		role         string
+		expectedCode codes.Code
+	}{
+		{name: "Admin Creates Product", method: "/product.ProductService/CreateProduct", role: roles.Admin, expectedCode: codes.OK},
+		{name: "Staff Updates Product", method: "/product.ProductService/UpdateProduct", role: roles.Staff, expectedCode: codes.OK},
+		{name: "Staff Deletes Category", method: "/product.ProductService/DeleteCategory", role: roles.Staff, expectedCode: codes.OK},
+		{name: "Customer Creates Product", method: "/product.ProductService/CreateProduct", role: roles.Customer, expectedCode: codes.PermissionDenied},
+		{name: "Customer Deletes Category", method: "/product.ProductService/DeleteCategory", role: roles.Customer, expectedCode: codes.PermissionDenied},
+		{name: "Missing Role", method: "/product.ProductService/CreateProduct", role: "", expectedCode: codes.PermissionDenied},
+		{name: "Staff Suspends User", method: "/user.UserService/SuspendUser", role: roles.Staff, expectedCode: codes.PermissionDenied},
+		{name: "Admin Suspends User", method: "/user.UserService/SuspendUser", role: roles.Admin, expectedCode: codes.OK},
+		{name: "Customer On Unlisted Method", method: "/product.ProductService/GetProduct", role: roles.Customer, expectedCode: codes.OK},
+		{name: "Missing Role On Unlisted Method", method: "/user.UserService/GetMe", role: "", expectedCode: codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.role != "" {
+				ctx = context.WithValue(ctx, contextkeys.UserRoleKey, tt.role)
+			}
+
+			called := false
+			handler := func(ctx context.Context, req any) (any, error) {
+				called = true
+				return "ok", nil
+			}
+
+			resp, err := interceptor.AuthorizationInterceptor()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+
+			if tt.expectedCode != codes.OK {
+				require.Error(t, err)
+				assert.Equal(t, tt.expectedCode, status.Code(err))
+				assert.False(t, called)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, called)
+			assert.Equal(t, "ok", resp)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package interceptor
+
+import (
+	"context"
+
+	"github.com/khoihuynh300/go-microservice/shared/pkg/const/contextkeys"
+	mdkeys "github.com/khoihuynh300/go-microservice/shared/pkg/const/metadata"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func MetadataForwardingClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		if traceID, ok := ctx.Value(contextkeys.TraceIDKey).(string); ok && traceID != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, mdkeys.TraceIDHeader, traceID)
+		}
+		if userID, ok := ctx.Value(contextkeys.UserIDKey).(string); ok && userID != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, mdkeys.UserIDHeader, userID)
+		}
+		if role, ok := ctx.Value(contextkeys.UserRoleKey).(string); ok && role != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, mdkeys.UserRoleHeader, role)
+		}
+		if clientIP, ok := ctx.Value(contextkeys.ClientIPKey).(string); ok && clientIP != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, mdkeys.ClientIPHeader, clientIP)
+		}
+		if userAgent, ok := ctx.Value(contextkeys.UserAgentKey).(string); ok && userAgent != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, mdkeys.UserAgentHeader, userAgent)
+		}
+
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
@@ -0,0 +1,24 @@
+package interceptor
+
+import (
+	"context"
+	"time"
+
+	"github.com/khoihuynh300/go-microservice/shared/pkg/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+func MetricsInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		metrics.ObserveGRPC(info.FullMethod, status.Code(err), time.Since(start))
+		return resp, err
+	}
+}
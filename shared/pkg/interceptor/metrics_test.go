@@ -0,0 +1,59 @@
+package interceptor_test
+
+import (
+	"context"
+	"testing"
+
+	apperr "github.com/khoihuynh300/go-microservice/shared/pkg/errors"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/interceptor"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// handledCount reads grpc_server_handled_total from the default registry,
+// which the metrics package registers its collectors on.
+func handledCount(t *testing.T, service, method, code string) float64 {
+	t.Helper()
+	families, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+
+	labels := map[string]string{"grpc_service": service, "grpc_method": method, "grpc_code": code}
+	for _, family := range families {
+		if family.GetName() != "grpc_server_handled_total" {
+			continue
+		}
+	metrics:
+		for _, metric := range family.GetMetric() {
+			for _, pair := range metric.GetLabel() {
+				if labels[pair.GetName()] != pair.GetValue() {
+					continue metrics
+				}
+			}
+			return metric.GetCounter().GetValue()
+		}
+	}
+	return 0
+}
+
+func TestMetricsInterceptor(t *testing.T) {
+	const service = "interceptor_test.MetricsService"
+	info := &grpc.UnaryServerInfo{FullMethod: "/" + service + "/GetThing"}
+	ok := func(ctx context.Context, req any) (any, error) { return "ok", nil }
+	notFound := func(ctx context.Context, req any) (any, error) { return nil, apperr.ToGRPC(apperr.ErrUserNotFound) }
+
+	okBefore := handledCount(t, service, "GetThing", "OK")
+	notFoundBefore := handledCount(t, service, "GetThing", "NotFound")
+
+	resp, err := interceptor.MetricsInterceptor()(context.Background(), nil, info, ok)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+	_, err = interceptor.MetricsInterceptor()(context.Background(), nil, info, ok)
+	require.NoError(t, err)
+	_, err = interceptor.MetricsInterceptor()(context.Background(), nil, info, notFound)
+	require.Error(t, err)
+
+	assert.Equal(t, okBefore+2, handledCount(t, service, "GetThing", "OK"))
+	assert.Equal(t, notFoundBefore+1, handledCount(t, service, "GetThing", "NotFound"))
+}
@@ -3,9 +3,15 @@ package interceptor
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/khoihuynh300/go-microservice/shared/pkg/const/contextkeys"
 	mdkeys "github.com/khoihuynh300/go-microservice/shared/pkg/const/metadata"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/telemetry"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -25,9 +31,16 @@ func TracingInterceptor(logger *zap.Logger) grpc.UnaryServerInterceptor {
 			return nil, status.Error(codes.Unauthenticated, "missing metadata")
 		}
 
+		ctx = otel.GetTextMapPropagator().Extract(ctx, telemetry.MetadataCarrier(md))
+		ctx, span := telemetry.Tracer().Start(ctx, info.FullMethod,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(rpcAttributes(info.FullMethod)...),
+		)
+		defer span.End()
+
 		traceID, err := extractMetadata(md, mdkeys.TraceIDHeader)
-		if err != nil {
-			return nil, status.Error(codes.Unauthenticated, "missing trace ID")
+		if err != nil || traceID == "" {
+			traceID = telemetry.TraceID(ctx)
 		}
 		if traceID == "" {
 			return nil, status.Error(codes.Unauthenticated, "missing trace ID")
@@ -37,7 +50,68 @@ func TracingInterceptor(logger *zap.Logger) grpc.UnaryServerInterceptor {
 		ctx = context.WithValue(ctx, contextkeys.TraceIDKey, traceID)
 		ctx = context.WithValue(ctx, contextkeys.LoggerKey, ctxLogger)
 
-		return handler(ctx, req)
+		resp, err := handler(ctx, req)
+		recordStatus(span, err)
+
+		return resp, err
+	}
+}
+
+// TracingClientInterceptor starts a client span for outgoing calls and injects
+// the W3C trace context into the request metadata.
+func TracingClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		ctx, span := telemetry.Tracer().Start(ctx, method,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(rpcAttributes(method)...),
+			trace.WithAttributes(attribute.String("server.address", cc.Target())),
+		)
+		defer span.End()
+
+		md, ok := metadata.FromOutgoingContext(ctx)
+		if ok {
+			md = md.Copy()
+		} else {
+			md = metadata.MD{}
+		}
+		otel.GetTextMapPropagator().Inject(ctx, telemetry.MetadataCarrier(md))
+		ctx = metadata.NewOutgoingContext(ctx, md)
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		recordStatus(span, err)
+
+		return err
+	}
+}
+
+func rpcAttributes(fullMethod string) []attribute.KeyValue {
+	service, method, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	return []attribute.KeyValue{
+		attribute.String("rpc.system", "grpc"),
+		attribute.String("rpc.service", service),
+		attribute.String("rpc.method", method),
+	}
+}
+
+func recordStatus(span trace.Span, err error) {
+	if err == nil {
+		span.SetAttributes(attribute.Int("rpc.grpc.status_code", int(codes.OK)))
+		return
+	}
+
+	st, _ := status.FromError(err)
+	span.SetAttributes(attribute.Int("rpc.grpc.status_code", int(st.Code())))
+	switch st.Code() {
+	case codes.Unknown, codes.DeadlineExceeded, codes.Unimplemented, codes.Internal, codes.Unavailable, codes.DataLoss:
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, st.Message())
 	}
 }
 
@@ -0,0 +1,117 @@
+package jwks
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+const (
+	AlgRS256 = "RS256"
+	AlgEdDSA = "EdDSA"
+)
+
+var ErrUnsupportedKey = errors.New("unsupported key type")
+
+// Key is a public signing key in JSON Web Key form (RFC 7517).
+type Key struct {
+	Kty string `json:"kty"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	Kid string `json:"kid"`
+
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+
+	// OKP (Ed25519)
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// Set is the document served at /.well-known/jwks.json.
+type Set struct {
+	Keys []Key `json:"keys"`
+}
+
+// AlgForKey returns the JWS algorithm used with the given public key.
+func AlgForKey(pub crypto.PublicKey) (string, error) {
+	switch pub.(type) {
+	case *rsa.PublicKey:
+		return AlgRS256, nil
+	case ed25519.PublicKey:
+		return AlgEdDSA, nil
+	default:
+		return "", ErrUnsupportedKey
+	}
+}
+
+func FromPublicKey(kid string, pub crypto.PublicKey) (Key, error) {
+	switch k := pub.(type) {
+	case *rsa.PublicKey:
+		return Key{
+			Kty: "RSA",
+			Use: "sig",
+			Alg: AlgRS256,
+			Kid: kid,
+			N:   encode(k.N.Bytes()),
+			E:   encode(big.NewInt(int64(k.E)).Bytes()),
+		}, nil
+	case ed25519.PublicKey:
+		return Key{
+			Kty: "OKP",
+			Use: "sig",
+			Alg: AlgEdDSA,
+			Kid: kid,
+			Crv: "Ed25519",
+			X:   encode(k),
+		}, nil
+	default:
+		return Key{}, ErrUnsupportedKey
+	}
+}
+
+func (k Key) PublicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decode(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid modulus for key %q: %w", k.Kid, err)
+		}
+		e, err := decode(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exponent for key %q: %w", k.Kid, err)
+		}
+		exponent := new(big.Int).SetBytes(e)
+		if len(n) == 0 || !exponent.IsInt64() || exponent.Int64() < 3 || exponent.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("invalid rsa key %q", k.Kid)
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("%w: curve %q", ErrUnsupportedKey, k.Crv)
+		}
+		x, err := decode(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("invalid public key for key %q: %w", k.Kid, err)
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid ed25519 key %q", k.Kid)
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedKey, k.Kty)
+	}
+}
+
+func encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decode(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(s)
+}
@@ -0,0 +1,102 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	eventspb "github.com/khoihuynh300/go-microservice/shared/proto/events"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// The content type travels in a message header; messages without one are JSON.
+const (
+	ContentTypeHeader   = "content-type"
+	ContentTypeJSON     = "application/json"
+	ContentTypeProtobuf = "application/x-protobuf"
+)
+
+type jsonEnvelope struct {
+	EventID    string          `json:"event_id"`
+	EventType  string          `json:"event_type"`
+	Version    int             `json:"version"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	TraceID    string          `json:"trace_id"`
+	Data       json.RawMessage `json:"data"`
+}
+
+func Marshal(event *Event, contentType string) ([]byte, error) {
+	version := event.Version
+	if version <= 0 {
+		version = max(DefaultRegistry.Latest(event.EventType), 1)
+	}
+
+	switch contentType {
+	case "", ContentTypeJSON:
+		data, err := DefaultRegistry.marshalJSONData(event.EventType, version, event.Data)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(&jsonEnvelope{
+			EventID:    event.EventID,
+			EventType:  event.EventType,
+			Version:    version,
+			OccurredAt: event.OccurredAt,
+			TraceID:    event.TraceID,
+			Data:       data,
+		})
+
+	case ContentTypeProtobuf:
+		data, err := DefaultRegistry.marshalProtoData(event.EventType, version, event.Data)
+		if err != nil {
+			return nil, err
+		}
+		return proto.Marshal(&eventspb.EventEnvelope{
+			EventId:    event.EventID,
+			EventType:  event.EventType,
+			Version:    int32(version),
+			OccurredAt: timestamppb.New(event.OccurredAt),
+			TraceId:    event.TraceID,
+			Data:       data,
+		})
+
+	default:
+		return nil, fmt.Errorf("unsupported content type %q", contentType)
+	}
+}
+
+func Unmarshal(value []byte, contentType string) (*Event, error) {
+	switch contentType {
+	case "", ContentTypeJSON:
+		var envelope jsonEnvelope
+		if err := json.Unmarshal(value, &envelope); err != nil {
+			return nil, err
+		}
+		return &Event{
+			EventID:    envelope.EventID,
+			EventType:  envelope.EventType,
+			Version:    envelope.Version,
+			OccurredAt: envelope.OccurredAt,
+			TraceID:    envelope.TraceID,
+			Data:       envelope.Data,
+		}, nil
+
+	case ContentTypeProtobuf:
+		var envelope eventspb.EventEnvelope
+		if err := proto.Unmarshal(value, &envelope); err != nil {
+			return nil, err
+		}
+		return &Event{
+			EventID:    envelope.EventId,
+			EventType:  envelope.EventType,
+			Version:    int(envelope.Version),
+			OccurredAt: envelope.OccurredAt.AsTime(),
+			TraceID:    envelope.TraceId,
+			Data:       ProtoData(envelope.Data),
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported content type %q", contentType)
+	}
+}
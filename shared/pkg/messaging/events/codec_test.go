@@ -0,0 +1,189 @@
+package events_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/khoihuynh300/go-microservice/shared/pkg/messaging/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var testTime = timestamppb.New(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+
+// registeredPayloads holds a fully populated payload for every registered event type.
+var registeredPayloads = map[string]proto.Message{
+	events.TypeUserRegisteredEvent: &events.UserRegisteredEvent{
+		Email: "test@gmail.com", FullName: "Test User", Token: "verify-token",
+	},
+	events.TypeEmailVerifySuccessEvent: &events.EmailVerifySuccessEvent{
+		Email: "test@gmail.com",
+	},
+	events.TypeForgotPasswordEvent: &events.UserForgotPasswordEvent{
+		Email: "test@gmail.com", FullName: "Test User", Token: "reset-token",
+	},
+	events.TypePasswordResetSuccessEvent: &events.UserPasswordResetSuccessEvent{
+		Email: "test@gmail.com",
+	},
+	events.TypeAccountLockedEvent: &events.AccountLockedEvent{
+		Email: "test@gmail.com", FullName: "Test User", Token: "unlock-token", IpAddress: "10.0.0.1", LockedUntil: testTime,
+	},
+	events.TypeRefreshTokenReusedEvent: &events.RefreshTokenReusedEvent{
+		UserId: "user-1", Email: "test@gmail.com", FullName: "Test User", SessionId: "session-1",
+		IpAddress: "10.0.0.1", UserAgent: "curl/8.0", DetectedAt: testTime,
+	},
+	events.TypeEmailChangeRequestedEvent: &events.EmailChangeRequestedEvent{
+		UserId: "user-1", FullName: "Test User", OldEmail: "old@gmail.com", NewEmail: "new@gmail.com", Token: "change-token",
+	},
+	events.TypeEmailChangedEvent: &events.EmailChangedEvent{
+		UserId: "user-1", FullName: "Test User", OldEmail: "old@gmail.com", NewEmail: "new@gmail.com", ChangedAt: testTime,
+	},
+	events.TypeMagicLinkRequestedEvent: &events.MagicLinkRequestedEvent{
+		UserId: "user-1", Email: "test@gmail.com", FullName: "Test User", Token: "magic-token",
+	},
+	events.TypePhoneVerificationRequestedEvent: &events.PhoneVerificationRequestedEvent{
+		UserId: "user-1", Phone: "+84901234567", Code: "123456", ExpiresAt: testTime,
+	},
+	events.TypeDataExportReadyEvent: &events.DataExportReadyEvent{
+		UserId: "user-1", Email: "test@gmail.com", FullName: "Test User", DownloadUrl: "https://example.com/export", ExpiresAt: testTime,
+	},
+	events.TypeUserSuspendedEvent: &events.UserSuspendedEvent{
+		UserId: "user-1", Email: "test@gmail.com", FullName: "Test User", Reason: "abuse", SuspendedAt: testTime,
+	},
+	events.TypeUserReactivatedEvent: &events.UserReactivatedEvent{
+		UserId: "user-1", Email: "test@gmail.com", FullName: "Test User", ReactivatedAt: testTime,
+	},
+	events.TypePasswordResetForcedEvent: &events.PasswordResetForcedEvent{
+		UserId: "user-1", Email: "test@gmail.com", FullName: "Test User", Token: "reset-token",
+	},
+	events.TypeSessionsRevokedEvent: &events.SessionsRevokedEvent{
+		UserId: "user-1", Email: "test@gmail.com", FullName: "Test User", RevokedAt: testTime,
+	},
+}
+
+func TestCodec_RoundTrip(t *testing.T) {
+	for _, contentType := range []string{events.ContentTypeJSON, events.ContentTypeProtobuf} {
+		for eventType, payload := range registeredPayloads {
+			t.Run(contentType+"/"+eventType, func(t *testing.T) {
+				event := &events.Event{
+					EventID:    "event-1",
+					EventType:  eventType,
+					OccurredAt: testTime.AsTime(),
+					TraceID:    "trace-1",
+					Data:       payload,
+				}
+
+				value, err := events.Marshal(event, contentType)
+				require.NoError(t, err)
+
+				decoded, err := events.Unmarshal(value, contentType)
+				require.NoError(t, err)
+				assert.Equal(t, "event-1", decoded.EventID)
+				assert.Equal(t, eventType, decoded.EventType)
+				assert.Equal(t, events.DefaultRegistry.Latest(eventType), decoded.Version)
+				assert.True(t, testTime.AsTime().Equal(decoded.OccurredAt))
+				assert.Equal(t, "trace-1", decoded.TraceID)
+
+				msg, err := events.DefaultRegistry.Decode(decoded)
+				require.NoError(t, err)
+				assertProtoEqual(t, payload, msg)
+			})
+		}
+	}
+}
+
+func TestCodec_RegisteredPayloadsCoverRegistry(t *testing.T) {
+	for eventType, payload := range registeredPayloads {
+		msg, err := events.DefaultRegistry.New(eventType, events.DefaultRegistry.Latest(eventType))
+		require.NoError(t, err, eventType)
+		assert.Equal(t, payload.ProtoReflect().Descriptor().FullName(), msg.ProtoReflect().Descriptor().FullName(), eventType)
+	}
+}
+
+func TestCodec_CrossContentType(t *testing.T) {
+	payload := registeredPayloads[events.TypeSessionsRevokedEvent]
+	event := &events.Event{EventID: "event-1", EventType: events.TypeSessionsRevokedEvent, Data: payload}
+
+	value, err := events.Marshal(event, events.ContentTypeProtobuf)
+	require.NoError(t, err)
+	decoded, err := events.Unmarshal(value, events.ContentTypeProtobuf)
+	require.NoError(t, err)
+
+	// a payload still in protobuf form can be re-encoded as JSON, as the DLQ tool does
+	value, err = events.Marshal(decoded, events.ContentTypeJSON)
+	require.NoError(t, err)
+	decoded, err = events.Unmarshal(value, events.ContentTypeJSON)
+	require.NoError(t, err)
+
+	msg, err := events.Decode[*events.SessionsRevokedEvent](decoded)
+	require.NoError(t, err)
+	assertProtoEqual(t, payload, msg)
+}
+
+func TestCodec_UnsupportedContentType(t *testing.T) {
+	_, err := events.Marshal(&events.Event{EventType: events.TypeUserRegisteredEvent}, "text/plain")
+	assert.Error(t, err)
+
+	_, err = events.Unmarshal([]byte("{}"), "text/plain")
+	assert.Error(t, err)
+}
+
+func TestCodec_LegacyMessages(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected proto.Message
+	}{
+		{
+			name:     "User Registered",
+			value:    `{"event_id":"event-1","event_type":"user.registered","occurred_at":"2025-06-01T10:00:00Z","trace_id":"trace-1","data":{"email":"test@gmail.com","full_name":"Test User","token":"verify-token"}}`,
+			expected: &events.UserRegisteredEvent{Email: "test@gmail.com", FullName: "Test User", Token: "verify-token"},
+		},
+		{
+			name:     "Email Verified",
+			value:    `{"event_id":"event-2","event_type":"user.email_verified","occurred_at":"2025-06-01T10:00:00Z","trace_id":"","data":{"email":"test@gmail.com"}}`,
+			expected: &events.EmailVerifySuccessEvent{Email: "test@gmail.com"},
+		},
+		{
+			name:     "Forgot Password",
+			value:    `{"event_id":"event-3","event_type":"user.forgot_password","occurred_at":"2025-06-01T10:00:00Z","trace_id":"","data":{"email":"test@gmail.com","full_name":"Test User","token":"reset-token"}}`,
+			expected: &events.UserForgotPasswordEvent{Email: "test@gmail.com", FullName: "Test User", Token: "reset-token"},
+		},
+		{
+			name:     "Password Reset Success",
+			value:    `{"event_id":"event-4","event_type":"user.password_reset_success","occurred_at":"2025-06-01T10:00:00Z","trace_id":"","data":{"email":"test@gmail.com"}}`,
+			expected: &events.UserPasswordResetSuccessEvent{Email: "test@gmail.com"},
+		},
+		{
+			name:     "Null And Unknown Fields",
+			value:    `{"event_id":"event-5","event_type":"user.registered","occurred_at":"2025-06-01T10:00:00Z","trace_id":"","data":{"email":"test@gmail.com","full_name":null,"token":"verify-token","user_id":"user-1"}}`,
+			expected: &events.UserRegisteredEvent{Email: "test@gmail.com", Token: "verify-token"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// messages from before versioning carry no content type header
+			event, err := events.Unmarshal([]byte(tt.value), "")
+			require.NoError(t, err)
+			assert.Equal(t, events.LegacyVersion, event.SchemaVersion())
+
+			msg, err := events.DefaultRegistry.Decode(event)
+			require.NoError(t, err)
+			assertProtoEqual(t, tt.expected, msg)
+		})
+	}
+}
+
+func TestCodec_LegacyMessageWithoutUpcaster(t *testing.T) {
+	event := &events.Event{
+		EventType: events.TypeSessionsRevokedEvent,
+		Data:      json.RawMessage(`{"user_id":"user-1"}`),
+	}
+
+	_, err := events.DefaultRegistry.Decode(event)
+	assert.ErrorIs(t, err, events.ErrUnsupportedVersion)
+}
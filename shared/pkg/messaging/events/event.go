@@ -2,10 +2,29 @@ package events
 
 import "time"
 
+// LegacyVersion is the schema version of events published before the envelope
+// carried one.
+const LegacyVersion = 0
+
+// Event is the envelope for every message on the bus. Version is the schema
+// version of Data for EventType; events written before versioning are at
+// LegacyVersion and reach the current schema through an upcaster.
+//
+// When publishing, Data is the payload message. After Unmarshal it holds the
+// still-encoded payload (json.RawMessage or ProtoData); use Decode to get the
+// typed payload at its latest version.
 type Event struct {
 	EventID    string    `json:"event_id"`
 	EventType  string    `json:"event_type"`
+	Version    int       `json:"version,omitempty"`
 	OccurredAt time.Time `json:"occurred_at"`
 	TraceID    string    `json:"trace_id"`
 	Data       any       `json:"data"`
 }
+
+// ProtoData is a payload still in protobuf wire format.
+type ProtoData []byte
+
+func (e *Event) SchemaVersion() int {
+	return max(e.Version, LegacyVersion)
+}
@@ -1,8 +1,19 @@
 package events
 
 const (
-	TypeUserRegisteredEvent       = "user.registered"
-	TypeEmailVerifySuccessEvent   = "user.email_verified"
-	TypeForgotPasswordEvent       = "user.forgot_password"
-	TypePasswordResetSuccessEvent = "user.password_reset_success"
+	TypeUserRegisteredEvent             = "user.registered"
+	TypeEmailVerifySuccessEvent         = "user.email_verified"
+	TypeForgotPasswordEvent             = "user.forgot_password"
+	TypePasswordResetSuccessEvent       = "user.password_reset_success"
+	TypeAccountLockedEvent              = "user.account_locked"
+	TypeRefreshTokenReusedEvent         = "user.refresh_token_reused"
+	TypeEmailChangeRequestedEvent       = "user.email_change_requested"
+	TypeEmailChangedEvent               = "user.email_changed"
+	TypeMagicLinkRequestedEvent         = "user.magic_link_requested"
+	TypePhoneVerificationRequestedEvent = "user.phone_verification_requested"
+	TypeDataExportReadyEvent            = "user.data_export_ready"
+	TypeUserSuspendedEvent              = "user.suspended"
+	TypeUserReactivatedEvent            = "user.reactivated"
+	TypePasswordResetForcedEvent        = "user.password_reset_forced"
+	TypeSessionsRevokedEvent            = "user.sessions_revoked"
 )
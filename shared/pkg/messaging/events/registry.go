@@ -0,0 +1,222 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	ErrUnknownEventType     = errors.New("unknown event type")
+	ErrUnsupportedVersion   = errors.New("unsupported event version")
+	ErrUnexpectedDataFormat = errors.New("unexpected event data format")
+)
+
+var (
+	protoJSONMarshal   = protojson.MarshalOptions{UseProtoNames: true}
+	protoJSONUnmarshal = protojson.UnmarshalOptions{DiscardUnknown: true}
+)
+
+// Upcaster rewrites a payload from one schema version to the next. It works on
+// the JSON form so old versions don't need to stay compiled in.
+type Upcaster func(data map[string]any) (map[string]any, error)
+
+type schemaKey struct {
+	eventType string
+	version   int
+}
+
+// Registry maps an event type and schema version to its payload message.
+type Registry struct {
+	mu        sync.RWMutex
+	factories map[schemaKey]func() proto.Message
+	upcasters map[schemaKey]Upcaster
+	latest    map[string]int
+}
+
+var DefaultRegistry = NewRegistry()
+
+func NewRegistry() *Registry {
+	return &Registry{
+		factories: make(map[schemaKey]func() proto.Message),
+		upcasters: make(map[schemaKey]Upcaster),
+		latest:    make(map[string]int),
+	}
+}
+
+func (r *Registry) Register(eventType string, version int, factory func() proto.Message) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.factories[schemaKey{eventType, version}] = factory
+	if version > r.latest[eventType] {
+		r.latest[eventType] = version
+	}
+}
+
+// RegisterUpcaster registers the step from fromVersion to fromVersion+1.
+func (r *Registry) RegisterUpcaster(eventType string, fromVersion int, upcast Upcaster) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.upcasters[schemaKey{eventType, fromVersion}] = upcast
+}
+
+// Latest returns the newest registered version of eventType, or 0 if unknown.
+func (r *Registry) Latest(eventType string) int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.latest[eventType]
+}
+
+func (r *Registry) New(eventType string, version int) (proto.Message, error) {
+	r.mu.RLock()
+	factory, ok := r.factories[schemaKey{eventType, version}]
+	r.mu.RUnlock()
+
+	if !ok {
+		if r.Latest(eventType) == 0 {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownEventType, eventType)
+		}
+		return nil, fmt.Errorf("%w: %s v%d", ErrUnsupportedVersion, eventType, version)
+	}
+	return factory(), nil
+}
+
+// Decode returns the event payload at the latest registered version, upcasting
+// older payloads on the way.
+func (r *Registry) Decode(event *Event) (proto.Message, error) {
+	latest := r.Latest(event.EventType)
+	if latest == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEventType, event.EventType)
+	}
+
+	version := event.SchemaVersion()
+	if version > latest {
+		return nil, fmt.Errorf("%w: %s v%d", ErrUnsupportedVersion, event.EventType, version)
+	}
+
+	if version == latest {
+		return r.decodeVersion(event.EventType, version, event.Data)
+	}
+
+	data, err := r.toJSONMap(event.EventType, version, event.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	for v := version; v < latest; v++ {
+		r.mu.RLock()
+		upcast, ok := r.upcasters[schemaKey{event.EventType, v}]
+		r.mu.RUnlock()
+		if !ok {
+			return nil, fmt.Errorf("%w: no upcaster for %s v%d", ErrUnsupportedVersion, event.EventType, v)
+		}
+		if data, err = upcast(data); err != nil {
+			return nil, fmt.Errorf("upcast %s v%d: %w", event.EventType, v, err)
+		}
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return r.decodeVersion(event.EventType, latest, json.RawMessage(raw))
+}
+
+func (r *Registry) decodeVersion(eventType string, version int, data any) (proto.Message, error) {
+	msg, err := r.New(eventType, version)
+	if err != nil {
+		return nil, err
+	}
+
+	switch d := data.(type) {
+	case json.RawMessage:
+		err = protoJSONUnmarshal.Unmarshal(d, msg)
+	case ProtoData:
+		err = proto.Unmarshal(d, msg)
+	case proto.Message:
+		if d.ProtoReflect().Descriptor() != msg.ProtoReflect().Descriptor() {
+			return nil, fmt.Errorf("%w: %T for %s v%d", ErrUnexpectedDataFormat, d, eventType, version)
+		}
+		return d, nil
+	default:
+		var raw []byte
+		if raw, err = json.Marshal(d); err == nil {
+			err = protoJSONUnmarshal.Unmarshal(raw, msg)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnexpectedDataFormat, err)
+	}
+	return msg, nil
+}
+
+func (r *Registry) toJSONMap(eventType string, version int, data any) (map[string]any, error) {
+	raw, err := r.marshalJSONData(eventType, version, data)
+	if err != nil {
+		return nil, err
+	}
+
+	var m map[string]any
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnexpectedDataFormat, err)
+	}
+	return m, nil
+}
+
+func (r *Registry) marshalJSONData(eventType string, version int, data any) (json.RawMessage, error) {
+	switch d := data.(type) {
+	case json.RawMessage:
+		return d, nil
+	case ProtoData:
+		msg, err := r.decodeVersion(eventType, version, d)
+		if err != nil {
+			return nil, err
+		}
+		return protoJSONMarshal.Marshal(msg)
+	case proto.Message:
+		return protoJSONMarshal.Marshal(d)
+	default:
+		return json.Marshal(d)
+	}
+}
+
+func (r *Registry) marshalProtoData(eventType string, version int, data any) ([]byte, error) {
+	if d, ok := data.(ProtoData); ok {
+		return d, nil
+	}
+
+	msg, err := r.decodeVersion(eventType, version, data)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(msg)
+}
+
+// Decode returns the typed payload of event from the DefaultRegistry.
+func Decode[T proto.Message](event *Event) (T, error) {
+	var zero T
+
+	msg, err := DefaultRegistry.Decode(event)
+	if err != nil {
+		return zero, err
+	}
+
+	payload, ok := msg.(T)
+	if !ok {
+		return zero, fmt.Errorf("%w: %s decodes to %T, not %T", ErrUnexpectedDataFormat, event.EventType, msg, zero)
+	}
+	return payload, nil
+}
+
+// MarshalData encodes a payload as JSON the same way Marshal does, for callers
+// that store payloads before they are published.
+func MarshalData(eventType string, data any) (json.RawMessage, error) {
+	return DefaultRegistry.marshalJSONData(eventType, DefaultRegistry.Latest(eventType), data)
+}
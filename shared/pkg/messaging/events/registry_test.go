@@ -0,0 +1,128 @@
+package events_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/khoihuynh300/go-microservice/shared/pkg/messaging/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+const testEventType = "test.email_changed"
+
+func assertProtoEqual(t *testing.T, expected, actual proto.Message) {
+	t.Helper()
+	assert.True(t, proto.Equal(expected, actual), "expected %v, got %v", expected, actual)
+}
+
+// newTestRegistry registers EmailVerifySuccessEvent as v1 and EmailChangedEvent
+// as v2 of testEventType, with an upcaster that renames email to new_email.
+func newTestRegistry() *events.Registry {
+	registry := events.NewRegistry()
+	registry.Register(testEventType, 1, func() proto.Message { return &events.EmailVerifySuccessEvent{} })
+	registry.Register(testEventType, 2, func() proto.Message { return &events.EmailChangedEvent{} })
+	registry.RegisterUpcaster(testEventType, 1, func(data map[string]any) (map[string]any, error) {
+		return map[string]any{"new_email": data["email"]}, nil
+	})
+	return registry
+}
+
+func TestRegistry_Decode(t *testing.T) {
+	tests := []struct {
+		name          string
+		event         *events.Event
+		expected      proto.Message
+		expectedError error
+	}{
+		{
+			name:     "Latest Version",
+			event:    &events.Event{EventType: testEventType, Version: 2, Data: json.RawMessage(`{"new_email":"new@gmail.com"}`)},
+			expected: &events.EmailChangedEvent{NewEmail: "new@gmail.com"},
+		},
+		{
+			name:     "Upcast From Previous Version",
+			event:    &events.Event{EventType: testEventType, Version: 1, Data: json.RawMessage(`{"email":"new@gmail.com"}`)},
+			expected: &events.EmailChangedEvent{NewEmail: "new@gmail.com"},
+		},
+		{
+			name:     "Upcast Typed Payload",
+			event:    &events.Event{EventType: testEventType, Version: 1, Data: &events.EmailVerifySuccessEvent{Email: "new@gmail.com"}},
+			expected: &events.EmailChangedEvent{NewEmail: "new@gmail.com"},
+		},
+		{
+			name:          "Newer Than Latest",
+			event:         &events.Event{EventType: testEventType, Version: 3, Data: json.RawMessage(`{}`)},
+			expectedError: events.ErrUnsupportedVersion,
+		},
+		{
+			name:          "Missing Upcaster",
+			event:         &events.Event{EventType: testEventType, Version: events.LegacyVersion, Data: json.RawMessage(`{}`)},
+			expectedError: events.ErrUnsupportedVersion,
+		},
+		{
+			name:          "Unknown Event Type",
+			event:         &events.Event{EventType: "test.unknown", Version: 1, Data: json.RawMessage(`{}`)},
+			expectedError: events.ErrUnknownEventType,
+		},
+		{
+			name:          "Wrong Payload Type",
+			event:         &events.Event{EventType: testEventType, Version: 2, Data: &events.EmailVerifySuccessEvent{}},
+			expectedError: events.ErrUnexpectedDataFormat,
+		},
+		{
+			name:          "Malformed JSON",
+			event:         &events.Event{EventType: testEventType, Version: 2, Data: json.RawMessage(`{"new_email":1}`)},
+			expectedError: events.ErrUnexpectedDataFormat,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, err := newTestRegistry().Decode(tt.event)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assertProtoEqual(t, tt.expected, msg)
+		})
+	}
+}
+
+func TestRegistry_UpcasterError(t *testing.T) {
+	errUpcast := errors.New("missing email")
+	registry := newTestRegistry()
+	registry.RegisterUpcaster(testEventType, 1, func(data map[string]any) (map[string]any, error) {
+		return nil, errUpcast
+	})
+
+	_, err := registry.Decode(&events.Event{EventType: testEventType, Version: 1, Data: json.RawMessage(`{}`)})
+
+	assert.ErrorIs(t, err, errUpcast)
+}
+
+func TestRegistry_Latest(t *testing.T) {
+	registry := newTestRegistry()
+
+	assert.Equal(t, 2, registry.Latest(testEventType))
+	assert.Equal(t, 0, registry.Latest("test.unknown"))
+}
+
+func TestDecode_TypeMismatch(t *testing.T) {
+	event := &events.Event{
+		EventType: events.TypeEmailVerifySuccessEvent,
+		Version:   1,
+		Data:      json.RawMessage(`{"email":"test@gmail.com"}`),
+	}
+
+	payload, err := events.Decode[*events.EmailVerifySuccessEvent](event)
+	require.NoError(t, err)
+	assert.Equal(t, "test@gmail.com", payload.GetEmail())
+
+	_, err = events.Decode[*events.UserRegisteredEvent](event)
+	assert.ErrorIs(t, err, events.ErrUnexpectedDataFormat)
+}
@@ -1,21 +1,61 @@
 package events
 
-type UserRegisteredEvent struct {
-	Email    string `json:"email"`
-	FullName string `json:"full_name"`
-	Token    string `json:"token"`
-}
+import (
+	eventspb "github.com/khoihuynh300/go-microservice/shared/proto/events"
+	"google.golang.org/protobuf/proto"
+)
 
-type EmailVerifySuccessEvent struct {
-	Email string `json:"email"`
-}
+type (
+	UserRegisteredEvent             = eventspb.UserRegisteredEvent
+	EmailVerifySuccessEvent         = eventspb.EmailVerifySuccessEvent
+	UserForgotPasswordEvent         = eventspb.UserForgotPasswordEvent
+	UserPasswordResetSuccessEvent   = eventspb.UserPasswordResetSuccessEvent
+	AccountLockedEvent              = eventspb.AccountLockedEvent
+	RefreshTokenReusedEvent         = eventspb.RefreshTokenReusedEvent
+	EmailChangeRequestedEvent       = eventspb.EmailChangeRequestedEvent
+	EmailChangedEvent               = eventspb.EmailChangedEvent
+	MagicLinkRequestedEvent         = eventspb.MagicLinkRequestedEvent
+	PhoneVerificationRequestedEvent = eventspb.PhoneVerificationRequestedEvent
+	DataExportReadyEvent            = eventspb.DataExportReadyEvent
+	UserSuspendedEvent              = eventspb.UserSuspendedEvent
+	UserReactivatedEvent            = eventspb.UserReactivatedEvent
+	PasswordResetForcedEvent        = eventspb.PasswordResetForcedEvent
+	SessionsRevokedEvent            = eventspb.SessionsRevokedEvent
+)
+
+func init() {
+	DefaultRegistry.Register(TypeUserRegisteredEvent, 1, func() proto.Message { return &UserRegisteredEvent{} })
+	DefaultRegistry.Register(TypeEmailVerifySuccessEvent, 1, func() proto.Message { return &EmailVerifySuccessEvent{} })
+	DefaultRegistry.Register(TypeForgotPasswordEvent, 1, func() proto.Message { return &UserForgotPasswordEvent{} })
+	DefaultRegistry.Register(TypePasswordResetSuccessEvent, 1, func() proto.Message { return &UserPasswordResetSuccessEvent{} })
+	DefaultRegistry.Register(TypeAccountLockedEvent, 1, func() proto.Message { return &AccountLockedEvent{} })
+	DefaultRegistry.Register(TypeRefreshTokenReusedEvent, 1, func() proto.Message { return &RefreshTokenReusedEvent{} })
+	DefaultRegistry.Register(TypeEmailChangeRequestedEvent, 1, func() proto.Message { return &EmailChangeRequestedEvent{} })
+	DefaultRegistry.Register(TypeEmailChangedEvent, 1, func() proto.Message { return &EmailChangedEvent{} })
+	DefaultRegistry.Register(TypeMagicLinkRequestedEvent, 1, func() proto.Message { return &MagicLinkRequestedEvent{} })
+	DefaultRegistry.Register(TypePhoneVerificationRequestedEvent, 1, func() proto.Message { return &PhoneVerificationRequestedEvent{} })
+	DefaultRegistry.Register(TypeDataExportReadyEvent, 1, func() proto.Message { return &DataExportReadyEvent{} })
+	DefaultRegistry.Register(TypeUserSuspendedEvent, 1, func() proto.Message { return &UserSuspendedEvent{} })
+	DefaultRegistry.Register(TypeUserReactivatedEvent, 1, func() proto.Message { return &UserReactivatedEvent{} })
+	DefaultRegistry.Register(TypePasswordResetForcedEvent, 1, func() proto.Message { return &PasswordResetForcedEvent{} })
+	DefaultRegistry.Register(TypeSessionsRevokedEvent, 1, func() proto.Message { return &SessionsRevokedEvent{} })
 
-type UserForgotPasswordEvent struct {
-	Email    string `json:"email"`
-	FullName string `json:"full_name"`
-	Token    string `json:"token"`
+	// event types that were published before the envelope was versioned
+	DefaultRegistry.RegisterUpcaster(TypeUserRegisteredEvent, LegacyVersion, upcastLegacyUserEvent)
+	DefaultRegistry.RegisterUpcaster(TypeEmailVerifySuccessEvent, LegacyVersion, upcastLegacyUserEvent)
+	DefaultRegistry.RegisterUpcaster(TypeForgotPasswordEvent, LegacyVersion, upcastLegacyUserEvent)
+	DefaultRegistry.RegisterUpcaster(TypePasswordResetSuccessEvent, LegacyVersion, upcastLegacyUserEvent)
 }
 
-type UserPasswordResetSuccessEvent struct {
-	Email string `json:"email"`
+// upcastLegacyUserEvent brings an unversioned payload to v1. Those payloads
+// were plain Go structs whose JSON names already match the v1 proto fields, so
+// only fields v1 does not know are dropped and null values are cleared.
+func upcastLegacyUserEvent(data map[string]any) (map[string]any, error) {
+	upcasted := make(map[string]any, len(data))
+	for _, field := range []string{"email", "full_name", "token"} {
+		if value, ok := data[field]; ok && value != nil {
+			upcasted[field] = value
+		}
+	}
+	return upcasted, nil
 }
@@ -0,0 +1,111 @@
+package idempotency_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/khoihuynh300/go-microservice/shared/pkg/cache"
+	"github.com/redis/go-redis/v9"
+)
+
+type fakeEntry struct {
+	value     string
+	expiresAt time.Time
+}
+
+// fakeCache is an in-memory cache.Cache with a manual clock. Only the methods
+// used by the store are implemented.
+type fakeCache struct {
+	cache.Cache
+
+	mu      sync.Mutex
+	now     time.Time
+	entries map[string]fakeEntry
+	err     error
+}
+
+func newFakeCache() *fakeCache {
+	return &fakeCache{
+		now:     time.Now(),
+		entries: make(map[string]fakeEntry),
+	}
+}
+
+func (c *fakeCache) advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func (c *fakeCache) failWith(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.err = err
+}
+
+// lookup returns the live entry for key. c.mu must be held.
+func (c *fakeCache) lookup(key string) (fakeEntry, bool) {
+	entry, ok := c.entries[key]
+	if ok && !c.now.Before(entry.expiresAt) {
+		delete(c.entries, key)
+		return fakeEntry{}, false
+	}
+	return entry, ok
+}
+
+func (c *fakeCache) value(key string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.lookup(key)
+	return entry.value, ok
+}
+
+func (c *fakeCache) Set(ctx context.Context, key string, value any, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return c.err
+	}
+	c.entries[key] = fakeEntry{value: fmt.Sprint(value), expiresAt: c.now.Add(ttl)}
+	return nil
+}
+
+func (c *fakeCache) Get(ctx context.Context, key string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return "", c.err
+	}
+	entry, ok := c.lookup(key)
+	if !ok {
+		return "", redis.Nil
+	}
+	return entry.value, nil
+}
+
+func (c *fakeCache) SetNX(ctx context.Context, key string, value any, ttl time.Duration) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return false, c.err
+	}
+	if _, ok := c.lookup(key); ok {
+		return false, nil
+	}
+	c.entries[key] = fakeEntry{value: fmt.Sprint(value), expiresAt: c.now.Add(ttl)}
+	return true, nil
+}
+
+func (c *fakeCache) Delete(ctx context.Context, keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return c.err
+	}
+	for _, key := range keys {
+		delete(c.entries, key)
+	}
+	return nil
+}
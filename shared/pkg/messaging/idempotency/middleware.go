@@ -0,0 +1,76 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	zaplogger "github.com/khoihuynh300/go-microservice/shared/pkg/logger"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/messaging/events"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/messaging/kafka"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+)
+
+var ErrEventInProgress = errors.New("event is being processed by another consumer")
+
+var duplicatesSkipped = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "kafka_consumer_duplicate_events_skipped_total",
+	Help: "Events skipped because they were already processed.",
+}, []string{"consumer", "event_type"})
+
+type Config struct {
+	// Consumer namespaces the records, so different consumer groups handling the
+	// same event do not skip each other's work.
+	Consumer string
+	// Lease bounds how long an event stays claimed if the handler never finishes.
+	Lease time.Duration
+	// TTL is how long a processed event is remembered.
+	TTL time.Duration
+}
+
+// Middleware skips events whose EventID has already been processed. A
+// redelivery that arrives while the first delivery is still running fails with
+// ErrEventInProgress, so the consumer's retry policy picks it up later.
+func Middleware(store Store, cfg Config) func(kafka.MessageHandler) kafka.MessageHandler {
+	return func(next kafka.MessageHandler) kafka.MessageHandler {
+		return func(ctx context.Context, event *events.Event) error {
+			if event.EventID == "" {
+				return next(ctx, event)
+			}
+
+			logger := zaplogger.FromContext(ctx)
+
+			result, err := store.Claim(ctx, cfg.Consumer, event.EventID, cfg.Lease)
+			if err != nil {
+				return fmt.Errorf("failed to claim event: %w", err)
+			}
+
+			switch result {
+			case AlreadyProcessed:
+				duplicatesSkipped.WithLabelValues(cfg.Consumer, event.EventType).Inc()
+				logger.Info("Skipping duplicate event",
+					zap.String("event_id", event.EventID),
+					zap.String("event_type", event.EventType),
+				)
+				return nil
+			case InProgress:
+				return ErrEventInProgress
+			}
+
+			if err := next(ctx, event); err != nil {
+				if releaseErr := store.Release(ctx, cfg.Consumer, event.EventID); releaseErr != nil {
+					logger.Error("Failed to release event claim", zap.String("event_id", event.EventID), zap.Error(releaseErr))
+				}
+				return err
+			}
+
+			if err := store.MarkProcessed(ctx, cfg.Consumer, event.EventID, cfg.TTL); err != nil {
+				logger.Error("Failed to mark event processed", zap.String("event_id", event.EventID), zap.Error(err))
+			}
+			return nil
+		}
+	}
+}
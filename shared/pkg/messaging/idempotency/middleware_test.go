@@ -0,0 +1,155 @@
+package idempotency_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/khoihuynh300/go-microservice/shared/pkg/messaging/events"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/messaging/idempotency"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/messaging/kafka"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errHandler = errors.New("handler failed")
+
+var testConfig = idempotency.Config{
+	Consumer: testConsumer,
+	Lease:    testLease,
+	TTL:      testTTL,
+}
+
+func newEvent(eventID string) *events.Event {
+	return &events.Event{EventID: eventID, EventType: "user.registered"}
+}
+
+// countingHandler counts its calls and returns whatever result returns.
+func countingHandler(calls *atomic.Int32, result func() error) kafka.MessageHandler {
+	return func(ctx context.Context, event *events.Event) error {
+		calls.Add(1)
+		return result()
+	}
+}
+
+func TestMiddleware_FirstDelivery(t *testing.T) {
+	c := newFakeCache()
+	var calls atomic.Int32
+	handler := idempotency.Middleware(idempotency.NewRedisStore(c), testConfig)(countingHandler(&calls, func() error { return nil }))
+
+	require.NoError(t, handler(context.Background(), newEvent(testEventID)))
+
+	assert.Equal(t, int32(1), calls.Load())
+	value, ok := c.value(testKey)
+	require.True(t, ok)
+	assert.Equal(t, "processed", value)
+}
+
+func TestMiddleware_DuplicateAfterProcessing(t *testing.T) {
+	c := newFakeCache()
+	var calls atomic.Int32
+	handler := idempotency.Middleware(idempotency.NewRedisStore(c), testConfig)(countingHandler(&calls, func() error { return nil }))
+
+	require.NoError(t, handler(context.Background(), newEvent(testEventID)))
+	require.NoError(t, handler(context.Background(), newEvent(testEventID)))
+	require.NoError(t, handler(context.Background(), newEvent("event-2")))
+
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestMiddleware_ConcurrentDeliveryWhileInProgress(t *testing.T) {
+	c := newFakeCache()
+	store := idempotency.NewRedisStore(c)
+
+	started := make(chan struct{})
+	finish := make(chan struct{})
+	var calls atomic.Int32
+	handler := idempotency.Middleware(store, testConfig)(func(ctx context.Context, event *events.Event) error {
+		if calls.Add(1) == 1 {
+			close(started)
+			<-finish
+		}
+		return nil
+	})
+
+	firstDone := make(chan error)
+	go func() {
+		firstDone <- handler(context.Background(), newEvent(testEventID))
+	}()
+	<-started
+
+	err := handler(context.Background(), newEvent(testEventID))
+	assert.ErrorIs(t, err, idempotency.ErrEventInProgress)
+
+	close(finish)
+	require.NoError(t, <-firstDone)
+
+	// once the first delivery finished, the redelivery is a duplicate
+	require.NoError(t, handler(context.Background(), newEvent(testEventID)))
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestMiddleware_HandlerErrorReleasesClaim(t *testing.T) {
+	c := newFakeCache()
+	var calls atomic.Int32
+	fail := true
+	handler := idempotency.Middleware(idempotency.NewRedisStore(c), testConfig)(countingHandler(&calls, func() error {
+		if fail {
+			return errHandler
+		}
+		return nil
+	}))
+
+	err := handler(context.Background(), newEvent(testEventID))
+	assert.ErrorIs(t, err, errHandler)
+	_, ok := c.value(testKey)
+	assert.False(t, ok, "claim must be released")
+
+	fail = false
+	require.NoError(t, handler(context.Background(), newEvent(testEventID)))
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestMiddleware_LeaseExpiry(t *testing.T) {
+	c := newFakeCache()
+	store := idempotency.NewRedisStore(c)
+
+	// a delivery that crashed after claiming never releases the event
+	result, err := store.Claim(context.Background(), testConsumer, testEventID, testLease)
+	require.NoError(t, err)
+	require.Equal(t, idempotency.Claimed, result)
+
+	var calls atomic.Int32
+	handler := idempotency.Middleware(store, testConfig)(countingHandler(&calls, func() error { return nil }))
+
+	err = handler(context.Background(), newEvent(testEventID))
+	assert.ErrorIs(t, err, idempotency.ErrEventInProgress)
+	assert.Equal(t, int32(0), calls.Load())
+
+	c.advance(testLease)
+
+	require.NoError(t, handler(context.Background(), newEvent(testEventID)))
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestMiddleware_EventWithoutID(t *testing.T) {
+	c := newFakeCache()
+	var calls atomic.Int32
+	handler := idempotency.Middleware(idempotency.NewRedisStore(c), testConfig)(countingHandler(&calls, func() error { return nil }))
+
+	require.NoError(t, handler(context.Background(), newEvent("")))
+	require.NoError(t, handler(context.Background(), newEvent("")))
+
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestMiddleware_ClaimError(t *testing.T) {
+	c := newFakeCache()
+	c.failWith(errors.New("connection refused"))
+	var calls atomic.Int32
+	handler := idempotency.Middleware(idempotency.NewRedisStore(c), testConfig)(countingHandler(&calls, func() error { return nil }))
+
+	assert.Error(t, handler(context.Background(), newEvent(testEventID)))
+	assert.Equal(t, int32(0), calls.Load())
+}
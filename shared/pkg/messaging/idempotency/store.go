@@ -0,0 +1,78 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/khoihuynh300/go-microservice/shared/pkg/cache"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/const/rediskeys"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	stateProcessing = "processing"
+	stateProcessed  = "processed"
+)
+
+type ClaimResult int
+
+const (
+	Claimed ClaimResult = iota
+	AlreadyProcessed
+	InProgress
+)
+
+// Store records which events a consumer has handled. Claim takes a short lease
+// on an event before the handler runs; MarkProcessed turns it into a long-lived
+// record, and Release drops it so a failed event can be handled again.
+type Store interface {
+	Claim(ctx context.Context, consumer, eventID string, lease time.Duration) (ClaimResult, error)
+	MarkProcessed(ctx context.Context, consumer, eventID string, ttl time.Duration) error
+	Release(ctx context.Context, consumer, eventID string) error
+}
+
+type redisStore struct {
+	cache cache.Cache
+}
+
+func NewRedisStore(cache cache.Cache) Store {
+	return &redisStore{cache: cache}
+}
+
+func (s *redisStore) Claim(ctx context.Context, consumer, eventID string, lease time.Duration) (ClaimResult, error) {
+	key := processedKey(consumer, eventID)
+
+	ok, err := s.cache.SetNX(ctx, key, stateProcessing, lease)
+	if err != nil {
+		return 0, err
+	}
+	if ok {
+		return Claimed, nil
+	}
+
+	state, err := s.cache.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			// the lease expired between SetNX and Get; let the redelivery claim it
+			return InProgress, nil
+		}
+		return 0, err
+	}
+	if state == stateProcessed {
+		return AlreadyProcessed, nil
+	}
+	return InProgress, nil
+}
+
+func (s *redisStore) MarkProcessed(ctx context.Context, consumer, eventID string, ttl time.Duration) error {
+	return s.cache.Set(ctx, processedKey(consumer, eventID), stateProcessed, ttl)
+}
+
+func (s *redisStore) Release(ctx context.Context, consumer, eventID string) error {
+	return s.cache.Delete(ctx, processedKey(consumer, eventID))
+}
+
+func processedKey(consumer, eventID string) string {
+	return rediskeys.ProcessedEventPrefix + consumer + ":" + eventID
+}
@@ -0,0 +1,130 @@
+package idempotency_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/khoihuynh300/go-microservice/shared/pkg/const/rediskeys"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/messaging/idempotency"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testConsumer = "notification-service"
+	testEventID  = "event-1"
+	testLease    = time.Minute
+	testTTL      = 24 * time.Hour
+)
+
+var testKey = rediskeys.ProcessedEventPrefix + testConsumer + ":" + testEventID
+
+func TestRedisStore_Claim(t *testing.T) {
+	tests := []struct {
+		name     string
+		setup    func(ctx context.Context, c *fakeCache, store idempotency.Store)
+		expected idempotency.ClaimResult
+	}{
+		{
+			name:     "First Delivery",
+			setup:    func(ctx context.Context, c *fakeCache, store idempotency.Store) {},
+			expected: idempotency.Claimed,
+		},
+		{
+			name: "Claimed By Another Delivery",
+			setup: func(ctx context.Context, c *fakeCache, store idempotency.Store) {
+				_, _ = store.Claim(ctx, testConsumer, testEventID, testLease)
+			},
+			expected: idempotency.InProgress,
+		},
+		{
+			name: "Already Processed",
+			setup: func(ctx context.Context, c *fakeCache, store idempotency.Store) {
+				_, _ = store.Claim(ctx, testConsumer, testEventID, testLease)
+				_ = store.MarkProcessed(ctx, testConsumer, testEventID, testTTL)
+			},
+			expected: idempotency.AlreadyProcessed,
+		},
+		{
+			name: "Released",
+			setup: func(ctx context.Context, c *fakeCache, store idempotency.Store) {
+				_, _ = store.Claim(ctx, testConsumer, testEventID, testLease)
+				_ = store.Release(ctx, testConsumer, testEventID)
+			},
+			expected: idempotency.Claimed,
+		},
+		{
+			name: "Lease Expired",
+			setup: func(ctx context.Context, c *fakeCache, store idempotency.Store) {
+				_, _ = store.Claim(ctx, testConsumer, testEventID, testLease)
+				c.advance(testLease)
+			},
+			expected: idempotency.Claimed,
+		},
+		{
+			name: "Processed Record Expired",
+			setup: func(ctx context.Context, c *fakeCache, store idempotency.Store) {
+				_, _ = store.Claim(ctx, testConsumer, testEventID, testLease)
+				_ = store.MarkProcessed(ctx, testConsumer, testEventID, testTTL)
+				c.advance(testTTL)
+			},
+			expected: idempotency.Claimed,
+		},
+		{
+			name: "Other Consumer",
+			setup: func(ctx context.Context, c *fakeCache, store idempotency.Store) {
+				_, _ = store.Claim(ctx, "other-consumer", testEventID, testLease)
+				_ = store.MarkProcessed(ctx, "other-consumer", testEventID, testTTL)
+			},
+			expected: idempotency.Claimed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			c := newFakeCache()
+			store := idempotency.NewRedisStore(c)
+			tt.setup(ctx, c, store)
+
+			result, err := store.Claim(ctx, testConsumer, testEventID, testLease)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestRedisStore_Records(t *testing.T) {
+	ctx := context.Background()
+	c := newFakeCache()
+	store := idempotency.NewRedisStore(c)
+
+	_, err := store.Claim(ctx, testConsumer, testEventID, testLease)
+	require.NoError(t, err)
+	value, ok := c.value(testKey)
+	require.True(t, ok)
+	assert.Equal(t, "processing", value)
+
+	require.NoError(t, store.MarkProcessed(ctx, testConsumer, testEventID, testTTL))
+	value, ok = c.value(testKey)
+	require.True(t, ok)
+	assert.Equal(t, "processed", value)
+
+	// the processed record outlives the lease
+	c.advance(testLease)
+	_, ok = c.value(testKey)
+	assert.True(t, ok)
+}
+
+func TestRedisStore_ClaimError(t *testing.T) {
+	c := newFakeCache()
+	c.failWith(errors.New("connection refused"))
+	store := idempotency.NewRedisStore(c)
+
+	_, err := store.Claim(context.Background(), testConsumer, testEventID, testLease)
+
+	assert.Error(t, err)
+}
@@ -8,6 +8,8 @@ import (
 
 type Consumer interface {
 	RegisterHandler(topic string, handler MessageHandler)
+	RegisterHandlerWithRetry(topic string, handler MessageHandler, policy RetryPolicy)
+	SetWorkers(workers int)
 	Start(ctx context.Context, logger *zap.Logger) error
 	Close() error
 }
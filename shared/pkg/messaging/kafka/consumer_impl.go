@@ -2,84 +2,514 @@ package kafka
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/khoihuynh300/go-microservice/shared/pkg/const/contextkeys"
 	"github.com/khoihuynh300/go-microservice/shared/pkg/messaging/events"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/metrics"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/telemetry"
 	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
+const (
+	retryGroupSuffix = ".retry"
+	workerQueueSize  = 16
+	drainTimeout     = 30 * time.Second
+)
+
 type MessageHandler func(ctx context.Context, event *events.Event) error
 
+// messageReader and messageWriter are the parts of kafka.Reader and kafka.Writer
+// the consumer depends on.
+type messageReader interface {
+	FetchMessage(ctx context.Context) (kafka.Message, error)
+	CommitMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+type messageWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
 type KafkaConsumer struct {
-	reader   *kafka.Reader
-	handlers map[string]MessageHandler
+	brokers     []string
+	groupID     string
+	workers     int
+	reader      messageReader
+	retryReader messageReader
+	writer      messageWriter
+	handlers    map[string]MessageHandler
+	policies    map[string]RetryPolicy
+
+	started   atomic.Bool
+	done      chan struct{}
+	closeOnce sync.Once
+	closeErr  error
+}
+
+type trackedMessage struct {
+	msg        kafka.Message
+	generation int
 }
 
 func NewConsumer(brokers, topics []string, groupID string) Consumer {
 	return &KafkaConsumer{
+		brokers: brokers,
+		groupID: groupID,
+		workers: 1,
 		reader: kafka.NewReader(kafka.ReaderConfig{
 			Brokers:     brokers,
 			GroupTopics: topics,
 			GroupID:     groupID,
 		}),
+		writer: &kafka.Writer{
+			Addr:                   kafka.TCP(brokers...),
+			Balancer:               &kafka.Hash{},
+			AllowAutoTopicCreation: true,
+		},
 		handlers: make(map[string]MessageHandler),
+		policies: make(map[string]RetryPolicy),
+		done:     make(chan struct{}),
 	}
 }
 
+// SetWorkers sets how many messages are handled concurrently. It must be called
+// before Start.
+func (c *KafkaConsumer) SetWorkers(workers int) {
+	c.workers = max(workers, 1)
+}
+
 func (c *KafkaConsumer) RegisterHandler(topic string, handler MessageHandler) {
 	c.handlers[topic] = handler
 }
 
+// RegisterHandlerWithRetry sends failed messages through the topic's retry topic
+// with exponential backoff, and to its dead-letter topic once the policy is
+// exhausted.
+func (c *KafkaConsumer) RegisterHandlerWithRetry(topic string, handler MessageHandler, policy RetryPolicy) {
+	c.handlers[topic] = handler
+	c.policies[topic] = policy.withDefaults()
+}
+
 func (c *KafkaConsumer) Start(ctx context.Context, logger *zap.Logger) error {
-	for {
+	c.started.Store(true)
+	defer close(c.done)
+
+	// in-flight work keeps running after ctx is cancelled, for at most drainTimeout
+	workCtx, cancelWork := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelWork()
+	go func() {
 		select {
 		case <-ctx.Done():
-			return c.reader.Close()
-
-		default:
-			msg, err := c.reader.FetchMessage(ctx)
-			if err != nil {
-				if err == context.Canceled {
-					return nil
-				}
-				logger.Error("Error fetching message", zap.Error(err))
-				continue
-			}
+			sleep(workCtx, drainTimeout)
+			cancelWork()
+		case <-workCtx.Done():
+		}
+	}()
 
-			handler, exists := c.handlers[msg.Topic]
-			if !exists {
-				logger.Warn("No handler registered for topic", zap.String("topic", msg.Topic))
-				c.reader.CommitMessages(ctx, msg)
-				continue
-			}
+	var wg sync.WaitGroup
 
-			var event = &events.Event{}
-			err = json.Unmarshal(msg.Value, event)
-			if err != nil {
-				logger.Error("Failed to parse event", zap.Error(err))
-				if commitErr := c.reader.CommitMessages(ctx, msg); commitErr != nil {
-					logger.Error("Failed to commit invalid message", zap.Error(commitErr))
-				}
-				continue
-			}
+	if len(c.policies) > 0 {
+		retryTopics := make([]string, 0, len(c.policies))
+		for topic := range c.policies {
+			retryTopics = append(retryTopics, RetryTopic(topic))
+		}
+		c.retryReader = kafka.NewReader(kafka.ReaderConfig{
+			Brokers:     c.brokers,
+			GroupTopics: retryTopics,
+			GroupID:     c.groupID + retryGroupSuffix,
+		})
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.consumeRetries(ctx, workCtx, logger)
+		}()
+	}
 
-			logger := logger.With(zap.String("trace_id", event.TraceID))
-			ctx = context.WithValue(ctx, contextkeys.LoggerKey, logger)
+	c.consume(ctx, workCtx, logger)
+	wg.Wait()
+
+	return c.close()
+}
+
+// consume fetches messages and spreads them over the worker pool. Messages with
+// the same key always go to the same worker, so they are handled in order, while
+// offsets are committed only once every earlier message of the partition is done.
+func (c *KafkaConsumer) consume(ctx, workCtx context.Context, logger *zap.Logger) {
+	tracker := newOffsetTracker()
+	queues := make([]chan trackedMessage, c.workers)
+
+	var wg sync.WaitGroup
+	for i := range queues {
+		queues[i] = make(chan trackedMessage, workerQueueSize)
+		wg.Add(1)
+		go func(queue <-chan trackedMessage) {
+			defer wg.Done()
+			c.work(workCtx, logger, tracker, queue)
+		}(queues[i])
+	}
+
+	defer func() {
+		for _, queue := range queues {
+			close(queue)
+		}
+		wg.Wait()
+	}()
 
-			if err := handler(ctx, event); err != nil {
-				logger.Error("Error handling message", zap.String("topic", msg.Topic), zap.Error(err))
+	for {
+		msg, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
 			}
+			logger.Error("Error fetching message", zap.Error(err))
+			continue
+		}
+
+		metrics.SetKafkaLag(c.groupID, msg.Topic, msg.Partition, msg.HighWaterMark-msg.Offset-1)
+
+		tm := trackedMessage{msg: msg, generation: tracker.add(msg)}
+		select {
+		case queues[workerFor(msg, len(queues))] <- tm:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (c *KafkaConsumer) work(ctx context.Context, logger *zap.Logger, tracker *offsetTracker, queue <-chan trackedMessage) {
+	for tm := range queue {
+		msg := tm.msg
+
+		// a later message must not be handled ahead of one that is left
+		// uncommitted, since both are redelivered after a restart
+		if tracker.stopped(msg, tm.generation) {
+			continue
+		}
+
+		handler, exists := c.handlers[msg.Topic]
+		if !exists {
+			logger.Warn("No handler registered for topic", zap.String("topic", msg.Topic))
+		} else if err := c.process(ctx, logger, msg, msg.Topic, handler, 0); err != nil {
+			// forward retries until ctx ends, so the consumer is shutting down
+			logger.Error("Failed to forward message, stopping partition",
+				zap.String("topic", msg.Topic),
+				zap.Int("partition", msg.Partition),
+				zap.Int64("offset", msg.Offset),
+				zap.Error(err),
+			)
+			tracker.stop(msg, tm.generation)
+			continue
+		}
+
+		tracker.complete(msg, tm.generation, func(committable kafka.Message) {
+			c.commit(ctx, c.reader, committable, logger)
+		})
+	}
+}
+
+func workerFor(msg kafka.Message, workers int) int {
+	h := fnv.New32a()
+	if len(msg.Key) > 0 {
+		h.Write(msg.Key)
+	} else {
+		h.Write([]byte(strconv.FormatInt(msg.Offset, 10)))
+	}
+	return int(h.Sum32() % uint32(workers))
+}
+
+// consumeRetries handles messages from the retry topics. Every partition gets
+// its own worker that holds a message until its next attempt is due, so a long
+// backoff on one partition does not delay the others while each partition is
+// still processed in delivery order. Fetching only waits for a partition whose
+// worker has workerQueueSize messages queued.
+func (c *KafkaConsumer) consumeRetries(ctx, workCtx context.Context, logger *zap.Logger) {
+	queues := make(map[topicPartition]chan kafka.Message)
+
+	var wg sync.WaitGroup
+	defer func() {
+		for _, queue := range queues {
+			close(queue)
+		}
+		wg.Wait()
+	}()
 
-			if err := c.reader.CommitMessages(ctx, msg); err != nil {
-				logger.Error("Error committing message", zap.Error(err))
+	for {
+		msg, err := c.retryReader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
 			}
+			logger.Error("Error fetching retry message", zap.Error(err))
+			continue
+		}
+		metrics.SetKafkaLag(c.groupID+retryGroupSuffix, msg.Topic, msg.Partition, msg.HighWaterMark-msg.Offset-1)
+
+		tp := topicPartition{topic: msg.Topic, partition: msg.Partition}
+		queue, ok := queues[tp]
+		if !ok {
+			queue = make(chan kafka.Message, workerQueueSize)
+			queues[tp] = queue
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				c.workRetries(ctx, workCtx, logger, queue)
+			}()
+		}
+
+		select {
+		case queue <- msg:
+		case <-ctx.Done():
+			return
 		}
 	}
+}
+
+func (c *KafkaConsumer) workRetries(ctx, workCtx context.Context, logger *zap.Logger, queue <-chan kafka.Message) {
+	stopped := false
+	for msg := range queue {
+		// once a message is left uncommitted the rest of the partition is
+		// redelivered after a restart, so it is not handled out of order now
+		if stopped {
+			continue
+		}
+		stopped = !c.retry(ctx, workCtx, logger, msg)
+	}
+}
+
+// retry waits until msg is due and handles it. It returns false if the message
+// was left uncommitted.
+func (c *KafkaConsumer) retry(ctx, workCtx context.Context, logger *zap.Logger, msg kafka.Message) bool {
+	sourceTopic := HeaderValue(msg, HeaderOriginalTopic)
+	handler, exists := c.handlers[sourceTopic]
+	if !exists {
+		logger.Warn("No handler registered for retried topic", zap.String("topic", sourceTopic))
+		c.commit(workCtx, c.retryReader, msg, logger)
+		return true
+	}
+
+	if nextAttemptAt, err := time.Parse(time.RFC3339Nano, HeaderValue(msg, HeaderNextAttemptAt)); err == nil {
+		if !sleep(ctx, time.Until(nextAttemptAt)) {
+			return false
+		}
+	}
+
+	if err := c.process(workCtx, logger, msg, sourceTopic, handler, attemptsOf(msg)); err != nil {
+		logger.Error("Failed to forward retried message, stopping partition",
+			zap.String("topic", sourceTopic),
+			zap.Int("partition", msg.Partition),
+			zap.Int64("offset", msg.Offset),
+			zap.Error(err),
+		)
+		return false
+	}
+
+	c.commit(workCtx, c.retryReader, msg, logger)
+	return true
+}
+
+// process runs the handler and, when it fails, forwards the message to the retry
+// or dead-letter topic. It only returns an error if forwarding did not succeed.
+func (c *KafkaConsumer) process(
+	ctx context.Context,
+	logger *zap.Logger,
+	msg kafka.Message,
+	sourceTopic string,
+	handler MessageHandler,
+	attempts int,
+) error {
+	policy, retryable := c.policies[sourceTopic]
+
+	start := time.Now()
+	result := metrics.KafkaResultSuccess
+	defer func() {
+		metrics.ObserveKafkaMessage(c.groupID, sourceTopic, result, time.Since(start))
+	}()
+
+	event, err := events.Unmarshal(msg.Value, HeaderValue(msg, events.ContentTypeHeader))
+	if err != nil {
+		logger.Error("Failed to parse event", zap.String("topic", sourceTopic), zap.Error(err))
+		if !retryable {
+			result = metrics.KafkaResultFailure
+			return nil
+		}
+		result = metrics.KafkaResultDeadLetter
+		return c.deadLetter(ctx, logger, msg, sourceTopic, attempts+1, err)
+	}
+
+	handlerCtx := otel.GetTextMapPropagator().Extract(ctx, telemetry.KafkaHeaderCarrier{Headers: &msg.Headers})
+	handlerCtx, span := telemetry.Tracer().Start(handlerCtx, sourceTopic+" process",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "kafka"),
+			attribute.String("messaging.operation.type", "process"),
+			attribute.String("messaging.destination.name", sourceTopic),
+			attribute.String("messaging.message.id", event.EventID),
+			attribute.String("messaging.event.type", event.EventType),
+			attribute.Int("messaging.kafka.attempt", attempts+1),
+		),
+	)
+
+	logger = logger.With(zap.String("trace_id", event.TraceID))
+	handlerCtx = context.WithValue(handlerCtx, contextkeys.LoggerKey, logger)
+
+	err = handler(handlerCtx, event)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, err.Error())
+	}
+	span.End()
+	if err == nil {
+		return nil
+	}
+
+	attempts++
+	if !retryable {
+		result = metrics.KafkaResultFailure
+		logger.Error("Error handling message", zap.String("topic", sourceTopic), zap.Error(err))
+		return nil
+	}
+
+	if attempts >= policy.MaxAttempts {
+		result = metrics.KafkaResultDeadLetter
+		return c.deadLetter(ctx, logger, msg, sourceTopic, attempts, err)
+	}
+
+	result = metrics.KafkaResultRetry
+
+	nextAttemptAt := time.Now().Add(policy.Backoff(attempts))
+	logger.Warn("Error handling message, scheduling retry",
+		zap.String("topic", sourceTopic),
+		zap.String("event_id", event.EventID),
+		zap.Int("attempts", attempts),
+		zap.Time("next_attempt_at", nextAttemptAt),
+		zap.Error(err),
+	)
 
+	return c.forward(ctx, policy, RetryTopic(sourceTopic), msg, sourceTopic, map[string]string{
+		HeaderError:         err.Error(),
+		HeaderAttempts:      strconv.Itoa(attempts),
+		HeaderNextAttemptAt: nextAttemptAt.UTC().Format(time.RFC3339Nano),
+	})
 }
 
+func (c *KafkaConsumer) deadLetter(
+	ctx context.Context,
+	logger *zap.Logger,
+	msg kafka.Message,
+	sourceTopic string,
+	attempts int,
+	cause error,
+) error {
+	logger.Error("Message dead-lettered",
+		zap.String("topic", sourceTopic),
+		zap.Int("attempts", attempts),
+		zap.Error(cause),
+	)
+
+	return c.forward(ctx, c.policies[sourceTopic], DLQTopic(sourceTopic), msg, sourceTopic, map[string]string{
+		HeaderError:    cause.Error(),
+		HeaderAttempts: strconv.Itoa(attempts),
+		HeaderFailedAt: time.Now().UTC().Format(time.RFC3339Nano),
+	})
+}
+
+// forward writes a copy of msg to target, retrying until it succeeds or ctx is
+// cancelled. The original message must not be committed before this returns.
+func (c *KafkaConsumer) forward(
+	ctx context.Context,
+	policy RetryPolicy,
+	target string,
+	msg kafka.Message,
+	sourceTopic string,
+	headers map[string]string,
+) error {
+	headers[HeaderOriginalTopic] = sourceTopic
+	if msg.Topic == sourceTopic {
+		headers[HeaderOriginalPartition] = strconv.Itoa(msg.Partition)
+		headers[HeaderOriginalOffset] = strconv.FormatInt(msg.Offset, 10)
+	}
+
+	out := kafka.Message{
+		Topic:   target,
+		Key:     msg.Key,
+		Value:   msg.Value,
+		Headers: withHeaders(msg.Headers, headers),
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := c.writer.WriteMessages(ctx, out)
+		if err == nil {
+			return nil
+		}
+		if !sleep(ctx, policy.Backoff(attempt)) {
+			return fmt.Errorf("write to %s: %w", target, err)
+		}
+	}
+}
+
+func (c *KafkaConsumer) commit(ctx context.Context, reader messageReader, msg kafka.Message, logger *zap.Logger) {
+	if err := reader.CommitMessages(ctx, msg); err != nil {
+		logger.Error("Error committing message", zap.Error(err))
+	}
+}
+
+// Close waits for a running Start to drain its in-flight messages before the
+// readers and writer are closed.
 func (c *KafkaConsumer) Close() error {
-	return c.reader.Close()
+	if c.started.Load() {
+		<-c.done
+	}
+	return c.close()
+}
+
+func (c *KafkaConsumer) close() error {
+	c.closeOnce.Do(func() {
+		c.closeErr = c.closeAll()
+	})
+	return c.closeErr
+}
+
+func (c *KafkaConsumer) closeAll() error {
+	var errs []error
+	if err := c.reader.Close(); err != nil {
+		errs = append(errs, err)
+	}
+	if c.retryReader != nil {
+		if err := c.retryReader.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := c.writer.Close(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+func sleep(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
 }
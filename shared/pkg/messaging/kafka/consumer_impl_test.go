@@ -0,0 +1,322 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/khoihuynh300/go-microservice/shared/pkg/messaging/events"
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const testTopic = "user-events"
+
+var errHandler = errors.New("handler failed")
+
+type fakeWriter struct {
+	mu       sync.Mutex
+	messages []kafka.Message
+}
+
+func (w *fakeWriter) WriteMessages(ctx context.Context, msgs ...kafka.Message) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.messages = append(w.messages, msgs...)
+	return nil
+}
+
+func (w *fakeWriter) Close() error { return nil }
+
+func (w *fakeWriter) written() []kafka.Message {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]kafka.Message(nil), w.messages...)
+}
+
+type fakeReader struct {
+	messages chan kafka.Message
+
+	mu        sync.Mutex
+	committed []kafka.Message
+}
+
+func newFakeReader(msgs ...kafka.Message) *fakeReader {
+	r := &fakeReader{messages: make(chan kafka.Message, len(msgs))}
+	for _, msg := range msgs {
+		r.messages <- msg
+	}
+	return r
+}
+
+func (r *fakeReader) FetchMessage(ctx context.Context) (kafka.Message, error) {
+	select {
+	case msg := <-r.messages:
+		return msg, nil
+	case <-ctx.Done():
+		return kafka.Message{}, ctx.Err()
+	}
+}
+
+func (r *fakeReader) CommitMessages(ctx context.Context, msgs ...kafka.Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.committed = append(r.committed, msgs...)
+	return nil
+}
+
+func (r *fakeReader) Close() error { return nil }
+
+func (r *fakeReader) committedOffsets() map[int][]int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	offsets := make(map[int][]int64)
+	for _, msg := range r.committed {
+		offsets[msg.Partition] = append(offsets[msg.Partition], msg.Offset)
+	}
+	return offsets
+}
+
+func newTestConsumer(handler MessageHandler, policy *RetryPolicy) (*KafkaConsumer, *fakeWriter) {
+	writer := &fakeWriter{}
+	c := &KafkaConsumer{
+		groupID:  "test-group",
+		writer:   writer,
+		handlers: map[string]MessageHandler{testTopic: handler},
+		policies: make(map[string]RetryPolicy),
+	}
+	if policy != nil {
+		c.policies[testTopic] = policy.withDefaults()
+	}
+	return c, writer
+}
+
+func eventMessage(topic string, partition int, offset int64, headers ...kafka.Header) kafka.Message {
+	return kafka.Message{
+		Topic:     topic,
+		Partition: partition,
+		Offset:    offset,
+		Key:       []byte("user-1"),
+		Value:     []byte(`{"event_id":"event-` + strconv.FormatInt(offset, 10) + `","event_type":"user.registered","version":1,"data":{}}`),
+		Headers:   headers,
+	}
+}
+
+func header(key, value string) kafka.Header {
+	return kafka.Header{Key: key, Value: []byte(value)}
+}
+
+func TestKafkaConsumer_Process(t *testing.T) {
+	policy := &RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Minute,
+		MaxBackoff:     time.Hour,
+		Multiplier:     2,
+	}
+
+	tests := []struct {
+		name             string
+		msg              kafka.Message
+		attempts         int
+		handlerErr       error
+		policy           *RetryPolicy
+		expectedTopic    string
+		expectedHeaders  map[string]string
+		expectedBackoff  time.Duration
+		expectNoForward  bool
+		expectNotHandled bool
+	}{
+		{
+			name:            "Success",
+			msg:             eventMessage(testTopic, 2, 7),
+			policy:          policy,
+			expectNoForward: true,
+		},
+		{
+			name:          "First Failure Goes To Retry Topic",
+			msg:           eventMessage(testTopic, 2, 7),
+			handlerErr:    errHandler,
+			policy:        policy,
+			expectedTopic: RetryTopic(testTopic),
+			expectedHeaders: map[string]string{
+				HeaderAttempts:          "1",
+				HeaderError:             errHandler.Error(),
+				HeaderOriginalTopic:     testTopic,
+				HeaderOriginalPartition: "2",
+				HeaderOriginalOffset:    "7",
+			},
+			expectedBackoff: time.Minute,
+		},
+		{
+			name: "Retried Failure Keeps Original Position",
+			msg: eventMessage(RetryTopic(testTopic), 0, 40,
+				header(HeaderOriginalTopic, testTopic),
+				header(HeaderOriginalPartition, "2"),
+				header(HeaderOriginalOffset, "7"),
+				header(HeaderAttempts, "1"),
+				header(HeaderError, "earlier failure"),
+			),
+			attempts:      1,
+			handlerErr:    errHandler,
+			policy:        policy,
+			expectedTopic: RetryTopic(testTopic),
+			expectedHeaders: map[string]string{
+				HeaderAttempts:          "2",
+				HeaderError:             errHandler.Error(),
+				HeaderOriginalTopic:     testTopic,
+				HeaderOriginalPartition: "2",
+				HeaderOriginalOffset:    "7",
+			},
+			expectedBackoff: 2 * time.Minute,
+		},
+		{
+			name: "Dead Lettered After Max Attempts",
+			msg: eventMessage(RetryTopic(testTopic), 0, 41,
+				header(HeaderOriginalTopic, testTopic),
+				header(HeaderOriginalPartition, "2"),
+				header(HeaderOriginalOffset, "7"),
+				header(HeaderAttempts, "2"),
+			),
+			attempts:      2,
+			handlerErr:    errHandler,
+			policy:        policy,
+			expectedTopic: DLQTopic(testTopic),
+			expectedHeaders: map[string]string{
+				HeaderAttempts:          "3",
+				HeaderError:             errHandler.Error(),
+				HeaderOriginalTopic:     testTopic,
+				HeaderOriginalPartition: "2",
+				HeaderOriginalOffset:    "7",
+			},
+		},
+		{
+			name:             "Unparsable Message Is Dead Lettered",
+			msg:              kafka.Message{Topic: testTopic, Partition: 1, Offset: 3, Value: []byte("not json")},
+			policy:           policy,
+			expectedTopic:    DLQTopic(testTopic),
+			expectNotHandled: true,
+			expectedHeaders: map[string]string{
+				HeaderAttempts:          "1",
+				HeaderOriginalTopic:     testTopic,
+				HeaderOriginalPartition: "1",
+				HeaderOriginalOffset:    "3",
+			},
+		},
+		{
+			name:            "Failure Without Policy Is Dropped",
+			msg:             eventMessage(testTopic, 2, 7),
+			handlerErr:      errHandler,
+			expectNoForward: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handled := false
+			c, writer := newTestConsumer(func(ctx context.Context, event *events.Event) error {
+				handled = true
+				return tt.handlerErr
+			}, tt.policy)
+
+			before := time.Now()
+			err := c.process(context.Background(), zap.NewNop(), tt.msg, testTopic, c.handlers[testTopic], tt.attempts)
+			require.NoError(t, err)
+			assert.Equal(t, !tt.expectNotHandled, handled)
+
+			written := writer.written()
+			if tt.expectNoForward {
+				assert.Empty(t, written)
+				return
+			}
+			require.Len(t, written, 1)
+
+			out := written[0]
+			assert.Equal(t, tt.expectedTopic, out.Topic)
+			assert.Equal(t, tt.msg.Key, out.Key)
+			assert.Equal(t, tt.msg.Value, out.Value)
+			for key, value := range tt.expectedHeaders {
+				assert.Equal(t, value, HeaderValue(out, key), key)
+			}
+			for _, h := range out.Headers {
+				if h.Key == HeaderAttempts {
+					assert.Equal(t, tt.expectedHeaders[HeaderAttempts], string(h.Value), "attempts header is not duplicated")
+				}
+			}
+
+			if tt.expectedTopic == DLQTopic(testTopic) {
+				assert.Empty(t, HeaderValue(out, HeaderNextAttemptAt))
+				_, err := time.Parse(time.RFC3339Nano, HeaderValue(out, HeaderFailedAt))
+				assert.NoError(t, err)
+				return
+			}
+
+			nextAttemptAt, err := time.Parse(time.RFC3339Nano, HeaderValue(out, HeaderNextAttemptAt))
+			require.NoError(t, err)
+			assert.WithinDuration(t, before.Add(tt.expectedBackoff), nextAttemptAt, time.Second)
+		})
+	}
+}
+
+func TestKafkaConsumer_ConsumeRetriesPerPartition(t *testing.T) {
+	due := time.Now().UTC().Format(time.RFC3339Nano)
+	later := time.Now().Add(time.Hour).UTC().Format(time.RFC3339Nano)
+	retryHeaders := func(nextAttemptAt string) []kafka.Header {
+		return []kafka.Header{
+			header(HeaderOriginalTopic, testTopic),
+			header(HeaderAttempts, "1"),
+			header(HeaderNextAttemptAt, nextAttemptAt),
+		}
+	}
+
+	handled := make(chan string, 4)
+	c, writer := newTestConsumer(func(ctx context.Context, event *events.Event) error {
+		handled <- event.EventID
+		return nil
+	}, &RetryPolicy{})
+	reader := newFakeReader(
+		eventMessage(RetryTopic(testTopic), 0, 1, retryHeaders(later)...),
+		eventMessage(RetryTopic(testTopic), 0, 2, retryHeaders(due)...),
+		eventMessage(RetryTopic(testTopic), 1, 1, retryHeaders(due)...),
+		eventMessage(RetryTopic(testTopic), 1, 2, retryHeaders(due)...),
+	)
+	c.retryReader = reader
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		c.consumeRetries(ctx, context.Background(), zap.NewNop())
+	}()
+
+	// partition 1 is not held up by the message partition 0 is waiting on
+	for _, expected := range []string{"event-1", "event-2"} {
+		select {
+		case eventID := <-handled:
+			assert.Equal(t, expected, eventID)
+		case <-time.After(5 * time.Second):
+			t.Fatal("retries of partition 1 were not handled")
+		}
+	}
+
+	cancel()
+	<-done
+
+	assert.Empty(t, handled, "partition 0 must wait for its first message")
+	assert.Equal(t, map[int][]int64{1: {1, 2}}, reader.committedOffsets())
+	assert.Empty(t, writer.written())
+}
+
+func TestKafkaConsumer_RetryWithoutHandlerIsCommitted(t *testing.T) {
+	c, _ := newTestConsumer(func(ctx context.Context, event *events.Event) error { return nil }, &RetryPolicy{})
+	reader := newFakeReader()
+	c.retryReader = reader
+
+	msg := eventMessage(RetryTopic("unknown"), 0, 5, header(HeaderOriginalTopic, "unknown"))
+	assert.True(t, c.retry(context.Background(), context.Background(), zap.NewNop(), msg))
+	assert.Equal(t, map[int][]int64{0: {5}}, reader.committedOffsets())
+}
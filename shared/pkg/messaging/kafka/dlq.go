@@ -0,0 +1,185 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/khoihuynh300/go-microservice/shared/pkg/messaging/events"
+	"github.com/segmentio/kafka-go"
+)
+
+const HeaderReplayedFrom = "x-replayed-from"
+
+type DeadLetter struct {
+	Partition     int
+	Offset        int64
+	Key           string
+	OriginalTopic string
+	Error         string
+	Attempts      int
+	FailedAt      string
+	Event         *events.Event
+	Value         []byte
+}
+
+// DLQ reads a topic's dead-letter topic partition by partition, from the oldest
+// retained message up to the high watermark at the time of the call. It does not
+// use a consumer group, so inspecting or replaying never moves committed offsets.
+type DLQ struct {
+	topic  string
+	source messageSource
+	writer messageWriter
+}
+
+// messageSource calls fn for the messages of topic in offset order, per
+// partition, until fn returns false.
+type messageSource interface {
+	scan(ctx context.Context, topic string, fn func(msg kafka.Message) bool) error
+}
+
+func NewDLQ(brokers []string, topic string) *DLQ {
+	return &DLQ{
+		topic:  topic,
+		source: brokerSource{brokers: brokers},
+		writer: &kafka.Writer{
+			Addr:     kafka.TCP(brokers...),
+			Balancer: &kafka.Hash{},
+		},
+	}
+}
+
+func (d *DLQ) Inspect(ctx context.Context, limit int) ([]*DeadLetter, error) {
+	var result []*DeadLetter
+	err := d.source.scan(ctx, DLQTopic(d.topic), func(msg kafka.Message) bool {
+		result = append(result, toDeadLetter(d.topic, msg))
+		return limit <= 0 || len(result) < limit
+	})
+	return result, err
+}
+
+// Replay republishes the dead letters accepted by match to their original topic,
+// with the retry bookkeeping headers stripped so they start over with a fresh
+// attempt count. Other headers, such as the trace context, are kept.
+func (d *DLQ) Replay(ctx context.Context, limit int, match func(*DeadLetter) bool) (int, error) {
+	replayed := 0
+	var writeErr error
+
+	err := d.source.scan(ctx, DLQTopic(d.topic), func(msg kafka.Message) bool {
+		dl := toDeadLetter(d.topic, msg)
+		if !match(dl) {
+			return true
+		}
+
+		out := kafka.Message{
+			Topic: dl.OriginalTopic,
+			Key:   msg.Key,
+			Value: msg.Value,
+			Headers: withHeaders(withoutHeaders(msg.Headers, bookkeepingHeaders), map[string]string{
+				HeaderReplayedFrom: fmt.Sprintf("%s/%d/%d", msg.Topic, msg.Partition, msg.Offset),
+			}),
+		}
+		if writeErr = d.writer.WriteMessages(ctx, out); writeErr != nil {
+			return false
+		}
+
+		replayed++
+		return limit <= 0 || replayed < limit
+	})
+	if err != nil {
+		return replayed, err
+	}
+	return replayed, writeErr
+}
+
+func (d *DLQ) Close() error {
+	return d.writer.Close()
+}
+
+// brokerSource reads a topic directly from the brokers, from the oldest
+// retained message up to the high watermark at the time of the call.
+type brokerSource struct {
+	brokers []string
+}
+
+func (s brokerSource) scan(ctx context.Context, topic string, fn func(msg kafka.Message) bool) error {
+	conn, err := kafka.DialContext(ctx, "tcp", s.brokers[0])
+	if err != nil {
+		return err
+	}
+	partitions, err := conn.ReadPartitions(topic)
+	conn.Close()
+	if err != nil {
+		return err
+	}
+
+	for _, p := range partitions {
+		more, err := s.scanPartition(ctx, topic, p.ID, fn)
+		if err != nil {
+			return err
+		}
+		if !more {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (s brokerSource) scanPartition(ctx context.Context, topic string, partition int, fn func(msg kafka.Message) bool) (bool, error) {
+	leader, err := kafka.DialLeader(ctx, "tcp", s.brokers[0], topic, partition)
+	if err != nil {
+		return false, err
+	}
+	first, last, err := leader.ReadOffsets()
+	leader.Close()
+	if err != nil {
+		return false, err
+	}
+	if first >= last {
+		return true, nil
+	}
+
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:   s.brokers,
+		Topic:     topic,
+		Partition: partition,
+	})
+	defer reader.Close()
+
+	if err := reader.SetOffset(first); err != nil {
+		return false, err
+	}
+
+	for {
+		msg, err := reader.ReadMessage(ctx)
+		if err != nil {
+			return false, err
+		}
+		if !fn(msg) {
+			return false, nil
+		}
+		if msg.Offset >= last-1 {
+			return true, nil
+		}
+	}
+}
+
+func toDeadLetter(topic string, msg kafka.Message) *DeadLetter {
+	dl := &DeadLetter{
+		Partition:     msg.Partition,
+		Offset:        msg.Offset,
+		Key:           string(msg.Key),
+		OriginalTopic: HeaderValue(msg, HeaderOriginalTopic),
+		Error:         HeaderValue(msg, HeaderError),
+		Attempts:      attemptsOf(msg),
+		FailedAt:      HeaderValue(msg, HeaderFailedAt),
+		Value:         msg.Value,
+	}
+	if dl.OriginalTopic == "" {
+		dl.OriginalTopic = topic
+	}
+
+	if event, err := events.Unmarshal(msg.Value, HeaderValue(msg, events.ContentTypeHeader)); err == nil {
+		dl.Event = event
+	}
+	return dl
+}
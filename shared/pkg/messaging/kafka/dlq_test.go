@@ -0,0 +1,170 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	"github.com/khoihuynh300/go-microservice/shared/pkg/messaging/events"
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sliceSource struct {
+	topic    string
+	messages []kafka.Message
+}
+
+func (s *sliceSource) scan(ctx context.Context, topic string, fn func(msg kafka.Message) bool) error {
+	s.topic = topic
+	for _, msg := range s.messages {
+		if !fn(msg) {
+			return nil
+		}
+	}
+	return nil
+}
+
+const (
+	testTraceparentHeader = "traceparent"
+	testTraceparent       = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+)
+
+func deadLetterMessage(partition int, offset int64) kafka.Message {
+	return eventMessage(DLQTopic(testTopic), partition, offset,
+		header(events.ContentTypeHeader, events.ContentTypeJSON),
+		header(HeaderOriginalTopic, testTopic),
+		header(HeaderOriginalPartition, "3"),
+		header(HeaderOriginalOffset, "9"),
+		header(HeaderAttempts, "5"),
+		header(HeaderError, "handler failed"),
+		header(HeaderFailedAt, "2026-01-02T03:04:05Z"),
+		header(testTraceparentHeader, testTraceparent),
+	)
+}
+
+func newTestDLQ(messages ...kafka.Message) (*DLQ, *sliceSource, *fakeWriter) {
+	source := &sliceSource{messages: messages}
+	writer := &fakeWriter{}
+	return &DLQ{topic: testTopic, source: source, writer: writer}, source, writer
+}
+
+func TestDLQ_Inspect(t *testing.T) {
+	unparsable := kafka.Message{Topic: DLQTopic(testTopic), Offset: 3, Value: []byte("not json")}
+	dlq, source, _ := newTestDLQ(deadLetterMessage(0, 1), deadLetterMessage(1, 2), unparsable)
+
+	deadLetters, err := dlq.Inspect(context.Background(), 0)
+	require.NoError(t, err)
+	assert.Equal(t, DLQTopic(testTopic), source.topic)
+	require.Len(t, deadLetters, 3)
+
+	first := deadLetters[0]
+	assert.Equal(t, 0, first.Partition)
+	assert.Equal(t, int64(1), first.Offset)
+	assert.Equal(t, "user-1", first.Key)
+	assert.Equal(t, testTopic, first.OriginalTopic)
+	assert.Equal(t, "handler failed", first.Error)
+	assert.Equal(t, 5, first.Attempts)
+	assert.Equal(t, "2026-01-02T03:04:05Z", first.FailedAt)
+	require.NotNil(t, first.Event)
+	assert.Equal(t, "event-1", first.Event.EventID)
+
+	// messages without headers still point back at the source topic
+	assert.Nil(t, deadLetters[2].Event)
+	assert.Equal(t, testTopic, deadLetters[2].OriginalTopic)
+	assert.Equal(t, []byte("not json"), deadLetters[2].Value)
+}
+
+func TestDLQ_InspectLimit(t *testing.T) {
+	dlq, _, _ := newTestDLQ(deadLetterMessage(0, 1), deadLetterMessage(0, 2), deadLetterMessage(0, 3))
+
+	deadLetters, err := dlq.Inspect(context.Background(), 2)
+	require.NoError(t, err)
+	assert.Len(t, deadLetters, 2)
+}
+
+func TestDLQ_Replay(t *testing.T) {
+	tests := []struct {
+		name             string
+		limit            int
+		match            func(*DeadLetter) bool
+		expectedReplayed []string
+	}{
+		{
+			name:             "All",
+			match:            func(*DeadLetter) bool { return true },
+			expectedReplayed: []string{"user-events.dlq/0/1", "user-events.dlq/0/2", "user-events.dlq/1/3"},
+		},
+		{
+			name:             "By Event ID",
+			match:            func(dl *DeadLetter) bool { return dl.Event != nil && dl.Event.EventID == "event-2" },
+			expectedReplayed: []string{"user-events.dlq/0/2"},
+		},
+		{
+			name:             "Limit",
+			limit:            2,
+			match:            func(*DeadLetter) bool { return true },
+			expectedReplayed: []string{"user-events.dlq/0/1", "user-events.dlq/0/2"},
+		},
+		{
+			name:             "None Matching",
+			match:            func(*DeadLetter) bool { return false },
+			expectedReplayed: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dlq, _, writer := newTestDLQ(deadLetterMessage(0, 1), deadLetterMessage(0, 2), deadLetterMessage(1, 3))
+
+			replayed, err := dlq.Replay(context.Background(), tt.limit, tt.match)
+			require.NoError(t, err)
+			assert.Equal(t, len(tt.expectedReplayed), replayed)
+
+			written := writer.written()
+			require.Len(t, written, len(tt.expectedReplayed))
+			for i, out := range written {
+				assert.Equal(t, testTopic, out.Topic)
+				assert.Equal(t, []byte("user-1"), out.Key)
+				assert.Equal(t, events.ContentTypeJSON, HeaderValue(out, events.ContentTypeHeader))
+				assert.Equal(t, tt.expectedReplayed[i], HeaderValue(out, HeaderReplayedFrom))
+				assert.Equal(t, testTraceparent, HeaderValue(out, testTraceparentHeader))
+
+				// a replayed message starts over with a fresh attempt count
+				assert.Empty(t, HeaderValue(out, HeaderAttempts))
+				assert.Empty(t, HeaderValue(out, HeaderError))
+				assert.Empty(t, HeaderValue(out, HeaderNextAttemptAt))
+				assert.Empty(t, HeaderValue(out, HeaderOriginalTopic))
+				assert.Empty(t, HeaderValue(out, HeaderFailedAt))
+				assert.Equal(t, 0, attemptsOf(out))
+			}
+		})
+	}
+}
+
+func TestDLQ_ReplayKeepsOriginalHeaders(t *testing.T) {
+	// a message that was replayed before and dead-lettered again, without a
+	// content type
+	msg := eventMessage(DLQTopic(testTopic), 0, 7,
+		header(HeaderOriginalTopic, testTopic),
+		header(HeaderAttempts, "5"),
+		header(HeaderReplayedFrom, "user-events.dlq/0/1"),
+		header(testTraceparentHeader, testTraceparent),
+		header("tracestate", "vendor=value"),
+		header("x-request-id", "request-1"),
+	)
+	dlq, _, writer := newTestDLQ(msg)
+
+	replayed, err := dlq.Replay(context.Background(), 0, func(*DeadLetter) bool { return true })
+	require.NoError(t, err)
+	require.Equal(t, 1, replayed)
+
+	written := writer.written()
+	require.Len(t, written, 1)
+	assert.ElementsMatch(t, []kafka.Header{
+		header(testTraceparentHeader, testTraceparent),
+		header("tracestate", "vendor=value"),
+		header("x-request-id", "request-1"),
+		header(HeaderReplayedFrom, "user-events.dlq/0/7"),
+	}, written[0].Headers)
+}
@@ -0,0 +1,146 @@
+package kafka
+
+import (
+	"sync"
+
+	"github.com/segmentio/kafka-go"
+)
+
+type topicPartition struct {
+	topic     string
+	partition int
+}
+
+type partitionOffsets struct {
+	generation int
+	highest    int64
+	pending    []int64
+	done       map[int64]bool
+	// stopped is set once a message of the generation could not be settled;
+	// nothing after it may be handled or committed.
+	stopped bool
+
+	// commitMu serializes the commits of the partition, so they never go
+	// backwards, without holding the tracker lock across the network call.
+	commitMu  sync.Mutex
+	committed int64
+}
+
+// offsetTracker records the offsets handed to workers and reports, per
+// partition, the highest offset below which every message has completed. That
+// is the only offset that is safe to commit when messages finish out of order.
+type offsetTracker struct {
+	mu         sync.Mutex
+	partitions map[topicPartition]*partitionOffsets
+}
+
+func newOffsetTracker() *offsetTracker {
+	return &offsetTracker{
+		partitions: make(map[topicPartition]*partitionOffsets),
+	}
+}
+
+// add registers a fetched message and returns the generation it belongs to. A
+// message at or below an offset already seen means the partition was rewound
+// after a rebalance, so the previous in-flight state is discarded.
+func (t *offsetTracker) add(msg kafka.Message) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	tp := topicPartition{topic: msg.Topic, partition: msg.Partition}
+	p, ok := t.partitions[tp]
+	if !ok {
+		p = &partitionOffsets{done: make(map[int64]bool), committed: -1}
+		t.partitions[tp] = p
+	} else if msg.Offset <= p.highest {
+		p.generation++
+		p.pending = nil
+		p.done = make(map[int64]bool)
+		p.stopped = false
+		p.committed = -1
+	}
+
+	p.highest = msg.Offset
+	p.pending = append(p.pending, msg.Offset)
+	return p.generation
+}
+
+// stop marks the partition of msg as stopped for generation. Its offset then
+// stays pending, so neither it nor anything after it is committed until the
+// partition is rewound.
+func (t *offsetTracker) stop(msg kafka.Message, generation int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if p, ok := t.current(msg, generation); ok {
+		p.stopped = true
+	}
+}
+
+// stopped reports whether msg belongs to a stopped partition generation.
+func (t *offsetTracker) stopped(msg kafka.Message, generation int) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	p, ok := t.current(msg, generation)
+	return ok && p.stopped
+}
+
+// complete marks a message as finished and calls commit with the message at the
+// new contiguous high-water mark, if it moved. commit runs outside the tracker
+// lock; commits of one partition are serialized and never go backwards.
+func (t *offsetTracker) complete(msg kafka.Message, generation int, commit func(kafka.Message)) {
+	p, committable := t.markDone(msg, generation)
+	if committable < 0 {
+		return
+	}
+
+	p.commitMu.Lock()
+	defer p.commitMu.Unlock()
+
+	t.mu.Lock()
+	if p.generation != generation || committable <= p.committed {
+		t.mu.Unlock()
+		return
+	}
+	p.committed = committable
+	t.mu.Unlock()
+
+	commit(kafka.Message{
+		Topic:     msg.Topic,
+		Partition: msg.Partition,
+		Offset:    committable,
+	})
+}
+
+// markDone records msg as finished and returns the new contiguous high-water
+// mark of its partition, or -1 if it did not move.
+func (t *offsetTracker) markDone(msg kafka.Message, generation int) (*partitionOffsets, int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	p, ok := t.current(msg, generation)
+	if !ok || p.stopped {
+		return nil, -1
+	}
+
+	p.done[msg.Offset] = true
+
+	committable := int64(-1)
+	for len(p.pending) > 0 && p.done[p.pending[0]] {
+		committable = p.pending[0]
+		delete(p.done, committable)
+		p.pending = p.pending[1:]
+	}
+	return p, committable
+}
+
+// current returns the partition of msg if it is still at generation. t.mu must
+// be held.
+func (t *offsetTracker) current(msg kafka.Message, generation int) (*partitionOffsets, bool) {
+	p, ok := t.partitions[topicPartition{topic: msg.Topic, partition: msg.Partition}]
+	if !ok || p.generation != generation {
+		return nil, false
+	}
+	return p, true
+}
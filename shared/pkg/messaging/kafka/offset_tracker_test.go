@@ -0,0 +1,182 @@
+package kafka
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func trackerMessage(partition int, offset int64) kafka.Message {
+	return kafka.Message{Topic: "orders", Partition: partition, Offset: offset}
+}
+
+type commitRecorder struct {
+	mu      sync.Mutex
+	offsets []int64
+}
+
+func (r *commitRecorder) commit(msg kafka.Message) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.offsets = append(r.offsets, msg.Offset)
+}
+
+func TestOffsetTracker_Complete(t *testing.T) {
+	tests := []struct {
+		name            string
+		offsets         []int64
+		completionOrder []int64
+		expectedCommits []int64
+	}{
+		{
+			name:            "In Order",
+			offsets:         []int64{0, 1, 2},
+			completionOrder: []int64{0, 1, 2},
+			expectedCommits: []int64{0, 1, 2},
+		},
+		{
+			name:            "Out Of Order",
+			offsets:         []int64{0, 1, 2, 3},
+			completionOrder: []int64{2, 1, 3, 0},
+			expectedCommits: []int64{3},
+		},
+		{
+			name:            "Waits For Earliest",
+			offsets:         []int64{0, 1, 2},
+			completionOrder: []int64{1, 2},
+			expectedCommits: nil,
+		},
+		{
+			name:            "Gaps In Offsets",
+			offsets:         []int64{10, 12, 15},
+			completionOrder: []int64{12, 10, 15},
+			expectedCommits: []int64{12, 15},
+		},
+		{
+			name:            "Partial Prefix",
+			offsets:         []int64{5, 6, 7, 8},
+			completionOrder: []int64{6, 5, 8},
+			expectedCommits: []int64{6},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := newOffsetTracker()
+			generations := make(map[int64]int)
+			for _, offset := range tt.offsets {
+				generations[offset] = tracker.add(trackerMessage(0, offset))
+			}
+
+			recorder := &commitRecorder{}
+			for _, offset := range tt.completionOrder {
+				tracker.complete(trackerMessage(0, offset), generations[offset], recorder.commit)
+			}
+
+			assert.Equal(t, tt.expectedCommits, recorder.offsets)
+		})
+	}
+}
+
+func TestOffsetTracker_PartitionsAreIndependent(t *testing.T) {
+	tracker := newOffsetTracker()
+	gen0 := tracker.add(trackerMessage(0, 0))
+	tracker.add(trackerMessage(0, 1))
+	gen1 := tracker.add(trackerMessage(1, 0))
+
+	recorder := &commitRecorder{}
+	tracker.complete(trackerMessage(1, 0), gen1, recorder.commit)
+	tracker.complete(trackerMessage(0, 0), gen0, recorder.commit)
+
+	assert.Equal(t, []int64{0, 0}, recorder.offsets)
+}
+
+func TestOffsetTracker_GenerationChangeOnRebalance(t *testing.T) {
+	tracker := newOffsetTracker()
+	oldGen := tracker.add(trackerMessage(0, 0))
+	tracker.add(trackerMessage(0, 1))
+	tracker.add(trackerMessage(0, 2))
+
+	// the partition is rewound to offset 1 after a rebalance
+	newGen := tracker.add(trackerMessage(0, 1))
+	tracker.add(trackerMessage(0, 2))
+	require.NotEqual(t, oldGen, newGen)
+
+	recorder := &commitRecorder{}
+
+	// completions of the previous generation are ignored
+	tracker.complete(trackerMessage(0, 0), oldGen, recorder.commit)
+	tracker.complete(trackerMessage(0, 1), oldGen, recorder.commit)
+	assert.Empty(t, recorder.offsets)
+
+	tracker.complete(trackerMessage(0, 2), newGen, recorder.commit)
+	assert.Empty(t, recorder.offsets)
+
+	tracker.complete(trackerMessage(0, 1), newGen, recorder.commit)
+	assert.Equal(t, []int64{2}, recorder.offsets)
+}
+
+func TestOffsetTracker_Stop(t *testing.T) {
+	tracker := newOffsetTracker()
+	gen := tracker.add(trackerMessage(0, 0))
+	tracker.add(trackerMessage(0, 1))
+	otherGen := tracker.add(trackerMessage(1, 0))
+
+	recorder := &commitRecorder{}
+	tracker.stop(trackerMessage(0, 0), gen)
+
+	assert.True(t, tracker.stopped(trackerMessage(0, 1), gen))
+	assert.False(t, tracker.stopped(trackerMessage(1, 0), otherGen))
+
+	tracker.complete(trackerMessage(0, 1), gen, recorder.commit)
+	assert.Empty(t, recorder.offsets)
+
+	// a rewind starts a new generation that is no longer stopped
+	newGen := tracker.add(trackerMessage(0, 0))
+	assert.False(t, tracker.stopped(trackerMessage(0, 0), newGen))
+	tracker.complete(trackerMessage(0, 0), newGen, recorder.commit)
+	assert.Equal(t, []int64{0}, recorder.offsets)
+}
+
+func TestOffsetTracker_CommitOutsideLock(t *testing.T) {
+	tracker := newOffsetTracker()
+	gen := tracker.add(trackerMessage(0, 0))
+	tracker.add(trackerMessage(0, 1))
+
+	recorder := &commitRecorder{}
+	tracker.complete(trackerMessage(0, 0), gen, func(msg kafka.Message) {
+		// would deadlock if commit ran under the tracker lock
+		tracker.add(trackerMessage(1, 0))
+		recorder.commit(msg)
+	})
+
+	assert.Equal(t, []int64{0}, recorder.offsets)
+}
+
+func TestOffsetTracker_ConcurrentCommitsNeverGoBackwards(t *testing.T) {
+	const messages = 1000
+
+	tracker := newOffsetTracker()
+	generations := make([]int, messages)
+	for i := range messages {
+		generations[i] = tracker.add(trackerMessage(0, int64(i)))
+	}
+
+	recorder := &commitRecorder{}
+	var wg sync.WaitGroup
+	for i := range messages {
+		wg.Add(1)
+		go func(offset int64) {
+			defer wg.Done()
+			tracker.complete(trackerMessage(0, offset), generations[offset], recorder.commit)
+		}(int64(i))
+	}
+	wg.Wait()
+
+	require.NotEmpty(t, recorder.offsets)
+	assert.IsIncreasing(t, recorder.offsets)
+	assert.Equal(t, int64(messages-1), recorder.offsets[len(recorder.offsets)-1])
+}
@@ -8,5 +8,7 @@ import (
 
 type Producer interface {
 	Publish(ctx context.Context, topic string, event *events.Event) error
+	PublishWithKey(ctx context.Context, topic string, key string, event *events.Event) error
+	SetContentType(contentType string)
 	Close() error
 }
@@ -2,34 +2,79 @@ package kafka
 
 import (
 	"context"
-	"encoding/json"
 
 	"github.com/khoihuynh300/go-microservice/shared/pkg/messaging/events"
+	"github.com/khoihuynh300/go-microservice/shared/pkg/telemetry"
 	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type KafkaProducer struct {
-	writer *kafka.Writer
+	writer      *kafka.Writer
+	contentType string
 }
 
 func NewProducer(brokers []string) Producer {
 	return &KafkaProducer{
 		writer: &kafka.Writer{
-			Addr: kafka.TCP(brokers...),
+			Addr:     kafka.TCP(brokers...),
+			Balancer: &kafka.Hash{},
 		},
+		contentType: events.ContentTypeJSON,
 	}
 }
 
+func (p *KafkaProducer) SetContentType(contentType string) {
+	p.contentType = contentType
+}
+
 func (p *KafkaProducer) Publish(ctx context.Context, topic string, event *events.Event) error {
-	value, err := json.Marshal(event)
+	return p.PublishWithKey(ctx, topic, "", event)
+}
+
+// PublishWithKey routes events sharing a key to the same partition, so they are
+// consumed in the order they were published. An empty key spreads messages
+// across partitions.
+func (p *KafkaProducer) PublishWithKey(ctx context.Context, topic string, key string, event *events.Event) error {
+	ctx, span := telemetry.Tracer().Start(ctx, topic+" publish",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "kafka"),
+			attribute.String("messaging.operation.type", "send"),
+			attribute.String("messaging.destination.name", topic),
+			attribute.String("messaging.message.id", event.EventID),
+		),
+	)
+	defer span.End()
+
+	value, err := events.Marshal(event, p.contentType)
 	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
 		return err
 	}
 
-	return p.writer.WriteMessages(ctx, kafka.Message{
+	msg := kafka.Message{
 		Topic: topic,
 		Value: value,
-	})
+		Headers: []kafka.Header{
+			{Key: events.ContentTypeHeader, Value: []byte(p.contentType)},
+		},
+	}
+	if key != "" {
+		msg.Key = []byte(key)
+	}
+	otel.GetTextMapPropagator().Inject(ctx, telemetry.KafkaHeaderCarrier{Headers: &msg.Headers})
+
+	if err := p.writer.WriteMessages(ctx, msg); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+	return nil
 }
 
 func (p *KafkaProducer) Close() error {
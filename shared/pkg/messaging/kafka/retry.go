@@ -0,0 +1,129 @@
+package kafka
+
+import (
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	HeaderOriginalTopic     = "x-original-topic"
+	HeaderOriginalPartition = "x-original-partition"
+	HeaderOriginalOffset    = "x-original-offset"
+	HeaderError             = "x-error"
+	HeaderAttempts          = "x-attempts"
+	HeaderNextAttemptAt     = "x-next-attempt-at"
+	HeaderFailedAt          = "x-failed-at"
+)
+
+// bookkeepingHeaders are added on the way through the retry and dead-letter
+// topics. They are stripped when a dead letter is replayed.
+var bookkeepingHeaders = []string{
+	HeaderOriginalTopic,
+	HeaderOriginalPartition,
+	HeaderOriginalOffset,
+	HeaderError,
+	HeaderAttempts,
+	HeaderNextAttemptAt,
+	HeaderFailedAt,
+	HeaderReplayedFrom,
+}
+
+const (
+	retryTopicSuffix = ".retry"
+	dlqTopicSuffix   = ".dlq"
+)
+
+// RetryPolicy controls how often a failed message is redelivered before it is
+// dead-lettered. MaxAttempts counts the first delivery.
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    5,
+	InitialBackoff: time.Second,
+	MaxBackoff:     5 * time.Minute,
+	Multiplier:     2,
+}
+
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = DefaultRetryPolicy.MaxAttempts
+	}
+	if p.InitialBackoff <= 0 {
+		p.InitialBackoff = DefaultRetryPolicy.InitialBackoff
+	}
+	if p.MaxBackoff < p.InitialBackoff {
+		p.MaxBackoff = max(DefaultRetryPolicy.MaxBackoff, p.InitialBackoff)
+	}
+	if p.Multiplier < 1 {
+		p.Multiplier = DefaultRetryPolicy.Multiplier
+	}
+	return p
+}
+
+// Backoff returns the delay before the given retry, starting at 1.
+func (p RetryPolicy) Backoff(retry int) time.Duration {
+	d := float64(p.InitialBackoff)
+	for i := 1; i < retry; i++ {
+		d *= p.Multiplier
+		if d >= float64(p.MaxBackoff) {
+			return p.MaxBackoff
+		}
+	}
+	return time.Duration(d)
+}
+
+func RetryTopic(topic string) string {
+	return topic + retryTopicSuffix
+}
+
+func DLQTopic(topic string) string {
+	return topic + dlqTopicSuffix
+}
+
+func HeaderValue(msg kafka.Message, key string) string {
+	for _, h := range msg.Headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+func withHeaders(headers []kafka.Header, values map[string]string) []kafka.Header {
+	result := make([]kafka.Header, 0, len(headers)+len(values))
+	for _, h := range headers {
+		if _, replaced := values[h.Key]; !replaced {
+			result = append(result, h)
+		}
+	}
+	for k, v := range values {
+		result = append(result, kafka.Header{Key: k, Value: []byte(v)})
+	}
+	return result
+}
+
+func withoutHeaders(headers []kafka.Header, keys []string) []kafka.Header {
+	result := make([]kafka.Header, 0, len(headers))
+	for _, h := range headers {
+		if !slices.Contains(keys, h.Key) {
+			result = append(result, h)
+		}
+	}
+	return result
+}
+
+func attemptsOf(msg kafka.Message) int {
+	attempts, err := strconv.Atoi(HeaderValue(msg, HeaderAttempts))
+	if err != nil {
+		return 0
+	}
+	return attempts
+}
@@ -0,0 +1,100 @@
+package kafka
+
+import (
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{
+		MaxAttempts:    5,
+		InitialBackoff: time.Second,
+		MaxBackoff:     10 * time.Second,
+		Multiplier:     2,
+	}
+
+	tests := []struct {
+		name     string
+		retry    int
+		expected time.Duration
+	}{
+		{name: "First Retry", retry: 1, expected: time.Second},
+		{name: "Second Retry", retry: 2, expected: 2 * time.Second},
+		{name: "Third Retry", retry: 3, expected: 4 * time.Second},
+		{name: "Fourth Retry", retry: 4, expected: 8 * time.Second},
+		{name: "Capped", retry: 5, expected: 10 * time.Second},
+		{name: "Stays Capped", retry: 50, expected: 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, policy.Backoff(tt.retry))
+		})
+	}
+}
+
+func TestRetryPolicy_WithDefaults(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   RetryPolicy
+		expected RetryPolicy
+	}{
+		{
+			name:     "Zero Value",
+			policy:   RetryPolicy{},
+			expected: DefaultRetryPolicy,
+		},
+		{
+			name:   "Keeps Set Values",
+			policy: RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Second, Multiplier: 3},
+			expected: RetryPolicy{
+				MaxAttempts:    3,
+				InitialBackoff: time.Millisecond,
+				MaxBackoff:     time.Second,
+				Multiplier:     3,
+			},
+		},
+		{
+			name:   "Max Below Initial",
+			policy: RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Hour, MaxBackoff: time.Second, Multiplier: 2},
+			expected: RetryPolicy{
+				MaxAttempts:    3,
+				InitialBackoff: time.Hour,
+				MaxBackoff:     time.Hour,
+				Multiplier:     2,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.policy.withDefaults())
+		})
+	}
+}
+
+func TestWithHeaders(t *testing.T) {
+	headers := withHeaders([]kafka.Header{
+		{Key: HeaderAttempts, Value: []byte("1")},
+		{Key: "content-type", Value: []byte("application/json")},
+	}, map[string]string{
+		HeaderAttempts: "2",
+		HeaderError:    "boom",
+	})
+
+	msg := kafka.Message{Headers: headers}
+	assert.Len(t, headers, 3)
+	assert.Equal(t, "2", HeaderValue(msg, HeaderAttempts))
+	assert.Equal(t, "boom", HeaderValue(msg, HeaderError))
+	assert.Equal(t, "application/json", HeaderValue(msg, "content-type"))
+	assert.Equal(t, 2, attemptsOf(msg))
+}
+
+func TestAttemptsOf(t *testing.T) {
+	assert.Equal(t, 0, attemptsOf(kafka.Message{}))
+	assert.Equal(t, 0, attemptsOf(kafka.Message{Headers: []kafka.Header{{Key: HeaderAttempts, Value: []byte("x")}}}))
+	assert.Equal(t, 4, attemptsOf(kafka.Message{Headers: []kafka.Header{{Key: HeaderAttempts, Value: []byte("4")}}}))
+}
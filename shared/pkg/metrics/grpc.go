@@ -0,0 +1,29 @@
+package metrics
+
+import (
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc/codes"
+)
+
+var (
+	grpcHandled = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_server_handled_total",
+		Help: "RPCs completed on the server, by status code.",
+	}, []string{"grpc_service", "grpc_method", "grpc_code"})
+
+	grpcDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_server_handling_seconds",
+		Help:    "Time taken by the server to handle an RPC.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"grpc_service", "grpc_method"})
+)
+
+func ObserveGRPC(fullMethod string, code codes.Code, duration time.Duration) {
+	service, method, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	grpcHandled.WithLabelValues(service, method, code.String()).Inc()
+	grpcDuration.WithLabelValues(service, method).Observe(duration.Seconds())
+}
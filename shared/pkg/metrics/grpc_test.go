@@ -0,0 +1,38 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestObserveGRPC(t *testing.T) {
+	grpcHandled.Reset()
+	grpcDuration.Reset()
+	reg := newTestRegistry(t, grpcHandled, grpcDuration)
+
+	ObserveGRPC("/user.UserService/Login", codes.OK, 10*time.Millisecond)
+	ObserveGRPC("/user.UserService/Login", codes.OK, 20*time.Millisecond)
+	ObserveGRPC("/user.UserService/Login", codes.Unauthenticated, 5*time.Millisecond)
+	ObserveGRPC("/product.ProductService/GetProduct", codes.NotFound, time.Millisecond)
+
+	expected := `
+# HELP grpc_server_handled_total RPCs completed on the server, by status code.
+# TYPE grpc_server_handled_total counter
+grpc_server_handled_total{grpc_code="NotFound",grpc_method="GetProduct",grpc_service="product.ProductService"} 1
+grpc_server_handled_total{grpc_code="OK",grpc_method="Login",grpc_service="user.UserService"} 2
+grpc_server_handled_total{grpc_code="Unauthenticated",grpc_method="Login",grpc_service="user.UserService"} 1
+`
+	require.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(expected), "grpc_server_handled_total"))
+
+	assert.Equal(t, 2, testutil.CollectAndCount(grpcDuration))
+	assert.Equal(t, uint64(3), sampleCount(t, reg, "grpc_server_handling_seconds", map[string]string{
+		"grpc_service": "user.UserService",
+		"grpc_method":  "Login",
+	}))
+}
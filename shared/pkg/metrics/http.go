@@ -0,0 +1,81 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	httpRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "HTTP requests completed, by route and status code.",
+	}, []string{"method", "route", "status"})
+
+	httpDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Time taken to serve an HTTP request.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route"})
+)
+
+type routeKey struct{}
+
+// HTTPMiddleware records request count and latency. Routes are labelled by
+// their template rather than the raw path, to keep label cardinality bounded:
+// route gives the template known when the request enters, and handlers further
+// down can refine it with SetHTTPRoute.
+func HTTPMiddleware(route func(*http.Request) string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			template := route(r)
+			r = r.WithContext(context.WithValue(r.Context(), routeKey{}, &template))
+
+			wrapped := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(wrapped, r)
+
+			httpRequests.WithLabelValues(r.Method, template, strconv.Itoa(wrapped.status)).Inc()
+			httpDuration.WithLabelValues(r.Method, template).Observe(time.Since(start).Seconds())
+		})
+	}
+}
+
+// SetHTTPRoute replaces the route label of the request being served.
+func SetHTTPRoute(ctx context.Context, route string) {
+	if template, ok := ctx.Value(routeKey{}).(*string); ok {
+		*template = route
+	}
+}
+
+// HTTPRoute returns the route label of the request being served, or "" outside
+// HTTPMiddleware.
+func HTTPRoute(ctx context.Context) string {
+	if template, ok := ctx.Value(routeKey{}).(*string); ok {
+		return *template
+	}
+	return ""
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (w *statusRecorder) WriteHeader(code int) {
+	if !w.wroteHeader {
+		w.status = code
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *statusRecorder) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
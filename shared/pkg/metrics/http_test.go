@@ -0,0 +1,51 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPMiddleware(t *testing.T) {
+	httpRequests.Reset()
+	httpDuration.Reset()
+	reg := newTestRegistry(t, httpRequests, httpDuration)
+
+	var seenRoute string
+	handler := HTTPMiddleware(func(*http.Request) string { return "/v1/*" })(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/v1/products/"):
+			SetHTTPRoute(r.Context(), "/v1/products/{id}")
+			seenRoute = HTTPRoute(r.Context())
+			w.Write([]byte("ok"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+
+	for _, path := range []string{"/v1/products/product-1", "/v1/products/product-2", "/v1/missing"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	assert.Equal(t, "/v1/products/{id}", seenRoute)
+	assert.Equal(t, float64(2), testutil.ToFloat64(httpRequests.WithLabelValues(http.MethodGet, "/v1/products/{id}", "200")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(httpRequests.WithLabelValues(http.MethodGet, "/v1/*", "404")))
+	assert.Equal(t, 2, testutil.CollectAndCount(httpRequests))
+	assert.Equal(t, uint64(2), sampleCount(t, reg, "http_request_duration_seconds", map[string]string{
+		"method": http.MethodGet,
+		"route":  "/v1/products/{id}",
+	}))
+}
+
+func TestHTTPRoute_OutsideMiddleware(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/v1/products", nil)
+
+	SetHTTPRoute(req.Context(), "/v1/products")
+
+	assert.Empty(t, HTTPRoute(req.Context()))
+}
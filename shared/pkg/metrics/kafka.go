@@ -0,0 +1,38 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Outcomes of handling a Kafka message.
+const (
+	KafkaResultSuccess    = "success"
+	KafkaResultRetry      = "retry"
+	KafkaResultDeadLetter = "dead_letter"
+	KafkaResultFailure    = "failure"
+)
+
+var (
+	kafkaProcessing = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "kafka_consumer_processing_seconds",
+		Help:    "Time taken to handle a consumed message, by outcome.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"group", "topic", "result"})
+
+	kafkaLag = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kafka_consumer_lag",
+		Help: "Messages between the last fetched offset and the partition high-water mark.",
+	}, []string{"group", "topic", "partition"})
+)
+
+func ObserveKafkaMessage(group, topic, result string, duration time.Duration) {
+	kafkaProcessing.WithLabelValues(group, topic, result).Observe(duration.Seconds())
+}
+
+func SetKafkaLag(group, topic string, partition int, lag int64) {
+	kafkaLag.WithLabelValues(group, topic, strconv.Itoa(partition)).Set(float64(max(lag, 0)))
+}
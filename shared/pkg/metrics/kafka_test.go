@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObserveKafkaMessage(t *testing.T) {
+	kafkaProcessing.Reset()
+	reg := newTestRegistry(t, kafkaProcessing)
+
+	ObserveKafkaMessage("notification-service", "user.registered", KafkaResultSuccess, 10*time.Millisecond)
+	ObserveKafkaMessage("notification-service", "user.registered", KafkaResultSuccess, 20*time.Millisecond)
+	ObserveKafkaMessage("notification-service", "user.registered", KafkaResultRetry, 5*time.Millisecond)
+	ObserveKafkaMessage("notification-service", "user.registered.retry", KafkaResultDeadLetter, 5*time.Millisecond)
+
+	assert.Equal(t, 3, testutil.CollectAndCount(kafkaProcessing))
+	assert.Equal(t, uint64(2), sampleCount(t, reg, "kafka_consumer_processing_seconds", map[string]string{
+		"group":  "notification-service",
+		"topic":  "user.registered",
+		"result": KafkaResultSuccess,
+	}))
+	assert.Equal(t, uint64(1), sampleCount(t, reg, "kafka_consumer_processing_seconds", map[string]string{
+		"group":  "notification-service",
+		"topic":  "user.registered.retry",
+		"result": KafkaResultDeadLetter,
+	}))
+}
+
+func TestSetKafkaLag(t *testing.T) {
+	kafkaLag.Reset()
+	newTestRegistry(t, kafkaLag)
+
+	SetKafkaLag("notification-service", "user.registered", 0, 42)
+	SetKafkaLag("notification-service", "user.registered", 1, 7)
+	SetKafkaLag("notification-service", "user.registered", 1, 3)
+	SetKafkaLag("notification-service", "user.registered", 2, -1)
+
+	assert.Equal(t, 3, testutil.CollectAndCount(kafkaLag))
+	assert.Equal(t, float64(42), testutil.ToFloat64(kafkaLag.WithLabelValues("notification-service", "user.registered", "0")))
+	assert.Equal(t, float64(3), testutil.ToFloat64(kafkaLag.WithLabelValues("notification-service", "user.registered", "1")))
+	assert.Equal(t, float64(0), testutil.ToFloat64(kafkaLag.WithLabelValues("notification-service", "user.registered", "2")))
+}
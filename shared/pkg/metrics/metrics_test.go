@@ -0,0 +1,51 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRegistry registers collectors on a private registry, so tests see
+// only the series they produce themselves.
+func newTestRegistry(t *testing.T, collectors ...prometheus.Collector) *prometheus.Registry {
+	t.Helper()
+	reg := prometheus.NewPedanticRegistry()
+	reg.MustRegister(collectors...)
+	return reg
+}
+
+// sampleCount returns the number of observations recorded by the histogram
+// series name{labels}.
+func sampleCount(t *testing.T, reg prometheus.Gatherer, name string, labels map[string]string) uint64 {
+	t.Helper()
+	families, err := reg.Gather()
+	require.NoError(t, err)
+
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			if matchLabels(metric.GetLabel(), labels) {
+				return metric.GetHistogram().GetSampleCount()
+			}
+		}
+	}
+	t.Fatalf("no %s series with labels %v", name, labels)
+	return 0
+}
+
+func matchLabels(pairs []*dto.LabelPair, labels map[string]string) bool {
+	if len(pairs) != len(labels) {
+		return false
+	}
+	for _, pair := range pairs {
+		if labels[pair.GetName()] != pair.GetValue() {
+			return false
+		}
+	}
+	return true
+}
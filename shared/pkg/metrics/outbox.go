@@ -0,0 +1,15 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var outboxParked = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "outbox_events_parked_total",
+	Help: "Outbox events given up on after too many failed publish attempts.",
+}, []string{"topic", "event_type"})
+
+func IncOutboxParked(topic, eventType string) {
+	outboxParked.WithLabelValues(topic, eventType).Inc()
+}
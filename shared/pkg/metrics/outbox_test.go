@@ -0,0 +1,20 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIncOutboxParked(t *testing.T) {
+	outboxParked.Reset()
+	newTestRegistry(t, outboxParked)
+
+	IncOutboxParked("user.events", "user.registered")
+	IncOutboxParked("user.events", "user.registered")
+	IncOutboxParked("user.events", "user.password_changed")
+
+	assert.Equal(t, 2, testutil.CollectAndCount(outboxParked))
+	assert.Equal(t, float64(2), testutil.ToFloat64(outboxParked.WithLabelValues("user.events", "user.registered")))
+}
@@ -0,0 +1,68 @@
+package metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type pgxPoolCollector struct {
+	pool *pgxpool.Pool
+
+	acquiredConns        *prometheus.Desc
+	idleConns            *prometheus.Desc
+	totalConns           *prometheus.Desc
+	maxConns             *prometheus.Desc
+	acquireCount         *prometheus.Desc
+	acquireDuration      *prometheus.Desc
+	canceledAcquireCount *prometheus.Desc
+	emptyAcquireCount    *prometheus.Desc
+}
+
+// RegisterPgxPool exports the pool's connection statistics, labelled with the
+// database name.
+func RegisterPgxPool(pool *pgxpool.Pool) error {
+	return prometheus.Register(newPgxPoolCollector(pool))
+}
+
+func newPgxPoolCollector(pool *pgxpool.Pool) *pgxPoolCollector {
+	labels := prometheus.Labels{"database": pool.Config().ConnConfig.Database}
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc("pgxpool_"+name, help, nil, labels)
+	}
+
+	return &pgxPoolCollector{
+		pool:                 pool,
+		acquiredConns:        desc("acquired_conns", "Connections currently checked out of the pool."),
+		idleConns:            desc("idle_conns", "Idle connections in the pool."),
+		totalConns:           desc("total_conns", "Connections currently open."),
+		maxConns:             desc("max_conns", "Maximum size of the pool."),
+		acquireCount:         desc("acquire_total", "Successful connection acquisitions."),
+		acquireDuration:      desc("acquire_duration_seconds_total", "Total time spent waiting to acquire a connection."),
+		canceledAcquireCount: desc("canceled_acquire_total", "Acquisitions cancelled by their context."),
+		emptyAcquireCount:    desc("empty_acquire_total", "Acquisitions that had to wait because the pool was empty."),
+	}
+}
+
+func (c *pgxPoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.acquiredConns
+	ch <- c.idleConns
+	ch <- c.totalConns
+	ch <- c.maxConns
+	ch <- c.acquireCount
+	ch <- c.acquireDuration
+	ch <- c.canceledAcquireCount
+	ch <- c.emptyAcquireCount
+}
+
+func (c *pgxPoolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.pool.Stat()
+
+	ch <- prometheus.MustNewConstMetric(c.acquiredConns, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(c.idleConns, prometheus.GaugeValue, float64(stat.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(c.totalConns, prometheus.GaugeValue, float64(stat.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(c.maxConns, prometheus.GaugeValue, float64(stat.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(c.acquireCount, prometheus.CounterValue, float64(stat.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.acquireDuration, prometheus.CounterValue, stat.AcquireDuration().Seconds())
+	ch <- prometheus.MustNewConstMetric(c.canceledAcquireCount, prometheus.CounterValue, float64(stat.CanceledAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.emptyAcquireCount, prometheus.CounterValue, float64(stat.EmptyAcquireCount()))
+}
@@ -0,0 +1,58 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/redis/go-redis/v9"
+)
+
+var redisDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "redis_command_duration_seconds",
+	Help:    "Latency of Redis commands, by command and outcome.",
+	Buckets: []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+}, []string{"command", "result"})
+
+// RedisHook times every command sent through a go-redis client.
+type RedisHook struct{}
+
+var _ redis.Hook = RedisHook{}
+
+func (RedisHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return next(ctx, network, addr)
+	}
+}
+
+func (RedisHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmd)
+		redisDuration.WithLabelValues(cmd.Name(), redisResult(err)).Observe(time.Since(start).Seconds())
+		return err
+	}
+}
+
+func (RedisHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmds)
+		redisDuration.WithLabelValues("pipeline", redisResult(err)).Observe(time.Since(start).Seconds())
+		return err
+	}
+}
+
+func redisResult(err error) string {
+	switch {
+	case err == nil:
+		return "ok"
+	case errors.Is(err, redis.Nil):
+		return "miss"
+	default:
+		return "error"
+	}
+}
@@ -0,0 +1,30 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	smtpSends = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "smtp_send_total",
+		Help: "Emails handed to the SMTP server, by outcome.",
+	}, []string{"result"})
+
+	smtpDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "smtp_send_duration_seconds",
+		Help:    "Time taken to deliver an email to the SMTP server.",
+		Buckets: []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+	}, []string{"result"})
+)
+
+func ObserveSMTPSend(err error, duration time.Duration) {
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+	smtpSends.WithLabelValues(result).Inc()
+	smtpDuration.WithLabelValues(result).Observe(duration.Seconds())
+}
@@ -0,0 +1,25 @@
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObserveSMTPSend(t *testing.T) {
+	smtpSends.Reset()
+	smtpDuration.Reset()
+	reg := newTestRegistry(t, smtpSends, smtpDuration)
+
+	ObserveSMTPSend(nil, 100*time.Millisecond)
+	ObserveSMTPSend(nil, 200*time.Millisecond)
+	ObserveSMTPSend(errors.New("connection refused"), time.Second)
+
+	assert.Equal(t, 2, testutil.CollectAndCount(smtpSends))
+	assert.Equal(t, float64(2), testutil.ToFloat64(smtpSends.WithLabelValues("success")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(smtpSends.WithLabelValues("failure")))
+	assert.Equal(t, uint64(1), sampleCount(t, reg, "smtp_send_duration_seconds", map[string]string{"result": "failure"}))
+}
@@ -0,0 +1,61 @@
+package telemetry
+
+import (
+	"github.com/segmentio/kafka-go"
+	"google.golang.org/grpc/metadata"
+)
+
+// MetadataCarrier adapts gRPC metadata to a propagation.TextMapCarrier.
+type MetadataCarrier metadata.MD
+
+func (c MetadataCarrier) Get(key string) string {
+	values := metadata.MD(c).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func (c MetadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c MetadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// KafkaHeaderCarrier adapts Kafka message headers to a propagation.TextMapCarrier.
+type KafkaHeaderCarrier struct {
+	Headers *[]kafka.Header
+}
+
+func (c KafkaHeaderCarrier) Get(key string) string {
+	for _, h := range *c.Headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+func (c KafkaHeaderCarrier) Set(key, value string) {
+	for i, h := range *c.Headers {
+		if h.Key == key {
+			(*c.Headers)[i].Value = []byte(value)
+			return
+		}
+	}
+	*c.Headers = append(*c.Headers, kafka.Header{Key: key, Value: []byte(value)})
+}
+
+func (c KafkaHeaderCarrier) Keys() []string {
+	keys := make([]string, 0, len(*c.Headers))
+	for _, h := range *c.Headers {
+		keys = append(keys, h.Key)
+	}
+	return keys
+}
@@ -0,0 +1,73 @@
+package telemetry
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// PgxTracer creates a client span for every query run through a pgx connection.
+// Queries outside a trace, such as background polling, are not traced.
+type PgxTracer struct{}
+
+type pgxSpanKey struct{}
+
+var _ pgx.QueryTracer = PgxTracer{}
+
+func NewPgxTracer() PgxTracer {
+	return PgxTracer{}
+}
+
+func (PgxTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx
+	}
+
+	attrs := []attribute.KeyValue{
+		attribute.String("db.system.name", "postgresql"),
+		attribute.String("db.query.text", data.SQL),
+	}
+	if conn != nil {
+		attrs = append(attrs, attribute.String("db.namespace", conn.Config().Database))
+	}
+
+	ctx, span := Tracer().Start(ctx, querySpanName(data.SQL),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+	return context.WithValue(ctx, pgxSpanKey{}, span)
+}
+
+func (PgxTracer) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+	span, ok := ctx.Value(pgxSpanKey{}).(trace.Span)
+	if !ok {
+		return
+	}
+	defer span.End()
+
+	if data.Err != nil && data.Err != pgx.ErrNoRows {
+		span.RecordError(data.Err)
+		span.SetStatus(codes.Error, data.Err.Error())
+		return
+	}
+	span.SetAttributes(attribute.Int64("db.response.returned_rows", data.CommandTag.RowsAffected()))
+}
+
+// querySpanName uses the sqlc query name ("-- name: GetUserByID :one") when
+// present, and the SQL verb otherwise.
+func querySpanName(sql string) string {
+	sql = strings.TrimSpace(sql)
+	if rest, ok := strings.CutPrefix(sql, "-- name: "); ok {
+		if name, _, found := strings.Cut(rest, " "); found {
+			return name
+		}
+	}
+	if verb, _, found := strings.Cut(sql, " "); found {
+		return strings.ToUpper(verb)
+	}
+	return "query"
+}
@@ -0,0 +1,546 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: cart/cart.proto
+
+package cartpb
+
+import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type AddItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddItemRequest) Reset() {
+	*x = AddItemRequest{}
+	mi := &file_cart_cart_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddItemRequest) ProtoMessage() {}
+
+func (x *AddItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_cart_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddItemRequest.ProtoReflect.Descriptor instead.
+func (*AddItemRequest) Descriptor() ([]byte, []int) {
+	return file_cart_cart_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AddItemRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *AddItemRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type UpdateQuantityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateQuantityRequest) Reset() {
+	*x = UpdateQuantityRequest{}
+	mi := &file_cart_cart_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateQuantityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateQuantityRequest) ProtoMessage() {}
+
+func (x *UpdateQuantityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_cart_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateQuantityRequest.ProtoReflect.Descriptor instead.
+func (*UpdateQuantityRequest) Descriptor() ([]byte, []int) {
+	return file_cart_cart_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *UpdateQuantityRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *UpdateQuantityRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type RemoveItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveItemRequest) Reset() {
+	*x = RemoveItemRequest{}
+	mi := &file_cart_cart_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveItemRequest) ProtoMessage() {}
+
+func (x *RemoveItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_cart_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveItemRequest.ProtoReflect.Descriptor instead.
+func (*RemoveItemRequest) Descriptor() ([]byte, []int) {
+	return file_cart_cart_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RemoveItemRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+type CartItem struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	ProductId     string                  `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ProductName   string                  `protobuf:"bytes,2,opt,name=product_name,json=productName,proto3" json:"product_name,omitempty"`
+	ProductSku    string                  `protobuf:"bytes,3,opt,name=product_sku,json=productSku,proto3" json:"product_sku,omitempty"`
+	Thumbnail     *wrapperspb.StringValue `protobuf:"bytes,4,opt,name=thumbnail,proto3" json:"thumbnail,omitempty"`
+	Quantity      int32                   `protobuf:"varint,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	UnitPrice     float64                 `protobuf:"fixed64,6,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
+	Subtotal      float64                 `protobuf:"fixed64,7,opt,name=subtotal,proto3" json:"subtotal,omitempty"`
+	Available     bool                    `protobuf:"varint,8,opt,name=available,proto3" json:"available,omitempty"`
+	PriceChanged  bool                    `protobuf:"varint,9,opt,name=price_changed,json=priceChanged,proto3" json:"price_changed,omitempty"`
+	PreviousPrice *wrapperspb.DoubleValue `protobuf:"bytes,10,opt,name=previous_price,json=previousPrice,proto3" json:"previous_price,omitempty"`
+	AddedAt       *timestamppb.Timestamp  `protobuf:"bytes,11,opt,name=added_at,json=addedAt,proto3" json:"added_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CartItem) Reset() {
+	*x = CartItem{}
+	mi := &file_cart_cart_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CartItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CartItem) ProtoMessage() {}
+
+func (x *CartItem) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_cart_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CartItem.ProtoReflect.Descriptor instead.
+func (*CartItem) Descriptor() ([]byte, []int) {
+	return file_cart_cart_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CartItem) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *CartItem) GetProductName() string {
+	if x != nil {
+		return x.ProductName
+	}
+	return ""
+}
+
+func (x *CartItem) GetProductSku() string {
+	if x != nil {
+		return x.ProductSku
+	}
+	return ""
+}
+
+func (x *CartItem) GetThumbnail() *wrapperspb.StringValue {
+	if x != nil {
+		return x.Thumbnail
+	}
+	return nil
+}
+
+func (x *CartItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *CartItem) GetUnitPrice() float64 {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return 0
+}
+
+func (x *CartItem) GetSubtotal() float64 {
+	if x != nil {
+		return x.Subtotal
+	}
+	return 0
+}
+
+func (x *CartItem) GetAvailable() bool {
+	if x != nil {
+		return x.Available
+	}
+	return false
+}
+
+func (x *CartItem) GetPriceChanged() bool {
+	if x != nil {
+		return x.PriceChanged
+	}
+	return false
+}
+
+func (x *CartItem) GetPreviousPrice() *wrapperspb.DoubleValue {
+	if x != nil {
+		return x.PreviousPrice
+	}
+	return nil
+}
+
+func (x *CartItem) GetAddedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AddedAt
+	}
+	return nil
+}
+
+type Cart struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*CartItem            `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	TotalQuantity int32                  `protobuf:"varint,2,opt,name=total_quantity,json=totalQuantity,proto3" json:"total_quantity,omitempty"`
+	TotalAmount   float64                `protobuf:"fixed64,3,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+	HasChanges    bool                   `protobuf:"varint,4,opt,name=has_changes,json=hasChanges,proto3" json:"has_changes,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Cart) Reset() {
+	*x = Cart{}
+	mi := &file_cart_cart_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Cart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Cart) ProtoMessage() {}
+
+func (x *Cart) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_cart_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Cart.ProtoReflect.Descriptor instead.
+func (*Cart) Descriptor() ([]byte, []int) {
+	return file_cart_cart_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Cart) GetItems() []*CartItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Cart) GetTotalQuantity() int32 {
+	if x != nil {
+		return x.TotalQuantity
+	}
+	return 0
+}
+
+func (x *Cart) GetTotalAmount() float64 {
+	if x != nil {
+		return x.TotalAmount
+	}
+	return 0
+}
+
+func (x *Cart) GetHasChanges() bool {
+	if x != nil {
+		return x.HasChanges
+	}
+	return false
+}
+
+func (x *Cart) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type CartResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cart          *Cart                  `protobuf:"bytes,1,opt,name=cart,proto3" json:"cart,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CartResponse) Reset() {
+	*x = CartResponse{}
+	mi := &file_cart_cart_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CartResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CartResponse) ProtoMessage() {}
+
+func (x *CartResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cart_cart_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CartResponse.ProtoReflect.Descriptor instead.
+func (*CartResponse) Descriptor() ([]byte, []int) {
+	return file_cart_cart_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CartResponse) GetCart() *Cart {
+	if x != nil {
+		return x.Cart
+	}
+	return nil
+}
+
+var File_cart_cart_proto protoreflect.FileDescriptor
+
+const file_cart_cart_proto_rawDesc = "" +
+	"\n" +
+	"\x0fcart/cart.proto\x12\x04cart\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1egoogle/protobuf/wrappers.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bbuf/validate/validate.proto\x1a\x1cgoogle/api/annotations.proto\"`\n" +
+	"\x0eAddItemRequest\x12'\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\tproductId\x12%\n" +
+	"\bquantity\x18\x02 \x01(\x05B\t\xbaH\x06\x1a\x04\x18d(\x01R\bquantity\"g\n" +
+	"\x15UpdateQuantityRequest\x12'\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\tproductId\x12%\n" +
+	"\bquantity\x18\x02 \x01(\x05B\t\xbaH\x06\x1a\x04\x18d(\x01R\bquantity\"<\n" +
+	"\x11RemoveItemRequest\x12'\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\tproductId\"\xbf\x03\n" +
+	"\bCartItem\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12!\n" +
+	"\fproduct_name\x18\x02 \x01(\tR\vproductName\x12\x1f\n" +
+	"\vproduct_sku\x18\x03 \x01(\tR\n" +
+	"productSku\x12:\n" +
+	"\tthumbnail\x18\x04 \x01(\v2\x1c.google.protobuf.StringValueR\tthumbnail\x12\x1a\n" +
+	"\bquantity\x18\x05 \x01(\x05R\bquantity\x12\x1d\n" +
+	"\n" +
+	"unit_price\x18\x06 \x01(\x01R\tunitPrice\x12\x1a\n" +
+	"\bsubtotal\x18\a \x01(\x01R\bsubtotal\x12\x1c\n" +
+	"\tavailable\x18\b \x01(\bR\tavailable\x12#\n" +
+	"\rprice_changed\x18\t \x01(\bR\fpriceChanged\x12C\n" +
+	"\x0eprevious_price\x18\n" +
+	" \x01(\v2\x1c.google.protobuf.DoubleValueR\rpreviousPrice\x125\n" +
+	"\badded_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\aaddedAt\"\xd2\x01\n" +
+	"\x04Cart\x12$\n" +
+	"\x05items\x18\x01 \x03(\v2\x0e.cart.CartItemR\x05items\x12%\n" +
+	"\x0etotal_quantity\x18\x02 \x01(\x05R\rtotalQuantity\x12!\n" +
+	"\ftotal_amount\x18\x03 \x01(\x01R\vtotalAmount\x12\x1f\n" +
+	"\vhas_changes\x18\x04 \x01(\bR\n" +
+	"hasChanges\x129\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\".\n" +
+	"\fCartResponse\x12\x1e\n" +
+	"\x04cart\x18\x01 \x01(\v2\n" +
+	".cart.CartR\x04cart2\xc0\x03\n" +
+	"\vCartService\x12N\n" +
+	"\aAddItem\x12\x14.cart.AddItemRequest\x1a\x12.cart.CartResponse\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/v1/cart/items\x12i\n" +
+	"\x0eUpdateQuantity\x12\x1b.cart.UpdateQuantityRequest\x1a\x12.cart.CartResponse\"&\x82\xd3\xe4\x93\x02 :\x01*\x1a\x1b/v1/cart/items/{product_id}\x12^\n" +
+	"\n" +
+	"RemoveItem\x12\x17.cart.RemoveItemRequest\x1a\x12.cart.CartResponse\"#\x82\xd3\xe4\x93\x02\x1d*\x1b/v1/cart/items/{product_id}\x12G\n" +
+	"\aGetCart\x12\x16.google.protobuf.Empty\x1a\x12.cart.CartResponse\"\x10\x82\xd3\xe4\x93\x02\n" +
+	"\x12\b/v1/cart\x12M\n" +
+	"\tClearCart\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.Empty\"\x10\x82\xd3\xe4\x93\x02\n" +
+	"*\b/v1/cartB\x87\x01\n" +
+	"\bcom.cartB\tCartProtoP\x01Z@github.com/khoihuynh300/go-microservice/shared/proto/cart;cartpb\xa2\x02\x03CXX\xaa\x02\x04Cart\xca\x02\x04Cart\xe2\x02\x10Cart\\GPBMetadata\xea\x02\x04Cartb\x06proto3"
+
+var (
+	file_cart_cart_proto_rawDescOnce sync.Once
+	file_cart_cart_proto_rawDescData []byte
+)
+
+func file_cart_cart_proto_rawDescGZIP() []byte {
+	file_cart_cart_proto_rawDescOnce.Do(func() {
+		file_cart_cart_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_cart_cart_proto_rawDesc), len(file_cart_cart_proto_rawDesc)))
+	})
+	return file_cart_cart_proto_rawDescData
+}
+
+var file_cart_cart_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_cart_cart_proto_goTypes = []any{
+	(*AddItemRequest)(nil),         // 0: cart.AddItemRequest
+	(*UpdateQuantityRequest)(nil),  // 1: cart.UpdateQuantityRequest
+	(*RemoveItemRequest)(nil),      // 2: cart.RemoveItemRequest
+	(*CartItem)(nil),               // 3: cart.CartItem
+	(*Cart)(nil),                   // 4: cart.Cart
+	(*CartResponse)(nil),           // 5: cart.CartResponse
+	(*wrapperspb.StringValue)(nil), // 6: google.protobuf.StringValue
+	(*wrapperspb.DoubleValue)(nil), // 7: google.protobuf.DoubleValue
+	(*timestamppb.Timestamp)(nil),  // 8: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),          // 9: google.protobuf.Empty
+}
+var file_cart_cart_proto_depIdxs = []int32{
+	6,  // 0: cart.CartItem.thumbnail:type_name -> google.protobuf.StringValue
+	7,  // 1: cart.CartItem.previous_price:type_name -> google.protobuf.DoubleValue
+	8,  // 2: cart.CartItem.added_at:type_name -> google.protobuf.Timestamp
+	3,  // 3: cart.Cart.items:type_name -> cart.CartItem
+	8,  // 4: cart.Cart.updated_at:type_name -> google.protobuf.Timestamp
+	4,  // 5: cart.CartResponse.cart:type_name -> cart.Cart
+	0,  // 6: cart.CartService.AddItem:input_type -> cart.AddItemRequest
+	1,  // 7: cart.CartService.UpdateQuantity:input_type -> cart.UpdateQuantityRequest
+	2,  // 8: cart.CartService.RemoveItem:input_type -> cart.RemoveItemRequest
+	9,  // 9: cart.CartService.GetCart:input_type -> google.protobuf.Empty
+	9,  // 10: cart.CartService.ClearCart:input_type -> google.protobuf.Empty
+	5,  // 11: cart.CartService.AddItem:output_type -> cart.CartResponse
+	5,  // 12: cart.CartService.UpdateQuantity:output_type -> cart.CartResponse
+	5,  // 13: cart.CartService.RemoveItem:output_type -> cart.CartResponse
+	5,  // 14: cart.CartService.GetCart:output_type -> cart.CartResponse
+	9,  // 15: cart.CartService.ClearCart:output_type -> google.protobuf.Empty
+	11, // [11:16] is the sub-list for method output_type
+	6,  // [6:11] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_cart_cart_proto_init() }
+func file_cart_cart_proto_init() {
+	if File_cart_cart_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_cart_cart_proto_rawDesc), len(file_cart_cart_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_cart_cart_proto_goTypes,
+		DependencyIndexes: file_cart_cart_proto_depIdxs,
+		MessageInfos:      file_cart_cart_proto_msgTypes,
+	}.Build()
+	File_cart_cart_proto = out.File
+	file_cart_cart_proto_goTypes = nil
+	file_cart_cart_proto_depIdxs = nil
+}
@@ -12,7 +12,7 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
-	_ "google.golang.org/protobuf/types/known/timestamppb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
 	reflect "reflect"
 	sync "sync"
@@ -271,9 +271,12 @@ func (x *LoginRequest) GetPassword() string {
 }
 
 type TokenResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
-	RefreshToken  string                 `protobuf:"bytes,2,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken  string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	RefreshToken string                 `protobuf:"bytes,2,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	// set instead of the tokens when the account has two-factor authentication
+	MfaRequired   bool   `protobuf:"varint,3,opt,name=mfa_required,json=mfaRequired,proto3" json:"mfa_required,omitempty"`
+	MfaToken      string `protobuf:"bytes,4,opt,name=mfa_token,json=mfaToken,proto3" json:"mfa_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -322,27 +325,43 @@ func (x *TokenResponse) GetRefreshToken() string {
 	return ""
 }
 
-type RefreshRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+func (x *TokenResponse) GetMfaRequired() bool {
+	if x != nil {
+		return x.MfaRequired
+	}
+	return false
+}
+
+func (x *TokenResponse) GetMfaToken() string {
+	if x != nil {
+		return x.MfaToken
+	}
+	return ""
+}
+
+type VerifyMFARequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	MfaToken string                 `protobuf:"bytes,1,opt,name=mfa_token,json=mfaToken,proto3" json:"mfa_token,omitempty"`
+	// a TOTP code or an unused recovery code
+	Code          string `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RefreshRequest) Reset() {
-	*x = RefreshRequest{}
+func (x *VerifyMFARequest) Reset() {
+	*x = VerifyMFARequest{}
 	mi := &file_user_user_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RefreshRequest) String() string {
+func (x *VerifyMFARequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RefreshRequest) ProtoMessage() {}
+func (*VerifyMFARequest) ProtoMessage() {}
 
-func (x *RefreshRequest) ProtoReflect() protoreflect.Message {
+func (x *VerifyMFARequest) ProtoReflect() protoreflect.Message {
 	mi := &file_user_user_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -354,39 +373,46 @@ func (x *RefreshRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RefreshRequest.ProtoReflect.Descriptor instead.
-func (*RefreshRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use VerifyMFARequest.ProtoReflect.Descriptor instead.
+func (*VerifyMFARequest) Descriptor() ([]byte, []int) {
 	return file_user_user_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *RefreshRequest) GetRefreshToken() string {
+func (x *VerifyMFARequest) GetMfaToken() string {
 	if x != nil {
-		return x.RefreshToken
+		return x.MfaToken
 	}
 	return ""
 }
 
-type GetUserRequest struct {
+func (x *VerifyMFARequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type StartOAuthLoginRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Provider      string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetUserRequest) Reset() {
-	*x = GetUserRequest{}
+func (x *StartOAuthLoginRequest) Reset() {
+	*x = StartOAuthLoginRequest{}
 	mi := &file_user_user_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetUserRequest) String() string {
+func (x *StartOAuthLoginRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetUserRequest) ProtoMessage() {}
+func (*StartOAuthLoginRequest) ProtoMessage() {}
 
-func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
+func (x *StartOAuthLoginRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_user_user_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -398,39 +424,40 @@ func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetUserRequest.ProtoReflect.Descriptor instead.
-func (*GetUserRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use StartOAuthLoginRequest.ProtoReflect.Descriptor instead.
+func (*StartOAuthLoginRequest) Descriptor() ([]byte, []int) {
 	return file_user_user_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *GetUserRequest) GetUserId() string {
+func (x *StartOAuthLoginRequest) GetProvider() string {
 	if x != nil {
-		return x.UserId
+		return x.Provider
 	}
 	return ""
 }
 
-type GetUserResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+type StartOAuthLoginResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// the gateway redirects the browser here
+	RedirectUrl   string `protobuf:"bytes,1,opt,name=redirect_url,json=redirectUrl,proto3" json:"redirect_url,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetUserResponse) Reset() {
-	*x = GetUserResponse{}
+func (x *StartOAuthLoginResponse) Reset() {
+	*x = StartOAuthLoginResponse{}
 	mi := &file_user_user_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetUserResponse) String() string {
+func (x *StartOAuthLoginResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetUserResponse) ProtoMessage() {}
+func (*StartOAuthLoginResponse) ProtoMessage() {}
 
-func (x *GetUserResponse) ProtoReflect() protoreflect.Message {
+func (x *StartOAuthLoginResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_user_user_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -442,39 +469,41 @@ func (x *GetUserResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetUserResponse.ProtoReflect.Descriptor instead.
-func (*GetUserResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use StartOAuthLoginResponse.ProtoReflect.Descriptor instead.
+func (*StartOAuthLoginResponse) Descriptor() ([]byte, []int) {
 	return file_user_user_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *GetUserResponse) GetUser() *User {
+func (x *StartOAuthLoginResponse) GetRedirectUrl() string {
 	if x != nil {
-		return x.User
+		return x.RedirectUrl
 	}
-	return nil
+	return ""
 }
 
-type GetPublicUserResponse struct {
+type CompleteOAuthLoginRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	User          *PublicUserProfile     `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	Provider      string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	State         string                 `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetPublicUserResponse) Reset() {
-	*x = GetPublicUserResponse{}
+func (x *CompleteOAuthLoginRequest) Reset() {
+	*x = CompleteOAuthLoginRequest{}
 	mi := &file_user_user_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetPublicUserResponse) String() string {
+func (x *CompleteOAuthLoginRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetPublicUserResponse) ProtoMessage() {}
+func (*CompleteOAuthLoginRequest) ProtoMessage() {}
 
-func (x *GetPublicUserResponse) ProtoReflect() protoreflect.Message {
+func (x *CompleteOAuthLoginRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_user_user_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -486,41 +515,53 @@ func (x *GetPublicUserResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetPublicUserResponse.ProtoReflect.Descriptor instead.
-func (*GetPublicUserResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use CompleteOAuthLoginRequest.ProtoReflect.Descriptor instead.
+func (*CompleteOAuthLoginRequest) Descriptor() ([]byte, []int) {
 	return file_user_user_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *GetPublicUserResponse) GetUser() *PublicUserProfile {
+func (x *CompleteOAuthLoginRequest) GetProvider() string {
 	if x != nil {
-		return x.User
+		return x.Provider
 	}
-	return nil
+	return ""
 }
 
-type UpdateUserRequest struct {
+func (x *CompleteOAuthLoginRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CompleteOAuthLoginRequest) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+type RequestMagicLinkRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	FullName      *string                `protobuf:"bytes,1,opt,name=full_name,json=fullName,proto3,oneof" json:"full_name,omitempty"`
-	DateOfBirth   *string                `protobuf:"bytes,2,opt,name=date_of_birth,json=dateOfBirth,proto3,oneof" json:"date_of_birth,omitempty"`
-	Gender        *string                `protobuf:"bytes,3,opt,name=gender,proto3,oneof" json:"gender,omitempty"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateUserRequest) Reset() {
-	*x = UpdateUserRequest{}
+func (x *RequestMagicLinkRequest) Reset() {
+	*x = RequestMagicLinkRequest{}
 	mi := &file_user_user_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateUserRequest) String() string {
+func (x *RequestMagicLinkRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateUserRequest) ProtoMessage() {}
+func (*RequestMagicLinkRequest) ProtoMessage() {}
 
-func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
+func (x *RequestMagicLinkRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_user_user_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -532,53 +573,39 @@ func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateUserRequest.ProtoReflect.Descriptor instead.
-func (*UpdateUserRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use RequestMagicLinkRequest.ProtoReflect.Descriptor instead.
+func (*RequestMagicLinkRequest) Descriptor() ([]byte, []int) {
 	return file_user_user_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *UpdateUserRequest) GetFullName() string {
-	if x != nil && x.FullName != nil {
-		return *x.FullName
-	}
-	return ""
-}
-
-func (x *UpdateUserRequest) GetDateOfBirth() string {
-	if x != nil && x.DateOfBirth != nil {
-		return *x.DateOfBirth
-	}
-	return ""
-}
-
-func (x *UpdateUserRequest) GetGender() string {
-	if x != nil && x.Gender != nil {
-		return *x.Gender
+func (x *RequestMagicLinkRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
 	}
 	return ""
 }
 
-type UpdateAvatarRequest struct {
+type ConsumeMagicLinkRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	AvatarUrl     string                 `protobuf:"bytes,1,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateAvatarRequest) Reset() {
-	*x = UpdateAvatarRequest{}
+func (x *ConsumeMagicLinkRequest) Reset() {
+	*x = ConsumeMagicLinkRequest{}
 	mi := &file_user_user_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateAvatarRequest) String() string {
+func (x *ConsumeMagicLinkRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateAvatarRequest) ProtoMessage() {}
+func (*ConsumeMagicLinkRequest) ProtoMessage() {}
 
-func (x *UpdateAvatarRequest) ProtoReflect() protoreflect.Message {
+func (x *ConsumeMagicLinkRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_user_user_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -590,39 +617,39 @@ func (x *UpdateAvatarRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateAvatarRequest.ProtoReflect.Descriptor instead.
-func (*UpdateAvatarRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ConsumeMagicLinkRequest.ProtoReflect.Descriptor instead.
+func (*ConsumeMagicLinkRequest) Descriptor() ([]byte, []int) {
 	return file_user_user_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *UpdateAvatarRequest) GetAvatarUrl() string {
+func (x *ConsumeMagicLinkRequest) GetToken() string {
 	if x != nil {
-		return x.AvatarUrl
+		return x.Token
 	}
 	return ""
 }
 
-type UpdateUserResponse struct {
+type RefreshRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateUserResponse) Reset() {
-	*x = UpdateUserResponse{}
+func (x *RefreshRequest) Reset() {
+	*x = RefreshRequest{}
 	mi := &file_user_user_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateUserResponse) String() string {
+func (x *RefreshRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateUserResponse) ProtoMessage() {}
+func (*RefreshRequest) ProtoMessage() {}
 
-func (x *UpdateUserResponse) ProtoReflect() protoreflect.Message {
+func (x *RefreshRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_user_user_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -634,40 +661,40 @@ func (x *UpdateUserResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateUserResponse.ProtoReflect.Descriptor instead.
-func (*UpdateUserResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use RefreshRequest.ProtoReflect.Descriptor instead.
+func (*RefreshRequest) Descriptor() ([]byte, []int) {
 	return file_user_user_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *UpdateUserResponse) GetUser() *User {
+func (x *RefreshRequest) GetRefreshToken() string {
 	if x != nil {
-		return x.User
+		return x.RefreshToken
 	}
-	return nil
+	return ""
 }
 
-type ChangePasswordRequest struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	CurrentPassword string                 `protobuf:"bytes,1,opt,name=current_password,json=currentPassword,proto3" json:"current_password,omitempty"`
-	NewPassword     string                 `protobuf:"bytes,2,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+type EnrollTOTPResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Secret        string                 `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
+	OtpauthUri    string                 `protobuf:"bytes,2,opt,name=otpauth_uri,json=otpauthUri,proto3" json:"otpauth_uri,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ChangePasswordRequest) Reset() {
-	*x = ChangePasswordRequest{}
+func (x *EnrollTOTPResponse) Reset() {
+	*x = EnrollTOTPResponse{}
 	mi := &file_user_user_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ChangePasswordRequest) String() string {
+func (x *EnrollTOTPResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChangePasswordRequest) ProtoMessage() {}
+func (*EnrollTOTPResponse) ProtoMessage() {}
 
-func (x *ChangePasswordRequest) ProtoReflect() protoreflect.Message {
+func (x *EnrollTOTPResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_user_user_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -679,46 +706,46 @@ func (x *ChangePasswordRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChangePasswordRequest.ProtoReflect.Descriptor instead.
-func (*ChangePasswordRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use EnrollTOTPResponse.ProtoReflect.Descriptor instead.
+func (*EnrollTOTPResponse) Descriptor() ([]byte, []int) {
 	return file_user_user_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *ChangePasswordRequest) GetCurrentPassword() string {
+func (x *EnrollTOTPResponse) GetSecret() string {
 	if x != nil {
-		return x.CurrentPassword
+		return x.Secret
 	}
 	return ""
 }
 
-func (x *ChangePasswordRequest) GetNewPassword() string {
+func (x *EnrollTOTPResponse) GetOtpauthUri() string {
 	if x != nil {
-		return x.NewPassword
+		return x.OtpauthUri
 	}
 	return ""
 }
 
-type ForgotPasswordRequest struct {
+type ConfirmTOTPRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ForgotPasswordRequest) Reset() {
-	*x = ForgotPasswordRequest{}
+func (x *ConfirmTOTPRequest) Reset() {
+	*x = ConfirmTOTPRequest{}
 	mi := &file_user_user_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ForgotPasswordRequest) String() string {
+func (x *ConfirmTOTPRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ForgotPasswordRequest) ProtoMessage() {}
+func (*ConfirmTOTPRequest) ProtoMessage() {}
 
-func (x *ForgotPasswordRequest) ProtoReflect() protoreflect.Message {
+func (x *ConfirmTOTPRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_user_user_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -730,40 +757,39 @@ func (x *ForgotPasswordRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ForgotPasswordRequest.ProtoReflect.Descriptor instead.
-func (*ForgotPasswordRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ConfirmTOTPRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmTOTPRequest) Descriptor() ([]byte, []int) {
 	return file_user_user_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *ForgotPasswordRequest) GetEmail() string {
+func (x *ConfirmTOTPRequest) GetCode() string {
 	if x != nil {
-		return x.Email
+		return x.Code
 	}
 	return ""
 }
 
-type ResetPasswordRequest struct {
+type ConfirmTOTPResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ResetToken    string                 `protobuf:"bytes,1,opt,name=reset_token,json=resetToken,proto3" json:"reset_token,omitempty"`
-	NewPassword   string                 `protobuf:"bytes,2,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
+	RecoveryCodes []string               `protobuf:"bytes,1,rep,name=recovery_codes,json=recoveryCodes,proto3" json:"recovery_codes,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ResetPasswordRequest) Reset() {
-	*x = ResetPasswordRequest{}
+func (x *ConfirmTOTPResponse) Reset() {
+	*x = ConfirmTOTPResponse{}
 	mi := &file_user_user_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ResetPasswordRequest) String() string {
+func (x *ConfirmTOTPResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ResetPasswordRequest) ProtoMessage() {}
+func (*ConfirmTOTPResponse) ProtoMessage() {}
 
-func (x *ResetPasswordRequest) ProtoReflect() protoreflect.Message {
+func (x *ConfirmTOTPResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_user_user_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -775,54 +801,40 @@ func (x *ResetPasswordRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ResetPasswordRequest.ProtoReflect.Descriptor instead.
-func (*ResetPasswordRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ConfirmTOTPResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmTOTPResponse) Descriptor() ([]byte, []int) {
 	return file_user_user_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *ResetPasswordRequest) GetResetToken() string {
-	if x != nil {
-		return x.ResetToken
-	}
-	return ""
-}
-
-func (x *ResetPasswordRequest) GetNewPassword() string {
+func (x *ConfirmTOTPResponse) GetRecoveryCodes() []string {
 	if x != nil {
-		return x.NewPassword
+		return x.RecoveryCodes
 	}
-	return ""
+	return nil
 }
 
-type CreateUserAddressRequest struct {
+type DisableTOTPRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	AddressType   string                 `protobuf:"bytes,1,opt,name=address_type,json=addressType,proto3" json:"address_type,omitempty"`
-	FullName      string                 `protobuf:"bytes,2,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
-	Phone         string                 `protobuf:"bytes,3,opt,name=phone,proto3" json:"phone,omitempty"`
-	AddressLine1  string                 `protobuf:"bytes,4,opt,name=address_line1,json=addressLine1,proto3" json:"address_line1,omitempty"`
-	AddressLine2  string                 `protobuf:"bytes,5,opt,name=address_line2,json=addressLine2,proto3" json:"address_line2,omitempty"`
-	Ward          string                 `protobuf:"bytes,6,opt,name=ward,proto3" json:"ward,omitempty"`
-	City          string                 `protobuf:"bytes,7,opt,name=city,proto3" json:"city,omitempty"`
-	Country       string                 `protobuf:"bytes,8,opt,name=country,proto3" json:"country,omitempty"`
-	IsDefault     bool                   `protobuf:"varint,9,opt,name=is_default,json=isDefault,proto3" json:"is_default,omitempty"`
+	Password      string                 `protobuf:"bytes,1,opt,name=password,proto3" json:"password,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateUserAddressRequest) Reset() {
-	*x = CreateUserAddressRequest{}
+func (x *DisableTOTPRequest) Reset() {
+	*x = DisableTOTPRequest{}
 	mi := &file_user_user_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateUserAddressRequest) String() string {
+func (x *DisableTOTPRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateUserAddressRequest) ProtoMessage() {}
+func (*DisableTOTPRequest) ProtoMessage() {}
 
-func (x *CreateUserAddressRequest) ProtoReflect() protoreflect.Message {
+func (x *DisableTOTPRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_user_user_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -834,96 +846,91 @@ func (x *CreateUserAddressRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateUserAddressRequest.ProtoReflect.Descriptor instead.
-func (*CreateUserAddressRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use DisableTOTPRequest.ProtoReflect.Descriptor instead.
+func (*DisableTOTPRequest) Descriptor() ([]byte, []int) {
 	return file_user_user_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *CreateUserAddressRequest) GetAddressType() string {
+func (x *DisableTOTPRequest) GetPassword() string {
 	if x != nil {
-		return x.AddressType
+		return x.Password
 	}
 	return ""
 }
 
-func (x *CreateUserAddressRequest) GetFullName() string {
+func (x *DisableTOTPRequest) GetCode() string {
 	if x != nil {
-		return x.FullName
+		return x.Code
 	}
 	return ""
 }
 
-func (x *CreateUserAddressRequest) GetPhone() string {
-	if x != nil {
-		return x.Phone
-	}
-	return ""
+type LogoutRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateUserAddressRequest) GetAddressLine1() string {
-	if x != nil {
-		return x.AddressLine1
-	}
-	return ""
+func (x *LogoutRequest) Reset() {
+	*x = LogoutRequest{}
+	mi := &file_user_user_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateUserAddressRequest) GetAddressLine2() string {
-	if x != nil {
-		return x.AddressLine2
-	}
-	return ""
+func (x *LogoutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *CreateUserAddressRequest) GetWard() string {
+func (*LogoutRequest) ProtoMessage() {}
+
+func (x *LogoutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[17]
 	if x != nil {
-		return x.Ward
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *CreateUserAddressRequest) GetCity() string {
+// Deprecated: Use LogoutRequest.ProtoReflect.Descriptor instead.
+func (*LogoutRequest) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *LogoutRequest) GetRefreshToken() string {
 	if x != nil {
-		return x.City
+		return x.RefreshToken
 	}
 	return ""
 }
 
-func (x *CreateUserAddressRequest) GetCountry() string {
-	if x != nil {
-		return x.Country
-	}
-	return ""
-}
-
-func (x *CreateUserAddressRequest) GetIsDefault() bool {
-	if x != nil {
-		return x.IsDefault
-	}
-	return false
-}
-
-type CreateUserAddressResponse struct {
+type ListSessionsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Address       *Address               `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Sessions      []*Session             `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateUserAddressResponse) Reset() {
-	*x = CreateUserAddressResponse{}
-	mi := &file_user_user_proto_msgTypes[17]
+func (x *ListSessionsResponse) Reset() {
+	*x = ListSessionsResponse{}
+	mi := &file_user_user_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateUserAddressResponse) String() string {
+func (x *ListSessionsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateUserAddressResponse) ProtoMessage() {}
+func (*ListSessionsResponse) ProtoMessage() {}
 
-func (x *CreateUserAddressResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_user_proto_msgTypes[17]
+func (x *ListSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -934,49 +941,40 @@ func (x *CreateUserAddressResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateUserAddressResponse.ProtoReflect.Descriptor instead.
-func (*CreateUserAddressResponse) Descriptor() ([]byte, []int) {
-	return file_user_user_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use ListSessionsResponse.ProtoReflect.Descriptor instead.
+func (*ListSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *CreateUserAddressResponse) GetAddress() *Address {
+func (x *ListSessionsResponse) GetSessions() []*Session {
 	if x != nil {
-		return x.Address
+		return x.Sessions
 	}
 	return nil
 }
 
-type UpdateUserAddressRequest struct {
+type RevokeSessionRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	AddressId     string                 `protobuf:"bytes,1,opt,name=address_id,json=addressId,proto3" json:"address_id,omitempty"`
-	AddressType   *string                `protobuf:"bytes,2,opt,name=address_type,json=addressType,proto3,oneof" json:"address_type,omitempty"`
-	FullName      *string                `protobuf:"bytes,3,opt,name=full_name,json=fullName,proto3,oneof" json:"full_name,omitempty"`
-	Phone         *string                `protobuf:"bytes,4,opt,name=phone,proto3,oneof" json:"phone,omitempty"`
-	AddressLine1  *string                `protobuf:"bytes,5,opt,name=address_line1,json=addressLine1,proto3,oneof" json:"address_line1,omitempty"`
-	AddressLine2  *string                `protobuf:"bytes,6,opt,name=address_line2,json=addressLine2,proto3,oneof" json:"address_line2,omitempty"`
-	Ward          *string                `protobuf:"bytes,7,opt,name=ward,proto3,oneof" json:"ward,omitempty"`
-	City          *string                `protobuf:"bytes,8,opt,name=city,proto3,oneof" json:"city,omitempty"`
-	Country       *string                `protobuf:"bytes,9,opt,name=country,proto3,oneof" json:"country,omitempty"`
-	IsDefault     *bool                  `protobuf:"varint,10,opt,name=is_default,json=isDefault,proto3,oneof" json:"is_default,omitempty"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateUserAddressRequest) Reset() {
-	*x = UpdateUserAddressRequest{}
-	mi := &file_user_user_proto_msgTypes[18]
+func (x *RevokeSessionRequest) Reset() {
+	*x = RevokeSessionRequest{}
+	mi := &file_user_user_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateUserAddressRequest) String() string {
+func (x *RevokeSessionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateUserAddressRequest) ProtoMessage() {}
+func (*RevokeSessionRequest) ProtoMessage() {}
 
-func (x *UpdateUserAddressRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_user_proto_msgTypes[18]
+func (x *RevokeSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -987,103 +985,84 @@ func (x *UpdateUserAddressRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateUserAddressRequest.ProtoReflect.Descriptor instead.
-func (*UpdateUserAddressRequest) Descriptor() ([]byte, []int) {
-	return file_user_user_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use RevokeSessionRequest.ProtoReflect.Descriptor instead.
+func (*RevokeSessionRequest) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *UpdateUserAddressRequest) GetAddressId() string {
+func (x *RevokeSessionRequest) GetSessionId() string {
 	if x != nil {
-		return x.AddressId
-	}
-	return ""
-}
-
-func (x *UpdateUserAddressRequest) GetAddressType() string {
-	if x != nil && x.AddressType != nil {
-		return *x.AddressType
+		return x.SessionId
 	}
 	return ""
 }
 
-func (x *UpdateUserAddressRequest) GetFullName() string {
-	if x != nil && x.FullName != nil {
-		return *x.FullName
-	}
-	return ""
+type GetUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateUserAddressRequest) GetPhone() string {
-	if x != nil && x.Phone != nil {
-		return *x.Phone
-	}
-	return ""
+func (x *GetUserRequest) Reset() {
+	*x = GetUserRequest{}
+	mi := &file_user_user_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateUserAddressRequest) GetAddressLine1() string {
-	if x != nil && x.AddressLine1 != nil {
-		return *x.AddressLine1
-	}
-	return ""
+func (x *GetUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *UpdateUserAddressRequest) GetAddressLine2() string {
-	if x != nil && x.AddressLine2 != nil {
-		return *x.AddressLine2
-	}
-	return ""
-}
+func (*GetUserRequest) ProtoMessage() {}
 
-func (x *UpdateUserAddressRequest) GetWard() string {
-	if x != nil && x.Ward != nil {
-		return *x.Ward
+func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *UpdateUserAddressRequest) GetCity() string {
-	if x != nil && x.City != nil {
-		return *x.City
-	}
-	return ""
+// Deprecated: Use GetUserRequest.ProtoReflect.Descriptor instead.
+func (*GetUserRequest) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *UpdateUserAddressRequest) GetCountry() string {
-	if x != nil && x.Country != nil {
-		return *x.Country
+func (x *GetUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
 	}
 	return ""
 }
 
-func (x *UpdateUserAddressRequest) GetIsDefault() bool {
-	if x != nil && x.IsDefault != nil {
-		return *x.IsDefault
-	}
-	return false
-}
-
-type UpdateUserAddressResponse struct {
+type GetUserResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Address       *Address               `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateUserAddressResponse) Reset() {
-	*x = UpdateUserAddressResponse{}
-	mi := &file_user_user_proto_msgTypes[19]
+func (x *GetUserResponse) Reset() {
+	*x = GetUserResponse{}
+	mi := &file_user_user_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateUserAddressResponse) String() string {
+func (x *GetUserResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateUserAddressResponse) ProtoMessage() {}
+func (*GetUserResponse) ProtoMessage() {}
 
-func (x *UpdateUserAddressResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_user_proto_msgTypes[19]
+func (x *GetUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1094,40 +1073,40 @@ func (x *UpdateUserAddressResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateUserAddressResponse.ProtoReflect.Descriptor instead.
-func (*UpdateUserAddressResponse) Descriptor() ([]byte, []int) {
-	return file_user_user_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use GetUserResponse.ProtoReflect.Descriptor instead.
+func (*GetUserResponse) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *UpdateUserAddressResponse) GetAddress() *Address {
+func (x *GetUserResponse) GetUser() *User {
 	if x != nil {
-		return x.Address
+		return x.User
 	}
 	return nil
 }
 
-type GetUserAddressesResponse struct {
+type GetPublicUserResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Addresses     []*Address             `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	User          *PublicUserProfile     `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetUserAddressesResponse) Reset() {
-	*x = GetUserAddressesResponse{}
-	mi := &file_user_user_proto_msgTypes[20]
+func (x *GetPublicUserResponse) Reset() {
+	*x = GetPublicUserResponse{}
+	mi := &file_user_user_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetUserAddressesResponse) String() string {
+func (x *GetPublicUserResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetUserAddressesResponse) ProtoMessage() {}
+func (*GetPublicUserResponse) ProtoMessage() {}
 
-func (x *GetUserAddressesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_user_proto_msgTypes[20]
+func (x *GetPublicUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1138,40 +1117,42 @@ func (x *GetUserAddressesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetUserAddressesResponse.ProtoReflect.Descriptor instead.
-func (*GetUserAddressesResponse) Descriptor() ([]byte, []int) {
-	return file_user_user_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use GetPublicUserResponse.ProtoReflect.Descriptor instead.
+func (*GetPublicUserResponse) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *GetUserAddressesResponse) GetAddresses() []*Address {
+func (x *GetPublicUserResponse) GetUser() *PublicUserProfile {
 	if x != nil {
-		return x.Addresses
+		return x.User
 	}
 	return nil
 }
 
-type GetUserAddressRequest struct {
+type UpdateUserRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	AddressId     string                 `protobuf:"bytes,1,opt,name=address_id,json=addressId,proto3" json:"address_id,omitempty"`
+	FullName      *string                `protobuf:"bytes,1,opt,name=full_name,json=fullName,proto3,oneof" json:"full_name,omitempty"`
+	DateOfBirth   *string                `protobuf:"bytes,2,opt,name=date_of_birth,json=dateOfBirth,proto3,oneof" json:"date_of_birth,omitempty"`
+	Gender        *string                `protobuf:"bytes,3,opt,name=gender,proto3,oneof" json:"gender,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetUserAddressRequest) Reset() {
-	*x = GetUserAddressRequest{}
-	mi := &file_user_user_proto_msgTypes[21]
+func (x *UpdateUserRequest) Reset() {
+	*x = UpdateUserRequest{}
+	mi := &file_user_user_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetUserAddressRequest) String() string {
+func (x *UpdateUserRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetUserAddressRequest) ProtoMessage() {}
+func (*UpdateUserRequest) ProtoMessage() {}
 
-func (x *GetUserAddressRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_user_proto_msgTypes[21]
+func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1182,40 +1163,54 @@ func (x *GetUserAddressRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetUserAddressRequest.ProtoReflect.Descriptor instead.
-func (*GetUserAddressRequest) Descriptor() ([]byte, []int) {
-	return file_user_user_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use UpdateUserRequest.ProtoReflect.Descriptor instead.
+func (*UpdateUserRequest) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *GetUserAddressRequest) GetAddressId() string {
-	if x != nil {
-		return x.AddressId
+func (x *UpdateUserRequest) GetFullName() string {
+	if x != nil && x.FullName != nil {
+		return *x.FullName
 	}
 	return ""
 }
 
-type GetUserAddressResponse struct {
+func (x *UpdateUserRequest) GetDateOfBirth() string {
+	if x != nil && x.DateOfBirth != nil {
+		return *x.DateOfBirth
+	}
+	return ""
+}
+
+func (x *UpdateUserRequest) GetGender() string {
+	if x != nil && x.Gender != nil {
+		return *x.Gender
+	}
+	return ""
+}
+
+type UpdateAvatarRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Address       *Address               `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	AvatarUrl     string                 `protobuf:"bytes,1,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetUserAddressResponse) Reset() {
-	*x = GetUserAddressResponse{}
-	mi := &file_user_user_proto_msgTypes[22]
+func (x *UpdateAvatarRequest) Reset() {
+	*x = UpdateAvatarRequest{}
+	mi := &file_user_user_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetUserAddressResponse) String() string {
+func (x *UpdateAvatarRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetUserAddressResponse) ProtoMessage() {}
+func (*UpdateAvatarRequest) ProtoMessage() {}
 
-func (x *GetUserAddressResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_user_proto_msgTypes[22]
+func (x *UpdateAvatarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1226,40 +1221,1967 @@ func (x *GetUserAddressResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetUserAddressResponse.ProtoReflect.Descriptor instead.
-func (*GetUserAddressResponse) Descriptor() ([]byte, []int) {
-	return file_user_user_proto_rawDescGZIP(), []int{22}
-}
+// Deprecated: Use UpdateAvatarRequest.ProtoReflect.Descriptor instead.
+func (*UpdateAvatarRequest) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *UpdateAvatarRequest) GetAvatarUrl() string {
+	if x != nil {
+		return x.AvatarUrl
+	}
+	return ""
+}
+
+type RequestPhoneVerificationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Phone         string                 `protobuf:"bytes,1,opt,name=phone,proto3" json:"phone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestPhoneVerificationRequest) Reset() {
+	*x = RequestPhoneVerificationRequest{}
+	mi := &file_user_user_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestPhoneVerificationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestPhoneVerificationRequest) ProtoMessage() {}
+
+func (x *RequestPhoneVerificationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestPhoneVerificationRequest.ProtoReflect.Descriptor instead.
+func (*RequestPhoneVerificationRequest) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *RequestPhoneVerificationRequest) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+type VerifyPhoneRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyPhoneRequest) Reset() {
+	*x = VerifyPhoneRequest{}
+	mi := &file_user_user_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyPhoneRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyPhoneRequest) ProtoMessage() {}
+
+func (x *VerifyPhoneRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyPhoneRequest.ProtoReflect.Descriptor instead.
+func (*VerifyPhoneRequest) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *VerifyPhoneRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type UpdateUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateUserResponse) Reset() {
+	*x = UpdateUserResponse{}
+	mi := &file_user_user_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateUserResponse) ProtoMessage() {}
+
+func (x *UpdateUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateUserResponse.ProtoReflect.Descriptor instead.
+func (*UpdateUserResponse) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *UpdateUserResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type DeleteAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Password      string                 `protobuf:"bytes,1,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAccountRequest) Reset() {
+	*x = DeleteAccountRequest{}
+	mi := &file_user_user_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAccountRequest) ProtoMessage() {}
+
+func (x *DeleteAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAccountRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAccountRequest) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *DeleteAccountRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type ChangePasswordRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	CurrentPassword string                 `protobuf:"bytes,1,opt,name=current_password,json=currentPassword,proto3" json:"current_password,omitempty"`
+	NewPassword     string                 `protobuf:"bytes,2,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ChangePasswordRequest) Reset() {
+	*x = ChangePasswordRequest{}
+	mi := &file_user_user_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangePasswordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangePasswordRequest) ProtoMessage() {}
+
+func (x *ChangePasswordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangePasswordRequest.ProtoReflect.Descriptor instead.
+func (*ChangePasswordRequest) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ChangePasswordRequest) GetCurrentPassword() string {
+	if x != nil {
+		return x.CurrentPassword
+	}
+	return ""
+}
+
+func (x *ChangePasswordRequest) GetNewPassword() string {
+	if x != nil {
+		return x.NewPassword
+	}
+	return ""
+}
+
+type ForgotPasswordRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForgotPasswordRequest) Reset() {
+	*x = ForgotPasswordRequest{}
+	mi := &file_user_user_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForgotPasswordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForgotPasswordRequest) ProtoMessage() {}
+
+func (x *ForgotPasswordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForgotPasswordRequest.ProtoReflect.Descriptor instead.
+func (*ForgotPasswordRequest) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ForgotPasswordRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type ResetPasswordRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ResetToken    string                 `protobuf:"bytes,1,opt,name=reset_token,json=resetToken,proto3" json:"reset_token,omitempty"`
+	NewPassword   string                 `protobuf:"bytes,2,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResetPasswordRequest) Reset() {
+	*x = ResetPasswordRequest{}
+	mi := &file_user_user_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetPasswordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetPasswordRequest) ProtoMessage() {}
+
+func (x *ResetPasswordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetPasswordRequest.ProtoReflect.Descriptor instead.
+func (*ResetPasswordRequest) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ResetPasswordRequest) GetResetToken() string {
+	if x != nil {
+		return x.ResetToken
+	}
+	return ""
+}
+
+func (x *ResetPasswordRequest) GetNewPassword() string {
+	if x != nil {
+		return x.NewPassword
+	}
+	return ""
+}
+
+type RequestEmailChangeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NewEmail      string                 `protobuf:"bytes,1,opt,name=new_email,json=newEmail,proto3" json:"new_email,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestEmailChangeRequest) Reset() {
+	*x = RequestEmailChangeRequest{}
+	mi := &file_user_user_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestEmailChangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestEmailChangeRequest) ProtoMessage() {}
+
+func (x *RequestEmailChangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestEmailChangeRequest.ProtoReflect.Descriptor instead.
+func (*RequestEmailChangeRequest) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *RequestEmailChangeRequest) GetNewEmail() string {
+	if x != nil {
+		return x.NewEmail
+	}
+	return ""
+}
+
+func (x *RequestEmailChangeRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type ConfirmEmailChangeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmEmailChangeRequest) Reset() {
+	*x = ConfirmEmailChangeRequest{}
+	mi := &file_user_user_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmEmailChangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmEmailChangeRequest) ProtoMessage() {}
+
+func (x *ConfirmEmailChangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmEmailChangeRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmEmailChangeRequest) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ConfirmEmailChangeRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type UnlockAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UnlockToken   string                 `protobuf:"bytes,1,opt,name=unlock_token,json=unlockToken,proto3" json:"unlock_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlockAccountRequest) Reset() {
+	*x = UnlockAccountRequest{}
+	mi := &file_user_user_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlockAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlockAccountRequest) ProtoMessage() {}
+
+func (x *UnlockAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlockAccountRequest.ProtoReflect.Descriptor instead.
+func (*UnlockAccountRequest) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *UnlockAccountRequest) GetUnlockToken() string {
+	if x != nil {
+		return x.UnlockToken
+	}
+	return ""
+}
+
+type CreateUserAddressRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AddressType   string                 `protobuf:"bytes,1,opt,name=address_type,json=addressType,proto3" json:"address_type,omitempty"`
+	FullName      string                 `protobuf:"bytes,2,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
+	Phone         string                 `protobuf:"bytes,3,opt,name=phone,proto3" json:"phone,omitempty"`
+	AddressLine1  string                 `protobuf:"bytes,4,opt,name=address_line1,json=addressLine1,proto3" json:"address_line1,omitempty"`
+	AddressLine2  string                 `protobuf:"bytes,5,opt,name=address_line2,json=addressLine2,proto3" json:"address_line2,omitempty"`
+	Ward          string                 `protobuf:"bytes,6,opt,name=ward,proto3" json:"ward,omitempty"`
+	City          string                 `protobuf:"bytes,7,opt,name=city,proto3" json:"city,omitempty"`
+	Country       string                 `protobuf:"bytes,8,opt,name=country,proto3" json:"country,omitempty"`
+	IsDefault     bool                   `protobuf:"varint,9,opt,name=is_default,json=isDefault,proto3" json:"is_default,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateUserAddressRequest) Reset() {
+	*x = CreateUserAddressRequest{}
+	mi := &file_user_user_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateUserAddressRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateUserAddressRequest) ProtoMessage() {}
+
+func (x *CreateUserAddressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateUserAddressRequest.ProtoReflect.Descriptor instead.
+func (*CreateUserAddressRequest) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *CreateUserAddressRequest) GetAddressType() string {
+	if x != nil {
+		return x.AddressType
+	}
+	return ""
+}
+
+func (x *CreateUserAddressRequest) GetFullName() string {
+	if x != nil {
+		return x.FullName
+	}
+	return ""
+}
+
+func (x *CreateUserAddressRequest) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *CreateUserAddressRequest) GetAddressLine1() string {
+	if x != nil {
+		return x.AddressLine1
+	}
+	return ""
+}
+
+func (x *CreateUserAddressRequest) GetAddressLine2() string {
+	if x != nil {
+		return x.AddressLine2
+	}
+	return ""
+}
+
+func (x *CreateUserAddressRequest) GetWard() string {
+	if x != nil {
+		return x.Ward
+	}
+	return ""
+}
+
+func (x *CreateUserAddressRequest) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *CreateUserAddressRequest) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *CreateUserAddressRequest) GetIsDefault() bool {
+	if x != nil {
+		return x.IsDefault
+	}
+	return false
+}
+
+type CreateUserAddressResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       *Address               `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateUserAddressResponse) Reset() {
+	*x = CreateUserAddressResponse{}
+	mi := &file_user_user_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateUserAddressResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateUserAddressResponse) ProtoMessage() {}
+
+func (x *CreateUserAddressResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateUserAddressResponse.ProtoReflect.Descriptor instead.
+func (*CreateUserAddressResponse) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *CreateUserAddressResponse) GetAddress() *Address {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+type UpdateUserAddressRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AddressId     string                 `protobuf:"bytes,1,opt,name=address_id,json=addressId,proto3" json:"address_id,omitempty"`
+	AddressType   *string                `protobuf:"bytes,2,opt,name=address_type,json=addressType,proto3,oneof" json:"address_type,omitempty"`
+	FullName      *string                `protobuf:"bytes,3,opt,name=full_name,json=fullName,proto3,oneof" json:"full_name,omitempty"`
+	Phone         *string                `protobuf:"bytes,4,opt,name=phone,proto3,oneof" json:"phone,omitempty"`
+	AddressLine1  *string                `protobuf:"bytes,5,opt,name=address_line1,json=addressLine1,proto3,oneof" json:"address_line1,omitempty"`
+	AddressLine2  *string                `protobuf:"bytes,6,opt,name=address_line2,json=addressLine2,proto3,oneof" json:"address_line2,omitempty"`
+	Ward          *string                `protobuf:"bytes,7,opt,name=ward,proto3,oneof" json:"ward,omitempty"`
+	City          *string                `protobuf:"bytes,8,opt,name=city,proto3,oneof" json:"city,omitempty"`
+	Country       *string                `protobuf:"bytes,9,opt,name=country,proto3,oneof" json:"country,omitempty"`
+	IsDefault     *bool                  `protobuf:"varint,10,opt,name=is_default,json=isDefault,proto3,oneof" json:"is_default,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateUserAddressRequest) Reset() {
+	*x = UpdateUserAddressRequest{}
+	mi := &file_user_user_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateUserAddressRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateUserAddressRequest) ProtoMessage() {}
+
+func (x *UpdateUserAddressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateUserAddressRequest.ProtoReflect.Descriptor instead.
+func (*UpdateUserAddressRequest) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *UpdateUserAddressRequest) GetAddressId() string {
+	if x != nil {
+		return x.AddressId
+	}
+	return ""
+}
+
+func (x *UpdateUserAddressRequest) GetAddressType() string {
+	if x != nil && x.AddressType != nil {
+		return *x.AddressType
+	}
+	return ""
+}
+
+func (x *UpdateUserAddressRequest) GetFullName() string {
+	if x != nil && x.FullName != nil {
+		return *x.FullName
+	}
+	return ""
+}
+
+func (x *UpdateUserAddressRequest) GetPhone() string {
+	if x != nil && x.Phone != nil {
+		return *x.Phone
+	}
+	return ""
+}
+
+func (x *UpdateUserAddressRequest) GetAddressLine1() string {
+	if x != nil && x.AddressLine1 != nil {
+		return *x.AddressLine1
+	}
+	return ""
+}
+
+func (x *UpdateUserAddressRequest) GetAddressLine2() string {
+	if x != nil && x.AddressLine2 != nil {
+		return *x.AddressLine2
+	}
+	return ""
+}
+
+func (x *UpdateUserAddressRequest) GetWard() string {
+	if x != nil && x.Ward != nil {
+		return *x.Ward
+	}
+	return ""
+}
+
+func (x *UpdateUserAddressRequest) GetCity() string {
+	if x != nil && x.City != nil {
+		return *x.City
+	}
+	return ""
+}
+
+func (x *UpdateUserAddressRequest) GetCountry() string {
+	if x != nil && x.Country != nil {
+		return *x.Country
+	}
+	return ""
+}
+
+func (x *UpdateUserAddressRequest) GetIsDefault() bool {
+	if x != nil && x.IsDefault != nil {
+		return *x.IsDefault
+	}
+	return false
+}
+
+type UpdateUserAddressResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       *Address               `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateUserAddressResponse) Reset() {
+	*x = UpdateUserAddressResponse{}
+	mi := &file_user_user_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateUserAddressResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateUserAddressResponse) ProtoMessage() {}
+
+func (x *UpdateUserAddressResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateUserAddressResponse.ProtoReflect.Descriptor instead.
+func (*UpdateUserAddressResponse) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *UpdateUserAddressResponse) GetAddress() *Address {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+type GetUserAddressesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Addresses     []*Address             `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserAddressesResponse) Reset() {
+	*x = GetUserAddressesResponse{}
+	mi := &file_user_user_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserAddressesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserAddressesResponse) ProtoMessage() {}
+
+func (x *GetUserAddressesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserAddressesResponse.ProtoReflect.Descriptor instead.
+func (*GetUserAddressesResponse) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *GetUserAddressesResponse) GetAddresses() []*Address {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+type GetUserAddressRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AddressId     string                 `protobuf:"bytes,1,opt,name=address_id,json=addressId,proto3" json:"address_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserAddressRequest) Reset() {
+	*x = GetUserAddressRequest{}
+	mi := &file_user_user_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserAddressRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserAddressRequest) ProtoMessage() {}
+
+func (x *GetUserAddressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserAddressRequest.ProtoReflect.Descriptor instead.
+func (*GetUserAddressRequest) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *GetUserAddressRequest) GetAddressId() string {
+	if x != nil {
+		return x.AddressId
+	}
+	return ""
+}
+
+type GetUserAddressResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       *Address               `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserAddressResponse) Reset() {
+	*x = GetUserAddressResponse{}
+	mi := &file_user_user_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserAddressResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserAddressResponse) ProtoMessage() {}
+
+func (x *GetUserAddressResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserAddressResponse.ProtoReflect.Descriptor instead.
+func (*GetUserAddressResponse) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *GetUserAddressResponse) GetAddress() *Address {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+type DeleteUserAddressRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AddressId     string                 `protobuf:"bytes,1,opt,name=address_id,json=addressId,proto3" json:"address_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserAddressRequest) Reset() {
+	*x = DeleteUserAddressRequest{}
+	mi := &file_user_user_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserAddressRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserAddressRequest) ProtoMessage() {}
+
+func (x *DeleteUserAddressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserAddressRequest.ProtoReflect.Descriptor instead.
+func (*DeleteUserAddressRequest) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *DeleteUserAddressRequest) GetAddressId() string {
+	if x != nil {
+		return x.AddressId
+	}
+	return ""
+}
+
+type SetDefaultUserAddressRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AddressId     string                 `protobuf:"bytes,1,opt,name=address_id,json=addressId,proto3" json:"address_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetDefaultUserAddressRequest) Reset() {
+	*x = SetDefaultUserAddressRequest{}
+	mi := &file_user_user_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetDefaultUserAddressRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetDefaultUserAddressRequest) ProtoMessage() {}
+
+func (x *SetDefaultUserAddressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetDefaultUserAddressRequest.ProtoReflect.Descriptor instead.
+func (*SetDefaultUserAddressRequest) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *SetDefaultUserAddressRequest) GetAddressId() string {
+	if x != nil {
+		return x.AddressId
+	}
+	return ""
+}
+
+type ListUsersRequest struct {
+	state  protoimpl.MessageState  `protogen:"open.v1"`
+	Status *wrapperspb.StringValue `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// case-insensitive substring of the email
+	Email         *wrapperspb.StringValue `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	CreatedFrom   *timestamppb.Timestamp  `protobuf:"bytes,3,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	CreatedTo     *timestamppb.Timestamp  `protobuf:"bytes,4,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
+	Page          int32                   `protobuf:"varint,5,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                   `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersRequest) Reset() {
+	*x = ListUsersRequest{}
+	mi := &file_user_user_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersRequest) ProtoMessage() {}
+
+func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersRequest.ProtoReflect.Descriptor instead.
+func (*ListUsersRequest) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ListUsersRequest) GetStatus() *wrapperspb.StringValue {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *ListUsersRequest) GetEmail() *wrapperspb.StringValue {
+	if x != nil {
+		return x.Email
+	}
+	return nil
+}
+
+func (x *ListUsersRequest) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *ListUsersRequest) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
+
+func (x *ListUsersRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListUsersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*UserDetail          `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	TotalPages    int32                  `protobuf:"varint,5,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersResponse) Reset() {
+	*x = ListUsersResponse{}
+	mi := &file_user_user_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersResponse) ProtoMessage() {}
+
+func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersResponse.ProtoReflect.Descriptor instead.
+func (*ListUsersResponse) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ListUsersResponse) GetUsers() []*UserDetail {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *ListUsersResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListUsersResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListUsersResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListUsersResponse) GetTotalPages() int32 {
+	if x != nil {
+		return x.TotalPages
+	}
+	return 0
+}
+
+type GetUserByIDRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserByIDRequest) Reset() {
+	*x = GetUserByIDRequest{}
+	mi := &file_user_user_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserByIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserByIDRequest) ProtoMessage() {}
+
+func (x *GetUserByIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserByIDRequest.ProtoReflect.Descriptor instead.
+func (*GetUserByIDRequest) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *GetUserByIDRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type UserDetailResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *UserDetail            `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserDetailResponse) Reset() {
+	*x = UserDetailResponse{}
+	mi := &file_user_user_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserDetailResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserDetailResponse) ProtoMessage() {}
+
+func (x *UserDetailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserDetailResponse.ProtoReflect.Descriptor instead.
+func (*UserDetailResponse) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *UserDetailResponse) GetUser() *UserDetail {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type SuspendUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuspendUserRequest) Reset() {
+	*x = SuspendUserRequest{}
+	mi := &file_user_user_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuspendUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuspendUserRequest) ProtoMessage() {}
+
+func (x *SuspendUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuspendUserRequest.ProtoReflect.Descriptor instead.
+func (*SuspendUserRequest) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *SuspendUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SuspendUserRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ReactivateUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReactivateUserRequest) Reset() {
+	*x = ReactivateUserRequest{}
+	mi := &file_user_user_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReactivateUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReactivateUserRequest) ProtoMessage() {}
+
+func (x *ReactivateUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReactivateUserRequest.ProtoReflect.Descriptor instead.
+func (*ReactivateUserRequest) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ReactivateUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ForceResetPasswordRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForceResetPasswordRequest) Reset() {
+	*x = ForceResetPasswordRequest{}
+	mi := &file_user_user_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForceResetPasswordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForceResetPasswordRequest) ProtoMessage() {}
+
+func (x *ForceResetPasswordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForceResetPasswordRequest.ProtoReflect.Descriptor instead.
+func (*ForceResetPasswordRequest) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ForceResetPasswordRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type RevokeAllSessionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeAllSessionsRequest) Reset() {
+	*x = RevokeAllSessionsRequest{}
+	mi := &file_user_user_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAllSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAllSessionsRequest) ProtoMessage() {}
+
+func (x *RevokeAllSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAllSessionsRequest.ProtoReflect.Descriptor instead.
+func (*RevokeAllSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *RevokeAllSessionsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ListSecurityEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSecurityEventsRequest) Reset() {
+	*x = ListSecurityEventsRequest{}
+	mi := &file_user_user_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSecurityEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSecurityEventsRequest) ProtoMessage() {}
+
+func (x *ListSecurityEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSecurityEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListSecurityEventsRequest) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ListSecurityEventsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListSecurityEventsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListSecurityEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*SecurityEvent       `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	TotalPages    int32                  `protobuf:"varint,5,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSecurityEventsResponse) Reset() {
+	*x = ListSecurityEventsResponse{}
+	mi := &file_user_user_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSecurityEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSecurityEventsResponse) ProtoMessage() {}
+
+func (x *ListSecurityEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSecurityEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListSecurityEventsResponse) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ListSecurityEventsResponse) GetEvents() []*SecurityEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *ListSecurityEventsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListSecurityEventsResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListSecurityEventsResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListSecurityEventsResponse) GetTotalPages() int32 {
+	if x != nil {
+		return x.TotalPages
+	}
+	return 0
+}
+
+type ListAuditLogRequest struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	UserId        *wrapperspb.StringValue `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	EventType     *wrapperspb.StringValue `protobuf:"bytes,2,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	IpAddress     *wrapperspb.StringValue `protobuf:"bytes,3,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+	CreatedFrom   *timestamppb.Timestamp  `protobuf:"bytes,4,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	CreatedTo     *timestamppb.Timestamp  `protobuf:"bytes,5,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
+	Page          int32                   `protobuf:"varint,6,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                   `protobuf:"varint,7,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditLogRequest) Reset() {
+	*x = ListAuditLogRequest{}
+	mi := &file_user_user_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditLogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditLogRequest) ProtoMessage() {}
+
+func (x *ListAuditLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditLogRequest.ProtoReflect.Descriptor instead.
+func (*ListAuditLogRequest) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ListAuditLogRequest) GetUserId() *wrapperspb.StringValue {
+	if x != nil {
+		return x.UserId
+	}
+	return nil
+}
+
+func (x *ListAuditLogRequest) GetEventType() *wrapperspb.StringValue {
+	if x != nil {
+		return x.EventType
+	}
+	return nil
+}
+
+func (x *ListAuditLogRequest) GetIpAddress() *wrapperspb.StringValue {
+	if x != nil {
+		return x.IpAddress
+	}
+	return nil
+}
+
+func (x *ListAuditLogRequest) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *ListAuditLogRequest) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
 
-func (x *GetUserAddressResponse) GetAddress() *Address {
+func (x *ListAuditLogRequest) GetPage() int32 {
 	if x != nil {
-		return x.Address
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListAuditLogRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type User struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Id            string                  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	FullName      string                  `protobuf:"bytes,2,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
+	Email         string                  `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Phone         *wrapperspb.StringValue `protobuf:"bytes,4,opt,name=phone,proto3" json:"phone,omitempty"`
+	AvatarUrl     *wrapperspb.StringValue `protobuf:"bytes,5,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`
+	DateOfBirth   *wrapperspb.StringValue `protobuf:"bytes,6,opt,name=date_of_birth,json=dateOfBirth,proto3" json:"date_of_birth,omitempty"`
+	Gender        *wrapperspb.StringValue `protobuf:"bytes,7,opt,name=gender,proto3" json:"gender,omitempty"`
+	Status        string                  `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
+	Role          string                  `protobuf:"bytes,9,opt,name=role,proto3" json:"role,omitempty"`
+	PhoneVerified bool                    `protobuf:"varint,10,opt,name=phone_verified,json=phoneVerified,proto3" json:"phone_verified,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_user_user_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *User) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *User) GetFullName() string {
+	if x != nil {
+		return x.FullName
+	}
+	return ""
+}
+
+func (x *User) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *User) GetPhone() *wrapperspb.StringValue {
+	if x != nil {
+		return x.Phone
 	}
 	return nil
 }
 
-type DeleteUserAddressRequest struct {
+func (x *User) GetAvatarUrl() *wrapperspb.StringValue {
+	if x != nil {
+		return x.AvatarUrl
+	}
+	return nil
+}
+
+func (x *User) GetDateOfBirth() *wrapperspb.StringValue {
+	if x != nil {
+		return x.DateOfBirth
+	}
+	return nil
+}
+
+func (x *User) GetGender() *wrapperspb.StringValue {
+	if x != nil {
+		return x.Gender
+	}
+	return nil
+}
+
+func (x *User) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *User) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *User) GetPhoneVerified() bool {
+	if x != nil {
+		return x.PhoneVerified
+	}
+	return false
+}
+
+type PublicUserProfile struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Id            string                  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	FullName      string                  `protobuf:"bytes,2,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
+	AvatarUrl     *wrapperspb.StringValue `protobuf:"bytes,3,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublicUserProfile) Reset() {
+	*x = PublicUserProfile{}
+	mi := &file_user_user_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublicUserProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublicUserProfile) ProtoMessage() {}
+
+func (x *PublicUserProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublicUserProfile.ProtoReflect.Descriptor instead.
+func (*PublicUserProfile) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *PublicUserProfile) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PublicUserProfile) GetFullName() string {
+	if x != nil {
+		return x.FullName
+	}
+	return ""
+}
+
+func (x *PublicUserProfile) GetAvatarUrl() *wrapperspb.StringValue {
+	if x != nil {
+		return x.AvatarUrl
+	}
+	return nil
+}
+
+type Session struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	AddressId     string                 `protobuf:"bytes,1,opt,name=address_id,json=addressId,proto3" json:"address_id,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DeviceInfo    string                 `protobuf:"bytes,2,opt,name=device_info,json=deviceInfo,proto3" json:"device_info,omitempty"`
+	IpAddress     string                 `protobuf:"bytes,3,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+	UserAgent     string                 `protobuf:"bytes,4,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	LastUsedAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_used_at,json=lastUsedAt,proto3" json:"last_used_at,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteUserAddressRequest) Reset() {
-	*x = DeleteUserAddressRequest{}
-	mi := &file_user_user_proto_msgTypes[23]
+func (x *Session) Reset() {
+	*x = Session{}
+	mi := &file_user_user_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteUserAddressRequest) String() string {
+func (x *Session) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Session) ProtoMessage() {}
+
+func (x *Session) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Session.ProtoReflect.Descriptor instead.
+func (*Session) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *Session) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Session) GetDeviceInfo() string {
+	if x != nil {
+		return x.DeviceInfo
+	}
+	return ""
+}
+
+func (x *Session) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
+}
+
+func (x *Session) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *Session) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Session) GetLastUsedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUsedAt
+	}
+	return nil
+}
+
+func (x *Session) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type Address struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	AddressType   string                 `protobuf:"bytes,3,opt,name=address_type,json=addressType,proto3" json:"address_type,omitempty"`
+	FullName      string                 `protobuf:"bytes,4,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
+	Phone         string                 `protobuf:"bytes,5,opt,name=phone,proto3" json:"phone,omitempty"`
+	AddressLine1  string                 `protobuf:"bytes,6,opt,name=address_line1,json=addressLine1,proto3" json:"address_line1,omitempty"`
+	AddressLine2  string                 `protobuf:"bytes,7,opt,name=address_line2,json=addressLine2,proto3" json:"address_line2,omitempty"`
+	Ward          string                 `protobuf:"bytes,8,opt,name=ward,proto3" json:"ward,omitempty"`
+	City          string                 `protobuf:"bytes,9,opt,name=city,proto3" json:"city,omitempty"`
+	Country       string                 `protobuf:"bytes,10,opt,name=country,proto3" json:"country,omitempty"`
+	IsDefault     bool                   `protobuf:"varint,11,opt,name=is_default,json=isDefault,proto3" json:"is_default,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Address) Reset() {
+	*x = Address{}
+	mi := &file_user_user_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Address) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteUserAddressRequest) ProtoMessage() {}
+func (*Address) ProtoMessage() {}
 
-func (x *DeleteUserAddressRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_user_proto_msgTypes[23]
+func (x *Address) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1270,91 +3192,124 @@ func (x *DeleteUserAddressRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteUserAddressRequest.ProtoReflect.Descriptor instead.
-func (*DeleteUserAddressRequest) Descriptor() ([]byte, []int) {
-	return file_user_user_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use Address.ProtoReflect.Descriptor instead.
+func (*Address) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{58}
 }
 
-func (x *DeleteUserAddressRequest) GetAddressId() string {
+func (x *Address) GetId() string {
 	if x != nil {
-		return x.AddressId
+		return x.Id
 	}
 	return ""
 }
 
-type SetDefaultUserAddressRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AddressId     string                 `protobuf:"bytes,1,opt,name=address_id,json=addressId,proto3" json:"address_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Address) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
 }
 
-func (x *SetDefaultUserAddressRequest) Reset() {
-	*x = SetDefaultUserAddressRequest{}
-	mi := &file_user_user_proto_msgTypes[24]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *Address) GetAddressType() string {
+	if x != nil {
+		return x.AddressType
+	}
+	return ""
 }
 
-func (x *SetDefaultUserAddressRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Address) GetFullName() string {
+	if x != nil {
+		return x.FullName
+	}
+	return ""
 }
 
-func (*SetDefaultUserAddressRequest) ProtoMessage() {}
+func (x *Address) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
 
-func (x *SetDefaultUserAddressRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_user_proto_msgTypes[24]
+func (x *Address) GetAddressLine1() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.AddressLine1
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use SetDefaultUserAddressRequest.ProtoReflect.Descriptor instead.
-func (*SetDefaultUserAddressRequest) Descriptor() ([]byte, []int) {
-	return file_user_user_proto_rawDescGZIP(), []int{24}
+func (x *Address) GetAddressLine2() string {
+	if x != nil {
+		return x.AddressLine2
+	}
+	return ""
 }
 
-func (x *SetDefaultUserAddressRequest) GetAddressId() string {
+func (x *Address) GetWard() string {
 	if x != nil {
-		return x.AddressId
+		return x.Ward
 	}
 	return ""
 }
 
-type User struct {
-	state         protoimpl.MessageState  `protogen:"open.v1"`
-	Id            string                  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	FullName      string                  `protobuf:"bytes,2,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
-	Email         string                  `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
-	Phone         *wrapperspb.StringValue `protobuf:"bytes,4,opt,name=phone,proto3" json:"phone,omitempty"`
-	AvatarUrl     *wrapperspb.StringValue `protobuf:"bytes,5,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`
-	DateOfBirth   *wrapperspb.StringValue `protobuf:"bytes,6,opt,name=date_of_birth,json=dateOfBirth,proto3" json:"date_of_birth,omitempty"`
-	Gender        *wrapperspb.StringValue `protobuf:"bytes,7,opt,name=gender,proto3" json:"gender,omitempty"`
-	Status        string                  `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Address) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
 }
 
-func (x *User) Reset() {
-	*x = User{}
-	mi := &file_user_user_proto_msgTypes[25]
+func (x *Address) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *Address) GetIsDefault() bool {
+	if x != nil {
+		return x.IsDefault
+	}
+	return false
+}
+
+// UserDetail is the full view of an account for administrators.
+type UserDetail struct {
+	state           protoimpl.MessageState  `protogen:"open.v1"`
+	Id              string                  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	FullName        string                  `protobuf:"bytes,2,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
+	Email           string                  `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Phone           *wrapperspb.StringValue `protobuf:"bytes,4,opt,name=phone,proto3" json:"phone,omitempty"`
+	AvatarUrl       *wrapperspb.StringValue `protobuf:"bytes,5,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`
+	DateOfBirth     *wrapperspb.StringValue `protobuf:"bytes,6,opt,name=date_of_birth,json=dateOfBirth,proto3" json:"date_of_birth,omitempty"`
+	Gender          *wrapperspb.StringValue `protobuf:"bytes,7,opt,name=gender,proto3" json:"gender,omitempty"`
+	Status          string                  `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
+	Role            string                  `protobuf:"bytes,9,opt,name=role,proto3" json:"role,omitempty"`
+	EmailVerifiedAt *timestamppb.Timestamp  `protobuf:"bytes,10,opt,name=email_verified_at,json=emailVerifiedAt,proto3" json:"email_verified_at,omitempty"`
+	PhoneVerifiedAt *timestamppb.Timestamp  `protobuf:"bytes,11,opt,name=phone_verified_at,json=phoneVerifiedAt,proto3" json:"phone_verified_at,omitempty"`
+	MfaEnabled      bool                    `protobuf:"varint,12,opt,name=mfa_enabled,json=mfaEnabled,proto3" json:"mfa_enabled,omitempty"`
+	CreatedAt       *timestamppb.Timestamp  `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt       *timestamppb.Timestamp  `protobuf:"bytes,14,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UserDetail) Reset() {
+	*x = UserDetail{}
+	mi := &file_user_user_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *User) String() string {
+func (x *UserDetail) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*User) ProtoMessage() {}
+func (*UserDetail) ProtoMessage() {}
 
-func (x *User) ProtoReflect() protoreflect.Message {
-	mi := &file_user_user_proto_msgTypes[25]
+func (x *UserDetail) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1365,159 +3320,141 @@ func (x *User) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use User.ProtoReflect.Descriptor instead.
-func (*User) Descriptor() ([]byte, []int) {
-	return file_user_user_proto_rawDescGZIP(), []int{25}
+// Deprecated: Use UserDetail.ProtoReflect.Descriptor instead.
+func (*UserDetail) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{59}
 }
 
-func (x *User) GetId() string {
+func (x *UserDetail) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-func (x *User) GetFullName() string {
+func (x *UserDetail) GetFullName() string {
 	if x != nil {
 		return x.FullName
 	}
 	return ""
 }
 
-func (x *User) GetEmail() string {
+func (x *UserDetail) GetEmail() string {
 	if x != nil {
 		return x.Email
 	}
 	return ""
 }
 
-func (x *User) GetPhone() *wrapperspb.StringValue {
+func (x *UserDetail) GetPhone() *wrapperspb.StringValue {
 	if x != nil {
 		return x.Phone
 	}
 	return nil
 }
 
-func (x *User) GetAvatarUrl() *wrapperspb.StringValue {
+func (x *UserDetail) GetAvatarUrl() *wrapperspb.StringValue {
 	if x != nil {
 		return x.AvatarUrl
 	}
 	return nil
 }
 
-func (x *User) GetDateOfBirth() *wrapperspb.StringValue {
+func (x *UserDetail) GetDateOfBirth() *wrapperspb.StringValue {
 	if x != nil {
 		return x.DateOfBirth
 	}
 	return nil
 }
 
-func (x *User) GetGender() *wrapperspb.StringValue {
+func (x *UserDetail) GetGender() *wrapperspb.StringValue {
 	if x != nil {
 		return x.Gender
 	}
 	return nil
 }
 
-func (x *User) GetStatus() string {
+func (x *UserDetail) GetStatus() string {
 	if x != nil {
 		return x.Status
 	}
 	return ""
 }
 
-type PublicUserProfile struct {
-	state         protoimpl.MessageState  `protogen:"open.v1"`
-	Id            string                  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	FullName      string                  `protobuf:"bytes,2,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
-	AvatarUrl     *wrapperspb.StringValue `protobuf:"bytes,3,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *PublicUserProfile) Reset() {
-	*x = PublicUserProfile{}
-	mi := &file_user_user_proto_msgTypes[26]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *PublicUserProfile) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *UserDetail) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
 }
 
-func (*PublicUserProfile) ProtoMessage() {}
-
-func (x *PublicUserProfile) ProtoReflect() protoreflect.Message {
-	mi := &file_user_user_proto_msgTypes[26]
+func (x *UserDetail) GetEmailVerifiedAt() *timestamppb.Timestamp {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.EmailVerifiedAt
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use PublicUserProfile.ProtoReflect.Descriptor instead.
-func (*PublicUserProfile) Descriptor() ([]byte, []int) {
-	return file_user_user_proto_rawDescGZIP(), []int{26}
+func (x *UserDetail) GetPhoneVerifiedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PhoneVerifiedAt
+	}
+	return nil
 }
 
-func (x *PublicUserProfile) GetId() string {
+func (x *UserDetail) GetMfaEnabled() bool {
 	if x != nil {
-		return x.Id
+		return x.MfaEnabled
 	}
-	return ""
+	return false
 }
 
-func (x *PublicUserProfile) GetFullName() string {
+func (x *UserDetail) GetCreatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.FullName
+		return x.CreatedAt
 	}
-	return ""
+	return nil
 }
 
-func (x *PublicUserProfile) GetAvatarUrl() *wrapperspb.StringValue {
+func (x *UserDetail) GetUpdatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.AvatarUrl
+		return x.UpdatedAt
 	}
 	return nil
 }
 
-type Address struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	AddressType   string                 `protobuf:"bytes,3,opt,name=address_type,json=addressType,proto3" json:"address_type,omitempty"`
-	FullName      string                 `protobuf:"bytes,4,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
-	Phone         string                 `protobuf:"bytes,5,opt,name=phone,proto3" json:"phone,omitempty"`
-	AddressLine1  string                 `protobuf:"bytes,6,opt,name=address_line1,json=addressLine1,proto3" json:"address_line1,omitempty"`
-	AddressLine2  string                 `protobuf:"bytes,7,opt,name=address_line2,json=addressLine2,proto3" json:"address_line2,omitempty"`
-	Ward          string                 `protobuf:"bytes,8,opt,name=ward,proto3" json:"ward,omitempty"`
-	City          string                 `protobuf:"bytes,9,opt,name=city,proto3" json:"city,omitempty"`
-	Country       string                 `protobuf:"bytes,10,opt,name=country,proto3" json:"country,omitempty"`
-	IsDefault     bool                   `protobuf:"varint,11,opt,name=is_default,json=isDefault,proto3" json:"is_default,omitempty"`
+type SecurityEvent struct {
+	state  protoimpl.MessageState  `protogen:"open.v1"`
+	Id     int64                   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId *wrapperspb.StringValue `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// set when an admin performed the action
+	ActorId       *wrapperspb.StringValue `protobuf:"bytes,3,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	EventType     string                  `protobuf:"bytes,4,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	Success       bool                    `protobuf:"varint,5,opt,name=success,proto3" json:"success,omitempty"`
+	Reason        *wrapperspb.StringValue `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	IpAddress     string                  `protobuf:"bytes,7,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+	UserAgent     string                  `protobuf:"bytes,8,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	TraceId       string                  `protobuf:"bytes,9,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp  `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Address) Reset() {
-	*x = Address{}
-	mi := &file_user_user_proto_msgTypes[27]
+func (x *SecurityEvent) Reset() {
+	*x = SecurityEvent{}
+	mi := &file_user_user_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Address) String() string {
+func (x *SecurityEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Address) ProtoMessage() {}
+func (*SecurityEvent) ProtoMessage() {}
 
-func (x *Address) ProtoReflect() protoreflect.Message {
-	mi := &file_user_user_proto_msgTypes[27]
+func (x *SecurityEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_user_user_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1528,86 +3465,79 @@ func (x *Address) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Address.ProtoReflect.Descriptor instead.
-func (*Address) Descriptor() ([]byte, []int) {
-	return file_user_user_proto_rawDescGZIP(), []int{27}
+// Deprecated: Use SecurityEvent.ProtoReflect.Descriptor instead.
+func (*SecurityEvent) Descriptor() ([]byte, []int) {
+	return file_user_user_proto_rawDescGZIP(), []int{60}
 }
 
-func (x *Address) GetId() string {
+func (x *SecurityEvent) GetId() int64 {
 	if x != nil {
 		return x.Id
 	}
-	return ""
+	return 0
 }
 
-func (x *Address) GetUserId() string {
+func (x *SecurityEvent) GetUserId() *wrapperspb.StringValue {
 	if x != nil {
 		return x.UserId
 	}
-	return ""
-}
-
-func (x *Address) GetAddressType() string {
-	if x != nil {
-		return x.AddressType
-	}
-	return ""
+	return nil
 }
 
-func (x *Address) GetFullName() string {
+func (x *SecurityEvent) GetActorId() *wrapperspb.StringValue {
 	if x != nil {
-		return x.FullName
+		return x.ActorId
 	}
-	return ""
+	return nil
 }
 
-func (x *Address) GetPhone() string {
+func (x *SecurityEvent) GetEventType() string {
 	if x != nil {
-		return x.Phone
+		return x.EventType
 	}
 	return ""
 }
 
-func (x *Address) GetAddressLine1() string {
+func (x *SecurityEvent) GetSuccess() bool {
 	if x != nil {
-		return x.AddressLine1
+		return x.Success
 	}
-	return ""
+	return false
 }
 
-func (x *Address) GetAddressLine2() string {
+func (x *SecurityEvent) GetReason() *wrapperspb.StringValue {
 	if x != nil {
-		return x.AddressLine2
+		return x.Reason
 	}
-	return ""
+	return nil
 }
 
-func (x *Address) GetWard() string {
+func (x *SecurityEvent) GetIpAddress() string {
 	if x != nil {
-		return x.Ward
+		return x.IpAddress
 	}
 	return ""
 }
 
-func (x *Address) GetCity() string {
+func (x *SecurityEvent) GetUserAgent() string {
 	if x != nil {
-		return x.City
+		return x.UserAgent
 	}
 	return ""
 }
 
-func (x *Address) GetCountry() string {
+func (x *SecurityEvent) GetTraceId() string {
 	if x != nil {
-		return x.Country
+		return x.TraceId
 	}
 	return ""
 }
 
-func (x *Address) GetIsDefault() bool {
+func (x *SecurityEvent) GetCreatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.IsDefault
+		return x.CreatedAt
 	}
-	return false
+	return nil
 }
 
 var File_user_user_proto protoreflect.FileDescriptor
@@ -1627,12 +3557,47 @@ const file_user_user_proto_rawDesc = "" +
 	"\x05email\x18\x01 \x01(\tB\a\xbaH\x04r\x02`\x01R\x05email\"R\n" +
 	"\fLoginRequest\x12\x1d\n" +
 	"\x05email\x18\x01 \x01(\tB\a\xbaH\x04r\x02`\x01R\x05email\x12#\n" +
-	"\bpassword\x18\x02 \x01(\tB\a\xbaH\x04r\x02\x10\bR\bpassword\"W\n" +
+	"\bpassword\x18\x02 \x01(\tB\a\xbaH\x04r\x02\x10\bR\bpassword\"\x97\x01\n" +
 	"\rTokenResponse\x12!\n" +
 	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12#\n" +
-	"\rrefresh_token\x18\x02 \x01(\tR\frefreshToken\"5\n" +
+	"\rrefresh_token\x18\x02 \x01(\tR\frefreshToken\x12!\n" +
+	"\fmfa_required\x18\x03 \x01(\bR\vmfaRequired\x12\x1b\n" +
+	"\tmfa_token\x18\x04 \x01(\tR\bmfaToken\"W\n" +
+	"\x10VerifyMFARequest\x12$\n" +
+	"\tmfa_token\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\bmfaToken\x12\x1d\n" +
+	"\x04code\x18\x02 \x01(\tB\t\xbaH\x06r\x04\x10\x06\x18 R\x04code\"=\n" +
+	"\x16StartOAuthLoginRequest\x12#\n" +
+	"\bprovider\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\bprovider\"<\n" +
+	"\x17StartOAuthLoginResponse\x12!\n" +
+	"\fredirect_url\x18\x01 \x01(\tR\vredirectUrl\"|\n" +
+	"\x19CompleteOAuthLoginRequest\x12#\n" +
+	"\bprovider\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\bprovider\x12\x1b\n" +
+	"\x04code\x18\x02 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x04code\x12\x1d\n" +
+	"\x05state\x18\x03 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x05state\"8\n" +
+	"\x17RequestMagicLinkRequest\x12\x1d\n" +
+	"\x05email\x18\x01 \x01(\tB\a\xbaH\x04r\x02`\x01R\x05email\"8\n" +
+	"\x17ConsumeMagicLinkRequest\x12\x1d\n" +
+	"\x05token\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x05token\"5\n" +
 	"\x0eRefreshRequest\x12#\n" +
-	"\rrefresh_token\x18\x01 \x01(\tR\frefreshToken\"3\n" +
+	"\rrefresh_token\x18\x01 \x01(\tR\frefreshToken\"M\n" +
+	"\x12EnrollTOTPResponse\x12\x16\n" +
+	"\x06secret\x18\x01 \x01(\tR\x06secret\x12\x1f\n" +
+	"\votpauth_uri\x18\x02 \x01(\tR\n" +
+	"otpauthUri\"2\n" +
+	"\x12ConfirmTOTPRequest\x12\x1c\n" +
+	"\x04code\x18\x01 \x01(\tB\b\xbaH\x05r\x03\x98\x01\x06R\x04code\"<\n" +
+	"\x13ConfirmTOTPResponse\x12%\n" +
+	"\x0erecovery_codes\x18\x01 \x03(\tR\rrecoveryCodes\"X\n" +
+	"\x12DisableTOTPRequest\x12#\n" +
+	"\bpassword\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\bpassword\x12\x1d\n" +
+	"\x04code\x18\x02 \x01(\tB\t\xbaH\x06r\x04\x10\x06\x18 R\x04code\"=\n" +
+	"\rLogoutRequest\x12,\n" +
+	"\rrefresh_token\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\frefreshToken\"A\n" +
+	"\x14ListSessionsResponse\x12)\n" +
+	"\bsessions\x18\x01 \x03(\v2\r.user.SessionR\bsessions\"?\n" +
+	"\x14RevokeSessionRequest\x12'\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\tsessionId\"3\n" +
 	"\x0eGetUserRequest\x12!\n" +
 	"\auser_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x06userId\"1\n" +
 	"\x0fGetUserResponse\x12\x1e\n" +
@@ -1650,10 +3615,17 @@ const file_user_user_proto_rawDesc = "" +
 	"\a_gender\">\n" +
 	"\x13UpdateAvatarRequest\x12'\n" +
 	"\n" +
-	"avatar_url\x18\x01 \x01(\tB\b\xbaH\x05r\x03\x88\x01\x01R\tavatarUrl\"4\n" +
+	"avatar_url\x18\x01 \x01(\tB\b\xbaH\x05r\x03\x88\x01\x01R\tavatarUrl\"N\n" +
+	"\x1fRequestPhoneVerificationRequest\x12+\n" +
+	"\x05phone\x18\x01 \x01(\tB\x15\xbaH\x12r\x102\x0e^[0-9]{10,15}$R\x05phone\";\n" +
+	"\x12VerifyPhoneRequest\x12%\n" +
+	"\x04code\x18\x01 \x01(\tB\x11\xbaH\x0er\f2\n" +
+	"^[0-9]{6}$R\x04code\"4\n" +
 	"\x12UpdateUserResponse\x12\x1e\n" +
 	"\x04user\x18\x01 \x01(\v2\n" +
-	".user.UserR\x04user\"y\n" +
+	".user.UserR\x04user\";\n" +
+	"\x14DeleteAccountRequest\x12#\n" +
+	"\bpassword\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\bpassword\"y\n" +
 	"\x15ChangePasswordRequest\x122\n" +
 	"\x10current_password\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\bR\x0fcurrentPassword\x12,\n" +
 	"\fnew_password\x18\x02 \x01(\tB\t\xbaH\x06r\x04\x10\b\x18@R\vnewPassword\"6\n" +
@@ -1662,7 +3634,14 @@ const file_user_user_proto_rawDesc = "" +
 	"\x14ResetPasswordRequest\x12(\n" +
 	"\vreset_token\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\n" +
 	"resetToken\x12,\n" +
-	"\fnew_password\x18\x02 \x01(\tB\t\xbaH\x06r\x04\x10\b\x18@R\vnewPassword\"\xf9\x02\n" +
+	"\fnew_password\x18\x02 \x01(\tB\t\xbaH\x06r\x04\x10\b\x18@R\vnewPassword\"f\n" +
+	"\x19RequestEmailChangeRequest\x12$\n" +
+	"\tnew_email\x18\x01 \x01(\tB\a\xbaH\x04r\x02`\x01R\bnewEmail\x12#\n" +
+	"\bpassword\x18\x02 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\bpassword\":\n" +
+	"\x19ConfirmEmailChangeRequest\x12\x1d\n" +
+	"\x05token\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x05token\"B\n" +
+	"\x14UnlockAccountRequest\x12*\n" +
+	"\funlock_token\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\vunlockToken\"\xf9\x02\n" +
 	"\x18CreateUserAddressRequest\x12;\n" +
 	"\faddress_type\x18\x01 \x01(\tB\x18\xbaH\x15r\x13R\x04homeR\x04workR\x05otherR\vaddressType\x12$\n" +
 	"\tfull_name\x18\x02 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\bfullName\x12+\n" +
@@ -1715,7 +3694,57 @@ const file_user_user_proto_rawDesc = "" +
 	"address_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\taddressId\"G\n" +
 	"\x1cSetDefaultUserAddressRequest\x12'\n" +
 	"\n" +
-	"address_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\taddressId\"\xca\x02\n" +
+	"address_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\taddressId\"\xf2\x02\n" +
+	"\x10ListUsersRequest\x12a\n" +
+	"\x06status\x18\x01 \x01(\v2\x1c.google.protobuf.StringValueB+\xbaH(r&R\apendingR\x06activeR\binactiveR\tsuspendedR\x06status\x12<\n" +
+	"\x05email\x18\x02 \x01(\v2\x1c.google.protobuf.StringValueB\b\xbaH\x05r\x03\x18\xff\x01R\x05email\x12=\n" +
+	"\fcreated_from\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\vcreatedFrom\x129\n" +
+	"\n" +
+	"created_to\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedTo\x12\x1b\n" +
+	"\x04page\x18\x05 \x01(\x05B\a\xbaH\x04\x1a\x02(\x01R\x04page\x12&\n" +
+	"\tpage_size\x18\x06 \x01(\x05B\t\xbaH\x06\x1a\x04\x18d(\x01R\bpageSize\"\xa3\x01\n" +
+	"\x11ListUsersResponse\x12&\n" +
+	"\x05users\x18\x01 \x03(\v2\x10.user.UserDetailR\x05users\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12\x1f\n" +
+	"\vtotal_pages\x18\x05 \x01(\x05R\n" +
+	"totalPages\"7\n" +
+	"\x12GetUserByIDRequest\x12!\n" +
+	"\auser_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x06userId\":\n" +
+	"\x12UserDetailResponse\x12$\n" +
+	"\x04user\x18\x01 \x01(\v2\x10.user.UserDetailR\x04user\"[\n" +
+	"\x12SuspendUserRequest\x12!\n" +
+	"\auser_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x06userId\x12\"\n" +
+	"\x06reason\x18\x02 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\xf4\x03R\x06reason\":\n" +
+	"\x15ReactivateUserRequest\x12!\n" +
+	"\auser_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x06userId\">\n" +
+	"\x19ForceResetPasswordRequest\x12!\n" +
+	"\auser_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x06userId\"=\n" +
+	"\x18RevokeAllSessionsRequest\x12!\n" +
+	"\auser_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x06userId\"`\n" +
+	"\x19ListSecurityEventsRequest\x12\x1b\n" +
+	"\x04page\x18\x01 \x01(\x05B\a\xbaH\x04\x1a\x02(\x01R\x04page\x12&\n" +
+	"\tpage_size\x18\x02 \x01(\x05B\t\xbaH\x06\x1a\x04\x18d(\x01R\bpageSize\"\xb1\x01\n" +
+	"\x1aListSecurityEventsResponse\x12+\n" +
+	"\x06events\x18\x01 \x03(\v2\x13.user.SecurityEventR\x06events\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12\x1f\n" +
+	"\vtotal_pages\x18\x05 \x01(\x05R\n" +
+	"totalPages\"\xa1\x03\n" +
+	"\x13ListAuditLogRequest\x12?\n" +
+	"\auser_id\x18\x01 \x01(\v2\x1c.google.protobuf.StringValueB\b\xbaH\x05r\x03\xb0\x01\x01R\x06userId\x12D\n" +
+	"\n" +
+	"event_type\x18\x02 \x01(\v2\x1c.google.protobuf.StringValueB\a\xbaH\x04r\x02\x182R\teventType\x12D\n" +
+	"\n" +
+	"ip_address\x18\x03 \x01(\v2\x1c.google.protobuf.StringValueB\a\xbaH\x04r\x02p\x01R\tipAddress\x12=\n" +
+	"\fcreated_from\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\vcreatedFrom\x129\n" +
+	"\n" +
+	"created_to\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedTo\x12\x1b\n" +
+	"\x04page\x18\x06 \x01(\x05B\a\xbaH\x04\x1a\x02(\x01R\x04page\x12&\n" +
+	"\tpage_size\x18\a \x01(\x05B\t\xbaH\x06\x1a\x04\x18d(\x01R\bpageSize\"\x85\x03\n" +
 	"\x04User\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
 	"\tfull_name\x18\x02 \x01(\tR\bfullName\x12\x14\n" +
@@ -1725,12 +3754,29 @@ const file_user_user_proto_rawDesc = "" +
 	"avatar_url\x18\x05 \x01(\v2\x1c.google.protobuf.StringValueR\tavatarUrl\x12@\n" +
 	"\rdate_of_birth\x18\x06 \x01(\v2\x1c.google.protobuf.StringValueR\vdateOfBirth\x124\n" +
 	"\x06gender\x18\a \x01(\v2\x1c.google.protobuf.StringValueR\x06gender\x12\x16\n" +
-	"\x06status\x18\b \x01(\tR\x06status\"}\n" +
+	"\x06status\x18\b \x01(\tR\x06status\x12\x12\n" +
+	"\x04role\x18\t \x01(\tR\x04role\x12%\n" +
+	"\x0ephone_verified\x18\n" +
+	" \x01(\bR\rphoneVerified\"}\n" +
 	"\x11PublicUserProfile\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
 	"\tfull_name\x18\x02 \x01(\tR\bfullName\x12;\n" +
 	"\n" +
-	"avatar_url\x18\x03 \x01(\v2\x1c.google.protobuf.StringValueR\tavatarUrl\"\xb3\x02\n" +
+	"avatar_url\x18\x03 \x01(\v2\x1c.google.protobuf.StringValueR\tavatarUrl\"\xac\x02\n" +
+	"\aSession\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vdevice_info\x18\x02 \x01(\tR\n" +
+	"deviceInfo\x12\x1d\n" +
+	"\n" +
+	"ip_address\x18\x03 \x01(\tR\tipAddress\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\x04 \x01(\tR\tuserAgent\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12<\n" +
+	"\flast_used_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastUsedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"\xb3\x02\n" +
 	"\aAddress\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12!\n" +
@@ -1744,26 +3790,91 @@ const file_user_user_proto_rawDesc = "" +
 	"\acountry\x18\n" +
 	" \x01(\tR\acountry\x12\x1d\n" +
 	"\n" +
-	"is_default\x18\v \x01(\bR\tisDefault2\xf4\r\n" +
+	"is_default\x18\v \x01(\bR\tisDefault\"\x8b\x05\n" +
+	"\n" +
+	"UserDetail\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tfull_name\x18\x02 \x01(\tR\bfullName\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x122\n" +
+	"\x05phone\x18\x04 \x01(\v2\x1c.google.protobuf.StringValueR\x05phone\x12;\n" +
+	"\n" +
+	"avatar_url\x18\x05 \x01(\v2\x1c.google.protobuf.StringValueR\tavatarUrl\x12@\n" +
+	"\rdate_of_birth\x18\x06 \x01(\v2\x1c.google.protobuf.StringValueR\vdateOfBirth\x124\n" +
+	"\x06gender\x18\a \x01(\v2\x1c.google.protobuf.StringValueR\x06gender\x12\x16\n" +
+	"\x06status\x18\b \x01(\tR\x06status\x12\x12\n" +
+	"\x04role\x18\t \x01(\tR\x04role\x12F\n" +
+	"\x11email_verified_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\x0femailVerifiedAt\x12F\n" +
+	"\x11phone_verified_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\x0fphoneVerifiedAt\x12\x1f\n" +
+	"\vmfa_enabled\x18\f \x01(\bR\n" +
+	"mfaEnabled\x129\n" +
+	"\n" +
+	"created_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x92\x03\n" +
+	"\rSecurityEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x125\n" +
+	"\auser_id\x18\x02 \x01(\v2\x1c.google.protobuf.StringValueR\x06userId\x127\n" +
+	"\bactor_id\x18\x03 \x01(\v2\x1c.google.protobuf.StringValueR\aactorId\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x04 \x01(\tR\teventType\x12\x18\n" +
+	"\asuccess\x18\x05 \x01(\bR\asuccess\x124\n" +
+	"\x06reason\x18\x06 \x01(\v2\x1c.google.protobuf.StringValueR\x06reason\x12\x1d\n" +
+	"\n" +
+	"ip_address\x18\a \x01(\tR\tipAddress\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\b \x01(\tR\tuserAgent\x12\x19\n" +
+	"\btrace_id\x18\t \x01(\tR\atraceId\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt2\xfe$\n" +
 	"\vUserService\x12W\n" +
 	"\bRegister\x12\x15.user.RegisterRequest\x1a\x16.user.RegisterResponse\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/v1/auth/register\x12a\n" +
 	"\vVerifyEmail\x12\x18.user.VerifyEmailRequest\x1a\x16.google.protobuf.Empty\" \x82\xd3\xe4\x93\x02\x1a:\x01*\"\x15/v1/auth/verify-email\x12|\n" +
 	"\x17ResendVerificationEmail\x12$.user.ResendVerificationEmailRequest\x1a\x16.google.protobuf.Empty\"#\x82\xd3\xe4\x93\x02\x1d:\x01*\"\x18/v1/auth/register/resend\x12K\n" +
-	"\x05Login\x12\x12.user.LoginRequest\x1a\x13.user.TokenResponse\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/v1/auth/login\x12Q\n" +
-	"\aRefresh\x12\x14.user.RefreshRequest\x1a\x13.user.TokenResponse\"\x1b\x82\xd3\xe4\x93\x02\x15:\x01*\"\x10/v1/auth/refresh\x12Y\n" +
+	"\x05Login\x12\x12.user.LoginRequest\x1a\x13.user.TokenResponse\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/v1/auth/login\x12X\n" +
+	"\tVerifyMFA\x12\x16.user.VerifyMFARequest\x1a\x13.user.TokenResponse\"\x1e\x82\xd3\xe4\x93\x02\x18:\x01*\"\x13/v1/auth/mfa/verify\x12{\n" +
+	"\x0fStartOAuthLogin\x12\x1c.user.StartOAuthLoginRequest\x1a\x1d.user.StartOAuthLoginResponse\"+\x82\xd3\xe4\x93\x02%\x12#/v1/auth/oauth/{provider}/authorize\x12v\n" +
+	"\x12CompleteOAuthLogin\x12\x1f.user.CompleteOAuthLoginRequest\x1a\x13.user.TokenResponse\"*\x82\xd3\xe4\x93\x02$\x12\"/v1/auth/oauth/{provider}/callback\x12i\n" +
+	"\x10RequestMagicLink\x12\x1d.user.RequestMagicLinkRequest\x1a\x16.google.protobuf.Empty\"\x1e\x82\xd3\xe4\x93\x02\x18:\x01*\"\x13/v1/auth/magic-link\x12n\n" +
+	"\x10ConsumeMagicLink\x12\x1d.user.ConsumeMagicLinkRequest\x1a\x13.user.TokenResponse\"&\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/v1/auth/magic-link/consume\x12Q\n" +
+	"\aRefresh\x12\x14.user.RefreshRequest\x1a\x13.user.TokenResponse\"\x1b\x82\xd3\xe4\x93\x02\x15:\x01*\"\x10/v1/auth/refresh\x12Q\n" +
+	"\x06Logout\x12\x13.user.LogoutRequest\x1a\x16.google.protobuf.Empty\"\x1a\x82\xd3\xe4\x93\x02\x14:\x01*\"\x0f/v1/auth/logout\x12\\\n" +
+	"\tLogoutAll\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.Empty\"\x1f\x82\xd3\xe4\x93\x02\x19\"\x17/v1/users/me/logout-all\x12a\n" +
+	"\fListSessions\x12\x16.google.protobuf.Empty\x1a\x1a.user.ListSessionsResponse\"\x1d\x82\xd3\xe4\x93\x02\x17\x12\x15/v1/users/me/sessions\x12o\n" +
+	"\rRevokeSession\x12\x1a.user.RevokeSessionRequest\x1a\x16.google.protobuf.Empty\"*\x82\xd3\xe4\x93\x02$*\"/v1/users/me/sessions/{session_id}\x12}\n" +
+	"\x12ListSecurityEvents\x12\x1f.user.ListSecurityEventsRequest\x1a .user.ListSecurityEventsResponse\"$\x82\xd3\xe4\x93\x02\x1e\x12\x1c/v1/users/me/security-events\x12Y\n" +
 	"\aGetUser\x12\x14.user.GetUserRequest\x1a\x1b.user.GetPublicUserResponse\"\x1b\x82\xd3\xe4\x93\x02\x15\x12\x13/v1/users/{user_id}\x12L\n" +
 	"\x05GetMe\x12\x16.google.protobuf.Empty\x1a\x15.user.GetUserResponse\"\x14\x82\xd3\xe4\x93\x02\x0e\x12\f/v1/users/me\x12X\n" +
 	"\n" +
 	"UpdateUser\x12\x17.user.UpdateUserRequest\x1a\x18.user.UpdateUserResponse\"\x17\x82\xd3\xe4\x93\x02\x11:\x01*2\f/v1/users/me\x12c\n" +
-	"\fUpdateAvatar\x12\x19.user.UpdateAvatarRequest\x1a\x18.user.UpdateUserResponse\"\x1e\x82\xd3\xe4\x93\x02\x18:\x01*2\x13/v1/users/me/avatar\x12n\n" +
+	"\fUpdateAvatar\x12\x19.user.UpdateAvatarRequest\x1a\x18.user.UpdateUserResponse\"\x1e\x82\xd3\xe4\x93\x02\x18:\x01*2\x13/v1/users/me/avatar\x12\x85\x01\n" +
+	"\x18RequestPhoneVerification\x12%.user.RequestPhoneVerificationRequest\x1a\x16.google.protobuf.Empty\"*\x82\xd3\xe4\x93\x02$:\x01*\"\x1f/v1/users/me/phone/verification\x12g\n" +
+	"\vVerifyPhone\x12\x18.user.VerifyPhoneRequest\x1a\x18.user.UpdateUserResponse\"$\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/v1/users/me/phone/verify\x12c\n" +
+	"\rDeleteAccount\x12\x1a.user.DeleteAccountRequest\x1a\x16.google.protobuf.Empty\"\x1e\x82\xd3\xe4\x93\x02\x18:\x01*\"\x13/v1/users/me/delete\x12[\n" +
+	"\fExportMyData\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.Empty\"\x1b\x82\xd3\xe4\x93\x02\x15\"\x13/v1/users/me/export\x12n\n" +
 	"\x0eChangePassword\x12\x1b.user.ChangePasswordRequest\x1a\x16.google.protobuf.Empty\"'\x82\xd3\xe4\x93\x02!:\x01*\"\x1c/v1/users/me/change-password\x12j\n" +
 	"\x0eForgotPassword\x12\x1b.user.ForgotPasswordRequest\x1a\x16.google.protobuf.Empty\"#\x82\xd3\xe4\x93\x02\x1d:\x01*\"\x18/v1/auth/forgot-password\x12g\n" +
-	"\rResetPassword\x12\x1a.user.ResetPasswordRequest\x1a\x16.google.protobuf.Empty\"\"\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/v1/auth/reset-password\x12w\n" +
+	"\rResetPassword\x12\x1a.user.ResetPasswordRequest\x1a\x16.google.protobuf.Empty\"\"\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/v1/auth/reset-password\x12s\n" +
+	"\x12RequestEmailChange\x12\x1f.user.RequestEmailChangeRequest\x1a\x16.google.protobuf.Empty\"$\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/v1/users/me/change-email\x12w\n" +
+	"\x12ConfirmEmailChange\x12\x1f.user.ConfirmEmailChangeRequest\x1a\x16.google.protobuf.Empty\"(\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/v1/auth/confirm-email-change\x12g\n" +
+	"\rUnlockAccount\x12\x1a.user.UnlockAccountRequest\x1a\x16.google.protobuf.Empty\"\"\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/v1/auth/unlock-account\x12`\n" +
+	"\n" +
+	"EnrollTOTP\x12\x16.google.protobuf.Empty\x1a\x18.user.EnrollTOTPResponse\" \x82\xd3\xe4\x93\x02\x1a:\x01*\"\x15/v1/users/me/mfa/totp\x12l\n" +
+	"\vConfirmTOTP\x12\x18.user.ConfirmTOTPRequest\x1a\x19.user.ConfirmTOTPResponse\"(\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/v1/users/me/mfa/totp/confirm\x12i\n" +
+	"\vDisableTOTP\x12\x18.user.DisableTOTPRequest\x1a\x16.google.protobuf.Empty\"(\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/v1/users/me/mfa/totp/disable\x12w\n" +
 	"\x11CreateUserAddress\x12\x1e.user.CreateUserAddressRequest\x1a\x1f.user.CreateUserAddressResponse\"!\x82\xd3\xe4\x93\x02\x1b:\x01*\"\x16/v1/users/me/addresses\x12j\n" +
 	"\x10GetUserAddresses\x12\x16.google.protobuf.Empty\x1a\x1e.user.GetUserAddressesResponse\"\x1e\x82\xd3\xe4\x93\x02\x18\x12\x16/v1/users/me/addresses\x12x\n" +
 	"\x0eGetUserAddress\x12\x1b.user.GetUserAddressRequest\x1a\x1c.user.GetUserAddressResponse\"+\x82\xd3\xe4\x93\x02%\x12#/v1/users/me/addresses/{address_id}\x12\x84\x01\n" +
 	"\x11UpdateUserAddress\x12\x1e.user.UpdateUserAddressRequest\x1a\x1f.user.UpdateUserAddressResponse\".\x82\xd3\xe4\x93\x02(:\x01*2#/v1/users/me/addresses/{address_id}\x12x\n" +
-	"\x11DeleteUserAddress\x12\x1e.user.DeleteUserAddressRequest\x1a\x16.google.protobuf.Empty\"+\x82\xd3\xe4\x93\x02%*#/v1/users/me/addresses/{address_id}B\x87\x01\n" +
+	"\x11DeleteUserAddress\x12\x1e.user.DeleteUserAddressRequest\x1a\x16.google.protobuf.Empty\"+\x82\xd3\xe4\x93\x02%*#/v1/users/me/addresses/{address_id}\x12U\n" +
+	"\tListUsers\x12\x16.user.ListUsersRequest\x1a\x17.user.ListUsersResponse\"\x17\x82\xd3\xe4\x93\x02\x11\x12\x0f/v1/admin/users\x12d\n" +
+	"\vGetUserByID\x12\x18.user.GetUserByIDRequest\x1a\x18.user.UserDetailResponse\"!\x82\xd3\xe4\x93\x02\x1b\x12\x19/v1/admin/users/{user_id}\x12o\n" +
+	"\vSuspendUser\x12\x18.user.SuspendUserRequest\x1a\x18.user.UserDetailResponse\",\x82\xd3\xe4\x93\x02&:\x01*\"!/v1/admin/users/{user_id}/suspend\x12u\n" +
+	"\x0eReactivateUser\x12\x1b.user.ReactivateUserRequest\x1a\x18.user.UserDetailResponse\",\x82\xd3\xe4\x93\x02&\"$/v1/admin/users/{user_id}/reactivate\x12\x85\x01\n" +
+	"\x12ForceResetPassword\x12\x1f.user.ForceResetPasswordRequest\x1a\x16.google.protobuf.Empty\"6\x82\xd3\xe4\x93\x020\"./v1/admin/users/{user_id}/force-password-reset\x12~\n" +
+	"\x11RevokeAllSessions\x12\x1e.user.RevokeAllSessionsRequest\x1a\x16.google.protobuf.Empty\"1\x82\xd3\xe4\x93\x02+\")/v1/admin/users/{user_id}/revoke-sessions\x12h\n" +
+	"\fListAuditLog\x12\x19.user.ListAuditLogRequest\x1a .user.ListSecurityEventsResponse\"\x1b\x82\xd3\xe4\x93\x02\x15\x12\x13/v1/admin/audit-logB\x87\x01\n" +
 	"\bcom.userB\tUserProtoP\x01Z@github.com/khoihuynh300/go-microservice/shared/proto/user;userpb\xa2\x02\x03UXX\xaa\x02\x04User\xca\x02\x04User\xe2\x02\x10User\\GPBMetadata\xea\x02\x04Userb\x06proto3"
 
 var (
@@ -1778,91 +3889,207 @@ func file_user_user_proto_rawDescGZIP() []byte {
 	return file_user_user_proto_rawDescData
 }
 
-var file_user_user_proto_msgTypes = make([]protoimpl.MessageInfo, 28)
+var file_user_user_proto_msgTypes = make([]protoimpl.MessageInfo, 61)
 var file_user_user_proto_goTypes = []any{
-	(*RegisterRequest)(nil),                // 0: user.RegisterRequest
-	(*RegisterResponse)(nil),               // 1: user.RegisterResponse
-	(*VerifyEmailRequest)(nil),             // 2: user.VerifyEmailRequest
-	(*ResendVerificationEmailRequest)(nil), // 3: user.ResendVerificationEmailRequest
-	(*LoginRequest)(nil),                   // 4: user.LoginRequest
-	(*TokenResponse)(nil),                  // 5: user.TokenResponse
-	(*RefreshRequest)(nil),                 // 6: user.RefreshRequest
-	(*GetUserRequest)(nil),                 // 7: user.GetUserRequest
-	(*GetUserResponse)(nil),                // 8: user.GetUserResponse
-	(*GetPublicUserResponse)(nil),          // 9: user.GetPublicUserResponse
-	(*UpdateUserRequest)(nil),              // 10: user.UpdateUserRequest
-	(*UpdateAvatarRequest)(nil),            // 11: user.UpdateAvatarRequest
-	(*UpdateUserResponse)(nil),             // 12: user.UpdateUserResponse
-	(*ChangePasswordRequest)(nil),          // 13: user.ChangePasswordRequest
-	(*ForgotPasswordRequest)(nil),          // 14: user.ForgotPasswordRequest
-	(*ResetPasswordRequest)(nil),           // 15: user.ResetPasswordRequest
-	(*CreateUserAddressRequest)(nil),       // 16: user.CreateUserAddressRequest
-	(*CreateUserAddressResponse)(nil),      // 17: user.CreateUserAddressResponse
-	(*UpdateUserAddressRequest)(nil),       // 18: user.UpdateUserAddressRequest
-	(*UpdateUserAddressResponse)(nil),      // 19: user.UpdateUserAddressResponse
-	(*GetUserAddressesResponse)(nil),       // 20: user.GetUserAddressesResponse
-	(*GetUserAddressRequest)(nil),          // 21: user.GetUserAddressRequest
-	(*GetUserAddressResponse)(nil),         // 22: user.GetUserAddressResponse
-	(*DeleteUserAddressRequest)(nil),       // 23: user.DeleteUserAddressRequest
-	(*SetDefaultUserAddressRequest)(nil),   // 24: user.SetDefaultUserAddressRequest
-	(*User)(nil),                           // 25: user.User
-	(*PublicUserProfile)(nil),              // 26: user.PublicUserProfile
-	(*Address)(nil),                        // 27: user.Address
-	(*wrapperspb.StringValue)(nil),         // 28: google.protobuf.StringValue
-	(*emptypb.Empty)(nil),                  // 29: google.protobuf.Empty
+	(*RegisterRequest)(nil),                 // 0: user.RegisterRequest
+	(*RegisterResponse)(nil),                // 1: user.RegisterResponse
+	(*VerifyEmailRequest)(nil),              // 2: user.VerifyEmailRequest
+	(*ResendVerificationEmailRequest)(nil),  // 3: user.ResendVerificationEmailRequest
+	(*LoginRequest)(nil),                    // 4: user.LoginRequest
+	(*TokenResponse)(nil),                   // 5: user.TokenResponse
+	(*VerifyMFARequest)(nil),                // 6: user.VerifyMFARequest
+	(*StartOAuthLoginRequest)(nil),          // 7: user.StartOAuthLoginRequest
+	(*StartOAuthLoginResponse)(nil),         // 8: user.StartOAuthLoginResponse
+	(*CompleteOAuthLoginRequest)(nil),       // 9: user.CompleteOAuthLoginRequest
+	(*RequestMagicLinkRequest)(nil),         // 10: user.RequestMagicLinkRequest
+	(*ConsumeMagicLinkRequest)(nil),         // 11: user.ConsumeMagicLinkRequest
+	(*RefreshRequest)(nil),                  // 12: user.RefreshRequest
+	(*EnrollTOTPResponse)(nil),              // 13: user.EnrollTOTPResponse
+	(*ConfirmTOTPRequest)(nil),              // 14: user.ConfirmTOTPRequest
+	(*ConfirmTOTPResponse)(nil),             // 15: user.ConfirmTOTPResponse
+	(*DisableTOTPRequest)(nil),              // 16: user.DisableTOTPRequest
+	(*LogoutRequest)(nil),                   // 17: user.LogoutRequest
+	(*ListSessionsResponse)(nil),            // 18: user.ListSessionsResponse
+	(*RevokeSessionRequest)(nil),            // 19: user.RevokeSessionRequest
+	(*GetUserRequest)(nil),                  // 20: user.GetUserRequest
+	(*GetUserResponse)(nil),                 // 21: user.GetUserResponse
+	(*GetPublicUserResponse)(nil),           // 22: user.GetPublicUserResponse
+	(*UpdateUserRequest)(nil),               // 23: user.UpdateUserRequest
+	(*UpdateAvatarRequest)(nil),             // 24: user.UpdateAvatarRequest
+	(*RequestPhoneVerificationRequest)(nil), // 25: user.RequestPhoneVerificationRequest
+	(*VerifyPhoneRequest)(nil),              // 26: user.VerifyPhoneRequest
+	(*UpdateUserResponse)(nil),              // 27: user.UpdateUserResponse
+	(*DeleteAccountRequest)(nil),            // 28: user.DeleteAccountRequest
+	(*ChangePasswordRequest)(nil),           // 29: user.ChangePasswordRequest
+	(*ForgotPasswordRequest)(nil),           // 30: user.ForgotPasswordRequest
+	(*ResetPasswordRequest)(nil),            // 31: user.ResetPasswordRequest
+	(*RequestEmailChangeRequest)(nil),       // 32: user.RequestEmailChangeRequest
+	(*ConfirmEmailChangeRequest)(nil),       // 33: user.ConfirmEmailChangeRequest
+	(*UnlockAccountRequest)(nil),            // 34: user.UnlockAccountRequest
+	(*CreateUserAddressRequest)(nil),        // 35: user.CreateUserAddressRequest
+	(*CreateUserAddressResponse)(nil),       // 36: user.CreateUserAddressResponse
+	(*UpdateUserAddressRequest)(nil),        // 37: user.UpdateUserAddressRequest
+	(*UpdateUserAddressResponse)(nil),       // 38: user.UpdateUserAddressResponse
+	(*GetUserAddressesResponse)(nil),        // 39: user.GetUserAddressesResponse
+	(*GetUserAddressRequest)(nil),           // 40: user.GetUserAddressRequest
+	(*GetUserAddressResponse)(nil),          // 41: user.GetUserAddressResponse
+	(*DeleteUserAddressRequest)(nil),        // 42: user.DeleteUserAddressRequest
+	(*SetDefaultUserAddressRequest)(nil),    // 43: user.SetDefaultUserAddressRequest
+	(*ListUsersRequest)(nil),                // 44: user.ListUsersRequest
+	(*ListUsersResponse)(nil),               // 45: user.ListUsersResponse
+	(*GetUserByIDRequest)(nil),              // 46: user.GetUserByIDRequest
+	(*UserDetailResponse)(nil),              // 47: user.UserDetailResponse
+	(*SuspendUserRequest)(nil),              // 48: user.SuspendUserRequest
+	(*ReactivateUserRequest)(nil),           // 49: user.ReactivateUserRequest
+	(*ForceResetPasswordRequest)(nil),       // 50: user.ForceResetPasswordRequest
+	(*RevokeAllSessionsRequest)(nil),        // 51: user.RevokeAllSessionsRequest
+	(*ListSecurityEventsRequest)(nil),       // 52: user.ListSecurityEventsRequest
+	(*ListSecurityEventsResponse)(nil),      // 53: user.ListSecurityEventsResponse
+	(*ListAuditLogRequest)(nil),             // 54: user.ListAuditLogRequest
+	(*User)(nil),                            // 55: user.User
+	(*PublicUserProfile)(nil),               // 56: user.PublicUserProfile
+	(*Session)(nil),                         // 57: user.Session
+	(*Address)(nil),                         // 58: user.Address
+	(*UserDetail)(nil),                      // 59: user.UserDetail
+	(*SecurityEvent)(nil),                   // 60: user.SecurityEvent
+	(*wrapperspb.StringValue)(nil),          // 61: google.protobuf.StringValue
+	(*timestamppb.Timestamp)(nil),           // 62: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),                   // 63: google.protobuf.Empty
 }
 var file_user_user_proto_depIdxs = []int32{
-	25, // 0: user.GetUserResponse.user:type_name -> user.User
-	26, // 1: user.GetPublicUserResponse.user:type_name -> user.PublicUserProfile
-	25, // 2: user.UpdateUserResponse.user:type_name -> user.User
-	27, // 3: user.CreateUserAddressResponse.address:type_name -> user.Address
-	27, // 4: user.UpdateUserAddressResponse.address:type_name -> user.Address
-	27, // 5: user.GetUserAddressesResponse.addresses:type_name -> user.Address
-	27, // 6: user.GetUserAddressResponse.address:type_name -> user.Address
-	28, // 7: user.User.phone:type_name -> google.protobuf.StringValue
-	28, // 8: user.User.avatar_url:type_name -> google.protobuf.StringValue
-	28, // 9: user.User.date_of_birth:type_name -> google.protobuf.StringValue
-	28, // 10: user.User.gender:type_name -> google.protobuf.StringValue
-	28, // 11: user.PublicUserProfile.avatar_url:type_name -> google.protobuf.StringValue
-	0,  // 12: user.UserService.Register:input_type -> user.RegisterRequest
-	2,  // 13: user.UserService.VerifyEmail:input_type -> user.VerifyEmailRequest
-	3,  // 14: user.UserService.ResendVerificationEmail:input_type -> user.ResendVerificationEmailRequest
-	4,  // 15: user.UserService.Login:input_type -> user.LoginRequest
-	6,  // 16: user.UserService.Refresh:input_type -> user.RefreshRequest
-	7,  // 17: user.UserService.GetUser:input_type -> user.GetUserRequest
-	29, // 18: user.UserService.GetMe:input_type -> google.protobuf.Empty
-	10, // 19: user.UserService.UpdateUser:input_type -> user.UpdateUserRequest
-	11, // 20: user.UserService.UpdateAvatar:input_type -> user.UpdateAvatarRequest
-	13, // 21: user.UserService.ChangePassword:input_type -> user.ChangePasswordRequest
-	14, // 22: user.UserService.ForgotPassword:input_type -> user.ForgotPasswordRequest
-	15, // 23: user.UserService.ResetPassword:input_type -> user.ResetPasswordRequest
-	16, // 24: user.UserService.CreateUserAddress:input_type -> user.CreateUserAddressRequest
-	29, // 25: user.UserService.GetUserAddresses:input_type -> google.protobuf.Empty
-	21, // 26: user.UserService.GetUserAddress:input_type -> user.GetUserAddressRequest
-	18, // 27: user.UserService.UpdateUserAddress:input_type -> user.UpdateUserAddressRequest
-	23, // 28: user.UserService.DeleteUserAddress:input_type -> user.DeleteUserAddressRequest
-	1,  // 29: user.UserService.Register:output_type -> user.RegisterResponse
-	29, // 30: user.UserService.VerifyEmail:output_type -> google.protobuf.Empty
-	29, // 31: user.UserService.ResendVerificationEmail:output_type -> google.protobuf.Empty
-	5,  // 32: user.UserService.Login:output_type -> user.TokenResponse
-	5,  // 33: user.UserService.Refresh:output_type -> user.TokenResponse
-	9,  // 34: user.UserService.GetUser:output_type -> user.GetPublicUserResponse
-	8,  // 35: user.UserService.GetMe:output_type -> user.GetUserResponse
-	12, // 36: user.UserService.UpdateUser:output_type -> user.UpdateUserResponse
-	12, // 37: user.UserService.UpdateAvatar:output_type -> user.UpdateUserResponse
-	29, // 38: user.UserService.ChangePassword:output_type -> google.protobuf.Empty
-	29, // 39: user.UserService.ForgotPassword:output_type -> google.protobuf.Empty
-	29, // 40: user.UserService.ResetPassword:output_type -> google.protobuf.Empty
-	17, // 41: user.UserService.CreateUserAddress:output_type -> user.CreateUserAddressResponse
-	20, // 42: user.UserService.GetUserAddresses:output_type -> user.GetUserAddressesResponse
-	22, // 43: user.UserService.GetUserAddress:output_type -> user.GetUserAddressResponse
-	19, // 44: user.UserService.UpdateUserAddress:output_type -> user.UpdateUserAddressResponse
-	29, // 45: user.UserService.DeleteUserAddress:output_type -> google.protobuf.Empty
-	29, // [29:46] is the sub-list for method output_type
-	12, // [12:29] is the sub-list for method input_type
-	12, // [12:12] is the sub-list for extension type_name
-	12, // [12:12] is the sub-list for extension extendee
-	0,  // [0:12] is the sub-list for field type_name
+	57, // 0: user.ListSessionsResponse.sessions:type_name -> user.Session
+	55, // 1: user.GetUserResponse.user:type_name -> user.User
+	56, // 2: user.GetPublicUserResponse.user:type_name -> user.PublicUserProfile
+	55, // 3: user.UpdateUserResponse.user:type_name -> user.User
+	58, // 4: user.CreateUserAddressResponse.address:type_name -> user.Address
+	58, // 5: user.UpdateUserAddressResponse.address:type_name -> user.Address
+	58, // 6: user.GetUserAddressesResponse.addresses:type_name -> user.Address
+	58, // 7: user.GetUserAddressResponse.address:type_name -> user.Address
+	61, // 8: user.ListUsersRequest.status:type_name -> google.protobuf.StringValue
+	61, // 9: user.ListUsersRequest.email:type_name -> google.protobuf.StringValue
+	62, // 10: user.ListUsersRequest.created_from:type_name -> google.protobuf.Timestamp
+	62, // 11: user.ListUsersRequest.created_to:type_name -> google.protobuf.Timestamp
+	59, // 12: user.ListUsersResponse.users:type_name -> user.UserDetail
+	59, // 13: user.UserDetailResponse.user:type_name -> user.UserDetail
+	60, // 14: user.ListSecurityEventsResponse.events:type_name -> user.SecurityEvent
+	61, // 15: user.ListAuditLogRequest.user_id:type_name -> google.protobuf.StringValue
+	61, // 16: user.ListAuditLogRequest.event_type:type_name -> google.protobuf.StringValue
+	61, // 17: user.ListAuditLogRequest.ip_address:type_name -> google.protobuf.StringValue
+	62, // 18: user.ListAuditLogRequest.created_from:type_name -> google.protobuf.Timestamp
+	62, // 19: user.ListAuditLogRequest.created_to:type_name -> google.protobuf.Timestamp
+	61, // 20: user.User.phone:type_name -> google.protobuf.StringValue
+	61, // 21: user.User.avatar_url:type_name -> google.protobuf.StringValue
+	61, // 22: user.User.date_of_birth:type_name -> google.protobuf.StringValue
+	61, // 23: user.User.gender:type_name -> google.protobuf.StringValue
+	61, // 24: user.PublicUserProfile.avatar_url:type_name -> google.protobuf.StringValue
+	62, // 25: user.Session.created_at:type_name -> google.protobuf.Timestamp
+	62, // 26: user.Session.last_used_at:type_name -> google.protobuf.Timestamp
+	62, // 27: user.Session.expires_at:type_name -> google.protobuf.Timestamp
+	61, // 28: user.UserDetail.phone:type_name -> google.protobuf.StringValue
+	61, // 29: user.UserDetail.avatar_url:type_name -> google.protobuf.StringValue
+	61, // 30: user.UserDetail.date_of_birth:type_name -> google.protobuf.StringValue
+	61, // 31: user.UserDetail.gender:type_name -> google.protobuf.StringValue
+	62, // 32: user.UserDetail.email_verified_at:type_name -> google.protobuf.Timestamp
+	62, // 33: user.UserDetail.phone_verified_at:type_name -> google.protobuf.Timestamp
+	62, // 34: user.UserDetail.created_at:type_name -> google.protobuf.Timestamp
+	62, // 35: user.UserDetail.updated_at:type_name -> google.protobuf.Timestamp
+	61, // 36: user.SecurityEvent.user_id:type_name -> google.protobuf.StringValue
+	61, // 37: user.SecurityEvent.actor_id:type_name -> google.protobuf.StringValue
+	61, // 38: user.SecurityEvent.reason:type_name -> google.protobuf.StringValue
+	62, // 39: user.SecurityEvent.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 40: user.UserService.Register:input_type -> user.RegisterRequest
+	2,  // 41: user.UserService.VerifyEmail:input_type -> user.VerifyEmailRequest
+	3,  // 42: user.UserService.ResendVerificationEmail:input_type -> user.ResendVerificationEmailRequest
+	4,  // 43: user.UserService.Login:input_type -> user.LoginRequest
+	6,  // 44: user.UserService.VerifyMFA:input_type -> user.VerifyMFARequest
+	7,  // 45: user.UserService.StartOAuthLogin:input_type -> user.StartOAuthLoginRequest
+	9,  // 46: user.UserService.CompleteOAuthLogin:input_type -> user.CompleteOAuthLoginRequest
+	10, // 47: user.UserService.RequestMagicLink:input_type -> user.RequestMagicLinkRequest
+	11, // 48: user.UserService.ConsumeMagicLink:input_type -> user.ConsumeMagicLinkRequest
+	12, // 49: user.UserService.Refresh:input_type -> user.RefreshRequest
+	17, // 50: user.UserService.Logout:input_type -> user.LogoutRequest
+	63, // 51: user.UserService.LogoutAll:input_type -> google.protobuf.Empty
+	63, // 52: user.UserService.ListSessions:input_type -> google.protobuf.Empty
+	19, // 53: user.UserService.RevokeSession:input_type -> user.RevokeSessionRequest
+	52, // 54: user.UserService.ListSecurityEvents:input_type -> user.ListSecurityEventsRequest
+	20, // 55: user.UserService.GetUser:input_type -> user.GetUserRequest
+	63, // 56: user.UserService.GetMe:input_type -> google.protobuf.Empty
+	23, // 57: user.UserService.UpdateUser:input_type -> user.UpdateUserRequest
+	24, // 58: user.UserService.UpdateAvatar:input_type -> user.UpdateAvatarRequest
+	25, // 59: user.UserService.RequestPhoneVerification:input_type -> user.RequestPhoneVerificationRequest
+	26, // 60: user.UserService.VerifyPhone:input_type -> user.VerifyPhoneRequest
+	28, // 61: user.UserService.DeleteAccount:input_type -> user.DeleteAccountRequest
+	63, // 62: user.UserService.ExportMyData:input_type -> google.protobuf.Empty
+	29, // 63: user.UserService.ChangePassword:input_type -> user.ChangePasswordRequest
+	30, // 64: user.UserService.ForgotPassword:input_type -> user.ForgotPasswordRequest
+	31, // 65: user.UserService.ResetPassword:input_type -> user.ResetPasswordRequest
+	32, // 66: user.UserService.RequestEmailChange:input_type -> user.RequestEmailChangeRequest
+	33, // 67: user.UserService.ConfirmEmailChange:input_type -> user.ConfirmEmailChangeRequest
+	34, // 68: user.UserService.UnlockAccount:input_type -> user.UnlockAccountRequest
+	63, // 69: user.UserService.EnrollTOTP:input_type -> google.protobuf.Empty
+	14, // 70: user.UserService.ConfirmTOTP:input_type -> user.ConfirmTOTPRequest
+	16, // 71: user.UserService.DisableTOTP:input_type -> user.DisableTOTPRequest
+	35, // 72: user.UserService.CreateUserAddress:input_type -> user.CreateUserAddressRequest
+	63, // 73: user.UserService.GetUserAddresses:input_type -> google.protobuf.Empty
+	40, // 74: user.UserService.GetUserAddress:input_type -> user.GetUserAddressRequest
+	37, // 75: user.UserService.UpdateUserAddress:input_type -> user.UpdateUserAddressRequest
+	42, // 76: user.UserService.DeleteUserAddress:input_type -> user.DeleteUserAddressRequest
+	44, // 77: user.UserService.ListUsers:input_type -> user.ListUsersRequest
+	46, // 78: user.UserService.GetUserByID:input_type -> user.GetUserByIDRequest
+	48, // 79: user.UserService.SuspendUser:input_type -> user.SuspendUserRequest
+	49, // 80: user.UserService.ReactivateUser:input_type -> user.ReactivateUserRequest
+	50, // 81: user.UserService.ForceResetPassword:input_type -> user.ForceResetPasswordRequest
+	51, // 82: user.UserService.RevokeAllSessions:input_type -> user.RevokeAllSessionsRequest
+	54, // 83: user.UserService.ListAuditLog:input_type -> user.ListAuditLogRequest
+	1,  // 84: user.UserService.Register:output_type -> user.RegisterResponse
+	63, // 85: user.UserService.VerifyEmail:output_type -> google.protobuf.Empty
+	63, // 86: user.UserService.ResendVerificationEmail:output_type -> google.protobuf.Empty
+	5,  // 87: user.UserService.Login:output_type -> user.TokenResponse
+	5,  // 88: user.UserService.VerifyMFA:output_type -> user.TokenResponse
+	8,  // 89: user.UserService.StartOAuthLogin:output_type -> user.StartOAuthLoginResponse
+	5,  // 90: user.UserService.CompleteOAuthLogin:output_type -> user.TokenResponse
+	63, // 91: user.UserService.RequestMagicLink:output_type -> google.protobuf.Empty
+	5,  // 92: user.UserService.ConsumeMagicLink:output_type -> user.TokenResponse
+	5,  // 93: user.UserService.Refresh:output_type -> user.TokenResponse
+	63, // 94: user.UserService.Logout:output_type -> google.protobuf.Empty
+	63, // 95: user.UserService.LogoutAll:output_type -> google.protobuf.Empty
+	18, // 96: user.UserService.ListSessions:output_type -> user.ListSessionsResponse
+	63, // 97: user.UserService.RevokeSession:output_type -> google.protobuf.Empty
+	53, // 98: user.UserService.ListSecurityEvents:output_type -> user.ListSecurityEventsResponse
+	22, // 99: user.UserService.GetUser:output_type -> user.GetPublicUserResponse
+	21, // 100: user.UserService.GetMe:output_type -> user.GetUserResponse
+	27, // 101: user.UserService.UpdateUser:output_type -> user.UpdateUserResponse
+	27, // 102: user.UserService.UpdateAvatar:output_type -> user.UpdateUserResponse
+	63, // 103: user.UserService.RequestPhoneVerification:output_type -> google.protobuf.Empty
+	27, // 104: user.UserService.VerifyPhone:output_type -> user.UpdateUserResponse
+	63, // 105: user.UserService.DeleteAccount:output_type -> google.protobuf.Empty
+	63, // 106: user.UserService.ExportMyData:output_type -> google.protobuf.Empty
+	63, // 107: user.UserService.ChangePassword:output_type -> google.protobuf.Empty
+	63, // 108: user.UserService.ForgotPassword:output_type -> google.protobuf.Empty
+	63, // 109: user.UserService.ResetPassword:output_type -> google.protobuf.Empty
+	63, // 110: user.UserService.RequestEmailChange:output_type -> google.protobuf.Empty
+	63, // 111: user.UserService.ConfirmEmailChange:output_type -> google.protobuf.Empty
+	63, // 112: user.UserService.UnlockAccount:output_type -> google.protobuf.Empty
+	13, // 113: user.UserService.EnrollTOTP:output_type -> user.EnrollTOTPResponse
+	15, // 114: user.UserService.ConfirmTOTP:output_type -> user.ConfirmTOTPResponse
+	63, // 115: user.UserService.DisableTOTP:output_type -> google.protobuf.Empty
+	36, // 116: user.UserService.CreateUserAddress:output_type -> user.CreateUserAddressResponse
+	39, // 117: user.UserService.GetUserAddresses:output_type -> user.GetUserAddressesResponse
+	41, // 118: user.UserService.GetUserAddress:output_type -> user.GetUserAddressResponse
+	38, // 119: user.UserService.UpdateUserAddress:output_type -> user.UpdateUserAddressResponse
+	63, // 120: user.UserService.DeleteUserAddress:output_type -> google.protobuf.Empty
+	45, // 121: user.UserService.ListUsers:output_type -> user.ListUsersResponse
+	47, // 122: user.UserService.GetUserByID:output_type -> user.UserDetailResponse
+	47, // 123: user.UserService.SuspendUser:output_type -> user.UserDetailResponse
+	47, // 124: user.UserService.ReactivateUser:output_type -> user.UserDetailResponse
+	63, // 125: user.UserService.ForceResetPassword:output_type -> google.protobuf.Empty
+	63, // 126: user.UserService.RevokeAllSessions:output_type -> google.protobuf.Empty
+	53, // 127: user.UserService.ListAuditLog:output_type -> user.ListSecurityEventsResponse
+	84, // [84:128] is the sub-list for method output_type
+	40, // [40:84] is the sub-list for method input_type
+	40, // [40:40] is the sub-list for extension type_name
+	40, // [40:40] is the sub-list for extension extendee
+	0,  // [0:40] is the sub-list for field type_name
 }
 
 func init() { file_user_user_proto_init() }
@@ -1870,15 +4097,15 @@ func file_user_user_proto_init() {
 	if File_user_user_proto != nil {
 		return
 	}
-	file_user_user_proto_msgTypes[10].OneofWrappers = []any{}
-	file_user_user_proto_msgTypes[18].OneofWrappers = []any{}
+	file_user_user_proto_msgTypes[23].OneofWrappers = []any{}
+	file_user_user_proto_msgTypes[37].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_user_user_proto_rawDesc), len(file_user_user_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   28,
+			NumMessages:   61,
 			NumExtensions: 0,
 			NumServices:   1,
 		},